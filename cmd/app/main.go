@@ -13,6 +13,9 @@ import (
 
 	"github.com/go-park-mail-ru/2020_2_Slash/config"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/mail"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/mail/mailers"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/mwares"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/mwares/monitoring"
 	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
@@ -43,6 +46,7 @@ import (
 	contentHandler "github.com/go-park-mail-ru/2020_2_Slash/internal/content/delivery"
 	contentRepo "github.com/go-park-mail-ru/2020_2_Slash/internal/content/repository"
 	contentUsecase "github.com/go-park-mail-ru/2020_2_Slash/internal/content/usecases"
+	contentWorkers "github.com/go-park-mail-ru/2020_2_Slash/internal/content/workers"
 
 	movieHandler "github.com/go-park-mail-ru/2020_2_Slash/internal/movie/delivery"
 	movieRepo "github.com/go-park-mail-ru/2020_2_Slash/internal/movie/repository"
@@ -69,11 +73,54 @@ import (
 	episodeUsecase "github.com/go-park-mail-ru/2020_2_Slash/internal/episode/usecases"
 
 	searchHandler "github.com/go-park-mail-ru/2020_2_Slash/internal/search/delivery"
+	searchIndex "github.com/go-park-mail-ru/2020_2_Slash/internal/search/index"
+	searchRepo "github.com/go-park-mail-ru/2020_2_Slash/internal/search/repository"
 	searchUsecase "github.com/go-park-mail-ru/2020_2_Slash/internal/search/usecases"
+	searchWorkers "github.com/go-park-mail-ru/2020_2_Slash/internal/search/workers"
+
+	paymentHandler "github.com/go-park-mail-ru/2020_2_Slash/internal/payment/delivery"
+	paymentProviders "github.com/go-park-mail-ru/2020_2_Slash/internal/payment/providers"
+	paymentRepo "github.com/go-park-mail-ru/2020_2_Slash/internal/payment/repository"
+	paymentUsecase "github.com/go-park-mail-ru/2020_2_Slash/internal/payment/usecases"
+	planHandler "github.com/go-park-mail-ru/2020_2_Slash/internal/plan/delivery"
+	planRepo "github.com/go-park-mail-ru/2020_2_Slash/internal/plan/repository"
+	planUsecase "github.com/go-park-mail-ru/2020_2_Slash/internal/plan/usecases"
 
 	subscriptionHandler "github.com/go-park-mail-ru/2020_2_Slash/internal/subscription/delivery"
 	subscriptionRepo "github.com/go-park-mail-ru/2020_2_Slash/internal/subscription/repository"
 	subscriptionUsecase "github.com/go-park-mail-ru/2020_2_Slash/internal/subscription/usecases"
+	subscriptionWorkers "github.com/go-park-mail-ru/2020_2_Slash/internal/subscription/workers"
+
+	entitlementUsecase "github.com/go-park-mail-ru/2020_2_Slash/internal/entitlement/usecases"
+
+	videoHandler "github.com/go-park-mail-ru/2020_2_Slash/internal/video/delivery"
+
+	jobHandler "github.com/go-park-mail-ru/2020_2_Slash/internal/job/delivery"
+	jobRepo "github.com/go-park-mail-ru/2020_2_Slash/internal/job/repository"
+	jobUsecase "github.com/go-park-mail-ru/2020_2_Slash/internal/job/usecases"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/job/workers"
+	progressHandler "github.com/go-park-mail-ru/2020_2_Slash/internal/progress/delivery"
+	progressRepo "github.com/go-park-mail-ru/2020_2_Slash/internal/progress/repository"
+	progressUsecase "github.com/go-park-mail-ru/2020_2_Slash/internal/progress/usecases"
+	recommendationHandler "github.com/go-park-mail-ru/2020_2_Slash/internal/recommendation/delivery"
+	recommendationRepo "github.com/go-park-mail-ru/2020_2_Slash/internal/recommendation/repository"
+	recommendationUsecase "github.com/go-park-mail-ru/2020_2_Slash/internal/recommendation/usecases"
+	recommendationWorkers "github.com/go-park-mail-ru/2020_2_Slash/internal/recommendation/workers"
+
+	auditHandler "github.com/go-park-mail-ru/2020_2_Slash/internal/audit/delivery"
+	auditRepo "github.com/go-park-mail-ru/2020_2_Slash/internal/audit/repository"
+	auditUsecase "github.com/go-park-mail-ru/2020_2_Slash/internal/audit/usecases"
+
+	trashHandler "github.com/go-park-mail-ru/2020_2_Slash/internal/trash/delivery"
+	trashRepo "github.com/go-park-mail-ru/2020_2_Slash/internal/trash/repository"
+	trashUsecase "github.com/go-park-mail-ru/2020_2_Slash/internal/trash/usecases"
+	trashWorkers "github.com/go-park-mail-ru/2020_2_Slash/internal/trash/workers"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/oidc"
+	oidcHandler "github.com/go-park-mail-ru/2020_2_Slash/internal/oidc/delivery"
+	oidcProviders "github.com/go-park-mail-ru/2020_2_Slash/internal/oidc/providers"
+	oidcRepo "github.com/go-park-mail-ru/2020_2_Slash/internal/oidc/repository"
+	oidcUsecase "github.com/go-park-mail-ru/2020_2_Slash/internal/oidc/usecases"
 )
 
 func main() {
@@ -119,21 +166,129 @@ func main() {
 	seasonRepo := seasonRepo.NewSeasonPgRepository(dbConnection)
 	episodeRepo := episodeRepo.NewEpisodeRepository(dbConnection)
 	subscriptionRepo := subscriptionRepo.NewSubscriptionPgRepository(dbConnection)
+	paymentRepo := paymentRepo.NewPaymentPgRepository(dbConnection)
+	planRepo := planRepo.NewPlanPgRepository(dbConnection)
+	jobRepo := jobRepo.NewJobPgRepository(dbConnection)
+	progressRepo := progressRepo.NewProgressPgRepository(dbConnection)
+	recommendationRepo := recommendationRepo.NewRecommendationPgRepository(dbConnection)
+	searchRepo := searchRepo.NewSearchPgRepository(dbConnection)
+	identityRepo := oidcRepo.NewIdentityPgRepository(dbConnection)
+	auditRepo := auditRepo.NewAuditPgRepository(dbConnection)
+	trashRepo := trashRepo.NewTrashPgRepository(dbConnection)
+
+	// Search suggestions index
+	suggestIndex := searchIndex.NewSuggestIndex(searchRepo)
+	if err := suggestIndex.Rebuild(); err != nil {
+		log.Fatal(err)
+	}
+
+	// Payment provider
+	paymentSecret, err := config.GetPaymentSecret()
+	if err != nil {
+		log.Fatal(err)
+	}
+	paymentProvider, err := paymentProviders.NewPaymentProvider(config.GetPaymentProviderName(),
+		config.GetPaymentReceiver(), paymentSecret, config.IsDev())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Mailer
+	var mailer mail.Mailer
+	switch config.GetMailerType() {
+	case consts.SMTPMailer:
+		smtpPassword, err := config.GetSMTPPassword()
+		if err != nil {
+			log.Fatal(err)
+		}
+		mailer = mailers.NewSMTPMailer(config.GetSMTPConnString(), config.GetMailFrom(),
+			config.GetSMTPUsername(), smtpPassword)
+	case consts.FileMailer:
+		mailsPath := config.GetMailsPath()
+		helpers.InitStorage(mailsPath)
+		mailer = mailers.NewFileMailer(mailsPath, config.GetMailFrom())
+	case consts.MemoryMailer:
+		mailer = mailers.NewMemoryMailer()
+	default:
+		log.Fatalln("Unknown mailer", config.GetMailerType())
+	}
+
+	// OpenID Connect providers
+	var identityProviders []oidc.Provider
+	for _, providerConfig := range config.GetOIDCProviders() {
+		clientSecret, err := providerConfig.GetClientSecret()
+		if err != nil {
+			log.Fatal(err)
+		}
+		identityProviders = append(identityProviders, oidcProviders.NewOIDCProvider(
+			providerConfig.Name, providerConfig.Issuer, providerConfig.ClientID, clientSecret,
+			providerConfig.RedirectURL, providerConfig.Scopes))
+	}
 
 	// Usecases
 	genreUcase := genreUsecase.NewGenreUsecase(genreRepo)
 	countryUcase := countryUsecase.NewCountryUsecase(countryRepo)
-	actorUcase := actorUsecase.NewActorUseCase(actorRepo)
-	directorUcase := directorUsecase.NewDirectorUseCase(directorRepo)
-	contentUcase := contentUsecase.NewContentUsecase(contentRepo, countryUcase, genreUcase, actorUcase, directorUcase)
-	movieUcase := movieUsecase.NewMovieUsecase(movieRepo, contentUcase)
+	actorUcase := actorUsecase.NewActorUseCase(actorRepo, suggestIndex)
+	directorUcase := directorUsecase.NewDirectorUseCase(directorRepo, suggestIndex)
+	contentUcase := contentUsecase.NewContentUsecase(contentRepo, countryUcase, genreUcase, actorUcase,
+		directorUcase, suggestIndex)
+	hlsPackager := helpers.NewHLSPackager(config.GetFFmpegPath())
+	movieUcase := movieUsecase.NewMovieUsecase(movieRepo, contentUcase, hlsPackager)
 	tvshowUcase := tvshowUsecase.NewTVShowUsecase(tvshowRepo, contentUcase)
 	ratingUcase := ratingUsecase.NewRatingUseCase(ratingRepo, contentUcase)
 	favouriteUcase := favouriteUsecase.NewFavouriteUsecase(favouriteRepo)
 	seasonUcase := seasonUsecase.NewSeasonUsecase(seasonRepo, tvshowUcase)
-	episodeUcase := episodeUsecase.NewEpisodeUsecase(episodeRepo, seasonUcase)
-	searchUcase := searchUsecase.NewSearchUsecase(actorRepo, movieRepo, tvshowRepo)
-	subscriptionUsecase := subscriptionUsecase.NewSubscriptionUseCase(subscriptionRepo)
+	episodeUcase := episodeUsecase.NewEpisodeUsecase(episodeRepo, seasonUcase, hlsPackager)
+	searchUcase := searchUsecase.NewSearchUsecase(actorRepo, movieRepo, tvshowRepo,
+		directorRepo, genreRepo, suggestIndex)
+	subscriptionUsecase := subscriptionUsecase.NewSubscriptionUseCase(subscriptionRepo,
+		config.GetSubscriptionGracePeriod())
+	entitlementUcase := entitlementUsecase.NewEntitlementUsecase(subscriptionUsecase)
+	planUcase := planUsecase.NewPlanUsecase(planRepo)
+	paymentUcase := paymentUsecase.NewPaymentUsecase(paymentRepo, subscriptionUsecase, planUcase,
+		paymentProvider)
+	videoURLSecret, err := config.GetVideoURLSecret()
+	if err != nil {
+		log.Fatal(err)
+	}
+	videoSigner := helpers.NewVideoURLSigner(videoURLSecret)
+	jobUcase := jobUsecase.NewJobUsecase(jobRepo)
+	progressUcase := progressUsecase.NewProgressUsecase(progressRepo, contentUcase, episodeUcase)
+	recommendationUcase := recommendationUsecase.NewRecommendationUsecase(recommendationRepo)
+	auditUcase := auditUsecase.NewAuditUsecase(auditRepo)
+	trashUcase := trashUsecase.NewTrashUsecase(trashRepo, suggestIndex, config.GetTrashRetention())
+
+	// Audited entities
+	auditUcase.RegisterEntity(consts.AuditMovie, func(id uint64) (interface{}, *errors.Error) {
+		return movieUcase.GetByID(id)
+	})
+	auditUcase.RegisterEntity(consts.AuditTVShow, func(id uint64) (interface{}, *errors.Error) {
+		return tvshowUcase.GetByID(id)
+	})
+	auditUcase.RegisterEntity(consts.AuditContent, func(id uint64) (interface{}, *errors.Error) {
+		return contentUcase.GetByID(id)
+	})
+	auditUcase.RegisterEntity(consts.AuditSeason, func(id uint64) (interface{}, *errors.Error) {
+		return seasonUcase.Get(id)
+	})
+	auditUcase.RegisterEntity(consts.AuditEpisode, func(id uint64) (interface{}, *errors.Error) {
+		return episodeUcase.GetByID(id)
+	})
+	auditUcase.RegisterEntity(consts.AuditActor, func(id uint64) (interface{}, *errors.Error) {
+		return actorUcase.Get(id)
+	})
+	auditUcase.RegisterEntity(consts.AuditDirector, func(id uint64) (interface{}, *errors.Error) {
+		return directorUcase.Get(id)
+	})
+	auditUcase.RegisterEntity(consts.AuditGenre, func(id uint64) (interface{}, *errors.Error) {
+		return genreUcase.GetByID(id)
+	})
+	auditUcase.RegisterEntity(consts.AuditCountry, func(id uint64) (interface{}, *errors.Error) {
+		return countryUcase.GetByID(id)
+	})
+	auditUcase.RegisterEntity(consts.AuditPlan, func(id uint64) (interface{}, *errors.Error) {
+		return planUcase.GetByID(id)
+	})
 
 	// Session microservice
 	sessionGrpcConn, err := grpc.Dial(consts.SessionblockAddress, grpc.WithInsecure())
@@ -151,36 +306,67 @@ func main() {
 	}
 	defer userblockGrpcConn.Close()
 	userBlockClient := userGRPC.NewUserBlockClient(userblockGrpcConn)
-	userUcase := userUsecase.NewUserUsecase(userBlockClient)
+	userUcase := userUsecase.NewUserUsecase(userBlockClient, mailer, config.GetSiteURL())
+	oidcStateSecret, err := config.GetOIDCStateSecret()
+	if err != nil {
+		log.Fatal(err)
+	}
+	oidcUcase := oidcUsecase.NewOIDCUsecase(identityRepo, userUcase, identityProviders,
+		oidcStateSecret)
 
 	// Monitoring
 	e := echo.New()
 	mntng := monitoring.NewMonitoring(e)
 
+	// Client IP is trusted only from nginx, it is used by rate limits and audit
+	trustedProxies, err := config.GetTrustedProxies()
+	if err != nil {
+		log.Fatal(err)
+	}
+	e.IPExtractor = helpers.NewIPExtractor(trustedProxies)
+
 	// Middleware
-	mw := mwares.NewMiddlewareManager(sessUcase, userUcase, mntng)
+	rateLimiters := make(map[string]*helpers.RateLimiter)
+	for group, limit := range config.GetRateLimits() {
+		rateLimiters[group] = helpers.NewRateLimiter(helpers.RateLimit{
+			IPRequests:      limit.IPRequests,
+			AccountRequests: limit.AccountRequests,
+			Period:          limit.GetPeriod(),
+			Lockout:         limit.GetLockout(),
+		})
+	}
+	mw := mwares.NewMiddlewareManager(sessUcase, userUcase, mntng, rateLimiters, auditUcase)
 	e.Use(mw.PanicRecovering, mw.AccessLog, mw.CORS)
 
 	e.Static("/avatars", avatarsPath)
 	e.Static("/images", postersPath)
-	e.Static("/videos", videosPath)
 
 	// Delivery
 	sessionHandler := sessionHandler.NewSessionHandler(sessUcase, userUcase)
-	userHandler := userHandler.NewUserHandler(userUcase, sessUcase)
+	userHandler := userHandler.NewUserHandler(userUcase, sessUcase, jobUcase)
 	genreHandler := genreHandler.NewGenreHandler(genreUcase)
 	countryHandler := countryHandler.NewCountryHandler(countryUcase)
 	actorHandler := actorHandler.NewActorHandler(actorUcase)
 	directorHandler := directorHandler.NewDirectorHandler(directorUcase)
-	contentHandler := contentHandler.NewContentHandler(contentUcase, movieUcase, tvshowUcase)
-	movieHandler := movieHandler.NewMovieHandler(movieUcase, contentUcase, countryUcase, genreUcase, actorUcase, directorUcase)
+	contentHandler := contentHandler.NewContentHandler(contentUcase, movieUcase, tvshowUcase, jobUcase)
+	movieHandler := movieHandler.NewMovieHandler(movieUcase, contentUcase, countryUcase, genreUcase, actorUcase, directorUcase, entitlementUcase, videoSigner, jobUcase)
 	tvshowHandler := tvshowHandler.NewTVShowHandler(tvshowUcase, contentUcase, countryUcase, genreUcase, actorUcase, directorUcase, seasonUcase)
 	ratingHandler := ratingHandler.NewRatingHandler(ratingUcase)
 	favouriteHandler := favouriteHandler.NewFavouriteHandler(favouriteUcase, contentUcase)
 	seasonHandler := seasonHandler.NewSeasonHandler(seasonUcase)
-	episodeHandler := episodeHandler.NewEpisodeHandler(episodeUcase)
+	episodeHandler := episodeHandler.NewEpisodeHandler(episodeUcase, entitlementUcase, videoSigner, jobUcase)
 	searchHandler := searchHandler.NewSearchHandler(searchUcase)
-	subscriptionHandler := subscriptionHandler.NewSubscriptionHandler(subscriptionUsecase)
+	subscriptionHandler := subscriptionHandler.NewSubscriptionHandler(subscriptionUsecase, paymentUcase,
+		paymentProvider)
+	paymentHandler := paymentHandler.NewPaymentHandler(paymentUcase)
+	planHandler := planHandler.NewPlanHandler(planUcase)
+	videoHandler := videoHandler.NewVideoHandler(videoSigner, mntng, videosPath)
+	jobHandler := jobHandler.NewJobHandler(jobUcase)
+	progressHandler := progressHandler.NewProgressHandler(progressUcase)
+	recommendationHandler := recommendationHandler.NewRecommendationHandler(recommendationUcase)
+	oidcHandler := oidcHandler.NewOIDCHandler(oidcUcase, sessUcase)
+	auditHandler := auditHandler.NewAuditHandler(auditUcase)
+	trashHandler := trashHandler.NewTrashHandler(trashUcase)
 
 	userHandler.Configure(e, mw)
 	sessionHandler.Configure(e, mw)
@@ -197,6 +383,41 @@ func main() {
 	episodeHandler.Configure(e, mw)
 	searchHandler.Configure(e, mw)
 	subscriptionHandler.Configure(e, mw)
+	paymentHandler.Configure(e, mw)
+	planHandler.Configure(e, mw)
+	videoHandler.Configure(e, mw)
+	jobHandler.Configure(e, mw)
+	progressHandler.Configure(e, mw)
+	recommendationHandler.Configure(e, mw)
+	oidcHandler.Configure(e, mw)
+	auditHandler.Configure(e, mw)
+	trashHandler.Configure(e, mw)
+
+	// Background jobs
+	jobWorkers := workers.NewWorkerPool(jobUcase, consts.JobWorkersCount)
+	jobWorkers.Register(consts.JobContentPosters, contentUcase.ProcessPostersJob)
+	jobWorkers.Register(consts.JobEpisodePoster, episodeUcase.ProcessPosterJob)
+	jobWorkers.Register(consts.JobMovieVideo, movieUcase.ProcessVideoJob)
+	jobWorkers.Register(consts.JobEpisodeVideo, episodeUcase.ProcessVideoJob)
+	jobWorkers.Register(consts.JobUserAvatar, userUcase.ProcessAvatarJob)
+	jobWorkers.Start()
+
+	recommendationsRebuilder := recommendationWorkers.NewRebuilder(recommendationUcase,
+		helpers.NewAdvisoryLock(dbConnection, consts.RecommendationsRebuildLock), consts.RecommendationsRebuildInterval)
+	recommendationsRebuilder.Start()
+
+	suggestRefresher := searchWorkers.NewRefresher(suggestIndex, consts.SuggestRefreshInterval)
+	suggestRefresher.Start()
+
+	subscriptionSweeper := subscriptionWorkers.NewSweeper(subscriptionUsecase,
+		helpers.NewAdvisoryLock(dbConnection, consts.SubscriptionSweepLock), consts.SubscriptionSweepInterval)
+	subscriptionSweeper.Start()
+
+	contentPublisher := contentWorkers.NewPublisher(contentUcase, consts.ContentPublishInterval)
+	contentPublisher.Start()
+
+	trashPurger := trashWorkers.NewPurger(trashUcase, consts.TrashPurgeInterval)
+	trashPurger.Start()
 
 	log.Fatal(e.Start(config.GetServerConnString()))
 }
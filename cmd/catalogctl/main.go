@@ -0,0 +1,224 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/config"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/catalog"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/catalog/formats"
+	catalogUsecase "github.com/go-park-mail-ru/2020_2_Slash/internal/catalog/usecases"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
+	_ "github.com/lib/pq"
+
+	actorRepo "github.com/go-park-mail-ru/2020_2_Slash/internal/actor/repository"
+	actorUsecase "github.com/go-park-mail-ru/2020_2_Slash/internal/actor/usecases"
+	contentRepo "github.com/go-park-mail-ru/2020_2_Slash/internal/content/repository"
+	contentUsecase "github.com/go-park-mail-ru/2020_2_Slash/internal/content/usecases"
+	countryRepo "github.com/go-park-mail-ru/2020_2_Slash/internal/country/repository"
+	countryUsecase "github.com/go-park-mail-ru/2020_2_Slash/internal/country/usecases"
+	directorRepo "github.com/go-park-mail-ru/2020_2_Slash/internal/director/repository"
+	directorUsecase "github.com/go-park-mail-ru/2020_2_Slash/internal/director/usecases"
+	episodeRepo "github.com/go-park-mail-ru/2020_2_Slash/internal/episode/repository"
+	episodeUsecase "github.com/go-park-mail-ru/2020_2_Slash/internal/episode/usecases"
+	genreRepo "github.com/go-park-mail-ru/2020_2_Slash/internal/genre/repository"
+	genreUsecase "github.com/go-park-mail-ru/2020_2_Slash/internal/genre/usecases"
+	movieRepo "github.com/go-park-mail-ru/2020_2_Slash/internal/movie/repository"
+	movieUsecase "github.com/go-park-mail-ru/2020_2_Slash/internal/movie/usecases"
+	seasonRepo "github.com/go-park-mail-ru/2020_2_Slash/internal/season/repository"
+	seasonUsecase "github.com/go-park-mail-ru/2020_2_Slash/internal/season/usecases"
+	tvshowRepo "github.com/go-park-mail-ru/2020_2_Slash/internal/tvshow/repository"
+	tvshowUsecase "github.com/go-park-mail-ru/2020_2_Slash/internal/tvshow/usecases"
+)
+
+const usage = `Usage: catalogctl [flags] import FILE
+       catalogctl [flags] export [FILE]
+
+Imports or exports movies and TV shows with their seasons and episodes.
+Countries, genres, actors and directors are referenced by name,
+missing ones are created on import. Export writes to stdout without FILE.
+
+Flags:
+`
+
+func main() {
+	configPath := flag.String("config", "./config.json", "path to the config")
+	format := flag.String("format", "", "file format: json or csv, by default from file extension")
+	dryRun := flag.Bool("dry-run", false, "check the import without creating anything")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	command, path := flag.Arg(0), flag.Arg(1)
+	if (command != "import" || path == "") && command != "export" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *format == "" {
+		*format = formatByPath(path)
+	}
+	if *format != consts.CatalogJSONFormat && *format != consts.CatalogCSVFormat {
+		log.Fatalln("Unknown format", *format)
+	}
+
+	config, err := config.LoadConfig(*configPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Logger
+	logger.InitLogger(config.GetLoggerDir(), config.GetLogLevel())
+
+	// Database
+	dbConnection, err := sql.Open("postgres", config.GetProdDbConnString())
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer dbConnection.Close()
+
+	if err := dbConnection.Ping(); err != nil {
+		log.Fatal(err)
+	}
+
+	catalogUcase := newCatalogUsecase(dbConnection)
+
+	switch command {
+	case "import":
+		if !importCatalog(catalogUcase, path, *format, *dryRun) {
+			// nolint: gocritic
+			os.Exit(1)
+		}
+	case "export":
+		if err := exportCatalog(catalogUcase, path, *format); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+func newCatalogUsecase(dbConnection *sql.DB) catalog.CatalogUsecase {
+	// Repository
+	genreRepo := genreRepo.NewGenrePgRepository(dbConnection)
+	countryRepo := countryRepo.NewCountryPgRepository(dbConnection)
+	actorRepo := actorRepo.NewActorPgRepository(dbConnection)
+	directorRepo := directorRepo.NewDirectorPgRepository(dbConnection)
+	contentRepo := contentRepo.NewContentPgRepository(dbConnection)
+	movieRepo := movieRepo.NewMoviePgRepository(dbConnection)
+	tvshowRepo := tvshowRepo.NewTVShowPgRepository(dbConnection)
+	seasonRepo := seasonRepo.NewSeasonPgRepository(dbConnection)
+	episodeRepo := episodeRepo.NewEpisodeRepository(dbConnection)
+
+	// Suggestions are served by the app, which picks imported names up
+	// on its periodic refresh, so there is nothing to rebuild here
+	suggestIndex := nopSuggestIndex{}
+
+	// Usecases
+	genreUcase := genreUsecase.NewGenreUsecase(genreRepo)
+	countryUcase := countryUsecase.NewCountryUsecase(countryRepo)
+	actorUcase := actorUsecase.NewActorUseCase(actorRepo, suggestIndex)
+	directorUcase := directorUsecase.NewDirectorUseCase(directorRepo, suggestIndex)
+	contentUcase := contentUsecase.NewContentUsecase(contentRepo, countryUcase, genreUcase, actorUcase,
+		directorUcase, suggestIndex)
+	// Media jobs are processed by the app, catalog has nothing to package
+	movieUcase := movieUsecase.NewMovieUsecase(movieRepo, contentUcase, nil)
+	tvshowUcase := tvshowUsecase.NewTVShowUsecase(tvshowRepo, contentUcase)
+	seasonUcase := seasonUsecase.NewSeasonUsecase(seasonRepo, tvshowUcase)
+	episodeUcase := episodeUsecase.NewEpisodeUsecase(episodeRepo, seasonUcase, nil)
+
+	return catalogUsecase.NewCatalogUsecase(contentUcase, movieUcase, tvshowUcase, seasonUcase,
+		episodeUcase, countryUcase, genreUcase, actorUcase, directorUcase)
+}
+
+// importCatalog prints result of every item and reports whether all of them succeeded
+func importCatalog(catalogUcase catalog.CatalogUsecase, path, format string, dryRun bool) bool {
+	file, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
+
+	var items []*models.CatalogItem
+	if format == consts.CatalogCSVFormat {
+		items, err = formats.ReadCSV(file)
+	} else {
+		items, err = formats.ReadJSON(file)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	results, customErr := catalogUcase.Import(items, dryRun)
+	if customErr != nil {
+		log.Fatal(customErr.Message)
+	}
+
+	failed := 0
+	for _, result := range results {
+		switch {
+		case result.Error != "":
+			failed++
+			fmt.Printf("row %d: %s: error: %s\n", result.Row, result.Name, result.Error)
+		case dryRun:
+			fmt.Printf("row %d: %s: ok\n", result.Row, result.Name)
+		default:
+			fmt.Printf("row %d: %s: created content %d\n", result.Row, result.Name, result.ContentID)
+		}
+	}
+
+	fmt.Printf("%d of %d items imported", len(results)-failed, len(results))
+	if dryRun {
+		fmt.Print(", dry run, nothing was created")
+	}
+	fmt.Println()
+	return failed == 0
+}
+
+func exportCatalog(catalogUcase catalog.CatalogUsecase, path, format string) error {
+	items, customErr := catalogUcase.Export()
+	if customErr != nil {
+		return errors.New(customErr.Message)
+	}
+
+	var out io.Writer = os.Stdout
+	if path != "" {
+		file, err := os.Create(filepath.Clean(path))
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		out = file
+	}
+
+	if format == consts.CatalogCSVFormat {
+		return formats.WriteCSV(out, items)
+	}
+	return formats.WriteJSON(out, items)
+}
+
+// nopSuggestIndex lets usecases invalidate suggestions the CLI doesn't serve
+type nopSuggestIndex struct{}
+
+func (nopSuggestIndex) Rebuild() error { return nil }
+
+func (nopSuggestIndex) Invalidate() {}
+
+func (nopSuggestIndex) Suggest(prefix string, limit int) []*models.Suggestion {
+	return []*models.Suggestion{}
+}
+
+func formatByPath(path string) string {
+	if strings.EqualFold(filepath.Ext(path), "."+consts.CatalogCSVFormat) {
+		return consts.CatalogCSVFormat
+	}
+	return consts.CatalogJSONFormat
+}
@@ -5,10 +5,13 @@ import (
 	"log"
 	"net"
 
+	"github.com/gomodule/redigo/redis"
 	_ "github.com/lib/pq"
 	"google.golang.org/grpc"
 
 	"github.com/go-park-mail-ru/2020_2_Slash/config"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/session"
 	grpcSess "github.com/go-park-mail-ru/2020_2_Slash/internal/session/delivery/grpc"
 	sessionRepo "github.com/go-park-mail-ru/2020_2_Slash/internal/session/repository"
 	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
@@ -23,15 +26,43 @@ func main() {
 	// Logger
 	logger.InitLogger(config.GetLoggerDir(), config.GetLogLevel())
 
-	// Database
-	dbConnection, err := sql.Open("postgres", config.GetProdDbConnString())
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer dbConnection.Close()
+	// Session storage
+	var sessRepo session.SessionRepository
+	switch config.GetSessionStorageType() {
+	case consts.SessionRedisStorage:
+		redisAddress := config.GetSessionRedisConnString()
+		redisPool := &redis.Pool{
+			MaxIdle:     consts.SessionRedisMaxIdle,
+			IdleTimeout: consts.SessionRedisIdleTimeout,
+			Dial: func() (redis.Conn, error) {
+				return redis.Dial("tcp", redisAddress)
+			},
+		}
+		defer redisPool.Close()
 
-	if err := dbConnection.Ping(); err != nil {
-		log.Fatal(err)
+		conn := redisPool.Get()
+		if _, err := conn.Do("PING"); err != nil {
+			log.Fatal(err)
+		}
+		conn.Close()
+
+		sessRepo = sessionRepo.NewSessionRedisRepository(redisPool)
+	case consts.SessionMemoryStorage:
+		sessRepo = sessionRepo.NewSessionMemoryRepository()
+	case consts.SessionPostgresStorage:
+		dbConnection, err := sql.Open("postgres", config.GetProdDbConnString())
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer dbConnection.Close()
+
+		if err := dbConnection.Ping(); err != nil {
+			log.Fatal(err)
+		}
+
+		sessRepo = sessionRepo.NewSessionPgRepository(dbConnection)
+	default:
+		log.Fatalln("Unknown session storage", config.GetSessionStorageType())
 	}
 
 	authMsAdress := config.GetAuthMSConnString()
@@ -43,8 +74,6 @@ func main() {
 	}
 	defer lis.Close()
 
-	sessRepo := sessionRepo.NewSessionPgRepository(dbConnection)
-
 	server := grpc.NewServer()
 	grpcSess.RegisterSessionBlockServer(server, grpcSess.NewSessionBlockMicroservice(sessRepo))
 
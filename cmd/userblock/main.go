@@ -52,10 +52,11 @@ func main() {
 	}
 	defer lis.Close()
 
+	userTokenRepo := userRepo.NewUserTokenPgRepository(dbConnection)
 	userRepo := userRepo.NewUserPgRepository(dbConnection)
 
 	server := grpc.NewServer()
-	userGRPC.RegisterUserBlockServer(server, userGRPC.NewUserblockMicroservice(userRepo))
+	userGRPC.RegisterUserBlockServer(server, userGRPC.NewUserblockMicroservice(userRepo, userTokenRepo))
 
 	logger.Println("Starting server at", userblockAddress)
 
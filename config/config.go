@@ -2,9 +2,14 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/ioutil"
+	"net"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 )
 
 var logLevelsCode = map[string]int{
@@ -15,6 +20,18 @@ var logLevelsCode = map[string]int{
 	"FATAL": 50,
 }
 
+const (
+	defaultTrashRetentionDays = 30
+	// Secret shipped in config.json, states signed with it can be forged
+	defaultOIDCStateSecret = "flicksbox_oidc_state_secret"
+	// Secret once shipped in config.json, signed video URLs can be forged with it
+	defaultVideoURLSecret = "flicksbox_video_url_secret"
+	videoURLSecretEnv     = "FLICKSBOX_VIDEO_URL_SECRET"
+	// Deployment environment, anything but dev is treated as production
+	envNameEnv = "FLICKSBOX_ENV"
+	devEnvName = "dev"
+)
+
 type Database struct {
 	User     string `json:"user"`
 	Password string `json:"password"`
@@ -28,17 +45,69 @@ type Server struct {
 	Port int    `json:"port"`
 }
 
+type SessionStorage struct {
+	Type  string `json:"type"`
+	Redis Server `json:"redis"`
+}
+
+type PaymentProvider struct {
+	Name       string `json:"name"`
+	Receiver   string `json:"receiver"`
+	SecretFile string `json:"secret_file"`
+}
+
+type Mailer struct {
+	Type         string `json:"type"`
+	From         string `json:"from"`
+	SMTP         Server `json:"smtp"`
+	Username     string `json:"username"`
+	PasswordFile string `json:"password_file"`
+	Dir          string `json:"dir"`
+}
+
+type OIDCProvider struct {
+	Name             string   `json:"name"`
+	Issuer           string   `json:"issuer"`
+	ClientID         string   `json:"client_id"`
+	ClientSecretFile string   `json:"client_secret_file"`
+	RedirectURL      string   `json:"redirect_url"`
+	Scopes           []string `json:"scopes"`
+}
+
+type OIDC struct {
+	StateSecret string         `json:"state_secret"`
+	Providers   []OIDCProvider `json:"providers"`
+}
+
+// RateLimit of the route group, periods are set in seconds
+type RateLimit struct {
+	IPRequests      int `json:"ip_requests"`
+	AccountRequests int `json:"account_requests"`
+	Period          int `json:"period"`
+	Lockout         int `json:"lockout"`
+}
+
 type Config struct {
-	Database              Database `json:"database"`
-	TestDatabase          Database `json:"test_database"`
-	Server                Server   `json:"server"`
-	UserblockMicroservice Server   `json:"userblock_microservice"`
-	AuthMicroservice      Server   `json:"auth_microservice"`
-	AvatarsDir            string   `json:"avatars"`
-	PostersDir            string   `json:"posters"`
-	VideosDir             string   `json:"videos"`
-	LoggerFile            string   `json:"logger"`
-	LogLevel              string   `json:"log_level"`
+	Database              Database             `json:"database"`
+	TestDatabase          Database             `json:"test_database"`
+	Server                Server               `json:"server"`
+	UserblockMicroservice Server               `json:"userblock_microservice"`
+	AuthMicroservice      Server               `json:"auth_microservice"`
+	AvatarsDir            string               `json:"avatars"`
+	PostersDir            string               `json:"posters"`
+	VideosDir             string               `json:"videos"`
+	FFmpegPath            string               `json:"ffmpeg"`
+	LoggerFile            string               `json:"logger"`
+	LogLevel              string               `json:"log_level"`
+	SubscriptionGraceDays int                  `json:"subscription_grace_days"`
+	TrashRetentionDays    int                  `json:"trash_retention_days"`
+	PaymentProvider       PaymentProvider      `json:"payment_provider"`
+	SessionStorage        SessionStorage       `json:"session_storage"`
+	SiteURL               string               `json:"site_url"`
+	Mailer                Mailer               `json:"mailer"`
+	OIDC                  OIDC                 `json:"oidc"`
+	RateLimits            map[string]RateLimit `json:"rate_limits"`
+	TrustedProxies        []string             `json:"trusted_proxies"`
 }
 
 func getDbConnString(database Database) string {
@@ -68,6 +137,15 @@ func (c *Config) GetAuthMSConnString() string {
 		c.AuthMicroservice.Port)
 }
 
+func (c *Config) GetSessionStorageType() string {
+	return c.SessionStorage.Type
+}
+
+func (c *Config) GetSessionRedisConnString() string {
+	return fmt.Sprintf("%s:%d", c.SessionStorage.Redis.Host,
+		c.SessionStorage.Redis.Port)
+}
+
 func (c *Config) GetAvatarsPath() string {
 	return fmt.Sprintf("./%s", c.AvatarsDir)
 }
@@ -80,6 +158,143 @@ func (c *Config) GetVideosPath() string {
 	return fmt.Sprintf("./%s", c.VideosDir)
 }
 
+// GetVideoURLSecret reads the secret from the environment
+// and refuses empty and default secrets
+func (c *Config) GetVideoURLSecret() (string, error) {
+	secret := os.Getenv(videoURLSecretEnv)
+	if secret == "" || secret == defaultVideoURLSecret {
+		return "", fmt.Errorf("%s isn't set", videoURLSecretEnv)
+	}
+	return secret, nil
+}
+
+func (c *Config) GetFFmpegPath() string {
+	return c.FFmpegPath
+}
+
+func (c *Config) GetSubscriptionGracePeriod() time.Duration {
+	return time.Duration(c.SubscriptionGraceDays) * 24 * time.Hour
+}
+
+// GetTrashRetention falls back to the default retention if it isn't set,
+// zero retention would let the purger empty the trash at once
+func (c *Config) GetTrashRetention() time.Duration {
+	days := c.TrashRetentionDays
+	if days <= 0 {
+		days = defaultTrashRetentionDays
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// IsDev reports whether app runs in development environment
+func (c *Config) IsDev() bool {
+	return os.Getenv(envNameEnv) == devEnvName
+}
+
+func (c *Config) GetPaymentProviderName() string {
+	return c.PaymentProvider.Name
+}
+
+func (c *Config) GetPaymentReceiver() string {
+	return c.PaymentProvider.Receiver
+}
+
+func (c *Config) GetPaymentSecret() (string, error) {
+	secret, err := ioutil.ReadFile(filepath.Clean(c.PaymentProvider.SecretFile))
+	if err != nil {
+		return "", err
+	}
+	return string(secret), nil
+}
+
+func (c *Config) GetSiteURL() string {
+	return c.SiteURL
+}
+
+func (c *Config) GetMailerType() string {
+	return c.Mailer.Type
+}
+
+func (c *Config) GetMailFrom() string {
+	return c.Mailer.From
+}
+
+func (c *Config) GetSMTPConnString() string {
+	return fmt.Sprintf("%s:%d", c.Mailer.SMTP.Host, c.Mailer.SMTP.Port)
+}
+
+func (c *Config) GetSMTPUsername() string {
+	return c.Mailer.Username
+}
+
+// GetSMTPPassword returns empty password if SMTP server doesn't need authentication
+func (c *Config) GetSMTPPassword() (string, error) {
+	if c.Mailer.PasswordFile == "" {
+		return "", nil
+	}
+	password, err := ioutil.ReadFile(filepath.Clean(c.Mailer.PasswordFile))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(password)), nil
+}
+
+func (c *Config) GetMailsPath() string {
+	return fmt.Sprintf("./%s", c.Mailer.Dir)
+}
+
+// GetOIDCStateSecret refuses empty and default secrets,
+// anyone knowing the secret can forge the sign in state
+func (c *Config) GetOIDCStateSecret() (string, error) {
+	secret := c.OIDC.StateSecret
+	if secret == "" || secret == defaultOIDCStateSecret {
+		return "", errors.New("oidc state secret isn't set")
+	}
+	return secret, nil
+}
+
+func (c *Config) GetOIDCProviders() []OIDCProvider {
+	return c.OIDC.Providers
+}
+
+// GetClientSecret returns empty secret for public clients
+func (p *OIDCProvider) GetClientSecret() (string, error) {
+	if p.ClientSecretFile == "" {
+		return "", nil
+	}
+	secret, err := ioutil.ReadFile(filepath.Clean(p.ClientSecretFile))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(secret)), nil
+}
+
+// GetTrustedProxies returns networks of the proxies allowed to pass
+// client IP in X-Real-IP, they are set in CIDR notation
+func (c *Config) GetTrustedProxies() ([]*net.IPNet, error) {
+	proxies := make([]*net.IPNet, 0, len(c.TrustedProxies))
+	for _, cidr := range c.TrustedProxies {
+		_, ipRange, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, err
+		}
+		proxies = append(proxies, ipRange)
+	}
+	return proxies, nil
+}
+
+func (c *Config) GetRateLimits() map[string]RateLimit {
+	return c.RateLimits
+}
+
+func (l *RateLimit) GetPeriod() time.Duration {
+	return time.Duration(l.Period) * time.Second
+}
+
+func (l *RateLimit) GetLockout() time.Duration {
+	return time.Duration(l.Lockout) * time.Second
+}
+
 func (c *Config) GetLoggerDir() string {
 	return c.LoggerFile
 }
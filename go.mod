@@ -11,6 +11,7 @@ require (
 	github.com/golang/mock v1.4.4
 	github.com/golang/protobuf v1.4.3
 	github.com/golang/snappy v0.0.2 // indirect
+	github.com/gomodule/redigo v1.8.3
 	github.com/h2non/bimg v1.1.5 // indirect
 	github.com/jinzhu/copier v0.1.0
 	github.com/labstack/echo/v4 v4.1.17
@@ -26,10 +26,10 @@ func NewActorHandler(actorUseCase actor.ActorUseCase) *ActorHandler {
 }
 
 func (ah *ActorHandler) Configure(e *echo.Echo, mw *mwares.MiddlewareManager) {
-	e.POST("/api/v1/actors", ah.CreateActorHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF)
-	e.PUT("/api/v1/actors/:id", ah.ChangeActorHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF)
+	e.POST("/api/v1/actors", ah.CreateActorHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF, mw.Audit(consts.AuditActor, ""))
+	e.PUT("/api/v1/actors/:id", ah.ChangeActorHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF, mw.Audit(consts.AuditActor, "id"))
 	e.GET("/api/v1/actors/:id", ah.GetActorHandler())
-	e.DELETE("/api/v1/actors/:id", ah.DeleteActorHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF)
+	e.DELETE("/api/v1/actors/:id", ah.DeleteActorHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF, mw.Audit(consts.AuditActor, "id"))
 	e.GET("/api/v1/actors", ah.GetActorsListHandler())
 }
 
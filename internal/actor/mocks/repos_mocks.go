@@ -90,19 +90,19 @@ func (mr *MockActorRepositoryMockRecorder) SelectById(id interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectById", reflect.TypeOf((*MockActorRepository)(nil).SelectById), id)
 }
 
-// SelectWhereNameLike mocks base method
-func (m *MockActorRepository) SelectWhereNameLike(name string, limit, offset uint64) ([]*models.Actor, error) {
+// SelectByQuery mocks base method
+func (m *MockActorRepository) SelectByQuery(query string, pgnt *models.Pagination) ([]*models.Actor, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SelectWhereNameLike", name, limit, offset)
+	ret := m.ctrl.Call(m, "SelectByQuery", query, pgnt)
 	ret0, _ := ret[0].([]*models.Actor)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// SelectWhereNameLike indicates an expected call of SelectWhereNameLike
-func (mr *MockActorRepositoryMockRecorder) SelectWhereNameLike(name, limit, offset interface{}) *gomock.Call {
+// SelectByQuery indicates an expected call of SelectByQuery
+func (mr *MockActorRepositoryMockRecorder) SelectByQuery(query, pgnt interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectWhereNameLike", reflect.TypeOf((*MockActorRepository)(nil).SelectWhereNameLike), name, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectByQuery", reflect.TypeOf((*MockActorRepository)(nil).SelectByQuery), query, pgnt)
 }
 
 // SelectAll mocks base method
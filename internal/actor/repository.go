@@ -7,6 +7,6 @@ type ActorRepository interface {
 	Update(actor *models.Actor) error
 	DeleteById(id uint64) error
 	SelectById(id uint64) (*models.Actor, error)
-	SelectWhereNameLike(name string, limit, offset uint64) ([]*models.Actor, error)
+	SelectByQuery(query string, pgnt *models.Pagination) ([]*models.Actor, error)
 	SelectAll(pgnt *models.Pagination) ([]*models.Actor, error)
 }
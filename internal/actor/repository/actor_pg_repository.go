@@ -3,12 +3,14 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"fmt"
 
 	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
 
 	"strings"
 
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/actor"
+	queryBuilder "github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/query_builder"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
 )
 
@@ -106,21 +108,21 @@ func (rep *ActorPgRepository) SelectById(id uint64) (*models.Actor, error) {
 	return dbActor, nil
 }
 
-func (rep *ActorPgRepository) SelectWhereNameLike(name string, limit, offset uint64) ([]*models.Actor, error) {
-	selectQuery := `
+func (rep *ActorPgRepository) SelectByQuery(query string, pgnt *models.Pagination) ([]*models.Actor, error) {
+	var values []interface{}
+
+	selectQuery := fmt.Sprintf(`
 		SELECT id, name
 		FROM actors
-		WHERE name ILIKE $1
-		ORDER BY id`
-
-	var values []interface{}
-	searchName := "%" + name + "%"
-	values = append(values, searchName)
+		WHERE %s
+		ORDER BY word_similarity($1, name) DESC, id`,
+		queryBuilder.BuildNameSearchCondition("name", "simple", 1))
+	values = append(values, query)
 
 	var pgntQuery string
-	if limit != 0 {
+	if pgnt.Count != 0 {
 		pgntQuery = "LIMIT $2 OFFSET $3"
-		values = append(values, limit, offset)
+		values = append(values, pgnt.Count, pgnt.From)
 	}
 
 	resultQuery := strings.Join([]string{
@@ -135,21 +137,18 @@ func (rep *ActorPgRepository) SelectWhereNameLike(name string, limit, offset uin
 	defer rows.Close()
 
 	var actors []*models.Actor
-
 	for rows.Next() {
 		actor := &models.Actor{}
-
 		err := rows.Scan(&actor.ID, &actor.Name)
 		if err != nil {
 			return nil, err
 		}
-
 		actors = append(actors, actor)
 	}
-	if err = rows.Err(); err != nil {
+
+	if err := rows.Err(); err != nil {
 		return nil, err
 	}
-
 	return actors, nil
 }
 
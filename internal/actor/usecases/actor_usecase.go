@@ -7,15 +7,18 @@ import (
 	. "github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/search"
 )
 
 type ActorUseCase struct {
-	actorRepo actor.ActorRepository
+	actorRepo    actor.ActorRepository
+	suggestIndex search.SuggestIndex
 }
 
-func NewActorUseCase(repo actor.ActorRepository) actor.ActorUseCase {
+func NewActorUseCase(repo actor.ActorRepository, suggestIndex search.SuggestIndex) actor.ActorUseCase {
 	return &ActorUseCase{
-		actorRepo: repo,
+		actorRepo:    repo,
+		suggestIndex: suggestIndex,
 	}
 }
 
@@ -24,6 +27,7 @@ func (au *ActorUseCase) Create(actor *models.Actor) *errors.Error {
 	if err != nil {
 		return errors.New(CodeInternalError, err)
 	}
+	au.suggestIndex.Invalidate()
 	return nil
 }
 
@@ -45,6 +49,7 @@ func (au *ActorUseCase) Change(newActor *models.Actor) *errors.Error {
 	if err := au.actorRepo.Update(newActor); err != nil {
 		return errors.New(CodeInternalError, err)
 	}
+	au.suggestIndex.Invalidate()
 
 	return nil
 }
@@ -57,6 +62,7 @@ func (au *ActorUseCase) DeleteById(id uint64) *errors.Error {
 	if err := au.actorRepo.DeleteById(id); err != nil {
 		return errors.New(CodeInternalError, err)
 	}
+	au.suggestIndex.Invalidate()
 
 	return nil
 }
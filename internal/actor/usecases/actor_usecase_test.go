@@ -8,6 +8,7 @@ import (
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	searchMocks "github.com/go-park-mail-ru/2020_2_Slash/internal/search/mocks"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 )
@@ -17,7 +18,8 @@ func TestActorUseCase_Create_OK(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	actorRep := mocks.NewMockActorRepository(ctrl)
-	actorUseCase := NewActorUseCase(actorRep)
+	suggestIndex := searchMocks.NewMockSuggestIndex(ctrl)
+	actorUseCase := NewActorUseCase(actorRep, suggestIndex)
 
 	actor := &models.Actor{
 		Name: "Jamie Fox",
@@ -28,6 +30,8 @@ func TestActorUseCase_Create_OK(t *testing.T) {
 		Insert(gomock.Eq(actor)).
 		Return(nil)
 
+	suggestIndex.EXPECT().Invalidate()
+
 	err := actorUseCase.Create(actor)
 	assert.Equal(t, err, (*errors.Error)(nil))
 }
@@ -37,7 +41,8 @@ func TestActorUseCase_Get_OK(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	actorRep := mocks.NewMockActorRepository(ctrl)
-	actorUseCase := NewActorUseCase(actorRep)
+	suggestIndex := searchMocks.NewMockSuggestIndex(ctrl)
+	actorUseCase := NewActorUseCase(actorRep, suggestIndex)
 
 	actor := &models.Actor{
 		ID:   3,
@@ -59,7 +64,8 @@ func TestActorUseCase_Get_Fail(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	actorRep := mocks.NewMockActorRepository(ctrl)
-	actorUseCase := NewActorUseCase(actorRep)
+	suggestIndex := searchMocks.NewMockSuggestIndex(ctrl)
+	actorUseCase := NewActorUseCase(actorRep, suggestIndex)
 
 	actor := &models.Actor{
 		ID:   3,
@@ -81,7 +87,8 @@ func TestActorUseCase_Delete_OK(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	actorRep := mocks.NewMockActorRepository(ctrl)
-	actorUseCase := NewActorUseCase(actorRep)
+	suggestIndex := searchMocks.NewMockSuggestIndex(ctrl)
+	actorUseCase := NewActorUseCase(actorRep, suggestIndex)
 
 	actor := &models.Actor{
 		ID:   3,
@@ -98,6 +105,8 @@ func TestActorUseCase_Delete_OK(t *testing.T) {
 		DeleteById(gomock.Eq(actor.ID)).
 		Return(nil)
 
+	suggestIndex.EXPECT().Invalidate()
+
 	err := actorUseCase.DeleteById(actor.ID)
 	assert.Equal(t, err, (*errors.Error)(nil))
 }
@@ -107,7 +116,8 @@ func TestActorUseCase_Update_OK(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	actorRep := mocks.NewMockActorRepository(ctrl)
-	actorUseCase := NewActorUseCase(actorRep)
+	suggestIndex := searchMocks.NewMockSuggestIndex(ctrl)
+	actorUseCase := NewActorUseCase(actorRep, suggestIndex)
 
 	actor := &models.Actor{
 		ID:   3,
@@ -124,6 +134,8 @@ func TestActorUseCase_Update_OK(t *testing.T) {
 		Update(gomock.Eq(actor)).
 		Return(nil)
 
+	suggestIndex.EXPECT().Invalidate()
+
 	err := actorUseCase.Change(actor)
 	assert.Equal(t, err, (*errors.Error)(nil))
 }
@@ -133,7 +145,8 @@ func TestActorUseCase_ListByID_OK(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	actorRep := mocks.NewMockActorRepository(ctrl)
-	actorUseCase := NewActorUseCase(actorRep)
+	suggestIndex := searchMocks.NewMockSuggestIndex(ctrl)
+	actorUseCase := NewActorUseCase(actorRep, suggestIndex)
 
 	actors := []*models.Actor{
 		&models.Actor{
@@ -168,7 +181,8 @@ func TestActorUseCase_List_OK(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	actorRep := mocks.NewMockActorRepository(ctrl)
-	actorUseCase := NewActorUseCase(actorRep)
+	suggestIndex := searchMocks.NewMockSuggestIndex(ctrl)
+	actorUseCase := NewActorUseCase(actorRep, suggestIndex)
 
 	actors := []*models.Actor{
 		&models.Actor{
@@ -0,0 +1,80 @@
+package delivery
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/audit"
+	. "github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/mwares"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
+	reader "github.com/go-park-mail-ru/2020_2_Slash/tools/request_reader"
+	. "github.com/go-park-mail-ru/2020_2_Slash/tools/response"
+	"github.com/labstack/echo/v4"
+)
+
+type AuditHandler struct {
+	auditUcase audit.AuditUsecase
+}
+
+func NewAuditHandler(auditUcase audit.AuditUsecase) *AuditHandler {
+	return &AuditHandler{
+		auditUcase: auditUcase,
+	}
+}
+
+func (ah *AuditHandler) Configure(e *echo.Echo, mw *mwares.MiddlewareManager) {
+	e.GET("/api/v1/admin/audit", ah.GetAuditHandler(), mw.CheckAuth, mw.CheckAdmin)
+}
+
+// GetAuditHandler lists records from the "since" date to the "until" date inclusive,
+// "from" and "count" are left for pagination
+func (ah *AuditHandler) GetAuditHandler() echo.HandlerFunc {
+	type Request struct {
+		EntityType string `query:"entity_type"`
+		EntityID   uint64 `query:"entity_id"`
+		Since      string `query:"since" validate:"omitempty,datetime=2006-01-02"`
+		Until      string `query:"until" validate:"omitempty,datetime=2006-01-02"`
+		models.Pagination
+	}
+
+	return func(cntx echo.Context) error {
+		req := &Request{}
+		if err := reader.NewRequestReader(cntx).Read(req); err != nil {
+			logger.Error(err.Message)
+			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		}
+
+		filter := &models.AuditFilter{
+			EntityType: req.EntityType,
+			EntityID:   req.EntityID,
+		}
+		var parseErr error
+		if req.Since != "" {
+			filter.From, parseErr = time.Parse(AuditDateLayout, req.Since)
+		}
+		if req.Until != "" && parseErr == nil {
+			filter.To, parseErr = time.Parse(AuditDateLayout, req.Until)
+			filter.To = filter.To.AddDate(0, 0, 1)
+		}
+		if parseErr != nil {
+			customErr := errors.New(CodeBadRequest, parseErr)
+			logger.Error(customErr.Message)
+			return cntx.JSON(customErr.HTTPCode, Response{Error: customErr})
+		}
+
+		records, err := ah.auditUcase.List(filter, &req.Pagination)
+		if err != nil {
+			logger.Error(err.Message)
+			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		}
+
+		return cntx.JSON(http.StatusOK, Response{
+			Body: &Body{
+				"records": records,
+			},
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package delivery
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/audit/mocks"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/pkg/converter"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/response"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditHandler_GetAuditHandler(t *testing.T) {
+	t.Parallel()
+	// Setup
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	auditUseCase := mocks.NewMockAuditUsecase(ctrl)
+
+	records := []*models.AuditRecord{
+		&models.AuditRecord{
+			ID:         1,
+			UserID:     3,
+			Action:     consts.AuditDelete,
+			EntityType: consts.AuditGenre,
+			EntityID:   2,
+			IP:         "127.0.0.1",
+		},
+	}
+	filter := &models.AuditFilter{
+		EntityType: consts.AuditGenre,
+		EntityID:   2,
+		From:       time.Date(2020, 12, 1, 0, 0, 0, 0, time.UTC),
+		To:         time.Date(2020, 12, 11, 0, 0, 0, 0, time.UTC),
+	}
+	pgnt := &models.Pagination{From: 0, Count: 10}
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet,
+		"/api/v1/admin/audit?entity_type=genre&entity_id=2&since=2020-12-01&until=2020-12-10&from=0&count=10",
+		strings.NewReader(""))
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	auditHandler := NewAuditHandler(auditUseCase)
+	handleFunc := auditHandler.GetAuditHandler()
+
+	auditUseCase.
+		EXPECT().
+		List(filter, pgnt).
+		Return(records, nil)
+
+	response := &response.Response{Body: &response.Body{"records": records}}
+
+	// Assertions
+	if assert.NoError(t, handleFunc(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		expResBody, err := converter.AnyToBytesBuffer(response)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		bytes, _ := ioutil.ReadAll(rec.Body)
+
+		assert.JSONEq(t, expResBody.String(), string(bytes))
+	}
+}
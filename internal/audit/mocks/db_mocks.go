@@ -0,0 +1,44 @@
+package mocks
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+)
+
+func MockAuditRepoInsertReturnRows(mock sqlmock.Sqlmock, record *models.AuditRecord) {
+	changes, _ := json.Marshal(record.Changes)
+	mock.ExpectBegin()
+	rows := sqlmock.NewRows([]string{"id", "created"})
+	rows.AddRow(record.ID, record.Created)
+	mock.ExpectQuery(`INSERT INTO audit_log`).
+		WithArgs(record.UserID, record.Action, record.EntityType, record.EntityID,
+			changes, record.IP).
+		WillReturnRows(rows)
+	mock.ExpectCommit()
+}
+
+func MockAuditRepoInsertReturnErrNoRows(mock sqlmock.Sqlmock, record *models.AuditRecord) {
+	changes, _ := json.Marshal(record.Changes)
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO audit_log`).
+		WithArgs(record.UserID, record.Action, record.EntityType, record.EntityID,
+			changes, record.IP).
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+}
+
+func MockAuditRepoSelectReturnRows(mock sqlmock.Sqlmock, record *models.AuditRecord,
+	args ...driver.Value) {
+	changes, _ := json.Marshal(record.Changes)
+	rows := sqlmock.NewRows([]string{"id", "user_id", "action", "entity_type",
+		"entity_id", "changes", "ip", "created"})
+	rows.AddRow(record.ID, record.UserID, record.Action, record.EntityType,
+		record.EntityID, changes, record.IP, record.Created)
+	mock.ExpectQuery(`SELECT (.+) FROM audit_log`).
+		WithArgs(args...).
+		WillReturnRows(rows)
+}
@@ -0,0 +1,91 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/audit/usecase.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	audit "github.com/go-park-mail-ru/2020_2_Slash/internal/audit"
+	errors "github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	models "github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockAuditUsecase is a mock of AuditUsecase interface
+type MockAuditUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditUsecaseMockRecorder
+}
+
+// MockAuditUsecaseMockRecorder is the mock recorder for MockAuditUsecase
+type MockAuditUsecaseMockRecorder struct {
+	mock *MockAuditUsecase
+}
+
+// NewMockAuditUsecase creates a new mock instance
+func NewMockAuditUsecase(ctrl *gomock.Controller) *MockAuditUsecase {
+	mock := &MockAuditUsecase{ctrl: ctrl}
+	mock.recorder = &MockAuditUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockAuditUsecase) EXPECT() *MockAuditUsecaseMockRecorder {
+	return m.recorder
+}
+
+// RegisterEntity mocks base method
+func (m *MockAuditUsecase) RegisterEntity(entityType string, loader audit.EntityLoader) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RegisterEntity", entityType, loader)
+}
+
+// RegisterEntity indicates an expected call of RegisterEntity
+func (mr *MockAuditUsecaseMockRecorder) RegisterEntity(entityType, loader interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterEntity", reflect.TypeOf((*MockAuditUsecase)(nil).RegisterEntity), entityType, loader)
+}
+
+// Snapshot mocks base method
+func (m *MockAuditUsecase) Snapshot(entityType string, entityID uint64) interface{} {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Snapshot", entityType, entityID)
+	ret0, _ := ret[0].(interface{})
+	return ret0
+}
+
+// Snapshot indicates an expected call of Snapshot
+func (mr *MockAuditUsecaseMockRecorder) Snapshot(entityType, entityID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Snapshot", reflect.TypeOf((*MockAuditUsecase)(nil).Snapshot), entityType, entityID)
+}
+
+// Record mocks base method
+func (m *MockAuditUsecase) Record(record *models.AuditRecord, before, after interface{}) *errors.Error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", record, before, after)
+	ret0, _ := ret[0].(*errors.Error)
+	return ret0
+}
+
+// Record indicates an expected call of Record
+func (mr *MockAuditUsecaseMockRecorder) Record(record, before, after interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockAuditUsecase)(nil).Record), record, before, after)
+}
+
+// List mocks base method
+func (m *MockAuditUsecase) List(filter *models.AuditFilter, pgnt *models.Pagination) ([]*models.AuditRecord, *errors.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", filter, pgnt)
+	ret0, _ := ret[0].([]*models.AuditRecord)
+	ret1, _ := ret[1].(*errors.Error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List
+func (mr *MockAuditUsecaseMockRecorder) List(filter, pgnt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAuditUsecase)(nil).List), filter, pgnt)
+}
@@ -0,0 +1,8 @@
+package audit
+
+import "github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+
+type AuditRepository interface {
+	Insert(record *models.AuditRecord) error
+	Select(filter *models.AuditFilter, pgnt *models.Pagination) ([]*models.AuditRecord, error)
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/audit"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
+)
+
+type AuditPgRepository struct {
+	dbConn *sql.DB
+}
+
+func NewAuditPgRepository(conn *sql.DB) audit.AuditRepository {
+	return &AuditPgRepository{
+		dbConn: conn,
+	}
+}
+
+func (rep *AuditPgRepository) Insert(record *models.AuditRecord) error {
+	changes, err := json.Marshal(record.Changes)
+	if err != nil {
+		return err
+	}
+
+	tx, err := rep.dbConn.BeginTx(context.Background(), &sql.TxOptions{})
+	if err != nil {
+		return err
+	}
+
+	row := tx.QueryRow(
+		`INSERT INTO audit_log(user_id, action, entity_type, entity_id, changes, ip)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created`,
+		record.UserID, record.Action, record.EntityType, record.EntityID, changes, record.IP)
+
+	if err := row.Scan(&record.ID, &record.Created); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			logger.Error(rollbackErr.Error())
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (rep *AuditPgRepository) Select(filter *models.AuditFilter,
+	pgnt *models.Pagination) ([]*models.AuditRecord, error) {
+	var values []interface{}
+	var conditions []string
+
+	if filter.EntityType != "" {
+		values = append(values, filter.EntityType)
+		conditions = append(conditions, fmt.Sprintf("entity_type=$%d", len(values)))
+	}
+	if filter.EntityID != 0 {
+		values = append(values, filter.EntityID)
+		conditions = append(conditions, fmt.Sprintf("entity_id=$%d", len(values)))
+	}
+	if !filter.From.IsZero() {
+		values = append(values, filter.From)
+		conditions = append(conditions, fmt.Sprintf("created>=$%d", len(values)))
+	}
+	if !filter.To.IsZero() {
+		values = append(values, filter.To)
+		conditions = append(conditions, fmt.Sprintf("created<$%d", len(values)))
+	}
+
+	selectQuery := `
+		SELECT id, user_id, action, entity_type, entity_id, changes, ip, created
+		FROM audit_log`
+
+	var whereQuery string
+	if len(conditions) != 0 {
+		whereQuery = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	orderQuery := "ORDER BY created DESC, id DESC"
+
+	var pgntQuery string
+	if pgnt.Count != 0 {
+		pgntQuery = fmt.Sprintf("LIMIT $%d OFFSET $%d", len(values)+1, len(values)+2)
+		values = append(values, pgnt.Count, pgnt.From)
+	}
+
+	resultQuery := strings.Join([]string{
+		selectQuery,
+		whereQuery,
+		orderQuery,
+		pgntQuery,
+	}, " ")
+
+	rows, err := rep.dbConn.Query(resultQuery, values...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []*models.AuditRecord
+	for rows.Next() {
+		record := &models.AuditRecord{}
+		var changes []byte
+		err := rows.Scan(&record.ID, &record.UserID, &record.Action, &record.EntityType,
+			&record.EntityID, &changes, &record.IP, &record.Created)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(changes, &record.Changes); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/audit/mocks"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+var testRecord = &models.AuditRecord{
+	ID:         1,
+	UserID:     3,
+	Action:     consts.AuditUpdate,
+	EntityType: consts.AuditGenre,
+	EntityID:   2,
+	Changes: map[string]*models.AuditChange{
+		"name": &models.AuditChange{
+			Before: json.RawMessage(`"comedy"`),
+			After:  json.RawMessage(`"drama"`),
+		},
+	},
+	IP:      "127.0.0.1",
+	Created: time.Now(),
+}
+
+func TestAuditPgRepository_Insert_OK(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	auditPgRep := NewAuditPgRepository(db)
+
+	record := *testRecord
+	mocks.MockAuditRepoInsertReturnRows(mock, &record)
+	err = auditPgRep.Insert(&record)
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestAuditPgRepository_Insert_Fail(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	auditPgRep := NewAuditPgRepository(db)
+
+	record := *testRecord
+	mocks.MockAuditRepoInsertReturnErrNoRows(mock, &record)
+	err = auditPgRep.Insert(&record)
+	assert.Equal(t, sql.ErrNoRows, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestAuditPgRepository_Select_OK(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	auditPgRep := NewAuditPgRepository(db)
+
+	filter := &models.AuditFilter{
+		EntityType: testRecord.EntityType,
+		EntityID:   testRecord.EntityID,
+	}
+	pgnt := &models.Pagination{From: 0, Count: 10}
+
+	mocks.MockAuditRepoSelectReturnRows(mock, testRecord,
+		filter.EntityType, filter.EntityID, pgnt.Count, pgnt.From)
+	records, err := auditPgRep.Select(filter, pgnt)
+	assert.NoError(t, err)
+	assert.Equal(t, []*models.AuditRecord{testRecord}, records)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,17 @@
+package audit
+
+import (
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+)
+
+// EntityLoader returns current state of the entity to be saved in the log
+type EntityLoader func(entityID uint64) (interface{}, *errors.Error)
+
+type AuditUsecase interface {
+	RegisterEntity(entityType string, loader EntityLoader)
+	// Snapshot returns nil if the entity doesn't exist
+	Snapshot(entityType string, entityID uint64) interface{}
+	Record(record *models.AuditRecord, before, after interface{}) *errors.Error
+	List(filter *models.AuditFilter, pgnt *models.Pagination) ([]*models.AuditRecord, *errors.Error)
+}
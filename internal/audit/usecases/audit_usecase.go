@@ -0,0 +1,113 @@
+package usecases
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/audit"
+	. "github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+)
+
+type AuditUsecase struct {
+	auditRepo audit.AuditRepository
+
+	mu      sync.RWMutex
+	loaders map[string]audit.EntityLoader
+}
+
+func NewAuditUsecase(repo audit.AuditRepository) audit.AuditUsecase {
+	return &AuditUsecase{
+		auditRepo: repo,
+		loaders:   make(map[string]audit.EntityLoader),
+	}
+}
+
+func (au *AuditUsecase) RegisterEntity(entityType string, loader audit.EntityLoader) {
+	au.mu.Lock()
+	defer au.mu.Unlock()
+	au.loaders[entityType] = loader
+}
+
+func (au *AuditUsecase) Snapshot(entityType string, entityID uint64) interface{} {
+	au.mu.RLock()
+	loader, ok := au.loaders[entityType]
+	au.mu.RUnlock()
+	if !ok || entityID == 0 {
+		return nil
+	}
+
+	entity, err := loader(entityID)
+	if err != nil {
+		return nil
+	}
+	return entity
+}
+
+// Record saves fields of the entity that differ before and after the action
+func (au *AuditUsecase) Record(record *models.AuditRecord, before, after interface{}) *errors.Error {
+	changes, err := diffFields(before, after)
+	if err != nil {
+		return errors.New(CodeInternalError, err)
+	}
+	record.Changes = changes
+
+	if err := au.auditRepo.Insert(record); err != nil {
+		return errors.New(CodeInternalError, err)
+	}
+	return nil
+}
+
+func (au *AuditUsecase) List(filter *models.AuditFilter,
+	pgnt *models.Pagination) ([]*models.AuditRecord, *errors.Error) {
+	records, err := au.auditRepo.Select(filter, pgnt)
+	if err != nil {
+		return nil, errors.New(CodeInternalError, err)
+	}
+	if len(records) == 0 {
+		return []*models.AuditRecord{}, nil
+	}
+	return records, nil
+}
+
+// diffFields compares top level fields of JSON representations
+func diffFields(before, after interface{}) (map[string]*models.AuditChange, error) {
+	beforeFields, err := jsonFields(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := jsonFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string]*models.AuditChange)
+	for field, beforeValue := range beforeFields {
+		if afterValue, ok := afterFields[field]; !ok || !bytes.Equal(beforeValue, afterValue) {
+			changes[field] = &models.AuditChange{Before: beforeValue, After: afterValue}
+		}
+	}
+	for field, afterValue := range afterFields {
+		if _, ok := beforeFields[field]; !ok {
+			changes[field] = &models.AuditChange{After: afterValue}
+		}
+	}
+	return changes, nil
+}
+
+func jsonFields(entity interface{}) (map[string]json.RawMessage, error) {
+	fields := make(map[string]json.RawMessage)
+	if entity == nil {
+		return fields, nil
+	}
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
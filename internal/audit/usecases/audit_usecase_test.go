@@ -0,0 +1,143 @@
+package usecases
+
+import (
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/audit/mocks"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var before = &models.Genre{
+	ID:   2,
+	Name: "comedy",
+}
+
+var after = &models.Genre{
+	ID:   2,
+	Name: "drama",
+}
+
+func newRecord(action string) *models.AuditRecord {
+	return &models.AuditRecord{
+		UserID:     3,
+		Action:     action,
+		EntityType: consts.AuditGenre,
+		EntityID:   before.ID,
+		IP:         "127.0.0.1",
+	}
+}
+
+func TestAuditUsecase_Snapshot_OK(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	auditRep := mocks.NewMockAuditRepository(ctrl)
+	auditUseCase := NewAuditUsecase(auditRep)
+
+	auditUseCase.RegisterEntity(consts.AuditGenre, func(id uint64) (interface{}, *errors.Error) {
+		if id != before.ID {
+			return nil, errors.Get(consts.CodeGenreDoesNotExist)
+		}
+		return before, nil
+	})
+
+	assert.Equal(t, before, auditUseCase.Snapshot(consts.AuditGenre, before.ID))
+	assert.Nil(t, auditUseCase.Snapshot(consts.AuditGenre, before.ID+1))
+	assert.Nil(t, auditUseCase.Snapshot(consts.AuditActor, before.ID))
+}
+
+func TestAuditUsecase_Record_Update(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	auditRep := mocks.NewMockAuditRepository(ctrl)
+	auditUseCase := NewAuditUsecase(auditRep)
+
+	record := newRecord(consts.AuditUpdate)
+	expected := newRecord(consts.AuditUpdate)
+	expected.Changes = map[string]*models.AuditChange{
+		"name": &models.AuditChange{
+			Before: json.RawMessage(`"comedy"`),
+			After:  json.RawMessage(`"drama"`),
+		},
+	}
+
+	auditRep.
+		EXPECT().
+		Insert(expected).
+		Return(nil)
+
+	err := auditUseCase.Record(record, before, after)
+	assert.Nil(t, err)
+}
+
+func TestAuditUsecase_Record_Delete(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	auditRep := mocks.NewMockAuditRepository(ctrl)
+	auditUseCase := NewAuditUsecase(auditRep)
+
+	record := newRecord(consts.AuditDelete)
+	expected := newRecord(consts.AuditDelete)
+	expected.Changes = map[string]*models.AuditChange{
+		"id": &models.AuditChange{
+			Before: json.RawMessage(`2`),
+		},
+		"name": &models.AuditChange{
+			Before: json.RawMessage(`"comedy"`),
+		},
+	}
+
+	auditRep.
+		EXPECT().
+		Insert(expected).
+		Return(nil)
+
+	err := auditUseCase.Record(record, before, nil)
+	assert.Nil(t, err)
+}
+
+func TestAuditUsecase_Record_Fail(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	auditRep := mocks.NewMockAuditRepository(ctrl)
+	auditUseCase := NewAuditUsecase(auditRep)
+
+	record := newRecord(consts.AuditUpdate)
+
+	auditRep.
+		EXPECT().
+		Insert(gomock.Any()).
+		Return(sql.ErrConnDone)
+
+	err := auditUseCase.Record(record, before, after)
+	assert.Equal(t, errors.New(consts.CodeInternalError, sql.ErrConnDone), err)
+}
+
+func TestAuditUsecase_List_Empty(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	auditRep := mocks.NewMockAuditRepository(ctrl)
+	auditUseCase := NewAuditUsecase(auditRep)
+
+	filter := &models.AuditFilter{EntityType: consts.AuditGenre}
+	pgnt := &models.Pagination{From: 0, Count: 10}
+
+	auditRep.
+		EXPECT().
+		Select(filter, pgnt).
+		Return(nil, nil)
+
+	records, err := auditUseCase.List(filter, pgnt)
+	assert.Nil(t, err)
+	assert.Equal(t, []*models.AuditRecord{}, records)
+}
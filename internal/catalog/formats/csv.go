@@ -0,0 +1,227 @@
+package formats
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	. "github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+)
+
+// CSV file has a row per movie, TV show season or episode,
+// rows of the same TV show repeat its content columns
+var csvHeader = []string{
+	"type", "name", "original_name", "description", "short_description", "year",
+	"is_free", "status", "publish_at", "countries", "genres", "actors", "directors",
+	"season", "episode", "episode_name", "episode_description",
+}
+
+type csvRow map[string]string
+
+// ReadCSV groups rows by content and numbers items by the line of the first row,
+// invalid value in any row of the item is reported with the item
+func ReadCSV(r io.Reader) ([]*models.CatalogItem, error) {
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	for _, column := range []string{"type", "name", "original_name", "year"} {
+		if !contains(header, column) {
+			return nil, fmt.Errorf("column %q is required", column)
+		}
+	}
+
+	var items []*models.CatalogItem
+	itemsByKey := make(map[string]*models.CatalogItem)
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		row := make(csvRow, len(header))
+		for idx, column := range header {
+			row[column] = strings.TrimSpace(record[idx])
+		}
+
+		key := strings.Join([]string{row["type"], row["original_name"], row["year"]}, "|")
+		item, ok := itemsByKey[key]
+		if !ok {
+			item = row.item(line)
+			itemsByKey[key] = item
+			items = append(items, item)
+		}
+		if item.ParseError != "" {
+			continue
+		}
+		if err := row.addEpisode(item, line); err != nil {
+			item.ParseError = err.Error()
+		}
+	}
+	return items, nil
+}
+
+func WriteCSV(w io.Writer, items []*models.CatalogItem) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		content := []string{
+			item.Type, item.Name, item.OriginalName, item.Description, item.ShortDescription,
+			strconv.Itoa(item.Year), strconv.FormatBool(item.IsFree),
+			item.Status, formatTime(item.PublishAt),
+			joinNames(item.Countries), joinNames(item.Genres),
+			joinNames(item.Actors), joinNames(item.Directors),
+		}
+		if len(item.Seasons) == 0 {
+			if err := writer.Write(append(content, "", "", "", "")); err != nil {
+				return err
+			}
+			continue
+		}
+
+		for _, season := range item.Seasons {
+			seasonNumber := strconv.Itoa(season.Number)
+			if len(season.Episodes) == 0 {
+				if err := writer.Write(append(content, seasonNumber, "", "", "")); err != nil {
+					return err
+				}
+				continue
+			}
+			for _, episode := range season.Episodes {
+				record := append(content[:len(content):len(content)], seasonNumber,
+					strconv.Itoa(episode.Number), episode.Name, episode.Description)
+				if err := writer.Write(record); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// item keeps the first invalid value of the row as the parse error
+func (row csvRow) item(line int) *models.CatalogItem {
+	item := &models.CatalogItem{
+		Row:              line,
+		Type:             row["type"],
+		Name:             row["name"],
+		OriginalName:     row["original_name"],
+		Description:      row["description"],
+		ShortDescription: row["short_description"],
+		Status:           row["status"],
+		Countries:        splitNames(row["countries"]),
+		Genres:           splitNames(row["genres"]),
+		Actors:           splitNames(row["actors"]),
+		Directors:        splitNames(row["directors"]),
+	}
+
+	var err error
+	if item.Year, err = row.int("year", line); err != nil {
+		item.ParseError = err.Error()
+		return item
+	}
+	if row["is_free"] != "" {
+		if item.IsFree, err = strconv.ParseBool(row["is_free"]); err != nil {
+			item.ParseError = fmt.Sprintf("line %d: invalid is_free %q", line, row["is_free"])
+			return item
+		}
+	}
+	if row["publish_at"] != "" {
+		publishAt, err := time.Parse(time.RFC3339, row["publish_at"])
+		if err != nil {
+			item.ParseError = fmt.Sprintf("line %d: invalid publish_at %q", line, row["publish_at"])
+			return item
+		}
+		item.PublishAt = &publishAt
+	}
+	return item
+}
+
+// addEpisode adds season and episode of the row to the item if they are set
+func (row csvRow) addEpisode(item *models.CatalogItem, line int) error {
+	if row["season"] == "" {
+		return nil
+	}
+	seasonNumber, err := row.int("season", line)
+	if err != nil {
+		return err
+	}
+
+	var season *models.CatalogSeason
+	for _, itemSeason := range item.Seasons {
+		if itemSeason.Number == seasonNumber {
+			season = itemSeason
+		}
+	}
+	if season == nil {
+		season = &models.CatalogSeason{
+			Number:   seasonNumber,
+			Episodes: []*models.CatalogEpisode{},
+		}
+		item.Seasons = append(item.Seasons, season)
+	}
+
+	if row["episode"] == "" {
+		return nil
+	}
+	episodeNumber, err := row.int("episode", line)
+	if err != nil {
+		return err
+	}
+	season.Episodes = append(season.Episodes, &models.CatalogEpisode{
+		Number:      episodeNumber,
+		Name:        row["episode_name"],
+		Description: row["episode_description"],
+	})
+	return nil
+}
+
+func (row csvRow) int(column string, line int) (int, error) {
+	value, err := strconv.Atoi(row[column])
+	if err != nil {
+		return 0, fmt.Errorf("line %d: invalid %s %q", line, column, row[column])
+	}
+	return value, nil
+}
+
+func splitNames(value string) []string {
+	names := []string{}
+	for _, name := range strings.Split(value, CatalogListSeparator) {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func joinNames(names []string) string {
+	return strings.Join(names, CatalogListSeparator+" ")
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,108 @@
+package formats
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+var publishAt = time.Date(2021, 1, 1, 10, 0, 0, 0, time.UTC)
+
+var catalogItems = []*models.CatalogItem{
+	{
+		Row:              1,
+		Type:             "movie",
+		Name:             "Шрек",
+		OriginalName:     "Shrek",
+		Description:      "Ogre, \"donkey\" and princess",
+		ShortDescription: "Ogre",
+		Year:             2001,
+		IsFree:           true,
+		Status:           "published",
+		Countries:        []string{"США"},
+		Genres:           []string{"Мультфильм", "Комедия"},
+		Actors:           []string{"Mike Myers", "Eddie Murphy"},
+		Directors:        []string{"Andrew Adamson"},
+	},
+	{
+		Row:          2,
+		Type:         "tvshow",
+		Name:         "Друзья",
+		OriginalName: "Friends",
+		Year:         1994,
+		Status:       "scheduled",
+		PublishAt:    &publishAt,
+		Countries:    []string{"США"},
+		Genres:       []string{},
+		Actors:       []string{},
+		Directors:    []string{},
+		Seasons: []*models.CatalogSeason{
+			{
+				Number: 1,
+				Episodes: []*models.CatalogEpisode{
+					{Number: 1, Name: "Pilot", Description: "Monica"},
+					{Number: 2, Name: "Sonogram"},
+				},
+			},
+			{
+				Number:   2,
+				Episodes: []*models.CatalogEpisode{},
+			},
+		},
+	},
+}
+
+func TestJSON_RoundTrip(t *testing.T) {
+	t.Parallel()
+	buf := &bytes.Buffer{}
+	assert.NoError(t, WriteJSON(buf, catalogItems))
+
+	items, err := ReadJSON(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, catalogItems, items)
+}
+
+func TestCSV_RoundTrip(t *testing.T) {
+	t.Parallel()
+	buf := &bytes.Buffer{}
+	assert.NoError(t, WriteCSV(buf, catalogItems))
+	assert.Equal(t, 5, strings.Count(buf.String(), "\n"))
+
+	items, err := ReadCSV(buf)
+	assert.NoError(t, err)
+
+	// Items are numbered by lines of the file
+	assert.Equal(t, 2, items[0].Row)
+	assert.Equal(t, 3, items[1].Row)
+	items[0].Row, items[1].Row = 1, 2
+	assert.Equal(t, catalogItems, items)
+}
+
+func TestReadCSV_InvalidValues(t *testing.T) {
+	t.Parallel()
+	file := "type,name,original_name,year,season,episode\n" +
+		"movie,Шрек,Shrek,2001,,\n" +
+		"movie,Шрек 2,Shrek 2,two,,\n" +
+		"tvshow,Друзья,Friends,1994,1,1\n" +
+		"tvshow,Друзья,Friends,1994,1,two\n"
+
+	// Invalid rows fail their items only
+	items, err := ReadCSV(strings.NewReader(file))
+	assert.NoError(t, err)
+	assert.Len(t, items, 3)
+	assert.Equal(t, "", items[0].ParseError)
+	assert.Equal(t, `line 3: invalid year "two"`, items[1].ParseError)
+	assert.Equal(t, "Шрек 2", items[1].Name)
+	assert.Equal(t, `line 5: invalid episode "two"`, items[2].ParseError)
+}
+
+func TestReadCSV_MissingColumn(t *testing.T) {
+	t.Parallel()
+	items, err := ReadCSV(strings.NewReader("type,name,year\n"))
+	assert.Nil(t, items)
+	assert.Error(t, err)
+}
@@ -0,0 +1,26 @@
+package formats
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+)
+
+// ReadJSON reads array of items, rows are numbered from one
+func ReadJSON(r io.Reader) ([]*models.CatalogItem, error) {
+	var items []*models.CatalogItem
+	if err := json.NewDecoder(r).Decode(&items); err != nil {
+		return nil, err
+	}
+	for idx, item := range items {
+		item.Row = idx + 1
+	}
+	return items, nil
+}
+
+func WriteJSON(w io.Writer, items []*models.CatalogItem) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(items)
+}
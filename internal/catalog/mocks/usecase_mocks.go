@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/catalog/usecase.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	errors "github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	models "github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockCatalogUsecase is a mock of CatalogUsecase interface
+type MockCatalogUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockCatalogUsecaseMockRecorder
+}
+
+// MockCatalogUsecaseMockRecorder is the mock recorder for MockCatalogUsecase
+type MockCatalogUsecaseMockRecorder struct {
+	mock *MockCatalogUsecase
+}
+
+// NewMockCatalogUsecase creates a new mock instance
+func NewMockCatalogUsecase(ctrl *gomock.Controller) *MockCatalogUsecase {
+	mock := &MockCatalogUsecase{ctrl: ctrl}
+	mock.recorder = &MockCatalogUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockCatalogUsecase) EXPECT() *MockCatalogUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Export mocks base method
+func (m *MockCatalogUsecase) Export() ([]*models.CatalogItem, *errors.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export")
+	ret0, _ := ret[0].([]*models.CatalogItem)
+	ret1, _ := ret[1].(*errors.Error)
+	return ret0, ret1
+}
+
+// Export indicates an expected call of Export
+func (mr *MockCatalogUsecaseMockRecorder) Export() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockCatalogUsecase)(nil).Export))
+}
+
+// Import mocks base method
+func (m *MockCatalogUsecase) Import(items []*models.CatalogItem, dryRun bool) ([]*models.CatalogImportResult, *errors.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", items, dryRun)
+	ret0, _ := ret[0].([]*models.CatalogImportResult)
+	ret1, _ := ret[1].(*errors.Error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import
+func (mr *MockCatalogUsecaseMockRecorder) Import(items, dryRun interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockCatalogUsecase)(nil).Import), items, dryRun)
+}
@@ -0,0 +1,13 @@
+package catalog
+
+import (
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+)
+
+type CatalogUsecase interface {
+	Export() ([]*models.CatalogItem, *errors.Error)
+	// Import creates items one by one and reports result of every item,
+	// error is returned only if the import can't be started
+	Import(items []*models.CatalogItem, dryRun bool) ([]*models.CatalogImportResult, *errors.Error)
+}
@@ -0,0 +1,451 @@
+package usecases
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/actor"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/catalog"
+	. "github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/content"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/country"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/director"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/episode"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/genre"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/movie"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/season"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/tvshow"
+)
+
+type CatalogUsecase struct {
+	contentUcase  content.ContentUsecase
+	movieUcase    movie.MovieUsecase
+	tvshowUcase   tvshow.TVShowUsecase
+	seasonUcase   season.SeasonUsecase
+	episodeUcase  episode.EpisodeUsecase
+	countryUcase  country.CountryUsecase
+	genreUcase    genre.GenreUsecase
+	actorUcase    actor.ActorUseCase
+	directorUcase director.DirectorUseCase
+}
+
+func NewCatalogUsecase(contentUcase content.ContentUsecase, movieUcase movie.MovieUsecase,
+	tvshowUcase tvshow.TVShowUsecase, seasonUcase season.SeasonUsecase,
+	episodeUcase episode.EpisodeUsecase, countryUcase country.CountryUsecase,
+	genreUcase genre.GenreUsecase, actorUcase actor.ActorUseCase,
+	directorUcase director.DirectorUseCase) catalog.CatalogUsecase {
+	return &CatalogUsecase{
+		contentUcase:  contentUcase,
+		movieUcase:    movieUcase,
+		tvshowUcase:   tvshowUcase,
+		seasonUcase:   seasonUcase,
+		episodeUcase:  episodeUcase,
+		countryUcase:  countryUcase,
+		genreUcase:    genreUcase,
+		actorUcase:    actorUcase,
+		directorUcase: directorUcase,
+	}
+}
+
+func (cu *CatalogUsecase) Export() ([]*models.CatalogItem, *errors.Error) {
+	// content in any status is exported, drafts included
+	movies, err := cu.movieUcase.ListByParams(&models.ContentFilter{}, ContentStatuses,
+		&models.Pagination{}, 0)
+	if err != nil {
+		return nil, err
+	}
+	tvshows, err := cu.tvshowUcase.ListByParams(&models.ContentFilter{}, ContentStatuses,
+		&models.Pagination{}, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*models.CatalogItem, 0, len(movies)+len(tvshows))
+	for _, movie := range movies {
+		if err := cu.contentUcase.FillContent(&movie.Content); err != nil {
+			return nil, err
+		}
+		items = append(items, newCatalogItem(&movie.Content, MovieContentType))
+	}
+
+	for _, tvshow := range tvshows {
+		if err := cu.contentUcase.FillContent(&tvshow.Content); err != nil {
+			return nil, err
+		}
+		item := newCatalogItem(&tvshow.Content, TVShowContentType)
+		if item.Seasons, err = cu.exportSeasons(tvshow.ID); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func (cu *CatalogUsecase) exportSeasons(tvshowID uint64) ([]*models.CatalogSeason, *errors.Error) {
+	seasons, err := cu.seasonUcase.ListByTVShow(tvshowID)
+	if err != nil {
+		return nil, err
+	}
+
+	var catalogSeasons []*models.CatalogSeason
+	for _, season := range seasons {
+		episodes, err := cu.seasonUcase.GetEpisodes(season.ID)
+		if err != nil {
+			return nil, err
+		}
+		catalogSeason := &models.CatalogSeason{
+			Number:   season.Number,
+			Episodes: []*models.CatalogEpisode{},
+		}
+		for _, episode := range episodes {
+			catalogSeason.Episodes = append(catalogSeason.Episodes, &models.CatalogEpisode{
+				Number:      episode.Number,
+				Name:        episode.Name,
+				Description: episode.Description,
+			})
+		}
+		catalogSeasons = append(catalogSeasons, catalogSeason)
+	}
+	return catalogSeasons, nil
+}
+
+func (cu *CatalogUsecase) Import(items []*models.CatalogItem,
+	dryRun bool) ([]*models.CatalogImportResult, *errors.Error) {
+	ci, err := cu.newCatalogImport(dryRun)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*models.CatalogImportResult, 0, len(items))
+	for _, item := range items {
+		result := &models.CatalogImportResult{
+			Row:  item.Row,
+			Name: item.Name,
+		}
+		contentID, err := ci.importItem(item)
+		if err != nil {
+			result.Error = err.Message
+		}
+		result.ContentID = contentID
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// catalogImport keeps names resolved during one import, so every
+// missing country, genre or person is created only once
+type catalogImport struct {
+	*CatalogUsecase
+	dryRun    bool
+	existing  map[string]bool
+	countries map[string]*models.Country
+	genres    map[string]*models.Genre
+	actors    map[string]*models.Actor
+	directors map[string]*models.Director
+}
+
+func (cu *CatalogUsecase) newCatalogImport(dryRun bool) (*catalogImport, *errors.Error) {
+	ci := &catalogImport{
+		CatalogUsecase: cu,
+		dryRun:         dryRun,
+		existing:       make(map[string]bool),
+		countries:      make(map[string]*models.Country),
+		genres:         make(map[string]*models.Genre),
+		actors:         make(map[string]*models.Actor),
+		directors:      make(map[string]*models.Director),
+	}
+
+	// drafts are listed too, so they aren't imported twice
+	movies, err := cu.movieUcase.ListByParams(&models.ContentFilter{}, ContentStatuses,
+		&models.Pagination{}, 0)
+	if err != nil {
+		return nil, err
+	}
+	for _, movie := range movies {
+		ci.existing[contentKey(MovieContentType, movie.OriginalName, movie.Year)] = true
+	}
+	tvshows, err := cu.tvshowUcase.ListByParams(&models.ContentFilter{}, ContentStatuses,
+		&models.Pagination{}, 0)
+	if err != nil {
+		return nil, err
+	}
+	for _, tvshow := range tvshows {
+		ci.existing[contentKey(TVShowContentType, tvshow.OriginalName, tvshow.Year)] = true
+	}
+
+	countries, err := cu.countryUcase.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, country := range countries {
+		ci.countries[nameKey(country.Name)] = country
+	}
+	genres, err := cu.genreUcase.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, genre := range genres {
+		ci.genres[nameKey(genre.Name)] = genre
+	}
+	actors, err := cu.actorUcase.List(&models.Pagination{})
+	if err != nil {
+		return nil, err
+	}
+	for _, actor := range actors {
+		ci.actors[nameKey(actor.Name)] = actor
+	}
+	directors, err := cu.directorUcase.List(&models.Pagination{})
+	if err != nil {
+		return nil, err
+	}
+	for _, director := range directors {
+		ci.directors[nameKey(director.Name)] = director
+	}
+	return ci, nil
+}
+
+func (ci *catalogImport) importItem(item *models.CatalogItem) (uint64, *errors.Error) {
+	if err := validateItem(item); err != nil {
+		return 0, errors.New(CodeBadRequest, err)
+	}
+
+	key := contentKey(item.Type, item.OriginalName, item.Year)
+	if ci.existing[key] {
+		if item.Type == MovieContentType {
+			return 0, errors.Get(CodeMovieContentAlreadyExists)
+		}
+		return 0, errors.Get(CodeTVShowContentAlreadyExists)
+	}
+
+	content, err := ci.buildContent(item)
+	if err != nil {
+		return 0, err
+	}
+	if ci.dryRun {
+		ci.existing[key] = true
+		return 0, nil
+	}
+
+	switch item.Type {
+	case MovieContentType:
+		movie := &models.Movie{Content: *content}
+		if err := ci.movieUcase.Create(movie); err != nil {
+			return 0, err
+		}
+		content = &movie.Content
+	case TVShowContentType:
+		tvshow := &models.TVShow{Content: *content}
+		if err := ci.tvshowUcase.Create(tvshow); err != nil {
+			return 0, err
+		}
+		content = &tvshow.Content
+		// Show is created even if some of its seasons fail
+		ci.existing[key] = true
+		if err := ci.importSeasons(tvshow.ID, item.Seasons); err != nil {
+			return content.ContentID, err
+		}
+	}
+	ci.existing[key] = true
+	return content.ContentID, nil
+}
+
+func (ci *catalogImport) importSeasons(tvshowID uint64, seasons []*models.CatalogSeason) *errors.Error {
+	for _, catalogSeason := range seasons {
+		season := &models.Season{
+			Number:         catalogSeason.Number,
+			EpisodesNumber: len(catalogSeason.Episodes),
+			TVShowID:       tvshowID,
+		}
+		if err := ci.seasonUcase.Create(season); err != nil {
+			return err
+		}
+
+		for _, catalogEpisode := range catalogSeason.Episodes {
+			episode := &models.Episode{
+				Name:        catalogEpisode.Name,
+				Number:      catalogEpisode.Number,
+				Description: catalogEpisode.Description,
+				SeasonID:    season.ID,
+			}
+			if err := ci.episodeUcase.Create(episode); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (ci *catalogImport) buildContent(item *models.CatalogItem) (*models.Content, *errors.Error) {
+	isFree := item.IsFree
+	content := &models.Content{
+		Name:             item.Name,
+		OriginalName:     item.OriginalName,
+		Description:      item.Description,
+		ShortDescription: item.ShortDescription,
+		Year:             item.Year,
+		IsFree:           &isFree,
+		Type:             item.Type,
+		Status:           item.Status,
+	}
+	if item.Status == ContentScheduled {
+		content.PublishAt = item.PublishAt
+	}
+
+	for _, name := range uniqueNames(item.Countries) {
+		country, ok := ci.countries[nameKey(name)]
+		if !ok {
+			country = &models.Country{Name: name}
+			if err := ci.create(func() *errors.Error { return ci.countryUcase.Create(country) }); err != nil {
+				return nil, err
+			}
+			ci.countries[nameKey(name)] = country
+		}
+		content.Countries = append(content.Countries, country)
+	}
+	for _, name := range uniqueNames(item.Genres) {
+		genre, ok := ci.genres[nameKey(name)]
+		if !ok {
+			genre = &models.Genre{Name: name}
+			if err := ci.create(func() *errors.Error { return ci.genreUcase.Create(genre) }); err != nil {
+				return nil, err
+			}
+			ci.genres[nameKey(name)] = genre
+		}
+		content.Genres = append(content.Genres, genre)
+	}
+	for _, name := range uniqueNames(item.Actors) {
+		actor, ok := ci.actors[nameKey(name)]
+		if !ok {
+			actor = &models.Actor{Name: name}
+			if err := ci.create(func() *errors.Error { return ci.actorUcase.Create(actor) }); err != nil {
+				return nil, err
+			}
+			ci.actors[nameKey(name)] = actor
+		}
+		content.Actors = append(content.Actors, actor)
+	}
+	for _, name := range uniqueNames(item.Directors) {
+		director, ok := ci.directors[nameKey(name)]
+		if !ok {
+			director = &models.Director{Name: name}
+			if err := ci.create(func() *errors.Error { return ci.directorUcase.Create(director) }); err != nil {
+				return nil, err
+			}
+			ci.directors[nameKey(name)] = director
+		}
+		content.Directors = append(content.Directors, director)
+	}
+	return content, nil
+}
+
+// create calls creation of missing relation unless it's dry run
+func (ci *catalogImport) create(createFunc func() *errors.Error) *errors.Error {
+	if ci.dryRun {
+		return nil
+	}
+	return createFunc()
+}
+
+func validateItem(item *models.CatalogItem) error {
+	switch {
+	case item.ParseError != "":
+		return fmt.Errorf("%s", item.ParseError)
+	case item.Type != MovieContentType && item.Type != TVShowContentType:
+		return fmt.Errorf("unknown content type %q", item.Type)
+	case strings.TrimSpace(item.Name) == "":
+		return fmt.Errorf("name is required")
+	case strings.TrimSpace(item.OriginalName) == "":
+		return fmt.Errorf("original name is required")
+	case item.Year <= 0:
+		return fmt.Errorf("year is required")
+	case item.Type == MovieContentType && len(item.Seasons) > 0:
+		return fmt.Errorf("movie can't have seasons")
+	case item.Status != "" && !containsStatus(item.Status):
+		return fmt.Errorf("unknown status %q", item.Status)
+	case item.Status == ContentScheduled && item.PublishAt == nil:
+		return fmt.Errorf("publish time of scheduled content is required")
+	}
+
+	seasonNumbers := make(map[int]bool)
+	for _, season := range item.Seasons {
+		if season.Number <= 0 || seasonNumbers[season.Number] {
+			return fmt.Errorf("invalid season number %d", season.Number)
+		}
+		seasonNumbers[season.Number] = true
+
+		episodeNumbers := make(map[int]bool)
+		for _, episode := range season.Episodes {
+			if episode.Number <= 0 || episodeNumbers[episode.Number] {
+				return fmt.Errorf("invalid episode number %d of season %d",
+					episode.Number, season.Number)
+			}
+			episodeNumbers[episode.Number] = true
+		}
+	}
+	return nil
+}
+
+func newCatalogItem(content *models.Content, contentType string) *models.CatalogItem {
+	item := &models.CatalogItem{
+		Type:             contentType,
+		Name:             content.Name,
+		OriginalName:     content.OriginalName,
+		Description:      content.Description,
+		ShortDescription: content.ShortDescription,
+		Year:             content.Year,
+		IsFree:           content.IsFree != nil && *content.IsFree,
+		Status:           content.Status,
+		PublishAt:        content.PublishAt,
+		Countries:        []string{},
+		Genres:           []string{},
+		Actors:           []string{},
+		Directors:        []string{},
+	}
+	for _, country := range content.Countries {
+		item.Countries = append(item.Countries, country.Name)
+	}
+	for _, genre := range content.Genres {
+		item.Genres = append(item.Genres, genre.Name)
+	}
+	for _, actor := range content.Actors {
+		item.Actors = append(item.Actors, actor.Name)
+	}
+	for _, director := range content.Directors {
+		item.Directors = append(item.Directors, director.Name)
+	}
+	return item
+}
+
+func uniqueNames(names []string) []string {
+	seen := make(map[string]bool, len(names))
+	var unique []string
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[nameKey(name)] {
+			continue
+		}
+		seen[nameKey(name)] = true
+		unique = append(unique, name)
+	}
+	return unique
+}
+
+func nameKey(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+func contentKey(contentType, originalName string, year int) string {
+	return strings.Join([]string{contentType, nameKey(originalName), strconv.Itoa(year)}, "|")
+}
+
+func containsStatus(status string) bool {
+	for _, s := range ContentStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,286 @@
+package usecases
+
+import (
+	"testing"
+
+	actorMocks "github.com/go-park-mail-ru/2020_2_Slash/internal/actor/mocks"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/catalog"
+	. "github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	contentMocks "github.com/go-park-mail-ru/2020_2_Slash/internal/content/mocks"
+	countryMocks "github.com/go-park-mail-ru/2020_2_Slash/internal/country/mocks"
+	directorMocks "github.com/go-park-mail-ru/2020_2_Slash/internal/director/mocks"
+	episodeMocks "github.com/go-park-mail-ru/2020_2_Slash/internal/episode/mocks"
+	genreMocks "github.com/go-park-mail-ru/2020_2_Slash/internal/genre/mocks"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	movieMocks "github.com/go-park-mail-ru/2020_2_Slash/internal/movie/mocks"
+	seasonMocks "github.com/go-park-mail-ru/2020_2_Slash/internal/season/mocks"
+	tvshowMocks "github.com/go-park-mail-ru/2020_2_Slash/internal/tvshow/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+type catalogMocks struct {
+	content  *contentMocks.MockContentUsecase
+	movie    *movieMocks.MockMovieUsecase
+	tvshow   *tvshowMocks.MockTVShowUsecase
+	season   *seasonMocks.MockSeasonUsecase
+	episode  *episodeMocks.MockEpisodeUsecase
+	country  *countryMocks.MockCountryUsecase
+	genre    *genreMocks.MockGenreUsecase
+	actor    *actorMocks.MockActorUseCase
+	director *directorMocks.MockDirectorUseCase
+}
+
+var (
+	usa    = &models.Country{ID: 1, Name: "США"}
+	comedy = &models.Genre{ID: 2, Name: "Комедия"}
+	myers  = &models.Actor{ID: 3, Name: "Mike Myers"}
+)
+
+func setupCatalogUsecase(ctrl *gomock.Controller) (*catalogMocks, catalog.CatalogUsecase) {
+	m := &catalogMocks{
+		content:  contentMocks.NewMockContentUsecase(ctrl),
+		movie:    movieMocks.NewMockMovieUsecase(ctrl),
+		tvshow:   tvshowMocks.NewMockTVShowUsecase(ctrl),
+		season:   seasonMocks.NewMockSeasonUsecase(ctrl),
+		episode:  episodeMocks.NewMockEpisodeUsecase(ctrl),
+		country:  countryMocks.NewMockCountryUsecase(ctrl),
+		genre:    genreMocks.NewMockGenreUsecase(ctrl),
+		actor:    actorMocks.NewMockActorUseCase(ctrl),
+		director: directorMocks.NewMockDirectorUseCase(ctrl),
+	}
+	catalogUcase := NewCatalogUsecase(m.content, m.movie, m.tvshow, m.season, m.episode,
+		m.country, m.genre, m.actor, m.director)
+	return m, catalogUcase
+}
+
+// expectPreload expects loading of the existing catalog before import
+func (m *catalogMocks) expectPreload(movies []*models.Movie) {
+	m.movie.EXPECT().
+		ListByParams(&models.ContentFilter{}, ContentStatuses, &models.Pagination{}, uint64(0)).
+		Return(movies, nil)
+	m.tvshow.EXPECT().
+		ListByParams(&models.ContentFilter{}, ContentStatuses, &models.Pagination{}, uint64(0)).
+		Return([]*models.TVShow{}, nil)
+	m.country.EXPECT().List().Return([]*models.Country{usa}, nil)
+	m.genre.EXPECT().List().Return([]*models.Genre{comedy}, nil)
+	m.actor.EXPECT().List(&models.Pagination{}).Return([]*models.Actor{myers}, nil)
+	m.director.EXPECT().List(&models.Pagination{}).Return([]*models.Director{}, nil)
+}
+
+func newShrekItem(row int) *models.CatalogItem {
+	return &models.CatalogItem{
+		Row:          row,
+		Type:         MovieContentType,
+		Name:         "Шрек",
+		OriginalName: "Shrek",
+		Year:         2001,
+		Countries:    []string{"США"},
+		Genres:       []string{"комедия", "Мультфильм"},
+		Actors:       []string{"Mike Myers"},
+		Directors:    []string{"Andrew Adamson"},
+	}
+}
+
+func TestCatalogUsecase_Import_Movie(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m, catalogUcase := setupCatalogUsecase(ctrl)
+	m.expectPreload(nil)
+
+	m.genre.EXPECT().
+		Create(&models.Genre{Name: "Мультфильм"}).
+		DoAndReturn(func(genre *models.Genre) *errors.Error {
+			genre.ID = 5
+			return nil
+		})
+	m.director.EXPECT().
+		Create(&models.Director{Name: "Andrew Adamson"}).
+		Return(nil)
+	m.movie.EXPECT().
+		Create(gomock.Any()).
+		DoAndReturn(func(movie *models.Movie) *errors.Error {
+			assert.Equal(t, []*models.Country{usa}, movie.Countries)
+			assert.Equal(t, []*models.Genre{comedy, {ID: 5, Name: "Мультфильм"}}, movie.Genres)
+			assert.Equal(t, []*models.Actor{myers}, movie.Actors)
+			assert.Equal(t, MovieContentType, movie.Type)
+			movie.ContentID = 7
+			return nil
+		})
+
+	results, err := catalogUcase.Import([]*models.CatalogItem{newShrekItem(1), newShrekItem(2)}, false)
+	assert.Nil(t, err)
+	assert.Equal(t, []*models.CatalogImportResult{
+		{Row: 1, Name: "Шрек", ContentID: 7},
+		{Row: 2, Name: "Шрек", Error: errors.Get(CodeMovieContentAlreadyExists).Message},
+	}, results)
+}
+
+func TestCatalogUsecase_Import_DryRun(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m, catalogUcase := setupCatalogUsecase(ctrl)
+	m.expectPreload(nil)
+
+	invalidItem := newShrekItem(2)
+	invalidItem.Year = 0
+
+	results, err := catalogUcase.Import([]*models.CatalogItem{newShrekItem(1), invalidItem}, true)
+	assert.Nil(t, err)
+	assert.Equal(t, []*models.CatalogImportResult{
+		{Row: 1, Name: "Шрек"},
+		{Row: 2, Name: "Шрек", Error: "year is required"},
+	}, results)
+}
+
+func TestCatalogUsecase_Import_ParseError(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m, catalogUcase := setupCatalogUsecase(ctrl)
+	m.expectPreload(nil)
+
+	invalidItem := newShrekItem(3)
+	invalidItem.ParseError = `line 3: invalid year "two"`
+
+	results, err := catalogUcase.Import([]*models.CatalogItem{newShrekItem(2), invalidItem}, true)
+	assert.Nil(t, err)
+	assert.Equal(t, []*models.CatalogImportResult{
+		{Row: 2, Name: "Шрек"},
+		{Row: 3, Name: "Шрек", Error: `line 3: invalid year "two"`},
+	}, results)
+}
+
+func TestCatalogUsecase_Import_ExistingMovie(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m, catalogUcase := setupCatalogUsecase(ctrl)
+	m.expectPreload([]*models.Movie{
+		{ID: 1, Content: models.Content{OriginalName: "shrek", Year: 2001}},
+	})
+
+	results, err := catalogUcase.Import([]*models.CatalogItem{newShrekItem(1)}, false)
+	assert.Nil(t, err)
+	assert.Equal(t, errors.Get(CodeMovieContentAlreadyExists).Message, results[0].Error)
+}
+
+func TestCatalogUsecase_Import_TVShow(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m, catalogUcase := setupCatalogUsecase(ctrl)
+	m.expectPreload(nil)
+
+	item := &models.CatalogItem{
+		Row:          1,
+		Type:         TVShowContentType,
+		Name:         "Друзья",
+		OriginalName: "Friends",
+		Year:         1994,
+		Seasons: []*models.CatalogSeason{
+			{
+				Number: 1,
+				Episodes: []*models.CatalogEpisode{
+					{Number: 1, Name: "Pilot"},
+				},
+			},
+		},
+	}
+
+	m.tvshow.EXPECT().
+		Create(gomock.Any()).
+		DoAndReturn(func(tvshow *models.TVShow) *errors.Error {
+			tvshow.ID = 4
+			tvshow.ContentID = 8
+			return nil
+		})
+	m.season.EXPECT().
+		Create(&models.Season{Number: 1, EpisodesNumber: 1, TVShowID: 4}).
+		DoAndReturn(func(season *models.Season) *errors.Error {
+			season.ID = 9
+			return nil
+		})
+	m.episode.EXPECT().
+		Create(&models.Episode{Name: "Pilot", Number: 1, SeasonID: 9}).
+		Return(errors.Get(CodeEpisodeAlreadyExist))
+
+	results, err := catalogUcase.Import([]*models.CatalogItem{item}, false)
+	assert.Nil(t, err)
+	assert.Equal(t, []*models.CatalogImportResult{
+		{Row: 1, Name: "Друзья", ContentID: 8, Error: errors.Get(CodeEpisodeAlreadyExist).Message},
+	}, results)
+}
+
+func TestCatalogUsecase_Export(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m, catalogUcase := setupCatalogUsecase(ctrl)
+
+	isFree := true
+	movie := &models.Movie{ID: 1, Content: models.Content{
+		ContentID: 7, Name: "Шрек", OriginalName: "Shrek", Year: 2001, IsFree: &isFree,
+	}}
+	tvshow := &models.TVShow{ID: 4, Content: models.Content{
+		ContentID: 8, Name: "Друзья", OriginalName: "Friends", Year: 1994,
+	}}
+
+	m.movie.EXPECT().
+		ListByParams(&models.ContentFilter{}, ContentStatuses, &models.Pagination{}, uint64(0)).
+		Return([]*models.Movie{movie}, nil)
+	m.tvshow.EXPECT().
+		ListByParams(&models.ContentFilter{}, ContentStatuses, &models.Pagination{}, uint64(0)).
+		Return([]*models.TVShow{tvshow}, nil)
+	m.content.EXPECT().
+		FillContent(&movie.Content).
+		DoAndReturn(func(content *models.Content) *errors.Error {
+			content.Countries = []*models.Country{usa}
+			content.Actors = []*models.Actor{myers}
+			return nil
+		})
+	m.content.EXPECT().
+		FillContent(&tvshow.Content).
+		Return(nil)
+	m.season.EXPECT().
+		ListByTVShow(tvshow.ID).
+		Return([]*models.Season{{ID: 9, Number: 1, TVShowID: tvshow.ID}}, nil)
+	m.season.EXPECT().
+		GetEpisodes(uint64(9)).
+		Return([]*models.Episode{{ID: 1, Number: 1, Name: "Pilot", SeasonID: 9}}, nil)
+
+	items, err := catalogUcase.Export()
+	assert.Nil(t, err)
+	assert.Equal(t, []*models.CatalogItem{
+		{
+			Type:         MovieContentType,
+			Name:         "Шрек",
+			OriginalName: "Shrek",
+			Year:         2001,
+			IsFree:       true,
+			Countries:    []string{"США"},
+			Genres:       []string{},
+			Actors:       []string{"Mike Myers"},
+			Directors:    []string{},
+		},
+		{
+			Type:         TVShowContentType,
+			Name:         "Друзья",
+			OriginalName: "Friends",
+			Year:         1994,
+			Countries:    []string{},
+			Genres:       []string{},
+			Actors:       []string{},
+			Directors:    []string{},
+			Seasons: []*models.CatalogSeason{
+				{
+					Number:   1,
+					Episodes: []*models.CatalogEpisode{{Number: 1, Name: "Pilot"}},
+				},
+			},
+		},
+	}, items)
+}
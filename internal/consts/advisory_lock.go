@@ -0,0 +1,7 @@
+package consts
+
+// Keys of advisory locks that keep periodic work on a single app instance
+const (
+	SubscriptionSweepLock int64 = iota + 1
+	RecommendationsRebuildLock
+)
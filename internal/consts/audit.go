@@ -0,0 +1,29 @@
+package consts
+
+// Audited entities
+const (
+	AuditMovie    = "movie"
+	AuditTVShow   = "tvshow"
+	AuditContent  = "content"
+	AuditSeason   = "season"
+	AuditEpisode  = "episode"
+	AuditActor    = "actor"
+	AuditDirector = "director"
+	AuditGenre    = "genre"
+	AuditCountry  = "country"
+	AuditPlan     = "plan"
+	AuditTrash    = "trash" // restored items are recorded with their own type
+)
+
+// AuditEntityTypeKey is the context key of the entity type
+// resolved for routes shared by several entities
+const AuditEntityTypeKey = "auditEntityType"
+
+// Audited actions, uploads of posters and videos are updates
+const (
+	AuditCreate = "create"
+	AuditUpdate = "update"
+	AuditDelete = "delete"
+)
+
+const AuditDateLayout = "2006-01-02"
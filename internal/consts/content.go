@@ -0,0 +1,38 @@
+package consts
+
+import "time"
+
+const (
+	MovieContentType  = "movie"
+	TVShowContentType = "tvshow"
+)
+
+// Publishing statuses of the content, only published content
+// is shown to users
+const (
+	ContentDraft     = "draft"
+	ContentScheduled = "scheduled"
+	ContentPublished = "published"
+	ContentArchived  = "archived"
+)
+
+var ContentStatuses = []string{ContentDraft, ContentScheduled, ContentPublished, ContentArchived}
+
+const ContentPublishInterval = time.Minute
+
+// Weights of the content links and of the release year proximity
+// used to rank similar content
+const (
+	SimilarGenreWeight    = 3
+	SimilarActorWeight    = 1
+	SimilarDirectorWeight = 4
+	SimilarCountryWeight  = 1
+	SimilarYearWeight     = 2
+)
+
+// Catalog import and export file formats
+const (
+	CatalogJSONFormat    = "json"
+	CatalogCSVFormat     = "csv"
+	CatalogListSeparator = ";"
+)
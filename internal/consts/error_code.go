@@ -51,4 +51,30 @@ const (
 	CodeReadKeyFileError
 	CodeParseCodeProError
 	CodeProtectedPayment
+	CodeSubscriptionRequired
+	CodeWrongVideoSignature
+	CodeVideoURLExpired
+	CodeJobDoesNotExist
+	CodeWrongWatchProgress
+	CodeParsePaymentAmountError
+	CodeWrongPaymentAmount
+	CodeWrongPaymentCurrency
+	CodePaymentRejected
+	CodePlanDoesNotExist
+	CodePlanNameAlreadyExists
+	CodeParsePlanIDError
+	CodeInvalidUserToken
+	CodeEmailAlreadyVerified
+	CodeSendMailError
+	CodeOIDCProviderDoesNotExist
+	CodeOIDCStateMismatch
+	CodeOIDCAuthFailed
+	CodeOIDCEmailRequired
+	CodeTooManyAttempts
+	CodeWrongPublishTime
+	CodeTrashItemDoesNotExist
+	CodeRevisionDoesNotExist
+	CodeOIDCAccountNotVerified
+	CodeOIDCEmailNotVerified
+	CodePlanHasPayments
 )
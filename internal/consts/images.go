@@ -4,3 +4,9 @@ const (
 	SmallImageWidth = 640
 	LargeImageWidth = 1920
 )
+
+// Content posters are stored in posters directory by width
+const (
+	SmallPosterName = "640"
+	LargePosterName = "1920"
+)
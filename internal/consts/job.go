@@ -0,0 +1,33 @@
+package consts
+
+import (
+	"time"
+)
+
+const (
+	JobQueued  = "queued"
+	JobRunning = "running"
+	JobFailed  = "failed"
+	JobDone    = "done"
+)
+
+const (
+	JobContentPosters = "content_posters"
+	JobEpisodePoster  = "episode_poster"
+	JobMovieVideo     = "movie_video"
+	JobEpisodeVideo   = "episode_video"
+	JobUserAvatar     = "user_avatar"
+)
+
+const (
+	JobMaxAttempts  = 3
+	JobRetryDelay   = time.Minute
+	JobWorkersCount = 4
+	JobPollInterval = 2 * time.Second
+	// JobRunningTimeout is longer than the longest transcoding,
+	// running job not updated for this time is left by a crashed worker
+	JobRunningTimeout  = 3 * time.Hour
+	JobRequeueInterval = 5 * time.Minute
+)
+
+const JobInterruptedError = "job was interrupted"
@@ -0,0 +1,13 @@
+package consts
+
+// Mailers selected in config
+const (
+	SMTPMailer   = "smtp"
+	FileMailer   = "file"
+	MemoryMailer = "memory"
+)
+
+const (
+	PasswordResetMailSubject     = "Восстановление пароля"
+	EmailVerificationMailSubject = "Подтверждение почты"
+)
@@ -0,0 +1,13 @@
+package consts
+
+import "time"
+
+const (
+	OIDCStateCookieName = "oidc_state"
+	OIDCStateCookiePath = "/api/v1/oidc"
+	OIDCStateTTL        = 10 * time.Minute
+	OIDCHTTPTimeout     = 10 * time.Second
+	OIDCClockSkew       = time.Minute
+	OIDCRandomBytes     = 32
+	OIDCDiscoveryPath   = "/.well-known/openid-configuration"
+)
@@ -0,0 +1,23 @@
+package consts
+
+// Payment label is "<user id>:<plan id>"
+const PaymentLabelSeparator = ":"
+
+const (
+	// Payment is not accepted by the provider yet, next notification may change it
+	PaymentHeld     = "held"
+	PaymentPending  = "pending"
+	PaymentAccepted = "accepted"
+	PaymentRejected = "rejected"
+)
+
+// Payment providers selected in config
+const (
+	YooMoneyPaymentProvider = "yoomoney"
+	FakePaymentProvider     = "fake"
+)
+
+const (
+	YooMoneyCheckoutURL = "https://yoomoney.ru/quickpay/confirm.xml"
+	FakeCheckoutURL     = "fake://checkout"
+)
@@ -0,0 +1,23 @@
+package consts
+
+import "time"
+
+// Route groups with separate rate limits in config
+const (
+	LoginRateLimit         = "login"
+	RegisterRateLimit      = "register"
+	PasswordResetRateLimit = "password_reset"
+	VerifyEmailRateLimit   = "verify_email"
+)
+
+// RateLimitCountKey is set by the handler to count the attempt
+// against the account, e.g. on wrong password
+const RateLimitCountKey = "rateLimitCount"
+
+// Keys of the rate limit buckets used in metrics
+const (
+	RateLimitByIP      = "ip"
+	RateLimitByAccount = "account"
+)
+
+const RateLimitSweepEvery = time.Minute
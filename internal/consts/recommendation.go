@@ -0,0 +1,27 @@
+package consts
+
+import (
+	"time"
+)
+
+const (
+	RecommendationsRebuildInterval = time.Hour
+	RecommendationsPerUser         = 100
+)
+
+const (
+	GenreFeature    = "genre"
+	ActorFeature    = "actor"
+	DirectorFeature = "director"
+	CountryFeature  = "country"
+)
+
+// Weights of the content links and of the content liked by similar users
+var RecommendationFeatureWeights = map[string]float64{
+	GenreFeature:    1,
+	ActorFeature:    0.5,
+	DirectorFeature: 1.5,
+	CountryFeature:  0.25,
+}
+
+const RecommendationCoOccurrenceWeight = 2
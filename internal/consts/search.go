@@ -0,0 +1,15 @@
+package consts
+
+import "time"
+
+const (
+	ActorSuggestionType    = "actor"
+	DirectorSuggestionType = "director"
+)
+
+const (
+	SuggestionsDefaultCount = 10
+	SuggestionsMaxCount     = 20
+)
+
+const SuggestRefreshInterval = 10 * time.Minute
@@ -5,4 +5,21 @@ import (
 )
 
 const ExpiresDuration = 10 * time.Hour
+const RememberExpiresDuration = 30 * 24 * time.Hour
 const SessionName = "session_id"
+
+// Session storages selected in config
+const (
+	SessionPostgresStorage = "postgres"
+	SessionRedisStorage    = "redis"
+	SessionMemoryStorage   = "memory"
+)
+
+const (
+	SessionRedisKeyPrefix     = "session:"
+	SessionRedisUserKeyPrefix = "user_sessions:"
+	SessionRedisIDKey         = "session_id_seq"
+	SessionRedisMaxIdle       = 10
+	SessionRedisIdleTimeout   = 4 * time.Minute
+	SessionMemorySweepEvery   = time.Minute
+)
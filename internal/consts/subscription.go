@@ -0,0 +1,15 @@
+package consts
+
+import "time"
+
+const SubscriptionSweepInterval = 10 * time.Minute
+
+// Events of the subscription history
+const (
+	SubscriptionTrialStarted = "trial_started"
+	SubscriptionCreated      = "created"
+	SubscriptionRenewed      = "renewed"
+	SubscriptionCanceled     = "canceled"
+	SubscriptionRecovered    = "recovered"
+	SubscriptionExpired      = "expired"
+)
@@ -0,0 +1,12 @@
+package consts
+
+import "time"
+
+const TrashPurgeInterval = time.Hour
+
+// Types of the trash items
+const (
+	TrashContent = "content"
+	TrashSeason  = "season"
+	TrashEpisode = "episode"
+)
@@ -0,0 +1,21 @@
+package consts
+
+import "time"
+
+// Purposes of one-time user tokens
+const (
+	PasswordResetToken     = "password_reset"
+	EmailVerificationToken = "email_verification"
+)
+
+const (
+	PasswordResetTokenTTL     = time.Hour
+	EmailVerificationTokenTTL = 24 * time.Hour
+	UserTokenBytes            = 32
+)
+
+// Pages of the frontend which accept tokens from mails
+const (
+	PasswordResetPage     = "/password/reset"
+	EmailVerificationPage = "/verify"
+)
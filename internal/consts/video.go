@@ -0,0 +1,7 @@
+package consts
+
+import (
+	"time"
+)
+
+const VideoURLExpiresDuration = 6 * time.Hour
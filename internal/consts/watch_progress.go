@@ -0,0 +1,4 @@
+package consts
+
+// Part of the duration after which movie or episode is considered watched
+const WatchedProgressRatio = 0.9
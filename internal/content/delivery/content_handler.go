@@ -1,15 +1,18 @@
 package delivery
 
 import (
+	"mime/multipart"
 	"net/http"
 	"os"
 	"path/filepath"
 	"strconv"
+	"time"
 
 	. "github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/content"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/job"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/movie"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/mwares"
@@ -25,21 +28,33 @@ type ContentHandler struct {
 	contentUcase content.ContentUsecase
 	movieUcase   movie.MovieUsecase
 	tvshowUcase  tvshow.TVShowUsecase
+	jobUcase     job.JobUsecase
 }
 
 func NewContentHandler(contentUcase content.ContentUsecase,
-	movieUcase movie.MovieUsecase, tvshowUcase tvshow.TVShowUsecase) *ContentHandler {
+	movieUcase movie.MovieUsecase, tvshowUcase tvshow.TVShowUsecase,
+	jobUcase job.JobUsecase) *ContentHandler {
 	return &ContentHandler{
 		contentUcase: contentUcase,
 		movieUcase:   movieUcase,
 		tvshowUcase:  tvshowUcase,
+		jobUcase:     jobUcase,
 	}
 }
 
 func (ch *ContentHandler) Configure(e *echo.Echo, mw *mwares.MiddlewareManager) {
 	e.GET("/api/v1/content", ch.GetContentHandler())
+	e.GET("/api/v1/admin/content", ch.GetAdminContentHandler(), mw.CheckAuth, mw.CheckAdmin)
+	e.PUT("/api/v1/content/:cid/status", ch.UpdateStatusHandler(),
+		mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF, mw.Audit(AuditContent, "cid"))
+	e.GET("/api/v1/admin/content/:cid/revisions", ch.GetRevisionsHandler(), mw.CheckAuth, mw.CheckAdmin)
+	e.GET("/api/v1/admin/content/:cid/revisions/:rid/diff", ch.GetRevisionDiffHandler(),
+		mw.CheckAuth, mw.CheckAdmin)
+	e.POST("/api/v1/admin/content/:cid/revisions/:rid/rollback", ch.RollbackHandler(),
+		mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF, mw.Audit(AuditContent, "cid"))
+	e.GET("/api/v1/content/:cid/similar", ch.GetSimilarContentHandler(), mw.GetAuth)
 	e.PUT("/api/v1/content/:mid/poster", ch.UpdatePostersHandler(),
-		middleware.BodyLimit("10M"), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF)
+		middleware.BodyLimit("10M"), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF, mw.Audit(AuditContent, "mid"))
 }
 
 func (ch *ContentHandler) GetContentHandler() echo.HandlerFunc {
@@ -58,14 +73,14 @@ func (ch *ContentHandler) GetContentHandler() echo.HandlerFunc {
 		// nolint: errcheck
 		userID, _ := cntx.Get("userID").(uint64)
 
-		movies, err := ch.movieUcase.ListByParams(&req.ContentFilter,
+		movies, err := ch.movieUcase.ListByParams(&req.ContentFilter, nil,
 			&req.Pagination, userID)
 		if err != nil {
 			logger.Error(err.Message)
 			return cntx.JSON(err.HTTPCode, Response{Error: err})
 		}
 
-		tvshows, err := ch.tvshowUcase.ListByParams(&req.ContentFilter,
+		tvshows, err := ch.tvshowUcase.ListByParams(&req.ContentFilter, nil,
 			&req.Pagination, userID)
 		if err != nil {
 			logger.Error(err.Message)
@@ -81,10 +96,222 @@ func (ch *ContentHandler) GetContentHandler() echo.HandlerFunc {
 	}
 }
 
+// GetAdminContentHandler lists content in any status, drafts included
+func (ch *ContentHandler) GetAdminContentHandler() echo.HandlerFunc {
+	type Request struct {
+		models.ContentFilter
+		Status []string `query:"status" validate:"dive,oneof=draft scheduled published archived"`
+		models.Pagination
+	}
+
+	return func(cntx echo.Context) error {
+		req := &Request{}
+		if err := reader.NewRequestReader(cntx).Read(req); err != nil {
+			logger.Error(err.Message)
+			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		}
+
+		statuses := req.Status
+		if len(statuses) == 0 {
+			statuses = ContentStatuses
+		}
+
+		// nolint: errcheck
+		userID, _ := cntx.Get("userID").(uint64)
+
+		movies, err := ch.movieUcase.ListByParams(&req.ContentFilter, statuses,
+			&req.Pagination, userID)
+		if err != nil {
+			logger.Error(err.Message)
+			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		}
+
+		tvshows, err := ch.tvshowUcase.ListByParams(&req.ContentFilter, statuses,
+			&req.Pagination, userID)
+		if err != nil {
+			logger.Error(err.Message)
+			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		}
+
+		return cntx.JSON(http.StatusOK, Response{
+			Body: &Body{
+				"movies":  movies,
+				"tvshows": tvshows,
+			},
+		})
+	}
+}
+
+// UpdateStatusHandler publishes, schedules, archives content or returns it to drafts
+func (ch *ContentHandler) UpdateStatusHandler() echo.HandlerFunc {
+	type Request struct {
+		Status    string     `json:"status" validate:"required,oneof=draft scheduled published archived"`
+		PublishAt *time.Time `json:"publish_at"`
+	}
+
+	return func(cntx echo.Context) error {
+		req := &Request{}
+		if err := reader.NewRequestReader(cntx).Read(req); err != nil {
+			logger.Error(err.Message)
+			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		}
+
+		contentID, parseErr := strconv.ParseUint(cntx.Param("cid"), 10, 64)
+		if parseErr != nil {
+			customErr := errors.New(CodeBadRequest, parseErr)
+			logger.Error(customErr.Message)
+			return cntx.JSON(customErr.HTTPCode, Response{Error: customErr})
+		}
+
+		content, err := ch.contentUcase.UpdateStatus(contentID, req.Status, req.PublishAt)
+		if err != nil {
+			logger.Error(err.Message)
+			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		}
+
+		return cntx.JSON(http.StatusOK, Response{
+			Body: &Body{
+				"content": content,
+			},
+		})
+	}
+}
+
+func (ch *ContentHandler) GetRevisionsHandler() echo.HandlerFunc {
+	type Request struct {
+		models.Pagination
+	}
+
+	return func(cntx echo.Context) error {
+		req := &Request{}
+		if err := reader.NewRequestReader(cntx).Read(req); err != nil {
+			logger.Error(err.Message)
+			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		}
+
+		contentID, parseErr := strconv.ParseUint(cntx.Param("cid"), 10, 64)
+		if parseErr != nil {
+			customErr := errors.New(CodeBadRequest, parseErr)
+			logger.Error(customErr.Message)
+			return cntx.JSON(customErr.HTTPCode, Response{Error: customErr})
+		}
+
+		revisions, err := ch.contentUcase.ListRevisions(contentID, &req.Pagination)
+		if err != nil {
+			logger.Error(err.Message)
+			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		}
+
+		return cntx.JSON(http.StatusOK, Response{
+			Body: &Body{
+				"revisions": revisions,
+			},
+		})
+	}
+}
+
+// GetRevisionDiffHandler returns fields changed by the revision
+func (ch *ContentHandler) GetRevisionDiffHandler() echo.HandlerFunc {
+	return func(cntx echo.Context) error {
+		contentID, parseErr := strconv.ParseUint(cntx.Param("cid"), 10, 64)
+		if parseErr != nil {
+			customErr := errors.New(CodeBadRequest, parseErr)
+			logger.Error(customErr.Message)
+			return cntx.JSON(customErr.HTTPCode, Response{Error: customErr})
+		}
+		revisionID, parseErr := strconv.ParseUint(cntx.Param("rid"), 10, 64)
+		if parseErr != nil {
+			customErr := errors.New(CodeBadRequest, parseErr)
+			logger.Error(customErr.Message)
+			return cntx.JSON(customErr.HTTPCode, Response{Error: customErr})
+		}
+
+		changes, err := ch.contentUcase.GetRevisionDiff(contentID, revisionID)
+		if err != nil {
+			logger.Error(err.Message)
+			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		}
+
+		return cntx.JSON(http.StatusOK, Response{
+			Body: &Body{
+				"changes": changes,
+			},
+		})
+	}
+}
+
+func (ch *ContentHandler) RollbackHandler() echo.HandlerFunc {
+	return func(cntx echo.Context) error {
+		contentID, parseErr := strconv.ParseUint(cntx.Param("cid"), 10, 64)
+		if parseErr != nil {
+			customErr := errors.New(CodeBadRequest, parseErr)
+			logger.Error(customErr.Message)
+			return cntx.JSON(customErr.HTTPCode, Response{Error: customErr})
+		}
+		revisionID, parseErr := strconv.ParseUint(cntx.Param("rid"), 10, 64)
+		if parseErr != nil {
+			customErr := errors.New(CodeBadRequest, parseErr)
+			logger.Error(customErr.Message)
+			return cntx.JSON(customErr.HTTPCode, Response{Error: customErr})
+		}
+
+		content, err := ch.contentUcase.Rollback(contentID, revisionID)
+		if err != nil {
+			logger.Error(err.Message)
+			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		}
+
+		return cntx.JSON(http.StatusOK, Response{
+			Body: &Body{
+				"content": content,
+			},
+		})
+	}
+}
+
+func (ch *ContentHandler) GetSimilarContentHandler() echo.HandlerFunc {
+	type Request struct {
+		models.Pagination
+	}
+
+	return func(cntx echo.Context) error {
+		req := &Request{}
+		if err := reader.NewRequestReader(cntx).Read(req); err != nil {
+			logger.Error(err.Message)
+			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		}
+
+		contentID, parseErr := strconv.ParseUint(cntx.Param("cid"), 10, 64)
+		if parseErr != nil {
+			customErr := errors.New(CodeBadRequest, parseErr)
+			logger.Error(customErr.Message)
+			return cntx.JSON(customErr.HTTPCode, Response{Error: customErr})
+		}
+
+		if _, err := ch.contentUcase.GetByID(contentID); err != nil {
+			logger.Error(err.Message)
+			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		}
+
+		// nolint: errcheck
+		userID, _ := cntx.Get("userID").(uint64)
+
+		contents, err := ch.contentUcase.ListSimilar(contentID, &req.Pagination, userID)
+		if err != nil {
+			logger.Error(err.Message)
+			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		}
+
+		return cntx.JSON(http.StatusOK, Response{
+			Body: &Body{
+				"content": contents,
+			},
+		})
+	}
+}
+
 func (ch *ContentHandler) UpdatePostersHandler() echo.HandlerFunc {
 	const postersDirRoot = "/images/"
-	const smallPosterName = "640"
-	const largePosterName = "1920"
 
 	return func(cntx echo.Context) error {
 		smallImage, err := reader.NewRequestReader(cntx).ReadNotRequiredImage("small_poster")
@@ -131,25 +358,24 @@ func (ch *ContentHandler) UpdatePostersHandler() echo.HandlerFunc {
 		postersDirPath := filepath.Join(path, postersDir)
 		helpers.InitStorage(postersDirPath)
 
-		// Store small poster
-		if smallImage != nil {
-			smallPosterPath := filepath.Join(postersDirPath, smallPosterName)
-			if err := helpers.StoreSmallImage(smallImage, smallPosterPath); err != nil {
-				if content.Images == "" {
-					removeErr := os.RemoveAll(postersDirPath)
-					if removeErr != nil {
-						logger.Error(removeErr)
-					}
-				}
-				logger.Error(err.Message)
-				return cntx.JSON(err.HTTPCode, Response{Error: err})
-			}
+		// Stage posters, they are compressed by background job
+		payload := &models.ContentPostersPayload{
+			ContentID:   content.ContentID,
+			PostersDir:  postersDir,
+			SmallPoster: smallImage != nil,
+			LargePoster: largeImage != nil,
 		}
-
-		// Store large poster
-		if largeImage != nil {
-			largePosterPath := filepath.Join(postersDirPath, largePosterName)
-			if err := helpers.StoreLargeImage(largeImage, largePosterPath); err != nil {
+		images := map[string]*multipart.FileHeader{
+			SmallPosterName: smallImage,
+			LargePosterName: largeImage,
+		}
+		for posterName, image := range images {
+			if image == nil {
+				continue
+			}
+			absStagedPath := helpers.GetStagedPath(filepath.Join(postersDirPath, posterName))
+			if err := helpers.StoreFile(image, absStagedPath); err != nil {
+				removeStagedPosters(images, postersDirPath)
 				if content.Images == "" {
 					removeErr := os.RemoveAll(postersDirPath)
 					if removeErr != nil {
@@ -161,22 +387,25 @@ func (ch *ContentHandler) UpdatePostersHandler() echo.HandlerFunc {
 			}
 		}
 
-		// Update content
-		if err := ch.contentUcase.UpdatePosters(content, postersDir); err != nil {
-			if content.Images == "" {
-				removeErr := os.RemoveAll(postersDirPath)
-				if removeErr != nil {
-					logger.Error(removeErr)
-				}
-			}
+		job, err := ch.jobUcase.Enqueue(JobContentPosters, payload)
+		if err != nil {
+			removeStagedPosters(images, postersDirPath)
 			logger.Error(err.Message)
 			return cntx.JSON(err.HTTPCode, Response{Error: err})
 		}
 
-		return cntx.JSON(http.StatusOK, Response{
+		return cntx.JSON(http.StatusAccepted, Response{
 			Body: &Body{
-				"images": postersDir,
+				"job": job,
 			},
 		})
 	}
 }
+
+func removeStagedPosters(images map[string]*multipart.FileHeader, postersDirPath string) {
+	for posterName, image := range images {
+		if image != nil {
+			helpers.RemoveStagedFile(helpers.GetStagedPath(filepath.Join(postersDirPath, posterName)))
+		}
+	}
+}
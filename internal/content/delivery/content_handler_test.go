@@ -7,8 +7,12 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
 	contentMocks "github.com/go-park-mail-ru/2020_2_Slash/internal/content/mocks"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	jobMocks "github.com/go-park-mail-ru/2020_2_Slash/internal/job/mocks"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
 	movieMocks "github.com/go-park-mail-ru/2020_2_Slash/internal/movie/mocks"
 	tvshowMocks "github.com/go-park-mail-ru/2020_2_Slash/internal/tvshow/mocks"
@@ -29,6 +33,7 @@ func TestContentHandler_GetContentPostersHandler(t *testing.T) {
 	contentUseCase := contentMocks.NewMockContentUsecase(ctrl)
 	movieUseCase := movieMocks.NewMockMovieUsecase(ctrl)
 	tvshowUseCase := tvshowMocks.NewMockTVShowUsecase(ctrl)
+	jobUseCase := jobMocks.NewMockJobUsecase(ctrl)
 
 	pgnt := &models.Pagination{
 		From:  0,
@@ -66,7 +71,7 @@ func TestContentHandler_GetContentPostersHandler(t *testing.T) {
 	c := e.NewContext(req, rec)
 	c.Set("userID", userID)
 
-	contentHandler := NewContentHandler(contentUseCase, movieUseCase, tvshowUseCase)
+	contentHandler := NewContentHandler(contentUseCase, movieUseCase, tvshowUseCase, jobUseCase)
 	handleFunc := contentHandler.GetContentHandler()
 	contentHandler.Configure(e, nil)
 
@@ -90,12 +95,12 @@ func TestContentHandler_GetContentPostersHandler(t *testing.T) {
 
 	movieUseCase.
 		EXPECT().
-		ListByParams(params, pgnt, userID).
+		ListByParams(params, nil, pgnt, userID).
 		Return(movies, nil)
 
 	tvshowUseCase.
 		EXPECT().
-		ListByParams(params, pgnt, userID).
+		ListByParams(params, nil, pgnt, userID).
 		Return(tvshows, nil)
 
 	response := &response.Response{Body: &response.Body{
@@ -127,6 +132,7 @@ func TestContentHandler_UpdateContentPostersHandler(t *testing.T) {
 	contentUseCase := contentMocks.NewMockContentUsecase(ctrl)
 	movieUseCase := movieMocks.NewMockMovieUsecase(ctrl)
 	tvshowUseCase := tvshowMocks.NewMockTVShowUsecase(ctrl)
+	jobUseCase := jobMocks.NewMockJobUsecase(ctrl)
 
 	e := echo.New()
 	strId := strconv.Itoa(1)
@@ -137,7 +143,7 @@ func TestContentHandler_UpdateContentPostersHandler(t *testing.T) {
 	c.SetParamNames("id")
 	c.SetParamValues(strId)
 
-	contentHandler := NewContentHandler(contentUseCase, movieUseCase, tvshowUseCase)
+	contentHandler := NewContentHandler(contentUseCase, movieUseCase, tvshowUseCase, jobUseCase)
 	handleFunc := contentHandler.UpdatePostersHandler()
 	contentHandler.Configure(e, nil)
 
@@ -163,3 +169,407 @@ func TestContentHandler_UpdateContentPostersHandler(t *testing.T) {
 		assert.JSONEq(t, expResBody.String(), string(bytes))
 	}
 }
+
+func TestContentHandler_GetSimilarContentHandler(t *testing.T) {
+	t.Parallel()
+	// Setup
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	contentUseCase := contentMocks.NewMockContentUsecase(ctrl)
+	movieUseCase := movieMocks.NewMockMovieUsecase(ctrl)
+	tvshowUseCase := tvshowMocks.NewMockTVShowUsecase(ctrl)
+	jobUseCase := jobMocks.NewMockJobUsecase(ctrl)
+
+	pgnt := &models.Pagination{
+		From:  0,
+		Count: 10,
+	}
+	var userID uint64 = 3
+	var contentID uint64 = 4
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/content/4/similar?from=0&count=10", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("cid")
+	c.SetParamValues(strconv.Itoa(int(contentID)))
+	c.Set("userID", userID)
+
+	contentHandler := NewContentHandler(contentUseCase, movieUseCase, tvshowUseCase, jobUseCase)
+	handleFunc := contentHandler.GetSimilarContentHandler()
+
+	isLiked := true
+	contents := []interface{}{
+		&models.Movie{
+			Content: models.Content{
+				ContentID: 5,
+				Name:      "Shrek 2",
+				IsLiked:   &isLiked,
+			},
+		},
+		&models.TVShow{
+			Seasons: 2,
+			Content: models.Content{
+				ContentID: 6,
+				Name:      "Shrek the Musical",
+			},
+		},
+	}
+
+	contentUseCase.
+		EXPECT().
+		GetByID(contentID).
+		Return(&models.Content{ContentID: contentID}, nil)
+
+	contentUseCase.
+		EXPECT().
+		ListSimilar(contentID, pgnt, userID).
+		Return(contents, nil)
+
+	response := &response.Response{Body: &response.Body{
+		"content": contents,
+	}}
+
+	// Assertions
+	if assert.NoError(t, handleFunc(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		expResBody, err := converter.AnyToBytesBuffer(response)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		bytes, _ := ioutil.ReadAll(rec.Body)
+
+		assert.JSONEq(t, expResBody.String(), string(bytes))
+	}
+}
+
+func TestContentHandler_GetSimilarContentHandler_NoContent(t *testing.T) {
+	t.Parallel()
+	// Setup
+	logger.DisableLogger()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	contentUseCase := contentMocks.NewMockContentUsecase(ctrl)
+	movieUseCase := movieMocks.NewMockMovieUsecase(ctrl)
+	tvshowUseCase := tvshowMocks.NewMockTVShowUsecase(ctrl)
+	jobUseCase := jobMocks.NewMockJobUsecase(ctrl)
+
+	var contentID uint64 = 4
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/content/4/similar", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("cid")
+	c.SetParamValues(strconv.Itoa(int(contentID)))
+
+	contentHandler := NewContentHandler(contentUseCase, movieUseCase, tvshowUseCase, jobUseCase)
+	handleFunc := contentHandler.GetSimilarContentHandler()
+
+	customErr := errors.Get(consts.CodeContentDoesNotExist)
+	contentUseCase.
+		EXPECT().
+		GetByID(contentID).
+		Return(nil, customErr)
+
+	response := &response.Response{Error: customErr}
+
+	// Assertions
+	if assert.NoError(t, handleFunc(c)) {
+		assert.Equal(t, customErr.HTTPCode, rec.Code)
+
+		expResBody, err := converter.AnyToBytesBuffer(response)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		bytes, _ := ioutil.ReadAll(rec.Body)
+
+		assert.JSONEq(t, expResBody.String(), string(bytes))
+	}
+}
+
+func TestContentHandler_GetAdminContentHandler(t *testing.T) {
+	t.Parallel()
+	// Setup
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	contentUseCase := contentMocks.NewMockContentUsecase(ctrl)
+	movieUseCase := movieMocks.NewMockMovieUsecase(ctrl)
+	tvshowUseCase := tvshowMocks.NewMockTVShowUsecase(ctrl)
+	jobUseCase := jobMocks.NewMockJobUsecase(ctrl)
+
+	var userID uint64 = 1
+	pgnt := &models.Pagination{From: 0, Count: 10}
+	params := &models.ContentFilter{}
+	statuses := []string{consts.ContentDraft, consts.ContentScheduled}
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet,
+		"/api/v1/admin/content?status=draft&status=scheduled&from=0&count=10", strings.NewReader(""))
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("userID", userID)
+
+	contentHandler := NewContentHandler(contentUseCase, movieUseCase, tvshowUseCase, jobUseCase)
+	handleFunc := contentHandler.GetAdminContentHandler()
+
+	movies := []*models.Movie{
+		&models.Movie{
+			Content: models.Content{
+				Name:   "Shrek",
+				Status: consts.ContentDraft,
+			},
+		},
+	}
+	tvshows := []*models.TVShow{}
+
+	movieUseCase.
+		EXPECT().
+		ListByParams(params, statuses, pgnt, userID).
+		Return(movies, nil)
+
+	tvshowUseCase.
+		EXPECT().
+		ListByParams(params, statuses, pgnt, userID).
+		Return(tvshows, nil)
+
+	response := &response.Response{Body: &response.Body{
+		"movies":  movies,
+		"tvshows": tvshows,
+	}}
+
+	// Assertions
+	if assert.NoError(t, handleFunc(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		expResBody, err := converter.AnyToBytesBuffer(response)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		bytes, _ := ioutil.ReadAll(rec.Body)
+
+		assert.JSONEq(t, expResBody.String(), string(bytes))
+	}
+}
+
+func TestContentHandler_UpdateStatusHandler(t *testing.T) {
+	t.Parallel()
+	// Setup
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	contentUseCase := contentMocks.NewMockContentUsecase(ctrl)
+	movieUseCase := movieMocks.NewMockMovieUsecase(ctrl)
+	tvshowUseCase := tvshowMocks.NewMockTVShowUsecase(ctrl)
+	jobUseCase := jobMocks.NewMockJobUsecase(ctrl)
+
+	publishAt := time.Date(2030, 1, 1, 10, 0, 0, 0, time.UTC)
+	content := &models.Content{
+		ContentID: 3,
+		Name:      "Shrek",
+		Status:    consts.ContentScheduled,
+		PublishAt: &publishAt,
+	}
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/content/3/status",
+		strings.NewReader(`{"status":"scheduled","publish_at":"2030-01-01T10:00:00Z"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("cid")
+	c.SetParamValues("3")
+
+	contentHandler := NewContentHandler(contentUseCase, movieUseCase, tvshowUseCase, jobUseCase)
+	handleFunc := contentHandler.UpdateStatusHandler()
+
+	contentUseCase.
+		EXPECT().
+		UpdateStatus(content.ContentID, consts.ContentScheduled, &publishAt).
+		Return(content, nil)
+
+	response := &response.Response{Body: &response.Body{"content": content}}
+
+	// Assertions
+	if assert.NoError(t, handleFunc(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		expResBody, err := converter.AnyToBytesBuffer(response)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		bytes, _ := ioutil.ReadAll(rec.Body)
+
+		assert.JSONEq(t, expResBody.String(), string(bytes))
+	}
+}
+
+func TestContentHandler_UpdateStatusHandler_WrongStatus(t *testing.T) {
+	t.Parallel()
+	// Setup
+	logger.DisableLogger()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	contentUseCase := contentMocks.NewMockContentUsecase(ctrl)
+	movieUseCase := movieMocks.NewMockMovieUsecase(ctrl)
+	tvshowUseCase := tvshowMocks.NewMockTVShowUsecase(ctrl)
+	jobUseCase := jobMocks.NewMockJobUsecase(ctrl)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/content/3/status",
+		strings.NewReader(`{"status":"hidden"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("cid")
+	c.SetParamValues("3")
+
+	contentHandler := NewContentHandler(contentUseCase, movieUseCase, tvshowUseCase, jobUseCase)
+	handleFunc := contentHandler.UpdateStatusHandler()
+
+	// Assertions
+	if assert.NoError(t, handleFunc(c)) {
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	}
+}
+
+func TestContentHandler_GetRevisionsHandler(t *testing.T) {
+	t.Parallel()
+	// Setup
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	contentUseCase := contentMocks.NewMockContentUsecase(ctrl)
+	movieUseCase := movieMocks.NewMockMovieUsecase(ctrl)
+	tvshowUseCase := tvshowMocks.NewMockTVShowUsecase(ctrl)
+	jobUseCase := jobMocks.NewMockJobUsecase(ctrl)
+
+	revisions := []*models.ContentRevision{
+		&models.ContentRevision{
+			ID:        2,
+			ContentID: 3,
+			Created:   time.Date(2020, 12, 1, 10, 0, 0, 0, time.UTC),
+			ContentRevisionData: models.ContentRevisionData{
+				Name:      "Шрек",
+				Year:      2001,
+				Countries: []uint64{1},
+			},
+		},
+	}
+	pgnt := &models.Pagination{From: 0, Count: 10}
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet,
+		"/api/v1/admin/content/3/revisions?from=0&count=10", strings.NewReader(""))
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("cid")
+	c.SetParamValues("3")
+
+	contentHandler := NewContentHandler(contentUseCase, movieUseCase, tvshowUseCase, jobUseCase)
+	handleFunc := contentHandler.GetRevisionsHandler()
+
+	contentUseCase.
+		EXPECT().
+		ListRevisions(uint64(3), pgnt).
+		Return(revisions, nil)
+
+	response := &response.Response{Body: &response.Body{"revisions": revisions}}
+
+	// Assertions
+	if assert.NoError(t, handleFunc(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		expResBody, err := converter.AnyToBytesBuffer(response)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		bytes, _ := ioutil.ReadAll(rec.Body)
+
+		assert.JSONEq(t, expResBody.String(), string(bytes))
+	}
+}
+
+func TestContentHandler_GetRevisionDiffHandler(t *testing.T) {
+	t.Parallel()
+	// Setup
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	contentUseCase := contentMocks.NewMockContentUsecase(ctrl)
+	movieUseCase := movieMocks.NewMockMovieUsecase(ctrl)
+	tvshowUseCase := tvshowMocks.NewMockTVShowUsecase(ctrl)
+	jobUseCase := jobMocks.NewMockJobUsecase(ctrl)
+
+	changes := map[string]*models.RevisionChange{
+		"year": &models.RevisionChange{Before: 2000, After: 2001},
+	}
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/content/3/revisions/2/diff",
+		strings.NewReader(""))
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("cid", "rid")
+	c.SetParamValues("3", "2")
+
+	contentHandler := NewContentHandler(contentUseCase, movieUseCase, tvshowUseCase, jobUseCase)
+	handleFunc := contentHandler.GetRevisionDiffHandler()
+
+	contentUseCase.
+		EXPECT().
+		GetRevisionDiff(uint64(3), uint64(2)).
+		Return(changes, nil)
+
+	response := &response.Response{Body: &response.Body{"changes": changes}}
+
+	// Assertions
+	if assert.NoError(t, handleFunc(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		expResBody, err := converter.AnyToBytesBuffer(response)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		bytes, _ := ioutil.ReadAll(rec.Body)
+
+		assert.JSONEq(t, expResBody.String(), string(bytes))
+	}
+}
+
+func TestContentHandler_RollbackHandler_RevisionDoesNotExist(t *testing.T) {
+	t.Parallel()
+	// Setup
+	logger.DisableLogger()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	contentUseCase := contentMocks.NewMockContentUsecase(ctrl)
+	movieUseCase := movieMocks.NewMockMovieUsecase(ctrl)
+	tvshowUseCase := tvshowMocks.NewMockTVShowUsecase(ctrl)
+	jobUseCase := jobMocks.NewMockJobUsecase(ctrl)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/content/3/revisions/7/rollback",
+		strings.NewReader(""))
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("cid", "rid")
+	c.SetParamValues("3", "7")
+
+	contentHandler := NewContentHandler(contentUseCase, movieUseCase, tvshowUseCase, jobUseCase)
+	handleFunc := contentHandler.RollbackHandler()
+
+	contentUseCase.
+		EXPECT().
+		Rollback(uint64(3), uint64(7)).
+		Return(nil, errors.Get(consts.CodeRevisionDoesNotExist))
+
+	// Assertions
+	if assert.NoError(t, handleFunc(c)) {
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	}
+}
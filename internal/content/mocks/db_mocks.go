@@ -3,23 +3,28 @@ package mocks
 import (
 	"database/sql"
 	"database/sql/driver"
+	"encoding/json"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
 )
 
 func MockContentRepoDeleteReturnResultOk(mock sqlmock.Sqlmock, id uint64) {
 	mock.ExpectBegin()
-	mock.ExpectExec(`DELETE FROM content`).
-		WithArgs(id).WillReturnResult(driver.ResultNoRows)
+	mock.ExpectExec(`UPDATE content SET deleted_at`).
+		WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 1))
 	mock.ExpectCommit()
 }
 
 func MockContentRepoSelectByIDReturnRows(mock sqlmock.Sqlmock, id uint64, content *models.Content) {
 	rows := sqlmock.NewRows([]string{"id", "name", "original_name",
-		"description", "short_description", "year", "images", "type", "is_free"})
+		"description", "short_description", "year", "images", "type", "is_free",
+		"status", "publish_at"})
 	rows.AddRow(content.ContentID, content.Name, content.OriginalName, content.Description,
-		content.ShortDescription, content.Year, content.Images, content.Type, content.IsFree)
+		content.ShortDescription, content.Year, content.Images, content.Type, content.IsFree,
+		content.Status, content.PublishAt)
 	mock.ExpectQuery(`SELECT`).WithArgs(id).WillReturnRows(rows)
 }
 
@@ -65,7 +70,8 @@ func MockContentRepoInsertReturnResultOk(mock sqlmock.Sqlmock, content *models.C
 	rows := sqlmock.NewRows([]string{"id"}).AddRow(content.ContentID)
 	mock.ExpectQuery(`INSERT INTO content`).
 		WithArgs(content.Name, content.OriginalName, content.Description,
-			content.ShortDescription, content.Year, content.Images, content.Type, content.IsFree).WillReturnRows(rows)
+			content.ShortDescription, content.Year, content.Images, content.Type, content.IsFree,
+			content.Status, content.PublishAt).WillReturnRows(rows)
 
 	mock.ExpectPrepare(``).ExpectExec().WithArgs(content.ContentID, country.ID).WillReturnResult(sqlmock.NewResult(0, 0))
 	mock.ExpectExec(``).WithArgs().WillReturnResult(driver.ResultNoRows)
@@ -79,6 +85,10 @@ func MockContentRepoInsertReturnResultOk(mock sqlmock.Sqlmock, content *models.C
 	mock.ExpectPrepare(``).ExpectExec().WithArgs(content.ContentID, director.ID).WillReturnResult(sqlmock.NewResult(0, 0))
 	mock.ExpectExec(``).WithArgs().WillReturnResult(driver.ResultNoRows)
 
+	data, _ := json.Marshal(models.NewContentRevisionData(content))
+	mock.ExpectExec(`INSERT INTO content_revisions`).
+		WithArgs(content.ContentID, data).WillReturnResult(sqlmock.NewResult(0, 1))
+
 	mock.ExpectCommit()
 }
 
@@ -109,6 +119,10 @@ func MockContentRepoUpdateReturnResultOk(mock sqlmock.Sqlmock, content *models.C
 	mock.ExpectPrepare(``).ExpectExec().WithArgs(content.ContentID, director.ID).WillReturnResult(sqlmock.NewResult(0, 0))
 	mock.ExpectExec(``).WithArgs().WillReturnResult(driver.ResultNoRows)
 
+	data, _ := json.Marshal(models.NewContentRevisionData(content))
+	mock.ExpectExec(`INSERT INTO content_revisions`).
+		WithArgs(content.ContentID, data).WillReturnResult(sqlmock.NewResult(0, 1))
+
 	mock.ExpectCommit()
 }
 
@@ -119,3 +133,59 @@ func MockContentRepoUpdateImagesReturnResultOk(mock sqlmock.Sqlmock, content *mo
 
 	mock.ExpectCommit()
 }
+
+func MockContentRepoUpdateStatusReturnResultOk(mock sqlmock.Sqlmock, content *models.Content) {
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE content`).
+		WithArgs(content.ContentID, content.Status, content.PublishAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+}
+
+func MockContentRepoPublishScheduledReturnResult(mock sqlmock.Sqlmock, now time.Time,
+	rowsAffected int64) {
+	mock.ExpectExec(`UPDATE content`).
+		WithArgs(consts.ContentPublished, consts.ContentScheduled, now).
+		WillReturnResult(sqlmock.NewResult(0, rowsAffected))
+}
+
+func MockContentRepoSelectRevisionsReturnRows(mock sqlmock.Sqlmock, revisions []*models.ContentRevision,
+	args ...driver.Value) {
+	rows := sqlmock.NewRows([]string{"id", "content_id", "data", "created"})
+	for _, revision := range revisions {
+		data, _ := json.Marshal(revision.ContentRevisionData)
+		rows.AddRow(revision.ID, revision.ContentID, data, revision.Created)
+	}
+	mock.ExpectQuery(`SELECT id, content_id, data, created FROM content_revisions`).
+		WithArgs(args...).WillReturnRows(rows)
+}
+
+func MockContentRepoSelectRevisionReturnErrNoRows(mock sqlmock.Sqlmock, args ...driver.Value) {
+	mock.ExpectQuery(`SELECT id, content_id, data, created FROM content_revisions`).
+		WithArgs(args...).WillReturnError(sql.ErrNoRows)
+}
+
+func MockContentRepoSelectSimilarReturnRows(mock sqlmock.Sqlmock, contentID uint64, pgnt *models.Pagination,
+	curUserID uint64, contents []interface{}) {
+	rows := sqlmock.NewRows([]string{"id", "video", "seasons", "content_id", "name",
+		"original_name", "description", "short_description", "rating",
+		"year", "images", "type", "is_free", "likes", "is_favourite"})
+	for _, item := range contents {
+		switch item := item.(type) {
+		case *models.Movie:
+			rows.AddRow(item.ID, item.Video, 0, item.ContentID, item.Name,
+				item.OriginalName, item.Description, item.ShortDescription, item.Rating,
+				item.Year, item.Images, item.Type, item.IsFree, item.IsLiked, item.IsFavourite)
+		case *models.TVShow:
+			rows.AddRow(item.ID, "", item.Seasons, item.ContentID, item.Name,
+				item.OriginalName, item.Description, item.ShortDescription, item.Rating,
+				item.Year, item.Images, item.Type, item.IsFree, item.IsLiked, item.IsFavourite)
+		}
+	}
+
+	mock.ExpectQuery(`UNION ALL`).
+		WithArgs(curUserID, contentID, consts.SimilarGenreWeight, consts.SimilarActorWeight,
+			consts.SimilarDirectorWeight, consts.SimilarCountryWeight, consts.SimilarYearWeight,
+			pgnt.Count, pgnt.From).
+		WillReturnRows(rows)
+}
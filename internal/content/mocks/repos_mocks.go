@@ -8,6 +8,7 @@ import (
 	models "github.com/go-park-mail-ru/2020_2_Slash/internal/models"
 	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
+	time "time"
 )
 
 // MockContentRepository is a mock of ContentRepository interface
@@ -75,6 +76,35 @@ func (mr *MockContentRepositoryMockRecorder) UpdateImages(content interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateImages", reflect.TypeOf((*MockContentRepository)(nil).UpdateImages), content)
 }
 
+// UpdateStatus mocks base method
+func (m *MockContentRepository) UpdateStatus(content *models.Content) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", content)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus
+func (mr *MockContentRepositoryMockRecorder) UpdateStatus(content interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockContentRepository)(nil).UpdateStatus), content)
+}
+
+// PublishScheduled mocks base method
+func (m *MockContentRepository) PublishScheduled(now time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishScheduled", now)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PublishScheduled indicates an expected call of PublishScheduled
+func (mr *MockContentRepositoryMockRecorder) PublishScheduled(now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishScheduled", reflect.TypeOf((*MockContentRepository)(nil).PublishScheduled), now)
+}
+
 // DeleteByID mocks base method
 func (m *MockContentRepository) DeleteByID(contentID uint64) error {
 	m.ctrl.T.Helper()
@@ -163,3 +193,63 @@ func (mr *MockContentRepositoryMockRecorder) SelectDirectorsByID(contentID inter
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectDirectorsByID", reflect.TypeOf((*MockContentRepository)(nil).SelectDirectorsByID), contentID)
 }
+
+// SelectSimilar mocks base method
+func (m *MockContentRepository) SelectSimilar(contentID uint64, pgnt *models.Pagination, curUserID uint64) ([]interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectSimilar", contentID, pgnt, curUserID)
+	ret0, _ := ret[0].([]interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectSimilar indicates an expected call of SelectSimilar
+func (mr *MockContentRepositoryMockRecorder) SelectSimilar(contentID, pgnt, curUserID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectSimilar", reflect.TypeOf((*MockContentRepository)(nil).SelectSimilar), contentID, pgnt, curUserID)
+}
+
+// SelectRevisions mocks base method
+func (m *MockContentRepository) SelectRevisions(contentID uint64, pgnt *models.Pagination) ([]*models.ContentRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectRevisions", contentID, pgnt)
+	ret0, _ := ret[0].([]*models.ContentRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectRevisions indicates an expected call of SelectRevisions
+func (mr *MockContentRepositoryMockRecorder) SelectRevisions(contentID, pgnt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectRevisions", reflect.TypeOf((*MockContentRepository)(nil).SelectRevisions), contentID, pgnt)
+}
+
+// SelectRevisionByID mocks base method
+func (m *MockContentRepository) SelectRevisionByID(contentID, revisionID uint64) (*models.ContentRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectRevisionByID", contentID, revisionID)
+	ret0, _ := ret[0].(*models.ContentRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectRevisionByID indicates an expected call of SelectRevisionByID
+func (mr *MockContentRepositoryMockRecorder) SelectRevisionByID(contentID, revisionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectRevisionByID", reflect.TypeOf((*MockContentRepository)(nil).SelectRevisionByID), contentID, revisionID)
+}
+
+// SelectPrevRevision mocks base method
+func (m *MockContentRepository) SelectPrevRevision(contentID, revisionID uint64) (*models.ContentRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectPrevRevision", contentID, revisionID)
+	ret0, _ := ret[0].(*models.ContentRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectPrevRevision indicates an expected call of SelectPrevRevision
+func (mr *MockContentRepositoryMockRecorder) SelectPrevRevision(contentID, revisionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectPrevRevision", reflect.TypeOf((*MockContentRepository)(nil).SelectPrevRevision), contentID, revisionID)
+}
@@ -9,6 +9,7 @@ import (
 	models "github.com/go-park-mail-ru/2020_2_Slash/internal/models"
 	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
+	time "time"
 )
 
 // MockContentUsecase is a mock of ContentUsecase interface
@@ -77,6 +78,49 @@ func (mr *MockContentUsecaseMockRecorder) UpdatePosters(content, newPostersDir i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePosters", reflect.TypeOf((*MockContentUsecase)(nil).UpdatePosters), content, newPostersDir)
 }
 
+// ProcessPostersJob mocks base method
+func (m *MockContentUsecase) ProcessPostersJob(job *models.Job) *errors.Error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessPostersJob", job)
+	ret0, _ := ret[0].(*errors.Error)
+	return ret0
+}
+
+// ProcessPostersJob indicates an expected call of ProcessPostersJob
+func (mr *MockContentUsecaseMockRecorder) ProcessPostersJob(job interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessPostersJob", reflect.TypeOf((*MockContentUsecase)(nil).ProcessPostersJob), job)
+}
+
+// UpdateStatus mocks base method
+func (m *MockContentUsecase) UpdateStatus(contentID uint64, status string, publishAt *time.Time) (*models.Content, *errors.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", contentID, status, publishAt)
+	ret0, _ := ret[0].(*models.Content)
+	ret1, _ := ret[1].(*errors.Error)
+	return ret0, ret1
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus
+func (mr *MockContentUsecaseMockRecorder) UpdateStatus(contentID, status, publishAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockContentUsecase)(nil).UpdateStatus), contentID, status, publishAt)
+}
+
+// PublishScheduled mocks base method
+func (m *MockContentUsecase) PublishScheduled() *errors.Error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishScheduled")
+	ret0, _ := ret[0].(*errors.Error)
+	return ret0
+}
+
+// PublishScheduled indicates an expected call of PublishScheduled
+func (mr *MockContentUsecaseMockRecorder) PublishScheduled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishScheduled", reflect.TypeOf((*MockContentUsecase)(nil).PublishScheduled))
+}
+
 // DeleteByID mocks base method
 func (m *MockContentUsecase) DeleteByID(contentID uint64) *errors.Error {
 	m.ctrl.T.Helper()
@@ -194,3 +238,63 @@ func (mr *MockContentUsecaseMockRecorder) GetDirectorsByID(contentID interface{}
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDirectorsByID", reflect.TypeOf((*MockContentUsecase)(nil).GetDirectorsByID), contentID)
 }
+
+// ListSimilar mocks base method
+func (m *MockContentUsecase) ListSimilar(contentID uint64, pgnt *models.Pagination, curUserID uint64) ([]interface{}, *errors.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSimilar", contentID, pgnt, curUserID)
+	ret0, _ := ret[0].([]interface{})
+	ret1, _ := ret[1].(*errors.Error)
+	return ret0, ret1
+}
+
+// ListSimilar indicates an expected call of ListSimilar
+func (mr *MockContentUsecaseMockRecorder) ListSimilar(contentID, pgnt, curUserID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSimilar", reflect.TypeOf((*MockContentUsecase)(nil).ListSimilar), contentID, pgnt, curUserID)
+}
+
+// ListRevisions mocks base method
+func (m *MockContentUsecase) ListRevisions(contentID uint64, pgnt *models.Pagination) ([]*models.ContentRevision, *errors.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRevisions", contentID, pgnt)
+	ret0, _ := ret[0].([]*models.ContentRevision)
+	ret1, _ := ret[1].(*errors.Error)
+	return ret0, ret1
+}
+
+// ListRevisions indicates an expected call of ListRevisions
+func (mr *MockContentUsecaseMockRecorder) ListRevisions(contentID, pgnt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRevisions", reflect.TypeOf((*MockContentUsecase)(nil).ListRevisions), contentID, pgnt)
+}
+
+// GetRevisionDiff mocks base method
+func (m *MockContentUsecase) GetRevisionDiff(contentID, revisionID uint64) (map[string]*models.RevisionChange, *errors.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevisionDiff", contentID, revisionID)
+	ret0, _ := ret[0].(map[string]*models.RevisionChange)
+	ret1, _ := ret[1].(*errors.Error)
+	return ret0, ret1
+}
+
+// GetRevisionDiff indicates an expected call of GetRevisionDiff
+func (mr *MockContentUsecaseMockRecorder) GetRevisionDiff(contentID, revisionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevisionDiff", reflect.TypeOf((*MockContentUsecase)(nil).GetRevisionDiff), contentID, revisionID)
+}
+
+// Rollback mocks base method
+func (m *MockContentUsecase) Rollback(contentID, revisionID uint64) (*models.Content, *errors.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rollback", contentID, revisionID)
+	ret0, _ := ret[0].(*models.Content)
+	ret1, _ := ret[1].(*errors.Error)
+	return ret0, ret1
+}
+
+// Rollback indicates an expected call of Rollback
+func (mr *MockContentUsecaseMockRecorder) Rollback(contentID, revisionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollback", reflect.TypeOf((*MockContentUsecase)(nil).Rollback), contentID, revisionID)
+}
@@ -1,6 +1,8 @@
 package content
 
 import (
+	"time"
+
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
 )
 
@@ -8,10 +10,16 @@ type ContentRepository interface {
 	Insert(content *models.Content) error
 	Update(content *models.Content) error
 	UpdateImages(content *models.Content) error
+	UpdateStatus(content *models.Content) error
+	PublishScheduled(now time.Time) (int64, error)
 	DeleteByID(contentID uint64) error
 	SelectByID(contentID uint64) (*models.Content, error)
 	SelectCountriesByID(contentID uint64) ([]uint64, error)
 	SelectGenresByID(contentID uint64) ([]uint64, error)
 	SelectActorsByID(contentID uint64) ([]uint64, error)
 	SelectDirectorsByID(contentID uint64) ([]uint64, error)
+	SelectSimilar(contentID uint64, pgnt *models.Pagination, curUserID uint64) ([]interface{}, error)
+	SelectRevisions(contentID uint64, pgnt *models.Pagination) ([]*models.ContentRevision, error)
+	SelectRevisionByID(contentID uint64, revisionID uint64) (*models.ContentRevision, error)
+	SelectPrevRevision(contentID uint64, revisionID uint64) (*models.ContentRevision, error)
 }
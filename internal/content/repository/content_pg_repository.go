@@ -3,10 +3,16 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
 
+	. "github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/content"
+	queryBuilder "github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/query_builder"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
 	"github.com/lib/pq"
 )
@@ -29,11 +35,12 @@ func (cr *ContentPgRepository) Insert(content *models.Content) error {
 
 	row := tx.QueryRow(
 		`INSERT INTO content(name, original_name, description, short_description,
-		year, images, type, is_free)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		year, images, type, is_free, status, publish_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 		RETURNING id`,
 		content.Name, content.OriginalName, content.Description,
-		content.ShortDescription, content.Year, content.Images, content.Type, content.IsFree)
+		content.ShortDescription, content.Year, content.Images, content.Type, content.IsFree,
+		content.Status, content.PublishAt)
 
 	err = row.Scan(&content.ContentID)
 	if err != nil {
@@ -75,6 +82,14 @@ func (cr *ContentPgRepository) Insert(content *models.Content) error {
 		return err
 	}
 
+	// First revision
+	if err := InsertRevision(tx, content); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			logger.Error(rollbackErr.Error())
+		}
+		return err
+	}
+
 	if err := tx.Commit(); err != nil {
 		return err
 	}
@@ -134,6 +149,14 @@ func (cr *ContentPgRepository) Update(content *models.Content) error {
 		return err
 	}
 
+	// Every update is a new revision
+	if err := InsertRevision(tx, content); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			logger.Error(rollbackErr.Error())
+		}
+		return err
+	}
+
 	if err := tx.Commit(); err != nil {
 		return err
 	}
@@ -165,6 +188,47 @@ func (cr *ContentPgRepository) UpdateImages(content *models.Content) error {
 	return nil
 }
 
+func (cr *ContentPgRepository) UpdateStatus(content *models.Content) error {
+	tx, err := cr.dbConn.BeginTx(context.Background(), &sql.TxOptions{})
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(
+		`UPDATE content
+		SET status = $2, publish_at = $3
+		WHERE id = $1;`,
+		content.ContentID, content.Status, content.PublishAt)
+
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			logger.Error(rollbackErr.Error())
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// PublishScheduled publishes scheduled content whose publish time has come
+// and returns the number of published items
+func (cr *ContentPgRepository) PublishScheduled(now time.Time) (int64, error) {
+	result, err := cr.dbConn.Exec(
+		`UPDATE content
+		SET status = $1
+		WHERE status = $2 AND publish_at <= $3`,
+		ContentPublished, ContentScheduled, now)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// DeleteByID moves content to the trash, related data are kept
+// until the trash is purged
 func (cr *ContentPgRepository) DeleteByID(contentID uint64) error {
 	tx, err := cr.dbConn.BeginTx(context.Background(), &sql.TxOptions{})
 	if err != nil {
@@ -172,8 +236,9 @@ func (cr *ContentPgRepository) DeleteByID(contentID uint64) error {
 	}
 
 	_, err = tx.Exec(
-		`DELETE FROM content
-		WHERE id=$1`,
+		`UPDATE content
+		SET deleted_at = now()
+		WHERE id=$1 AND deleted_at IS NULL`,
 		contentID)
 	if err != nil {
 		if rollbackErr := tx.Rollback(); rollbackErr != nil {
@@ -193,13 +258,14 @@ func (cr *ContentPgRepository) SelectByID(contentID uint64) (*models.Content, er
 
 	row := cr.dbConn.QueryRow(
 		`SELECT id, name, original_name, description, short_description,
-		year, images, type, is_free
+		year, images, type, is_free, status, publish_at
 		FROM content
-		WHERE id=$1`,
+		WHERE id=$1 AND deleted_at IS NULL`,
 		contentID)
 
 	err := row.Scan(&content.ContentID, &content.Name, &content.OriginalName, &content.Description,
-		&content.ShortDescription, &content.Year, &content.Images, &content.Type, &content.IsFree)
+		&content.ShortDescription, &content.Year, &content.Images, &content.Type, &content.IsFree,
+		&content.Status, &content.PublishAt)
 
 	if err != nil {
 		return nil, err
@@ -323,6 +389,180 @@ func (cr *ContentPgRepository) SelectDirectorsByID(contentID uint64) ([]uint64,
 	return directors, nil
 }
 
+// similarQuery selects movies or tvshows of the similar content,
+// %[1]s is the id and the fields of the kind, %[2]s is the join of its table
+const similarQuery = `
+		SELECT %[1]s, c.id AS content_id, c.name, c.original_name,
+		c.description, c.short_description,
+		c.rating, c.year, c.images, c.type, c.is_free, r.likes,
+		CASE WHEN f.content_id IS NULL THEN false ELSE true END AS is_favourite,
+		s.score + $7::real / (1 + ABS(c.year - src.year) / 5.0) AS rank
+		FROM similar AS s
+		JOIN content AS c ON c.id=s.content_id
+		%[2]s
+		JOIN content AS src ON src.id=$2
+		LEFT OUTER JOIN rates as r ON r.user_id=$1 AND r.content_id=c.id
+		LEFT OUTER JOIN favourites as f ON f.user_id=$1 AND f.content_id=c.id
+		WHERE `
+
+// SelectSimilar ranks movies and tvshows together by weighted overlap of genres,
+// actors, directors and countries with the content and by release year proximity,
+// items are *models.Movie or *models.TVShow
+func (cr *ContentPgRepository) SelectSimilar(contentID uint64, pgnt *models.Pagination,
+	curUserID uint64) ([]interface{}, error) {
+	var values []interface{}
+
+	published := queryBuilder.BuildPublishedCondition()
+	moviesQuery := fmt.Sprintf(similarQuery, "m.id, m.video, 0 AS seasons",
+		"JOIN movies AS m ON m.content_id=c.id") + published
+	tvshowsQuery := fmt.Sprintf(similarQuery, "tv.id, '' AS video, tv.seasons",
+		"JOIN tv_shows AS tv ON tv.content_id=c.id") + published
+
+	selectQuery := queryBuilder.BuildSimilarQuery(2, 3) + `
+		SELECT sc.id, sc.video, sc.seasons, sc.content_id, sc.name, sc.original_name,
+		sc.description, sc.short_description,
+		sc.rating, sc.year, sc.images, sc.type, sc.is_free, sc.likes, sc.is_favourite
+		FROM (` + moviesQuery + `
+		UNION ALL` + tvshowsQuery + `
+		) AS sc
+		ORDER BY sc.rank DESC, sc.rating DESC, sc.content_id`
+	values = append(values, curUserID, contentID, SimilarGenreWeight,
+		SimilarActorWeight, SimilarDirectorWeight, SimilarCountryWeight,
+		SimilarYearWeight)
+
+	var pgntQuery string
+	if pgnt.Count != 0 {
+		pgntQuery = "LIMIT $8 OFFSET $9"
+		values = append(values, pgnt.Count, pgnt.From)
+	}
+
+	resultQuery := strings.Join([]string{
+		selectQuery,
+		pgntQuery,
+	}, " ")
+
+	rows, err := cr.dbConn.Query(resultQuery, values...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var contents []interface{}
+	for rows.Next() {
+		var id uint64
+		var video string
+		var seasons int
+		cnt := &models.Content{}
+
+		err := rows.Scan(&id, &video, &seasons, &cnt.ContentID, &cnt.Name,
+			&cnt.OriginalName, &cnt.Description, &cnt.ShortDescription,
+			&cnt.Rating, &cnt.Year, &cnt.Images, &cnt.Type, &cnt.IsFree,
+			&cnt.IsLiked, &cnt.IsFavourite)
+		if err != nil {
+			return nil, err
+		}
+
+		if cnt.Type == MovieContentType {
+			contents = append(contents, &models.Movie{ID: id, Video: video, Content: *cnt})
+		} else {
+			contents = append(contents, &models.TVShow{ID: id, Seasons: seasons, Content: *cnt})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return contents, nil
+}
+
+func (cr *ContentPgRepository) SelectRevisions(contentID uint64,
+	pgnt *models.Pagination) ([]*models.ContentRevision, error) {
+	values := []interface{}{contentID}
+	selectQuery := `
+		SELECT id, content_id, data, created
+		FROM content_revisions
+		WHERE content_id=$1
+		ORDER BY id DESC`
+
+	var pgntQuery string
+	if pgnt.Count != 0 {
+		pgntQuery = "LIMIT $2 OFFSET $3"
+		values = append(values, pgnt.Count, pgnt.From)
+	}
+
+	rows, err := cr.dbConn.Query(selectQuery+" "+pgntQuery, values...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var revisions []*models.ContentRevision
+	for rows.Next() {
+		revision, err := scanRevision(rows)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, revision)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+func (cr *ContentPgRepository) SelectRevisionByID(contentID uint64,
+	revisionID uint64) (*models.ContentRevision, error) {
+	row := cr.dbConn.QueryRow(
+		`SELECT id, content_id, data, created
+		FROM content_revisions
+		WHERE content_id=$1 AND id=$2`,
+		contentID, revisionID)
+	return scanRevision(row)
+}
+
+// SelectPrevRevision returns sql.ErrNoRows for the first revision
+func (cr *ContentPgRepository) SelectPrevRevision(contentID uint64,
+	revisionID uint64) (*models.ContentRevision, error) {
+	row := cr.dbConn.QueryRow(
+		`SELECT id, content_id, data, created
+		FROM content_revisions
+		WHERE content_id=$1 AND id<$2
+		ORDER BY id DESC
+		LIMIT 1`,
+		contentID, revisionID)
+	return scanRevision(row)
+}
+
+type revisionScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanRevision(row revisionScanner) (*models.ContentRevision, error) {
+	revision := &models.ContentRevision{}
+	var data []byte
+	if err := row.Scan(&revision.ID, &revision.ContentID, &data, &revision.Created); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &revision.ContentRevisionData); err != nil {
+		return nil, err
+	}
+	return revision, nil
+}
+
+// InsertRevision saves current metadata of the content
+func InsertRevision(tx *sql.Tx, content *models.Content) error {
+	data, err := json.Marshal(models.NewContentRevisionData(content))
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(
+		`INSERT INTO content_revisions(content_id, data)
+		VALUES ($1, $2)`,
+		content.ContentID, data)
+	return err
+}
+
 func InsertCountries(tx *sql.Tx, content *models.Content) error {
 	stmt, err := tx.Prepare(pq.CopyIn("content_country", "content_id", "country_id"))
 	if err != nil {
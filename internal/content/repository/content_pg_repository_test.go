@@ -1,9 +1,12 @@
 package repository
 
 import (
+	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/content/mocks"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
 	"github.com/stretchr/testify/assert"
@@ -326,3 +329,176 @@ func TestContentPgRepository_UpdateImages_OK(t *testing.T) {
 		t.Errorf("there were unfulfilled expectations: %s", err)
 	}
 }
+
+func TestContentPgRepository_UpdateStatus_OK(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	contentPgRep := NewContentPgRepository(db)
+
+	publishAt := time.Now().Add(time.Hour)
+	content := *contentInst
+	content.Status = consts.ContentScheduled
+	content.PublishAt = &publishAt
+
+	mocks.MockContentRepoUpdateStatusReturnResultOk(mock, &content)
+	err = contentPgRep.UpdateStatus(&content)
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestContentPgRepository_PublishScheduled_OK(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	contentPgRep := NewContentPgRepository(db)
+
+	now := time.Now()
+	mocks.MockContentRepoPublishScheduledReturnResult(mock, now, 2)
+	published, err := contentPgRep.PublishScheduled(now)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), published)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+var testRevisions = []*models.ContentRevision{
+	&models.ContentRevision{
+		ID:        2,
+		ContentID: 3,
+		Created:   time.Now(),
+		ContentRevisionData: models.ContentRevisionData{
+			Name:         "Шрек",
+			OriginalName: "Shrek",
+			Year:         2001,
+			IsFree:       true,
+			Countries:    []uint64{1},
+			Genres:       []uint64{2, 3},
+			Actors:       []uint64{},
+			Directors:    []uint64{4},
+		},
+	},
+}
+
+func TestContentPgRepository_SelectRevisions_OK(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	contentPgRep := NewContentPgRepository(db)
+	pgnt := &models.Pagination{From: 0, Count: 10}
+
+	mocks.MockContentRepoSelectRevisionsReturnRows(mock, testRevisions, uint64(3), pgnt.Count, pgnt.From)
+
+	revisions, err := contentPgRep.SelectRevisions(3, pgnt)
+	assert.NoError(t, err)
+	assert.Equal(t, testRevisions, revisions)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestContentPgRepository_SelectSimilar_OK(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	contentPgRep := NewContentPgRepository(db)
+	pgnt := &models.Pagination{From: 0, Count: 10}
+	var userID uint64 = 3
+	var contentID uint64 = 4
+
+	isLiked := true
+	contents := []interface{}{
+		&models.TVShow{
+			ID:      2,
+			Seasons: 3,
+			Content: models.Content{
+				ContentID: 6,
+				Name:      "Шрек Третий",
+				Type:      consts.TVShowContentType,
+				IsLiked:   &isLiked,
+			},
+		},
+		&models.Movie{
+			ID:    1,
+			Video: "shrek2.mp4",
+			Content: models.Content{
+				ContentID: 5,
+				Name:      "Шрек 2",
+				Type:      consts.MovieContentType,
+			},
+		},
+	}
+
+	mocks.MockContentRepoSelectSimilarReturnRows(mock, contentID, pgnt, userID, contents)
+	dbContents, err := contentPgRep.SelectSimilar(contentID, pgnt, userID)
+	assert.NoError(t, err)
+	assert.Equal(t, contents, dbContents)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestContentPgRepository_SelectRevisionByID_OK(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	contentPgRep := NewContentPgRepository(db)
+
+	mocks.MockContentRepoSelectRevisionsReturnRows(mock, testRevisions, uint64(3), uint64(2))
+
+	revision, err := contentPgRep.SelectRevisionByID(3, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, testRevisions[0], revision)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestContentPgRepository_SelectPrevRevision_First(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	contentPgRep := NewContentPgRepository(db)
+
+	mocks.MockContentRepoSelectRevisionReturnErrNoRows(mock, uint64(3), uint64(1))
+
+	revision, err := contentPgRep.SelectPrevRevision(3, 1)
+	assert.Equal(t, sql.ErrNoRows, err)
+	assert.Nil(t, revision)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
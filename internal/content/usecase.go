@@ -1,6 +1,8 @@
 package content
 
 import (
+	"time"
+
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
 )
@@ -9,6 +11,9 @@ type ContentUsecase interface {
 	Create(content *models.Content) *errors.Error
 	UpdateByID(contentID uint64, newContentData *models.Content) (*models.Content, *errors.Error)
 	UpdatePosters(content *models.Content, newPostersDir string) *errors.Error
+	ProcessPostersJob(job *models.Job) *errors.Error
+	UpdateStatus(contentID uint64, status string, publishAt *time.Time) (*models.Content, *errors.Error)
+	PublishScheduled() *errors.Error
 	DeleteByID(contentID uint64) *errors.Error
 	GetByID(contentID uint64) (*models.Content, *errors.Error)
 	GetFullByID(contentID uint64) (*models.Content, *errors.Error)
@@ -17,4 +22,9 @@ type ContentUsecase interface {
 	GetGenresByID(contentID uint64) ([]*models.Genre, *errors.Error)
 	GetActorsByID(contentID uint64) ([]*models.Actor, *errors.Error)
 	GetDirectorsByID(contentID uint64) ([]*models.Director, *errors.Error)
+	// ListSimilar returns ranked *models.Movie and *models.TVShow items
+	ListSimilar(contentID uint64, pgnt *models.Pagination, curUserID uint64) ([]interface{}, *errors.Error)
+	ListRevisions(contentID uint64, pgnt *models.Pagination) ([]*models.ContentRevision, *errors.Error)
+	GetRevisionDiff(contentID uint64, revisionID uint64) (map[string]*models.RevisionChange, *errors.Error)
+	Rollback(contentID uint64, revisionID uint64) (*models.Content, *errors.Error)
 }
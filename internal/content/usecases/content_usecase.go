@@ -2,8 +2,10 @@ package usecases
 
 import (
 	"database/sql"
+	"encoding/json"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/actor"
 	. "github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
@@ -11,8 +13,10 @@ import (
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/country"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/director"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/genre"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/search"
 )
 
 type ContentUsecase struct {
@@ -21,24 +25,32 @@ type ContentUsecase struct {
 	genreUcase    genre.GenreUsecase
 	actorUcase    actor.ActorUseCase
 	directorUcase director.DirectorUseCase
+	suggestIndex  search.SuggestIndex
 }
 
 func NewContentUsecase(repo content.ContentRepository, countryUcase country.CountryUsecase,
 	genreUcase genre.GenreUsecase, actorUcase actor.ActorUseCase,
-	directorUcase director.DirectorUseCase) content.ContentUsecase {
+	directorUcase director.DirectorUseCase,
+	suggestIndex search.SuggestIndex) content.ContentUsecase {
 	return &ContentUsecase{
 		contentRepo:   repo,
 		countryUcase:  countryUcase,
 		genreUcase:    genreUcase,
 		actorUcase:    actorUcase,
 		directorUcase: directorUcase,
+		suggestIndex:  suggestIndex,
 	}
 }
 
+// Create keeps new content as a draft unless other status is set
 func (cu *ContentUsecase) Create(content *models.Content) *errors.Error {
+	if content.Status == "" {
+		content.Status = ContentDraft
+	}
 	if err := cu.contentRepo.Insert(content); err != nil {
 		return errors.New(CodeInternalError, err)
 	}
+	cu.suggestIndex.Invalidate()
 	return nil
 }
 
@@ -52,6 +64,7 @@ func (cu *ContentUsecase) UpdateByID(contentID uint64, newContentData *models.Co
 	if err := cu.contentRepo.Update(content); err != nil {
 		return nil, errors.New(CodeInternalError, err)
 	}
+	cu.suggestIndex.Invalidate()
 	return content, nil
 }
 
@@ -72,28 +85,108 @@ func (cu *ContentUsecase) UpdatePosters(content *models.Content, newPostersDir s
 	return nil
 }
 
-func (cu *ContentUsecase) DeleteByID(contentID uint64) *errors.Error {
-	content, err := cu.GetByID(contentID)
+// ProcessPostersJob compresses staged posters and sets them to the content
+func (cu *ContentUsecase) ProcessPostersJob(job *models.Job) *errors.Error {
+	payload := &models.ContentPostersPayload{}
+	if err := json.Unmarshal(job.Payload, payload); err != nil {
+		return errors.New(CodeInternalError, err)
+	}
+
+	path, osErr := os.Getwd()
+	if osErr != nil {
+		return errors.New(CodeInternalError, osErr)
+	}
+	postersDirPath := filepath.Join(path, payload.PostersDir)
+
+	err := cu.processPosters(payload, postersDirPath)
+	if err == nil || job.IsLastAttempt() {
+		cu.removeStagedPosters(payload, postersDirPath)
+	}
+	return err
+}
+
+func (cu *ContentUsecase) processPosters(payload *models.ContentPostersPayload, postersDirPath string) *errors.Error {
+	content, err := cu.GetByID(payload.ContentID)
 	if err != nil {
-		return errors.Get(CodeContentDoesNotExist)
+		return err
 	}
 
-	// Delete posters dir
-	if content.Images != "" {
-		path, err := os.Getwd()
+	if payload.SmallPoster {
+		smallPosterPath := filepath.Join(postersDirPath, SmallPosterName)
+		err := helpers.StoreStagedSmallImage(helpers.GetStagedPath(smallPosterPath), smallPosterPath)
 		if err != nil {
-			return errors.New(CodeInternalError, err)
+			return err
 		}
-		postersDirPath := filepath.Join(path, content.Images)
+	}
 
-		if err := os.RemoveAll(postersDirPath); err != nil {
-			return errors.New(CodeInternalError, err)
+	if payload.LargePoster {
+		largePosterPath := filepath.Join(postersDirPath, LargePosterName)
+		err := helpers.StoreStagedLargeImage(helpers.GetStagedPath(largePosterPath), largePosterPath)
+		if err != nil {
+			return err
 		}
 	}
 
+	return cu.UpdatePosters(content, payload.PostersDir)
+}
+
+func (cu *ContentUsecase) removeStagedPosters(payload *models.ContentPostersPayload, postersDirPath string) {
+	if payload.SmallPoster {
+		helpers.RemoveStagedFile(helpers.GetStagedPath(filepath.Join(postersDirPath, SmallPosterName)))
+	}
+	if payload.LargePoster {
+		helpers.RemoveStagedFile(helpers.GetStagedPath(filepath.Join(postersDirPath, LargePosterName)))
+	}
+}
+
+// UpdateStatus moves content through the publishing workflow,
+// only scheduled content keeps the publish time
+func (cu *ContentUsecase) UpdateStatus(contentID uint64, status string,
+	publishAt *time.Time) (*models.Content, *errors.Error) {
+	content, err := cu.GetByID(contentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if status == ContentScheduled {
+		if publishAt == nil || !publishAt.After(time.Now()) {
+			return nil, errors.Get(CodeWrongPublishTime)
+		}
+	} else {
+		publishAt = nil
+	}
+	content.Status = status
+	content.PublishAt = publishAt
+
+	if err := cu.contentRepo.UpdateStatus(content); err != nil {
+		return nil, errors.New(CodeInternalError, err)
+	}
+	cu.suggestIndex.Invalidate()
+	return content, nil
+}
+
+func (cu *ContentUsecase) PublishScheduled() *errors.Error {
+	published, err := cu.contentRepo.PublishScheduled(time.Now())
+	if err != nil {
+		return errors.New(CodeInternalError, err)
+	}
+	if published != 0 {
+		cu.suggestIndex.Invalidate()
+	}
+	return nil
+}
+
+// DeleteByID moves content to the trash, posters and videos
+// are removed when the trash is purged
+func (cu *ContentUsecase) DeleteByID(contentID uint64) *errors.Error {
+	if _, err := cu.GetByID(contentID); err != nil {
+		return errors.Get(CodeContentDoesNotExist)
+	}
+
 	if err := cu.contentRepo.DeleteByID(contentID); err != nil {
 		return errors.New(CodeInternalError, err)
 	}
+	cu.suggestIndex.Invalidate()
 	return nil
 }
 
@@ -183,3 +276,160 @@ func (cu *ContentUsecase) GetDirectorsByID(contentID uint64) ([]*models.Director
 	}
 	return directors, nil
 }
+
+func (cu *ContentUsecase) ListSimilar(contentID uint64, pgnt *models.Pagination,
+	curUserID uint64) ([]interface{}, *errors.Error) {
+	contents, err := cu.contentRepo.SelectSimilar(contentID, pgnt, curUserID)
+	if err != nil {
+		return nil, errors.New(CodeInternalError, err)
+	}
+
+	if len(contents) == 0 {
+		return []interface{}{}, nil
+	}
+
+	return contents, nil
+}
+
+func (cu *ContentUsecase) ListRevisions(contentID uint64,
+	pgnt *models.Pagination) ([]*models.ContentRevision, *errors.Error) {
+	if _, err := cu.GetByID(contentID); err != nil {
+		return nil, err
+	}
+
+	revisions, err := cu.contentRepo.SelectRevisions(contentID, pgnt)
+	if err != nil {
+		return nil, errors.New(CodeInternalError, err)
+	}
+	if len(revisions) == 0 {
+		return []*models.ContentRevision{}, nil
+	}
+	return revisions, nil
+}
+
+// GetRevisionDiff returns fields changed by the revision
+// compared to the previous one
+func (cu *ContentUsecase) GetRevisionDiff(contentID uint64,
+	revisionID uint64) (map[string]*models.RevisionChange, *errors.Error) {
+	revision, customErr := cu.getRevision(contentID, revisionID)
+	if customErr != nil {
+		return nil, customErr
+	}
+
+	var prevData *models.ContentRevisionData
+	prev, err := cu.contentRepo.SelectPrevRevision(contentID, revisionID)
+	switch {
+	case err == nil:
+		prevData = &prev.ContentRevisionData
+	case err != sql.ErrNoRows:
+		return nil, errors.New(CodeInternalError, err)
+	}
+	return revision.Diff(prevData), nil
+}
+
+// Rollback sets metadata of the revision to the content,
+// the rollback is saved as a new revision
+func (cu *ContentUsecase) Rollback(contentID uint64, revisionID uint64) (*models.Content, *errors.Error) {
+	revision, err := cu.getRevision(contentID, revisionID)
+	if err != nil {
+		return nil, err
+	}
+	content, err := cu.GetByID(contentID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Links deleted since the revision can't be restored, they are skipped
+	if content.Countries, err = cu.existingCountries(revision.Countries); err != nil {
+		return nil, err
+	}
+	if content.Genres, err = cu.existingGenres(revision.Genres); err != nil {
+		return nil, err
+	}
+	if content.Actors, err = cu.existingActors(revision.Actors); err != nil {
+		return nil, err
+	}
+	if content.Directors, err = cu.existingDirectors(revision.Directors); err != nil {
+		return nil, err
+	}
+	isFree := revision.IsFree
+	content.Name = revision.Name
+	content.OriginalName = revision.OriginalName
+	content.Description = revision.Description
+	content.ShortDescription = revision.ShortDescription
+	content.Year = revision.Year
+	content.IsFree = &isFree
+
+	if err := cu.contentRepo.Update(content); err != nil {
+		return nil, errors.New(CodeInternalError, err)
+	}
+	cu.suggestIndex.Invalidate()
+	return content, nil
+}
+
+func (cu *ContentUsecase) existingCountries(countriesID []uint64) ([]*models.Country, *errors.Error) {
+	countries := []*models.Country{}
+	for _, countryID := range countriesID {
+		country, err := cu.countryUcase.GetByID(countryID)
+		switch {
+		case err == nil:
+			countries = append(countries, country)
+		case err.Code != CodeCountryDoesNotExist:
+			return nil, err
+		}
+	}
+	return countries, nil
+}
+
+func (cu *ContentUsecase) existingGenres(genresID []uint64) ([]*models.Genre, *errors.Error) {
+	genres := []*models.Genre{}
+	for _, genreID := range genresID {
+		genre, err := cu.genreUcase.GetByID(genreID)
+		switch {
+		case err == nil:
+			genres = append(genres, genre)
+		case err.Code != CodeGenreDoesNotExist:
+			return nil, err
+		}
+	}
+	return genres, nil
+}
+
+func (cu *ContentUsecase) existingActors(actorsID []uint64) ([]*models.Actor, *errors.Error) {
+	actors := []*models.Actor{}
+	for _, actorID := range actorsID {
+		actor, err := cu.actorUcase.Get(actorID)
+		switch {
+		case err == nil:
+			actors = append(actors, actor)
+		case err.Code != CodeActorDoesNotExist:
+			return nil, err
+		}
+	}
+	return actors, nil
+}
+
+func (cu *ContentUsecase) existingDirectors(directorsID []uint64) ([]*models.Director, *errors.Error) {
+	directors := []*models.Director{}
+	for _, directorID := range directorsID {
+		director, err := cu.directorUcase.Get(directorID)
+		switch {
+		case err == nil:
+			directors = append(directors, director)
+		case err.Code != CodeDirectorDoesNotExist:
+			return nil, err
+		}
+	}
+	return directors, nil
+}
+
+func (cu *ContentUsecase) getRevision(contentID uint64, revisionID uint64) (*models.ContentRevision, *errors.Error) {
+	revision, err := cu.contentRepo.SelectRevisionByID(contentID, revisionID)
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, errors.Get(CodeRevisionDoesNotExist)
+	case err != nil:
+		return nil, errors.New(CodeInternalError, err)
+	}
+	return revision, nil
+}
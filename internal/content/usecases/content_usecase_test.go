@@ -1,16 +1,20 @@
 package usecases
 
 import (
+	"database/sql"
 	actorMocks "github.com/go-park-mail-ru/2020_2_Slash/internal/actor/mocks"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/content/mocks"
 	countryMocks "github.com/go-park-mail-ru/2020_2_Slash/internal/country/mocks"
 	directorMocks "github.com/go-park-mail-ru/2020_2_Slash/internal/director/mocks"
 	genreMocks "github.com/go-park-mail-ru/2020_2_Slash/internal/genre/mocks"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	searchMocks "github.com/go-park-mail-ru/2020_2_Slash/internal/search/mocks"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 var countries = []*models.Country{
@@ -69,17 +73,22 @@ func TestContentUseCase_Create_OK(t *testing.T) {
 	genreUseCase := genreMocks.NewMockGenreUsecase(ctrl)
 	actorUseCase := actorMocks.NewMockActorUseCase(ctrl)
 	directorUseCase := directorMocks.NewMockDirectorUseCase(ctrl)
+	suggestIndex := searchMocks.NewMockSuggestIndex(ctrl)
 
 	contentUseCase := NewContentUsecase(contentRep, countryUseCase,
-		genreUseCase, actorUseCase, directorUseCase)
+		genreUseCase, actorUseCase, directorUseCase, suggestIndex)
 
+	content := *contentInst
 	contentRep.
 		EXPECT().
-		Insert(gomock.Eq(contentInst)).
+		Insert(gomock.Eq(&content)).
 		Return(nil)
 
-	err := contentUseCase.Create(contentInst)
+	suggestIndex.EXPECT().Invalidate()
+
+	err := contentUseCase.Create(&content)
 	assert.Equal(t, err, (*errors.Error)(nil))
+	assert.Equal(t, consts.ContentDraft, content.Status)
 }
 
 func TestContentUseCase_Update_OK(t *testing.T) {
@@ -91,6 +100,7 @@ func TestContentUseCase_Update_OK(t *testing.T) {
 	genreUseCase := genreMocks.NewMockGenreUsecase(ctrl)
 	actorUseCase := actorMocks.NewMockActorUseCase(ctrl)
 	directorUseCase := directorMocks.NewMockDirectorUseCase(ctrl)
+	suggestIndex := searchMocks.NewMockSuggestIndex(ctrl)
 
 	countriesID := []uint64{1}
 	directorsID := []uint64{1, 2}
@@ -98,7 +108,7 @@ func TestContentUseCase_Update_OK(t *testing.T) {
 	genresID := []uint64{1, 2}
 
 	contentUseCase := NewContentUsecase(contentRep, countryUseCase,
-		genreUseCase, actorUseCase, directorUseCase)
+		genreUseCase, actorUseCase, directorUseCase, suggestIndex)
 
 	contentRep.
 		EXPECT().
@@ -150,6 +160,8 @@ func TestContentUseCase_Update_OK(t *testing.T) {
 		Update(gomock.Eq(contentInst)).
 		Return(nil)
 
+	suggestIndex.EXPECT().Invalidate()
+
 	dbContent, err := contentUseCase.UpdateByID(contentInst.ContentID, contentInst)
 	assert.Equal(t, err, (*errors.Error)(nil))
 	assert.Equal(t, dbContent, contentInst)
@@ -164,11 +176,12 @@ func TestContentUseCase_UpdatePosters_OK(t *testing.T) {
 	genreUseCase := genreMocks.NewMockGenreUsecase(ctrl)
 	actorUseCase := actorMocks.NewMockActorUseCase(ctrl)
 	directorUseCase := directorMocks.NewMockDirectorUseCase(ctrl)
+	suggestIndex := searchMocks.NewMockSuggestIndex(ctrl)
 
 	newPostersDir := "/images/0"
 
 	contentUseCase := NewContentUsecase(contentRep, countryUseCase,
-		genreUseCase, actorUseCase, directorUseCase)
+		genreUseCase, actorUseCase, directorUseCase, suggestIndex)
 
 	contentRep.
 		EXPECT().
@@ -188,9 +201,10 @@ func TestContentUseCase_Delete_OK(t *testing.T) {
 	genreUseCase := genreMocks.NewMockGenreUsecase(ctrl)
 	actorUseCase := actorMocks.NewMockActorUseCase(ctrl)
 	directorUseCase := directorMocks.NewMockDirectorUseCase(ctrl)
+	suggestIndex := searchMocks.NewMockSuggestIndex(ctrl)
 
 	contentUseCase := NewContentUsecase(contentRep, countryUseCase,
-		genreUseCase, actorUseCase, directorUseCase)
+		genreUseCase, actorUseCase, directorUseCase, suggestIndex)
 
 	contentRep.
 		EXPECT().
@@ -202,6 +216,399 @@ func TestContentUseCase_Delete_OK(t *testing.T) {
 		DeleteByID(gomock.Eq(contentInst.ContentID)).
 		Return(nil)
 
+	suggestIndex.EXPECT().Invalidate()
+
 	err := contentUseCase.DeleteByID(contentInst.ContentID)
 	assert.Equal(t, err, (*errors.Error)(nil))
 }
+
+func TestContentUseCase_UpdateStatus_Scheduled(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	contentRep := mocks.NewMockContentRepository(ctrl)
+	countryUseCase := countryMocks.NewMockCountryUsecase(ctrl)
+	genreUseCase := genreMocks.NewMockGenreUsecase(ctrl)
+	actorUseCase := actorMocks.NewMockActorUseCase(ctrl)
+	directorUseCase := directorMocks.NewMockDirectorUseCase(ctrl)
+	suggestIndex := searchMocks.NewMockSuggestIndex(ctrl)
+
+	contentUseCase := NewContentUsecase(contentRep, countryUseCase,
+		genreUseCase, actorUseCase, directorUseCase, suggestIndex)
+
+	content := *contentInst
+	content.Status = consts.ContentDraft
+	publishAt := time.Now().Add(time.Hour)
+
+	contentRep.
+		EXPECT().
+		SelectByID(gomock.Eq(contentInst.ContentID)).
+		Return(&content, nil)
+
+	contentRep.
+		EXPECT().
+		UpdateStatus(gomock.Eq(&content)).
+		Return(nil)
+
+	suggestIndex.EXPECT().Invalidate()
+
+	dbContent, err := contentUseCase.UpdateStatus(contentInst.ContentID, consts.ContentScheduled, &publishAt)
+	assert.Equal(t, err, (*errors.Error)(nil))
+	assert.Equal(t, consts.ContentScheduled, dbContent.Status)
+	assert.Equal(t, &publishAt, dbContent.PublishAt)
+}
+
+func TestContentUseCase_UpdateStatus_PastPublishTime(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	contentRep := mocks.NewMockContentRepository(ctrl)
+	countryUseCase := countryMocks.NewMockCountryUsecase(ctrl)
+	genreUseCase := genreMocks.NewMockGenreUsecase(ctrl)
+	actorUseCase := actorMocks.NewMockActorUseCase(ctrl)
+	directorUseCase := directorMocks.NewMockDirectorUseCase(ctrl)
+	suggestIndex := searchMocks.NewMockSuggestIndex(ctrl)
+
+	contentUseCase := NewContentUsecase(contentRep, countryUseCase,
+		genreUseCase, actorUseCase, directorUseCase, suggestIndex)
+
+	content := *contentInst
+	publishAt := time.Now().Add(-time.Hour)
+
+	contentRep.
+		EXPECT().
+		SelectByID(gomock.Eq(contentInst.ContentID)).
+		Return(&content, nil)
+
+	dbContent, err := contentUseCase.UpdateStatus(contentInst.ContentID, consts.ContentScheduled, &publishAt)
+	assert.Equal(t, errors.Get(consts.CodeWrongPublishTime), err)
+	assert.Nil(t, dbContent)
+}
+
+func TestContentUseCase_PublishScheduled(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	contentRep := mocks.NewMockContentRepository(ctrl)
+	countryUseCase := countryMocks.NewMockCountryUsecase(ctrl)
+	genreUseCase := genreMocks.NewMockGenreUsecase(ctrl)
+	actorUseCase := actorMocks.NewMockActorUseCase(ctrl)
+	directorUseCase := directorMocks.NewMockDirectorUseCase(ctrl)
+	suggestIndex := searchMocks.NewMockSuggestIndex(ctrl)
+
+	contentUseCase := NewContentUsecase(contentRep, countryUseCase,
+		genreUseCase, actorUseCase, directorUseCase, suggestIndex)
+
+	gomock.InOrder(
+		contentRep.
+			EXPECT().
+			PublishScheduled(gomock.Any()).
+			Return(int64(0), nil),
+		contentRep.
+			EXPECT().
+			PublishScheduled(gomock.Any()).
+			Return(int64(2), nil),
+	)
+
+	// Index is rebuilt only when something was published
+	suggestIndex.EXPECT().Invalidate().Times(1)
+
+	assert.Equal(t, (*errors.Error)(nil), contentUseCase.PublishScheduled())
+	assert.Equal(t, (*errors.Error)(nil), contentUseCase.PublishScheduled())
+}
+
+func TestContentUseCase_ListSimilar_Empty(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	contentRep := mocks.NewMockContentRepository(ctrl)
+	countryUseCase := countryMocks.NewMockCountryUsecase(ctrl)
+	genreUseCase := genreMocks.NewMockGenreUsecase(ctrl)
+	actorUseCase := actorMocks.NewMockActorUseCase(ctrl)
+	directorUseCase := directorMocks.NewMockDirectorUseCase(ctrl)
+	suggestIndex := searchMocks.NewMockSuggestIndex(ctrl)
+
+	contentUseCase := NewContentUsecase(contentRep, countryUseCase,
+		genreUseCase, actorUseCase, directorUseCase, suggestIndex)
+
+	pgnt := &models.Pagination{From: 0, Count: 10}
+	var userID uint64 = 3
+	var contentID uint64 = 4
+
+	contentRep.
+		EXPECT().
+		SelectSimilar(contentID, pgnt, userID).
+		Return(nil, nil)
+
+	contents, err := contentUseCase.ListSimilar(contentID, pgnt, userID)
+	assert.Equal(t, (*errors.Error)(nil), err)
+	assert.Equal(t, []interface{}{}, contents)
+}
+
+var firstRevision = &models.ContentRevision{
+	ID:        1,
+	ContentID: 3,
+	ContentRevisionData: models.ContentRevisionData{
+		Name:         "Шрек",
+		OriginalName: "Shrek",
+		Year:         2000,
+		Countries:    []uint64{1},
+		Genres:       []uint64{},
+		Actors:       []uint64{},
+		Directors:    []uint64{},
+	},
+}
+
+var secondRevision = &models.ContentRevision{
+	ID:        2,
+	ContentID: 3,
+	ContentRevisionData: models.ContentRevisionData{
+		Name:         "Шрек",
+		OriginalName: "Shrek",
+		Year:         2001,
+		Countries:    []uint64{},
+		Genres:       []uint64{},
+		Actors:       []uint64{},
+		Directors:    []uint64{},
+	},
+}
+
+func TestContentUseCase_GetRevisionDiff_OK(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	contentRep := mocks.NewMockContentRepository(ctrl)
+	countryUseCase := countryMocks.NewMockCountryUsecase(ctrl)
+	genreUseCase := genreMocks.NewMockGenreUsecase(ctrl)
+	actorUseCase := actorMocks.NewMockActorUseCase(ctrl)
+	directorUseCase := directorMocks.NewMockDirectorUseCase(ctrl)
+	suggestIndex := searchMocks.NewMockSuggestIndex(ctrl)
+
+	contentUseCase := NewContentUsecase(contentRep, countryUseCase,
+		genreUseCase, actorUseCase, directorUseCase, suggestIndex)
+
+	contentRep.
+		EXPECT().
+		SelectRevisionByID(secondRevision.ContentID, secondRevision.ID).
+		Return(secondRevision, nil)
+
+	contentRep.
+		EXPECT().
+		SelectPrevRevision(secondRevision.ContentID, secondRevision.ID).
+		Return(firstRevision, nil)
+
+	changes, err := contentUseCase.GetRevisionDiff(secondRevision.ContentID, secondRevision.ID)
+	assert.Equal(t, err, (*errors.Error)(nil))
+	assert.Equal(t, map[string]*models.RevisionChange{
+		"year":      &models.RevisionChange{Before: 2000, After: 2001},
+		"countries": &models.RevisionChange{Before: []uint64{1}, After: []uint64{}},
+	}, changes)
+}
+
+func TestContentUseCase_GetRevisionDiff_ReorderedLinks(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	contentRep := mocks.NewMockContentRepository(ctrl)
+	countryUseCase := countryMocks.NewMockCountryUsecase(ctrl)
+	genreUseCase := genreMocks.NewMockGenreUsecase(ctrl)
+	actorUseCase := actorMocks.NewMockActorUseCase(ctrl)
+	directorUseCase := directorMocks.NewMockDirectorUseCase(ctrl)
+	suggestIndex := searchMocks.NewMockSuggestIndex(ctrl)
+
+	contentUseCase := NewContentUsecase(contentRep, countryUseCase,
+		genreUseCase, actorUseCase, directorUseCase, suggestIndex)
+
+	prev := &models.ContentRevision{ID: 1, ContentID: 3, ContentRevisionData: firstRevision.ContentRevisionData}
+	prev.Genres = []uint64{1, 2}
+	revision := &models.ContentRevision{ID: 2, ContentID: 3, ContentRevisionData: prev.ContentRevisionData}
+	revision.Genres = []uint64{2, 1}
+
+	contentRep.
+		EXPECT().
+		SelectRevisionByID(revision.ContentID, revision.ID).
+		Return(revision, nil)
+
+	contentRep.
+		EXPECT().
+		SelectPrevRevision(revision.ContentID, revision.ID).
+		Return(prev, nil)
+
+	changes, err := contentUseCase.GetRevisionDiff(revision.ContentID, revision.ID)
+	assert.Equal(t, err, (*errors.Error)(nil))
+	assert.Empty(t, changes)
+	assert.Equal(t, []uint64{2, 1}, revision.Genres)
+}
+
+func TestContentUseCase_GetRevisionDiff_First(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	contentRep := mocks.NewMockContentRepository(ctrl)
+	countryUseCase := countryMocks.NewMockCountryUsecase(ctrl)
+	genreUseCase := genreMocks.NewMockGenreUsecase(ctrl)
+	actorUseCase := actorMocks.NewMockActorUseCase(ctrl)
+	directorUseCase := directorMocks.NewMockDirectorUseCase(ctrl)
+	suggestIndex := searchMocks.NewMockSuggestIndex(ctrl)
+
+	contentUseCase := NewContentUsecase(contentRep, countryUseCase,
+		genreUseCase, actorUseCase, directorUseCase, suggestIndex)
+
+	contentRep.
+		EXPECT().
+		SelectRevisionByID(firstRevision.ContentID, firstRevision.ID).
+		Return(firstRevision, nil)
+
+	contentRep.
+		EXPECT().
+		SelectPrevRevision(firstRevision.ContentID, firstRevision.ID).
+		Return(nil, sql.ErrNoRows)
+
+	changes, err := contentUseCase.GetRevisionDiff(firstRevision.ContentID, firstRevision.ID)
+	assert.Equal(t, err, (*errors.Error)(nil))
+	assert.Len(t, changes, 10)
+	assert.Equal(t, &models.RevisionChange{Before: nil, After: "Шрек"}, changes["name"])
+}
+
+func TestContentUseCase_Rollback_OK(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	contentRep := mocks.NewMockContentRepository(ctrl)
+	countryUseCase := countryMocks.NewMockCountryUsecase(ctrl)
+	genreUseCase := genreMocks.NewMockGenreUsecase(ctrl)
+	actorUseCase := actorMocks.NewMockActorUseCase(ctrl)
+	directorUseCase := directorMocks.NewMockDirectorUseCase(ctrl)
+	suggestIndex := searchMocks.NewMockSuggestIndex(ctrl)
+
+	contentUseCase := NewContentUsecase(contentRep, countryUseCase,
+		genreUseCase, actorUseCase, directorUseCase, suggestIndex)
+
+	content := models.Content{
+		ContentID:    firstRevision.ContentID,
+		Name:         "Шрек 2",
+		OriginalName: "Shrek 2",
+		Year:         2004,
+	}
+
+	contentRep.
+		EXPECT().
+		SelectRevisionByID(firstRevision.ContentID, firstRevision.ID).
+		Return(firstRevision, nil)
+
+	contentRep.
+		EXPECT().
+		SelectByID(firstRevision.ContentID).
+		Return(&content, nil)
+
+	countryUseCase.
+		EXPECT().
+		GetByID(countries[0].ID).
+		Return(countries[0], nil)
+
+	contentRep.
+		EXPECT().
+		Update(gomock.Any()).
+		DoAndReturn(func(updated *models.Content) error {
+			assert.Equal(t, firstRevision.ContentRevisionData, models.NewContentRevisionData(updated))
+			return nil
+		})
+
+	suggestIndex.EXPECT().Invalidate()
+
+	dbContent, err := contentUseCase.Rollback(firstRevision.ContentID, firstRevision.ID)
+	assert.Equal(t, err, (*errors.Error)(nil))
+	assert.Equal(t, "Shrek", dbContent.OriginalName)
+	assert.Equal(t, 2000, dbContent.Year)
+	assert.Equal(t, countries, dbContent.Countries)
+}
+
+func TestContentUseCase_Rollback_SkipsDeletedLinks(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	contentRep := mocks.NewMockContentRepository(ctrl)
+	countryUseCase := countryMocks.NewMockCountryUsecase(ctrl)
+	genreUseCase := genreMocks.NewMockGenreUsecase(ctrl)
+	actorUseCase := actorMocks.NewMockActorUseCase(ctrl)
+	directorUseCase := directorMocks.NewMockDirectorUseCase(ctrl)
+	suggestIndex := searchMocks.NewMockSuggestIndex(ctrl)
+
+	contentUseCase := NewContentUsecase(contentRep, countryUseCase,
+		genreUseCase, actorUseCase, directorUseCase, suggestIndex)
+
+	revision := &models.ContentRevision{
+		ID:        1,
+		ContentID: 3,
+		ContentRevisionData: models.ContentRevisionData{
+			Name:         "Шрек",
+			OriginalName: "Shrek",
+			Year:         2000,
+			Countries:    []uint64{1, 2},
+			Genres:       []uint64{},
+			Actors:       []uint64{5},
+			Directors:    []uint64{},
+		},
+	}
+
+	contentRep.
+		EXPECT().
+		SelectRevisionByID(revision.ContentID, revision.ID).
+		Return(revision, nil)
+
+	contentRep.
+		EXPECT().
+		SelectByID(revision.ContentID).
+		Return(&models.Content{ContentID: revision.ContentID}, nil)
+
+	countryUseCase.
+		EXPECT().
+		GetByID(uint64(1)).
+		Return(countries[0], nil)
+
+	countryUseCase.
+		EXPECT().
+		GetByID(uint64(2)).
+		Return(nil, errors.Get(consts.CodeCountryDoesNotExist))
+
+	actorUseCase.
+		EXPECT().
+		Get(uint64(5)).
+		Return(nil, errors.Get(consts.CodeActorDoesNotExist))
+
+	contentRep.
+		EXPECT().
+		Update(gomock.Any()).
+		Return(nil)
+
+	suggestIndex.EXPECT().Invalidate()
+
+	dbContent, err := contentUseCase.Rollback(revision.ContentID, revision.ID)
+	assert.Equal(t, err, (*errors.Error)(nil))
+	assert.Equal(t, countries, dbContent.Countries)
+	assert.Empty(t, dbContent.Actors)
+}
+
+func TestContentUseCase_Rollback_RevisionDoesNotExist(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	contentRep := mocks.NewMockContentRepository(ctrl)
+	countryUseCase := countryMocks.NewMockCountryUsecase(ctrl)
+	genreUseCase := genreMocks.NewMockGenreUsecase(ctrl)
+	actorUseCase := actorMocks.NewMockActorUseCase(ctrl)
+	directorUseCase := directorMocks.NewMockDirectorUseCase(ctrl)
+	suggestIndex := searchMocks.NewMockSuggestIndex(ctrl)
+
+	contentUseCase := NewContentUsecase(contentRep, countryUseCase,
+		genreUseCase, actorUseCase, directorUseCase, suggestIndex)
+
+	contentRep.
+		EXPECT().
+		SelectRevisionByID(uint64(3), uint64(7)).
+		Return(nil, sql.ErrNoRows)
+
+	dbContent, err := contentUseCase.Rollback(3, 7)
+	assert.Equal(t, errors.Get(consts.CodeRevisionDoesNotExist), err)
+	assert.Nil(t, dbContent)
+}
@@ -0,0 +1,58 @@
+package workers
+
+import (
+	"sync"
+	"time"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/content"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
+)
+
+type Publisher struct {
+	contentUcase content.ContentUsecase
+	interval     time.Duration
+	stop         chan struct{}
+	wg           sync.WaitGroup
+}
+
+func NewPublisher(contentUcase content.ContentUsecase, interval time.Duration) *Publisher {
+	return &Publisher{
+		contentUcase: contentUcase,
+		interval:     interval,
+		stop:         make(chan struct{}),
+	}
+}
+
+// Start publishes due scheduled content right away and then every interval
+func (pb *Publisher) Start() {
+	pb.wg.Add(1)
+	go pb.work()
+}
+
+// Stop waits for running publish to finish
+func (pb *Publisher) Stop() {
+	close(pb.stop)
+	pb.wg.Wait()
+}
+
+func (pb *Publisher) work() {
+	defer pb.wg.Done()
+
+	ticker := time.NewTicker(pb.interval)
+	defer ticker.Stop()
+	for {
+		pb.publish()
+
+		select {
+		case <-pb.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (pb *Publisher) publish() {
+	if err := pb.contentUcase.PublishScheduled(); err != nil {
+		logger.Error(err.Message)
+	}
+}
@@ -26,9 +26,9 @@ func NewCountryHandler(countryUcase country.CountryUsecase) *CountryHandler {
 }
 
 func (ch *CountryHandler) Configure(e *echo.Echo, mw *mwares.MiddlewareManager) {
-	e.POST("/api/v1/countries", ch.CreateCountryHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF)
-	e.PUT("/api/v1/countries/:cid", ch.UpdateCountryHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF)
-	e.DELETE("/api/v1/countries/:cid", ch.DeleteCountryHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF)
+	e.POST("/api/v1/countries", ch.CreateCountryHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF, mw.Audit(consts.AuditCountry, ""))
+	e.PUT("/api/v1/countries/:cid", ch.UpdateCountryHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF, mw.Audit(consts.AuditCountry, "cid"))
+	e.DELETE("/api/v1/countries/:cid", ch.DeleteCountryHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF, mw.Audit(consts.AuditCountry, "cid"))
 	e.GET("/api/v1/countries", ch.GetCountriesListHandler())
 }
 
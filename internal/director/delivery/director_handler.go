@@ -26,10 +26,10 @@ func NewDirectorHandler(directorUseCase director.DirectorUseCase) *DirectorHandl
 }
 
 func (dh *DirectorHandler) Configure(e *echo.Echo, mw *mwares.MiddlewareManager) {
-	e.POST("/api/v1/directors", dh.CreateDirectorHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF)
-	e.PUT("/api/v1/directors/:id", dh.ChangeDirectorHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF)
+	e.POST("/api/v1/directors", dh.CreateDirectorHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF, mw.Audit(consts.AuditDirector, ""))
+	e.PUT("/api/v1/directors/:id", dh.ChangeDirectorHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF, mw.Audit(consts.AuditDirector, "id"))
 	e.GET("/api/v1/directors/:id", dh.GetDirectorHandler())
-	e.DELETE("/api/v1/directors/:id", dh.DeleteDirectorHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF)
+	e.DELETE("/api/v1/directors/:id", dh.DeleteDirectorHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF, mw.Audit(consts.AuditDirector, "id"))
 	e.GET("/api/v1/directors", dh.GetDirectorsListHandler())
 }
 
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
 )
 
 func MockDirectorRepoInsertReturnRows(mock sqlmock.Sqlmock, id uint64, name string) {
@@ -55,3 +56,12 @@ func MockDirectorRepoSelectReturnRows(mock sqlmock.Sqlmock, id uint64, name stri
 func MockDirectorRepoSelectReturnErrNoRows(mock sqlmock.Sqlmock, id uint64) {
 	mock.ExpectQuery(`SELECT`).WithArgs(id).WillReturnError(sql.ErrNoRows)
 }
+
+func MockDirectorRepoSelectByQueryReturnRows(mock sqlmock.Sqlmock, query string,
+	pgnt *models.Pagination, directors []*models.Director) {
+	rows := sqlmock.NewRows([]string{"id", "name"})
+	for _, director := range directors {
+		rows.AddRow(director.ID, director.Name)
+	}
+	mock.ExpectQuery(`SELECT`).WithArgs(query, pgnt.Count, pgnt.From).WillReturnRows(rows)
+}
@@ -104,3 +104,18 @@ func (mr *MockDirectorRepositoryMockRecorder) SelectAll(pgnt interface{}) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectAll", reflect.TypeOf((*MockDirectorRepository)(nil).SelectAll), pgnt)
 }
+
+// SelectByQuery mocks base method
+func (m *MockDirectorRepository) SelectByQuery(query string, pgnt *models.Pagination) ([]*models.Director, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectByQuery", query, pgnt)
+	ret0, _ := ret[0].([]*models.Director)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectByQuery indicates an expected call of SelectByQuery
+func (mr *MockDirectorRepositoryMockRecorder) SelectByQuery(query, pgnt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectByQuery", reflect.TypeOf((*MockDirectorRepository)(nil).SelectByQuery), query, pgnt)
+}
@@ -8,4 +8,5 @@ type DirectorRepository interface {
 	DeleteById(id uint64) error
 	SelectById(id uint64) (*models.Director, error)
 	SelectAll(pgnt *models.Pagination) ([]*models.Director, error)
+	SelectByQuery(query string, pgnt *models.Pagination) ([]*models.Director, error)
 }
@@ -3,11 +3,13 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"strings"
 
 	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
 
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/director"
+	queryBuilder "github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/query_builder"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
 )
 
@@ -145,3 +147,47 @@ func (dr *DirectorPgRepository) SelectAll(pgnt *models.Pagination) ([]*models.Di
 	}
 	return directors, nil
 }
+
+func (dr *DirectorPgRepository) SelectByQuery(query string, pgnt *models.Pagination) ([]*models.Director, error) {
+	var values []interface{}
+
+	selectQuery := fmt.Sprintf(`
+		SELECT id, name
+		FROM directors
+		WHERE %s
+		ORDER BY word_similarity($1, name) DESC, id`,
+		queryBuilder.BuildNameSearchCondition("name", "simple", 1))
+	values = append(values, query)
+
+	var pgntQuery string
+	if pgnt.Count != 0 {
+		pgntQuery = "LIMIT $2 OFFSET $3"
+		values = append(values, pgnt.Count, pgnt.From)
+	}
+
+	resultQuery := strings.Join([]string{
+		selectQuery,
+		pgntQuery,
+	}, " ")
+
+	rows, err := dr.dbConn.Query(resultQuery, values...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var directors []*models.Director
+	for rows.Next() {
+		director := &models.Director{}
+		err := rows.Scan(&director.ID, &director.Name)
+		if err != nil {
+			return nil, err
+		}
+		directors = append(directors, director)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return directors, nil
+}
@@ -201,3 +201,35 @@ func TestDirectorPgRepository_SelectById_NoDirectorWithThisID(t *testing.T) {
 		t.Errorf("there were unfulfilled expectations: %s", err)
 	}
 }
+
+func TestDirectorPgRepository_SelectByQuery_OK(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	directors := []*models.Director{
+		&models.Director{
+			ID:   3,
+			Name: "Quentin Tarantino",
+		},
+	}
+	pgnt := &models.Pagination{
+		From:  0,
+		Count: 10,
+	}
+	query := "Tarantno"
+
+	directorPgRep := NewDirectorPgRepository(db)
+
+	mocks.MockDirectorRepoSelectByQueryReturnRows(mock, query, pgnt, directors)
+	dbDirectors, err := directorPgRep.SelectByQuery(query, pgnt)
+	assert.Equal(t, directors, dbDirectors)
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -7,15 +7,18 @@ import (
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/director"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/search"
 )
 
 type DirectorUseCase struct {
 	directorRepo director.DirectorRepository
+	suggestIndex search.SuggestIndex
 }
 
-func NewDirectorUseCase(repo director.DirectorRepository) director.DirectorUseCase {
+func NewDirectorUseCase(repo director.DirectorRepository, suggestIndex search.SuggestIndex) director.DirectorUseCase {
 	return &DirectorUseCase{
 		directorRepo: repo,
+		suggestIndex: suggestIndex,
 	}
 }
 
@@ -24,6 +27,7 @@ func (du *DirectorUseCase) Create(director *models.Director) *errors.Error {
 	if err != nil {
 		return errors.New(CodeInternalError, err)
 	}
+	du.suggestIndex.Invalidate()
 	return nil
 }
 
@@ -45,6 +49,7 @@ func (du *DirectorUseCase) Change(newDirector *models.Director) *errors.Error {
 	if err := du.directorRepo.Update(newDirector); err != nil {
 		return errors.New(CodeInternalError, err)
 	}
+	du.suggestIndex.Invalidate()
 
 	return nil
 }
@@ -57,6 +62,7 @@ func (du *DirectorUseCase) DeleteById(id uint64) *errors.Error {
 	if err := du.directorRepo.DeleteById(id); err != nil {
 		return errors.New(CodeInternalError, err)
 	}
+	du.suggestIndex.Invalidate()
 
 	return nil
 }
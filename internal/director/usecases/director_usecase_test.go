@@ -8,6 +8,7 @@ import (
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/director/mocks"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	searchMocks "github.com/go-park-mail-ru/2020_2_Slash/internal/search/mocks"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 )
@@ -17,7 +18,8 @@ func TestDirectorUseCase_Create_OK(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	directorRep := mocks.NewMockDirectorRepository(ctrl)
-	directorUseCase := NewDirectorUseCase(directorRep)
+	suggestIndex := searchMocks.NewMockSuggestIndex(ctrl)
+	directorUseCase := NewDirectorUseCase(directorRep, suggestIndex)
 
 	director := &models.Director{
 		Name: "Sergio Leone",
@@ -28,6 +30,8 @@ func TestDirectorUseCase_Create_OK(t *testing.T) {
 		Insert(gomock.Eq(director)).
 		Return(nil)
 
+	suggestIndex.EXPECT().Invalidate()
+
 	err := directorUseCase.Create(director)
 	assert.Equal(t, err, (*errors.Error)(nil))
 }
@@ -37,7 +41,8 @@ func TestDirectorUseCase_Get_OK(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	directorRep := mocks.NewMockDirectorRepository(ctrl)
-	directorUseCase := NewDirectorUseCase(directorRep)
+	suggestIndex := searchMocks.NewMockSuggestIndex(ctrl)
+	directorUseCase := NewDirectorUseCase(directorRep, suggestIndex)
 
 	director := &models.Director{
 		ID:   3,
@@ -59,7 +64,8 @@ func TestDirectorUseCase_Get_Fail(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	directorRep := mocks.NewMockDirectorRepository(ctrl)
-	directorUseCase := NewDirectorUseCase(directorRep)
+	suggestIndex := searchMocks.NewMockSuggestIndex(ctrl)
+	directorUseCase := NewDirectorUseCase(directorRep, suggestIndex)
 
 	director := &models.Director{
 		ID:   3,
@@ -81,7 +87,8 @@ func TestDirectorUseCase_Delete_OK(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	directorRep := mocks.NewMockDirectorRepository(ctrl)
-	directorUseCase := NewDirectorUseCase(directorRep)
+	suggestIndex := searchMocks.NewMockSuggestIndex(ctrl)
+	directorUseCase := NewDirectorUseCase(directorRep, suggestIndex)
 
 	director := &models.Director{
 		ID:   3,
@@ -98,6 +105,8 @@ func TestDirectorUseCase_Delete_OK(t *testing.T) {
 		DeleteById(gomock.Eq(director.ID)).
 		Return(nil)
 
+	suggestIndex.EXPECT().Invalidate()
+
 	err := directorUseCase.DeleteById(director.ID)
 	assert.Equal(t, err, (*errors.Error)(nil))
 }
@@ -107,7 +116,8 @@ func TestDirectorUseCase_Update_OK(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	directorRep := mocks.NewMockDirectorRepository(ctrl)
-	directorUseCase := NewDirectorUseCase(directorRep)
+	suggestIndex := searchMocks.NewMockSuggestIndex(ctrl)
+	directorUseCase := NewDirectorUseCase(directorRep, suggestIndex)
 
 	director := &models.Director{
 		ID:   3,
@@ -124,6 +134,8 @@ func TestDirectorUseCase_Update_OK(t *testing.T) {
 		Update(gomock.Eq(director)).
 		Return(nil)
 
+	suggestIndex.EXPECT().Invalidate()
+
 	err := directorUseCase.Change(director)
 	assert.Equal(t, err, (*errors.Error)(nil))
 }
@@ -133,7 +145,8 @@ func TestDirectorUseCase_ListByID_OK(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	directorRep := mocks.NewMockDirectorRepository(ctrl)
-	directorUseCase := NewDirectorUseCase(directorRep)
+	suggestIndex := searchMocks.NewMockSuggestIndex(ctrl)
+	directorUseCase := NewDirectorUseCase(directorRep, suggestIndex)
 
 	directors := []*models.Director{
 		&models.Director{
@@ -168,7 +181,8 @@ func TestDirectorUseCase_List_OK(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	directorRep := mocks.NewMockDirectorRepository(ctrl)
-	directorUseCase := NewDirectorUseCase(directorRep)
+	suggestIndex := searchMocks.NewMockSuggestIndex(ctrl)
+	directorUseCase := NewDirectorUseCase(directorRep, suggestIndex)
 
 	directors := []*models.Director{
 		&models.Director{
@@ -0,0 +1,64 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/entitlement/usecase.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	errors "github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	models "github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockEntitlementUsecase is a mock of EntitlementUsecase interface
+type MockEntitlementUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockEntitlementUsecaseMockRecorder
+}
+
+// MockEntitlementUsecaseMockRecorder is the mock recorder for MockEntitlementUsecase
+type MockEntitlementUsecaseMockRecorder struct {
+	mock *MockEntitlementUsecase
+}
+
+// NewMockEntitlementUsecase creates a new mock instance
+func NewMockEntitlementUsecase(ctrl *gomock.Controller) *MockEntitlementUsecase {
+	mock := &MockEntitlementUsecase{ctrl: ctrl}
+	mock.recorder = &MockEntitlementUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockEntitlementUsecase) EXPECT() *MockEntitlementUsecaseMockRecorder {
+	return m.recorder
+}
+
+// HasAccess mocks base method
+func (m *MockEntitlementUsecase) HasAccess(userID uint64, content *models.Content) (bool, *errors.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasAccess", userID, content)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(*errors.Error)
+	return ret0, ret1
+}
+
+// HasAccess indicates an expected call of HasAccess
+func (mr *MockEntitlementUsecaseMockRecorder) HasAccess(userID, content interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasAccess", reflect.TypeOf((*MockEntitlementUsecase)(nil).HasAccess), userID, content)
+}
+
+// CheckAccess mocks base method
+func (m *MockEntitlementUsecase) CheckAccess(userID uint64, content *models.Content) *errors.Error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckAccess", userID, content)
+	ret0, _ := ret[0].(*errors.Error)
+	return ret0
+}
+
+// CheckAccess indicates an expected call of CheckAccess
+func (mr *MockEntitlementUsecaseMockRecorder) CheckAccess(userID, content interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckAccess", reflect.TypeOf((*MockEntitlementUsecase)(nil).CheckAccess), userID, content)
+}
@@ -0,0 +1,11 @@
+package entitlement
+
+import (
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+)
+
+type EntitlementUsecase interface {
+	HasAccess(userID uint64, content *models.Content) (bool, *errors.Error)
+	CheckAccess(userID uint64, content *models.Content) *errors.Error
+}
@@ -0,0 +1,49 @@
+package usecases
+
+import (
+	. "github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/entitlement"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/subscription"
+)
+
+type EntitlementUsecase struct {
+	subUcase subscription.SubscriptionUseCase
+}
+
+func NewEntitlementUsecase(subUcase subscription.SubscriptionUseCase) entitlement.EntitlementUsecase {
+	return &EntitlementUsecase{
+		subUcase: subUcase,
+	}
+}
+
+func (eu *EntitlementUsecase) HasAccess(userID uint64, content *models.Content) (bool, *errors.Error) {
+	if content.IsFree != nil && *content.IsFree {
+		return true, nil
+	}
+	// Anonymous user can watch only free content
+	if userID == 0 {
+		return false, nil
+	}
+
+	subscription, err := eu.subUcase.GetByUserID(userID)
+	if err != nil {
+		return false, err
+	}
+	if subscription == nil {
+		return false, nil
+	}
+	return subscription.IsActive(), nil
+}
+
+func (eu *EntitlementUsecase) CheckAccess(userID uint64, content *models.Content) *errors.Error {
+	hasAccess, err := eu.HasAccess(userID, content)
+	if err != nil {
+		return err
+	}
+	if !hasAccess {
+		return errors.Get(CodeSubscriptionRequired)
+	}
+	return nil
+}
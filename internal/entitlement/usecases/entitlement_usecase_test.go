@@ -0,0 +1,119 @@
+package usecases
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	subscriptionMocks "github.com/go-park-mail-ru/2020_2_Slash/internal/subscription/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var isFree = true
+var isPaid = false
+
+var freeContent = &models.Content{
+	ContentID: 1,
+	IsFree:    &isFree,
+}
+
+var paidContent = &models.Content{
+	ContentID: 2,
+	IsFree:    &isPaid,
+}
+
+var userID uint64 = 3
+
+func TestEntitlementUseCase_HasAccess_FreeContent(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	subUseCase := subscriptionMocks.NewMockSubscriptionUseCase(ctrl)
+	entitlementUseCase := NewEntitlementUsecase(subUseCase)
+
+	hasAccess, err := entitlementUseCase.HasAccess(0, freeContent)
+	assert.Equal(t, err, (*errors.Error)(nil))
+	assert.True(t, hasAccess)
+}
+
+func TestEntitlementUseCase_HasAccess_Anonymous(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	subUseCase := subscriptionMocks.NewMockSubscriptionUseCase(ctrl)
+	entitlementUseCase := NewEntitlementUsecase(subUseCase)
+
+	hasAccess, err := entitlementUseCase.HasAccess(0, paidContent)
+	assert.Equal(t, err, (*errors.Error)(nil))
+	assert.False(t, hasAccess)
+}
+
+func TestEntitlementUseCase_HasAccess_ActiveSubscription(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	subUseCase := subscriptionMocks.NewMockSubscriptionUseCase(ctrl)
+	entitlementUseCase := NewEntitlementUsecase(subUseCase)
+
+	subscription := &models.Subscription{
+		UserID:  userID,
+		Expires: time.Now().Add(time.Hour),
+		IsPaid:  true,
+	}
+
+	subUseCase.
+		EXPECT().
+		GetByUserID(gomock.Eq(userID)).
+		Return(subscription, nil)
+
+	hasAccess, err := entitlementUseCase.HasAccess(userID, paidContent)
+	assert.Equal(t, err, (*errors.Error)(nil))
+	assert.True(t, hasAccess)
+}
+
+func TestEntitlementUseCase_HasAccess_NotPaidSubscription(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	subUseCase := subscriptionMocks.NewMockSubscriptionUseCase(ctrl)
+	entitlementUseCase := NewEntitlementUsecase(subUseCase)
+
+	subscription := &models.Subscription{
+		UserID:  userID,
+		Expires: time.Now().Add(-time.Hour),
+		IsPaid:  false,
+	}
+
+	subUseCase.
+		EXPECT().
+		GetByUserID(gomock.Eq(userID)).
+		Return(subscription, nil)
+
+	hasAccess, err := entitlementUseCase.HasAccess(userID, paidContent)
+	assert.Equal(t, err, (*errors.Error)(nil))
+	assert.False(t, hasAccess)
+}
+
+func TestEntitlementUseCase_CheckAccess_NoSubscription(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	subUseCase := subscriptionMocks.NewMockSubscriptionUseCase(ctrl)
+	entitlementUseCase := NewEntitlementUsecase(subUseCase)
+
+	subUseCase.
+		EXPECT().
+		GetByUserID(gomock.Eq(userID)).
+		Return(nil, nil)
+
+	err := entitlementUseCase.CheckAccess(userID, paidContent)
+	assert.Equal(t, err, errors.Get(consts.CodeSubscriptionRequired))
+}
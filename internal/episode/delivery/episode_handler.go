@@ -7,9 +7,11 @@ import (
 	"strconv"
 
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/entitlement"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/episode"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/job"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/mwares"
 	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
@@ -20,22 +22,32 @@ import (
 )
 
 type EpisodeHandler struct {
-	episodeUsecase episode.EpisodeUsecase
+	episodeUsecase     episode.EpisodeUsecase
+	entitlementUsecase entitlement.EntitlementUsecase
+	videoSigner        *helpers.VideoURLSigner
+	jobUsecase         job.JobUsecase
 }
 
-func NewEpisodeHandler(usecase episode.EpisodeUsecase) *EpisodeHandler {
-	return &EpisodeHandler{episodeUsecase: usecase}
+func NewEpisodeHandler(usecase episode.EpisodeUsecase,
+	entitlementUsecase entitlement.EntitlementUsecase,
+	videoSigner *helpers.VideoURLSigner, jobUsecase job.JobUsecase) *EpisodeHandler {
+	return &EpisodeHandler{
+		episodeUsecase:     usecase,
+		entitlementUsecase: entitlementUsecase,
+		videoSigner:        videoSigner,
+		jobUsecase:         jobUsecase,
+	}
 }
 
 func (eh *EpisodeHandler) Configure(e *echo.Echo, mw *mwares.MiddlewareManager) {
-	e.POST("/api/v1/episodes", eh.CreateHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF)
-	e.PUT("/api/v1/episodes/:eid", eh.ChangeHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF)
-	e.DELETE("/api/v1/episodes/:eid", eh.DeleteHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF)
+	e.POST("/api/v1/episodes", eh.CreateHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF, mw.Audit(consts.AuditEpisode, ""))
+	e.PUT("/api/v1/episodes/:eid", eh.ChangeHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF, mw.Audit(consts.AuditEpisode, "eid"))
+	e.DELETE("/api/v1/episodes/:eid", eh.DeleteHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF, mw.Audit(consts.AuditEpisode, "eid"))
 	e.GET("/api/v1/episodes/:eid", eh.GetHandler(), mw.GetAuth)
 	e.PUT("/api/v1/episodes/:eid/poster", eh.UpdatePosterHandler(),
-		middleware.BodyLimit("10M"), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF)
+		middleware.BodyLimit("10M"), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF, mw.Audit(consts.AuditEpisode, "eid"))
 	e.PUT("/api/v1/episodes/:eid/video", eh.UpdateVideoHandler(),
-		middleware.BodyLimit("1000M"), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF)
+		middleware.BodyLimit("1000M"), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF, mw.Audit(consts.AuditEpisode, "eid"))
 }
 
 func (eh *EpisodeHandler) CreateHandler() echo.HandlerFunc {
@@ -144,11 +156,33 @@ func (eh *EpisodeHandler) GetHandler() echo.HandlerFunc {
 			return cntx.JSON(customErr.HTTPCode, Response{Error: customErr})
 		}
 
-		episode, customErr := eh.episodeUsecase.GetByID(episodeID)
+		episode, customErr := eh.episodeUsecase.GetPublishedByID(episodeID)
+		if customErr != nil {
+			logger.Error(customErr.Message)
+			return cntx.JSON(customErr.HTTPCode, Response{Error: customErr})
+		}
+
+		content, customErr := eh.episodeUsecase.GetContentByEID(episodeID)
+		if customErr != nil {
+			logger.Error(customErr.Message)
+			return cntx.JSON(customErr.HTTPCode, Response{Error: customErr})
+		}
+
+		// nolint: errcheck
+		userID, _ := cntx.Get("userID").(uint64)
+
+		customErr = eh.entitlementUsecase.CheckAccess(userID, content)
 		if customErr != nil {
 			logger.Error(customErr.Message)
 			return cntx.JSON(customErr.HTTPCode, Response{Error: customErr})
 		}
+		if episode.Video != "" {
+			// Playlist is given only after packaging, video is played until then
+			if playlistPath, ok := helpers.GetPackagedPlaylist(episode.Video); ok {
+				episode.Playlist = eh.videoSigner.SignPlaylist(playlistPath, userID)
+			}
+			episode.Video = eh.videoSigner.Sign(episode.Video, userID)
+		}
 
 		return cntx.JSON(http.StatusOK, Response{
 			Body: &Body{
@@ -228,10 +262,11 @@ func (eh *EpisodeHandler) UpdatePosterHandler() echo.HandlerFunc {
 		postersDirAbsPath := filepath.Join(path, seasonDir)
 		helpers.InitTree(postersDirAbsPath)
 
-		// Store poster
+		// Stage poster, it is compressed by background job
 		posterName := strconv.Itoa(episode.Number) + format
 		absPosterPath := filepath.Join(postersDirAbsPath, posterName)
-		if err := helpers.StoreSmallImage(posterImage, absPosterPath); err != nil {
+		absStagedPath := helpers.GetStagedPath(absPosterPath)
+		if err := helpers.StoreFile(posterImage, absStagedPath); err != nil {
 			if episode.Poster == "" {
 				removeErr := os.RemoveAll(postersDirAbsPath)
 				if removeErr != nil {
@@ -242,22 +277,20 @@ func (eh *EpisodeHandler) UpdatePosterHandler() echo.HandlerFunc {
 			return cntx.JSON(err.HTTPCode, Response{Error: err})
 		}
 
-		// Update episode poster
 		rltPosterPath := filepath.Join(seasonDir, posterName)
-		if err := eh.episodeUsecase.UpdatePoster(episode, rltPosterPath); err != nil {
-			if episode.Poster == "" {
-				removeErr := os.RemoveAll(postersDirAbsPath)
-				if removeErr != nil {
-					logger.Error(removeErr)
-				}
-			}
-			logger.Error(err.Message)
-			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		job, customErr := eh.jobUsecase.Enqueue(consts.JobEpisodePoster, &models.EpisodeMediaPayload{
+			EpisodeID: episode.ID,
+			Path:      rltPosterPath,
+		})
+		if customErr != nil {
+			helpers.RemoveStagedFile(absStagedPath)
+			logger.Error(customErr.Message)
+			return cntx.JSON(customErr.HTTPCode, Response{Error: customErr})
 		}
 
-		return cntx.JSON(http.StatusOK, Response{
+		return cntx.JSON(http.StatusAccepted, Response{
 			Body: &Body{
-				"poster": rltPosterPath,
+				"job": job,
 			},
 		})
 	}
@@ -300,10 +333,11 @@ func (eh *EpisodeHandler) UpdateVideoHandler() echo.HandlerFunc {
 		videosDirPath := filepath.Join(path, seasonDir)
 		helpers.InitTree(videosDirPath)
 
-		// Store video
+		// Stage video, it is processed by background job
 		videoName := strconv.Itoa(episode.Number) + format
 		absVideoPath := filepath.Join(videosDirPath, videoName)
-		if err := helpers.StoreFile(video, absVideoPath); err != nil {
+		absStagedPath := helpers.GetStagedPath(absVideoPath)
+		if err := helpers.StoreFile(video, absStagedPath); err != nil {
 			if episode.Video == "" {
 				removeErr := os.RemoveAll(videosDirPath)
 				if removeErr != nil {
@@ -314,22 +348,20 @@ func (eh *EpisodeHandler) UpdateVideoHandler() echo.HandlerFunc {
 			return cntx.JSON(err.HTTPCode, Response{Error: err})
 		}
 
-		// Update episode
 		rltVideoPath := filepath.Join(seasonDir, videoName)
-		if err := eh.episodeUsecase.UpdateVideo(episode, rltVideoPath); err != nil {
-			if episode.Video == "" {
-				removeErr := os.RemoveAll(videosDirPath)
-				if removeErr != nil {
-					logger.Error(removeErr)
-				}
-			}
-			logger.Error(err.Message)
-			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		job, customErr := eh.jobUsecase.Enqueue(consts.JobEpisodeVideo, &models.EpisodeMediaPayload{
+			EpisodeID: episode.ID,
+			Path:      rltVideoPath,
+		})
+		if customErr != nil {
+			helpers.RemoveStagedFile(absStagedPath)
+			logger.Error(customErr.Message)
+			return cntx.JSON(customErr.HTTPCode, Response{Error: customErr})
 		}
 
-		return cntx.JSON(http.StatusOK, Response{
+		return cntx.JSON(http.StatusAccepted, Response{
 			Body: &Body{
-				"video": rltVideoPath,
+				"job": job,
 			},
 		})
 	}
@@ -6,6 +6,7 @@ type EpisodeRepository interface {
 	Insert(episode *models.Episode) error
 	Update(newEpisode *models.Episode) error
 	SelectByID(id uint64) (*models.Episode, error)
+	SelectPublishedByID(id uint64) (*models.Episode, error)
 	SelectByNumberAndSeason(number int, seasonID uint64) (*models.Episode, error)
 	SelectContentByID(id uint64) (*models.Content, error)
 	SelectSeasonNumberByID(id uint64) (int, error)
@@ -6,6 +6,7 @@ import (
 	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
 
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/episode"
+	queryBuilder "github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/query_builder"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
 )
 
@@ -46,9 +47,13 @@ func (rep *EpisodeRepository) SelectByID(id uint64) (*models.Episode, error) {
 	dbEpisode := &models.Episode{}
 
 	row := rep.db.QueryRow(`
-		SELECT id, number, name, video, description, poster, season_id
-		FROM episodes
-		WHERE id=$1`, id)
+		SELECT e.id, e.number, e.name, e.video, e.description, e.poster, e.season_id
+		FROM episodes AS e
+		JOIN seasons AS s ON s.id=e.season_id
+		JOIN tv_shows AS tv ON tv.id=s.tv_show_id
+		JOIN content AS c ON c.id=tv.content_id
+		WHERE e.id=$1 AND e.deleted_at IS NULL
+		AND s.deleted_at IS NULL AND c.deleted_at IS NULL`, id)
 	err := row.Scan(&dbEpisode.ID, &dbEpisode.Number, &dbEpisode.Name, &dbEpisode.Video,
 		&dbEpisode.Description, &dbEpisode.Poster, &dbEpisode.SeasonID)
 	if err != nil {
@@ -58,6 +63,29 @@ func (rep *EpisodeRepository) SelectByID(id uint64) (*models.Episode, error) {
 	return dbEpisode, nil
 }
 
+// SelectPublishedByID finds episode only if its tv show is published
+func (rep *EpisodeRepository) SelectPublishedByID(id uint64) (*models.Episode, error) {
+	dbEpisode := &models.Episode{}
+
+	row := rep.db.QueryRow(`
+		SELECT e.id, e.number, e.name, e.video, e.description, e.poster, e.season_id
+		FROM episodes AS e
+		JOIN seasons AS s ON s.id=e.season_id
+		JOIN tv_shows AS tv ON tv.id=s.tv_show_id
+		JOIN content AS c ON c.id=tv.content_id
+		WHERE e.id=$1 AND e.deleted_at IS NULL
+		AND s.deleted_at IS NULL AND `+queryBuilder.BuildPublishedCondition(), id)
+	err := row.Scan(&dbEpisode.ID, &dbEpisode.Number, &dbEpisode.Name, &dbEpisode.Video,
+		&dbEpisode.Description, &dbEpisode.Poster, &dbEpisode.SeasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dbEpisode, nil
+}
+
+// SelectByNumberAndSeason finds episode that isn't in the trash,
+// number of the trashed episode can be given to the new one
 func (rep *EpisodeRepository) SelectByNumberAndSeason(number int,
 	seasonID uint64) (*models.Episode, error) {
 	dbEpisode := &models.Episode{}
@@ -65,7 +93,7 @@ func (rep *EpisodeRepository) SelectByNumberAndSeason(number int,
 	row := rep.db.QueryRow(`
 		SELECT id, number, name, video, description, poster, season_id
 		FROM episodes
-		WHERE number=$1 AND season_id=$2`, number, seasonID)
+		WHERE number=$1 AND season_id=$2 AND deleted_at IS NULL`, number, seasonID)
 	err := row.Scan(&dbEpisode.ID, &dbEpisode.Number, &dbEpisode.Name, &dbEpisode.Video,
 		&dbEpisode.Description, &dbEpisode.Poster, &dbEpisode.SeasonID)
 	if err != nil {
@@ -104,6 +132,7 @@ func (rep *EpisodeRepository) Update(newEpisode *models.Episode) error {
 	return nil
 }
 
+// DeleteByID moves episode to the trash
 func (rep *EpisodeRepository) DeleteByID(id uint64) error {
 	tx, err := rep.db.BeginTx(context.Background(), &sql.TxOptions{})
 	if err != nil {
@@ -111,8 +140,9 @@ func (rep *EpisodeRepository) DeleteByID(id uint64) error {
 	}
 
 	_, err = tx.Exec(`
-		DELETE FROM episodes
-		WHERE id=$1`, id)
+		UPDATE episodes
+		SET deleted_at = now()
+		WHERE id=$1 AND deleted_at IS NULL`, id)
 	if err != nil {
 		if rollbackErr := tx.Rollback(); rollbackErr != nil {
 			logger.Error(rollbackErr)
@@ -176,7 +206,7 @@ func (rep *EpisodeRepository) SelectContentByID(id uint64) (*models.Content, err
 	content := &models.Content{}
 	row := rep.db.QueryRow(`
 		SELECT content_id, c.name, c.original_name, c.description, c.short_description,
-		       c.rating, c.year, c.images, c.type
+		       c.rating, c.year, c.images, c.type, c.is_free
 		FROM episodes
 		LEFT OUTER JOIN seasons ON season_id=seasons.id
 		LEFT OUTER JOIN tv_shows on tv_show_id=tv_shows.id
@@ -184,7 +214,7 @@ func (rep *EpisodeRepository) SelectContentByID(id uint64) (*models.Content, err
 		WHERE episodes.id=$1`, id)
 	err := row.Scan(&content.ContentID, &content.Name, &content.OriginalName,
 		&content.Description, &content.ShortDescription,
-		&content.Rating, &content.Year, &content.Images, &content.Type)
+		&content.Rating, &content.Year, &content.Images, &content.Type, &content.IsFree)
 	if err != nil {
 		return nil, err
 	}
@@ -9,9 +9,13 @@ type EpisodeUsecase interface {
 	Create(episode *models.Episode) *errors.Error
 	Change(episode *models.Episode) *errors.Error
 	GetByID(id uint64) (*models.Episode, *errors.Error)
+	// GetPublishedByID hides episodes of tv shows that aren't published
+	GetPublishedByID(id uint64) (*models.Episode, *errors.Error)
 	DeleteByID(id uint64) *errors.Error
 	GetContentByEID(eid uint64) (*models.Content, *errors.Error)
 	GetSeasonNumber(eid uint64) (int, *errors.Error)
 	UpdatePoster(episode *models.Episode, posters string) *errors.Error
 	UpdateVideo(episode *models.Episode, video string) *errors.Error
+	ProcessPosterJob(job *models.Job) *errors.Error
+	ProcessVideoJob(job *models.Job) *errors.Error
 }
@@ -2,9 +2,13 @@ package usecases
 
 import (
 	"database/sql"
+	"encoding/json"
+	"os"
+	"path/filepath"
 
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/episode"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/season"
@@ -13,12 +17,15 @@ import (
 type EpisodeUsecase struct {
 	rep           episode.EpisodeRepository
 	seasonUseCase season.SeasonUsecase
+	hlsPackager   *helpers.HLSPackager
 }
 
-func NewEpisodeUsecase(rep episode.EpisodeRepository, seasonUseCase season.SeasonUsecase) episode.EpisodeUsecase {
+func NewEpisodeUsecase(rep episode.EpisodeRepository, seasonUseCase season.SeasonUsecase,
+	hlsPackager *helpers.HLSPackager) episode.EpisodeUsecase {
 	return &EpisodeUsecase{
 		rep:           rep,
 		seasonUseCase: seasonUseCase,
+		hlsPackager:   hlsPackager,
 	}
 }
 
@@ -94,6 +101,16 @@ func (uc *EpisodeUsecase) GetByID(id uint64) (*models.Episode, *errors.Error) {
 	return dbEpisode, nil
 }
 
+func (uc *EpisodeUsecase) GetPublishedByID(id uint64) (*models.Episode, *errors.Error) {
+	dbEpisode, err := uc.rep.SelectPublishedByID(id)
+	if err == sql.ErrNoRows {
+		return nil, errors.Get(consts.CodeEpisodeDoesNotExist)
+	} else if err != nil {
+		return nil, errors.New(consts.CodeInternalError, err)
+	}
+	return dbEpisode, nil
+}
+
 func (uc *EpisodeUsecase) DeleteByID(id uint64) *errors.Error {
 	_, customErr := uc.GetByID(id)
 	if customErr != nil {
@@ -148,3 +165,47 @@ func (uc *EpisodeUsecase) UpdateVideo(episode *models.Episode, newVideoPath stri
 	}
 	return nil
 }
+
+// ProcessPosterJob compresses staged poster and sets it to the episode
+func (uc *EpisodeUsecase) ProcessPosterJob(job *models.Job) *errors.Error {
+	return uc.processMediaJob(job, func(episode *models.Episode, absStagedPath, absPath, rltPath string) *errors.Error {
+		if err := helpers.StoreStagedSmallImage(absStagedPath, absPath); err != nil {
+			return err
+		}
+		return uc.UpdatePoster(episode, rltPath)
+	})
+}
+
+// ProcessVideoJob segments staged video into HLS and sets it to the episode
+func (uc *EpisodeUsecase) ProcessVideoJob(job *models.Job) *errors.Error {
+	return uc.processMediaJob(job, func(episode *models.Episode, absStagedPath, absPath, rltPath string) *errors.Error {
+		if err := uc.hlsPackager.StoreStaged(absStagedPath, absPath); err != nil {
+			return err
+		}
+		return uc.UpdateVideo(episode, rltPath)
+	})
+}
+
+func (uc *EpisodeUsecase) processMediaJob(job *models.Job,
+	process func(episode *models.Episode, absStagedPath, absPath, rltPath string) *errors.Error) *errors.Error {
+	payload := &models.EpisodeMediaPayload{}
+	if err := json.Unmarshal(job.Payload, payload); err != nil {
+		return errors.New(consts.CodeInternalError, err)
+	}
+
+	path, osErr := os.Getwd()
+	if osErr != nil {
+		return errors.New(consts.CodeInternalError, osErr)
+	}
+	absPath := filepath.Join(path, payload.Path)
+	absStagedPath := helpers.GetStagedPath(absPath)
+
+	episode, err := uc.GetByID(payload.EpisodeID)
+	if err == nil {
+		err = process(episode, absStagedPath, absPath, payload.Path)
+	}
+	if err == nil || job.IsLastAttempt() {
+		helpers.RemoveStagedFile(absStagedPath)
+	}
+	return err
+}
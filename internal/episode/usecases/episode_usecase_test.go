@@ -0,0 +1,31 @@
+package usecases
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/episode/mocks"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	seasonMocks "github.com/go-park-mail-ru/2020_2_Slash/internal/season/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpisodeUseCase_GetPublishedByID_NotPublished(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	episodeRep := mocks.NewMockEpisodeRepository(ctrl)
+	seasonUseCase := seasonMocks.NewMockSeasonUsecase(ctrl)
+	episodeUseCase := NewEpisodeUsecase(episodeRep, seasonUseCase, nil)
+
+	episodeRep.
+		EXPECT().
+		SelectPublishedByID(uint64(1)).
+		Return(nil, sql.ErrNoRows)
+
+	episode, err := episodeUseCase.GetPublishedByID(1)
+	assert.Equal(t, errors.Get(consts.CodeEpisodeDoesNotExist), err)
+	assert.Nil(t, episode)
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/favourite"
+	queryBuilder "github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/query_builder"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
 )
 
@@ -66,7 +67,7 @@ func (rep *FavouritePgRepository) SelectFavouriteMovies(userID uint64,
 		JOIN movies as m ON m.content_id=c.id
 		LEFT OUTER JOIN rates as r ON r.user_id=$1 AND r.content_id=c.id
 		LEFT OUTER JOIN favourites as f ON f.user_id=$1 AND f.content_id=c.id
-		WHERE f.user_id=$1
+		WHERE f.user_id=$1 AND ` + queryBuilder.BuildPublishedCondition() + `
 		ORDER BY created DESC`
 	values = append(values, userID)
 
@@ -121,7 +122,7 @@ func (rep *FavouritePgRepository) SelectFavouriteTVShows(userID uint64,
 		JOIN tv_shows as t ON t.content_id=c.id
 		LEFT OUTER JOIN rates as r ON r.user_id=$1 AND r.content_id=c.id
 		LEFT OUTER JOIN favourites as f ON f.user_id=$1 AND f.content_id=c.id
-		WHERE f.user_id=$1
+		WHERE f.user_id=$1 AND ` + queryBuilder.BuildPublishedCondition() + `
 		ORDER BY created DESC`
 	values = append(values, userID)
 
@@ -26,9 +26,9 @@ func NewGenreHandler(genreUcase genre.GenreUsecase) *GenreHandler {
 }
 
 func (gh *GenreHandler) Configure(e *echo.Echo, mw *mwares.MiddlewareManager) {
-	e.POST("/api/v1/genres", gh.CreateGenreHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF)
-	e.PUT("/api/v1/genres/:gid", gh.UpdateGenreHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF)
-	e.DELETE("/api/v1/genres/:gid", gh.DeleteGenreHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF)
+	e.POST("/api/v1/genres", gh.CreateGenreHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF, mw.Audit(consts.AuditGenre, ""))
+	e.PUT("/api/v1/genres/:gid", gh.UpdateGenreHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF, mw.Audit(consts.AuditGenre, "gid"))
+	e.DELETE("/api/v1/genres/:gid", gh.DeleteGenreHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF, mw.Audit(consts.AuditGenre, "gid"))
 	e.GET("/api/v1/genres", gh.GetGenresListHandler())
 }
 
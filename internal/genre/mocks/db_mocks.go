@@ -75,3 +75,12 @@ func MockGenreRepoSelectAllReturnRows(mock sqlmock.Sqlmock, genres []*models.Gen
 	}
 	mock.ExpectQuery(`SELECT`).WillReturnRows(rows)
 }
+
+func MockGenreRepoSelectByQueryReturnRows(mock sqlmock.Sqlmock, query string,
+	pgnt *models.Pagination, genres []*models.Genre) {
+	rows := sqlmock.NewRows([]string{"id", "name"})
+	for _, genre := range genres {
+		rows.AddRow(genre.ID, genre.Name)
+	}
+	mock.ExpectQuery(`SELECT`).WithArgs(query, pgnt.Count, pgnt.From).WillReturnRows(rows)
+}
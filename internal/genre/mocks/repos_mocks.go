@@ -119,3 +119,18 @@ func (mr *MockGenreRepositoryMockRecorder) SelectAll() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectAll", reflect.TypeOf((*MockGenreRepository)(nil).SelectAll))
 }
+
+// SelectByQuery mocks base method
+func (m *MockGenreRepository) SelectByQuery(query string, pgnt *models.Pagination) ([]*models.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectByQuery", query, pgnt)
+	ret0, _ := ret[0].([]*models.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectByQuery indicates an expected call of SelectByQuery
+func (mr *MockGenreRepositoryMockRecorder) SelectByQuery(query, pgnt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectByQuery", reflect.TypeOf((*MockGenreRepository)(nil).SelectByQuery), query, pgnt)
+}
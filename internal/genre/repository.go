@@ -11,4 +11,5 @@ type GenreRepository interface {
 	SelectByID(genreID uint64) (*models.Genre, error)
 	SelectByName(name string) (*models.Genre, error)
 	SelectAll() ([]*models.Genre, error)
+	SelectByQuery(query string, pgnt *models.Pagination) ([]*models.Genre, error)
 }
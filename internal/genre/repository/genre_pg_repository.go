@@ -3,9 +3,13 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"strings"
+
 	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
 
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/genre"
+	queryBuilder "github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/query_builder"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
 )
 
@@ -146,3 +150,47 @@ func (gr *GenrePgRepository) SelectAll() ([]*models.Genre, error) {
 	}
 	return genres, nil
 }
+
+func (gr *GenrePgRepository) SelectByQuery(query string, pgnt *models.Pagination) ([]*models.Genre, error) {
+	var values []interface{}
+
+	selectQuery := fmt.Sprintf(`
+		SELECT id, name
+		FROM genres
+		WHERE %s
+		ORDER BY word_similarity($1, name) DESC, id`,
+		queryBuilder.BuildNameSearchCondition("name", "russian", 1))
+	values = append(values, query)
+
+	var pgntQuery string
+	if pgnt.Count != 0 {
+		pgntQuery = "LIMIT $2 OFFSET $3"
+		values = append(values, pgnt.Count, pgnt.From)
+	}
+
+	resultQuery := strings.Join([]string{
+		selectQuery,
+		pgntQuery,
+	}, " ")
+
+	rows, err := gr.dbConn.Query(resultQuery, values...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var genres []*models.Genre
+	for rows.Next() {
+		genre := &models.Genre{}
+		err := rows.Scan(&genre.ID, &genre.Name)
+		if err != nil {
+			return nil, err
+		}
+		genres = append(genres, genre)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return genres, nil
+}
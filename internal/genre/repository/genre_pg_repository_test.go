@@ -260,3 +260,35 @@ func TestGenrePgRepository_SelectAll_OK(t *testing.T) {
 		t.Errorf("there were unfulfilled expectations: %s", err)
 	}
 }
+
+func TestGenrePgRepository_SelectByQuery_OK(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	genres := []*models.Genre{
+		&models.Genre{
+			ID:   1,
+			Name: "Комедия",
+		},
+	}
+	pgnt := &models.Pagination{
+		From:  0,
+		Count: 10,
+	}
+	query := "комедии"
+
+	genrePgRep := NewGenrePgRepository(db)
+
+	mocks.MockGenreRepoSelectByQueryReturnRows(mock, query, pgnt, genres)
+	dbGenres, err := genrePgRep.SelectByQuery(query, pgnt)
+	assert.Equal(t, genres, dbGenres)
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,50 @@
+package helpers
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
+)
+
+// AdvisoryLock lets only one app instance run periodic work at a time
+type AdvisoryLock struct {
+	db  *sql.DB
+	key int64
+}
+
+func NewAdvisoryLock(db *sql.DB, key int64) *AdvisoryLock {
+	return &AdvisoryLock{
+		db:  db,
+		key: key,
+	}
+}
+
+// Do runs work if no other instance holds the lock
+// and reports whether the work was run
+func (al *AdvisoryLock) Do(work func()) (bool, error) {
+	ctx := context.Background()
+	// Session lock belongs to the connection, so it is taken and released on the same one
+	conn, err := al.db.Conn(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	var locked bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", al.key).
+		Scan(&locked); err != nil {
+		return false, err
+	}
+	if !locked {
+		return false, nil
+	}
+	defer func() {
+		if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", al.key); err != nil {
+			logger.Error(err)
+		}
+	}()
+
+	work()
+	return true, nil
+}
@@ -0,0 +1,59 @@
+package helpers
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdvisoryLock_Do_Locked(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT pg_try_advisory_lock`).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(true))
+	mock.ExpectExec(`SELECT pg_advisory_unlock`).
+		WithArgs(int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	worked := false
+	done, err := NewAdvisoryLock(db, 1).Do(func() {
+		worked = true
+	})
+	assert.NoError(t, err)
+	assert.True(t, done)
+	assert.True(t, worked)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestAdvisoryLock_Do_HeldByOther(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT pg_try_advisory_lock`).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(false))
+
+	done, err := NewAdvisoryLock(db, 1).Do(func() {
+		t.Error("work must not run")
+	})
+	assert.NoError(t, err)
+	assert.False(t, done)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -336,4 +336,160 @@ var Errors = map[ErrorCode]*Error{
 		Message:     "user should input protection code",
 		UserMessage: "Необходимо ввести код протекции",
 	},
+	CodeSubscriptionRequired: {
+		Code:        CodeSubscriptionRequired,
+		HTTPCode:    http.StatusPaymentRequired,
+		Message:     "subscription is required to access this content",
+		UserMessage: "Для просмотра необходима подписка",
+	},
+	CodeWrongVideoSignature: {
+		Code:        CodeWrongVideoSignature,
+		HTTPCode:    http.StatusForbidden,
+		Message:     "wrong video url signature",
+		UserMessage: "Ссылка на видео недействительна",
+	},
+	CodeVideoURLExpired: {
+		Code:        CodeVideoURLExpired,
+		HTTPCode:    http.StatusForbidden,
+		Message:     "video url expired",
+		UserMessage: "Срок действия ссылки на видео истёк",
+	},
+	CodeJobDoesNotExist: {
+		Code:        CodeJobDoesNotExist,
+		HTTPCode:    http.StatusNotFound,
+		Message:     "job does not exist",
+		UserMessage: "Задача не найдена",
+	},
+	CodeWrongWatchProgress: {
+		Code:        CodeWrongWatchProgress,
+		HTTPCode:    http.StatusBadRequest,
+		Message:     "wrong watch progress",
+		UserMessage: "Неверный прогресс просмотра",
+	},
+	CodeParsePaymentAmountError: {
+		Code:        CodeParsePaymentAmountError,
+		HTTPCode:    http.StatusBadRequest,
+		Message:     "unable to parse payment amount",
+		UserMessage: "Что-то пошло не так",
+	},
+	CodeWrongPaymentAmount: {
+		Code:        CodeWrongPaymentAmount,
+		HTTPCode:    http.StatusBadRequest,
+		Message:     "payment amount is less than plan price",
+		UserMessage: "Сумма платежа меньше стоимости подписки",
+	},
+	CodeWrongPaymentCurrency: {
+		Code:        CodeWrongPaymentCurrency,
+		HTTPCode:    http.StatusBadRequest,
+		Message:     "payment currency differs from plan currency",
+		UserMessage: "Неверная валюта платежа",
+	},
+	CodePaymentRejected: {
+		Code:        CodePaymentRejected,
+		HTTPCode:    http.StatusBadRequest,
+		Message:     "payment has been rejected",
+		UserMessage: "Платёж отклонён",
+	},
+	CodePlanDoesNotExist: {
+		Code:        CodePlanDoesNotExist,
+		HTTPCode:    http.StatusBadRequest,
+		Message:     "plan does not exist",
+		UserMessage: "Такого тарифа не существует",
+	},
+	CodePlanNameAlreadyExists: {
+		Code:        CodePlanNameAlreadyExists,
+		HTTPCode:    http.StatusBadRequest,
+		Message:     "plan with this name already exists",
+		UserMessage: "Данный тариф уже существует",
+	},
+	CodeParsePlanIDError: {
+		Code:        CodeParsePlanIDError,
+		HTTPCode:    http.StatusBadRequest,
+		Message:     "unable to parse planID",
+		UserMessage: "Что-то пошло не так",
+	},
+	CodeInvalidUserToken: {
+		Code:        CodeInvalidUserToken,
+		HTTPCode:    http.StatusBadRequest,
+		Message:     "user token is invalid or expired",
+		UserMessage: "Ссылка недействительна или устарела",
+	},
+	CodeEmailAlreadyVerified: {
+		Code:        CodeEmailAlreadyVerified,
+		HTTPCode:    http.StatusBadRequest,
+		Message:     "email is already verified",
+		UserMessage: "Почта уже подтверждена",
+	},
+	CodeSendMailError: {
+		Code:        CodeSendMailError,
+		HTTPCode:    http.StatusInternalServerError,
+		Message:     "unable to send mail",
+		UserMessage: "Не удалось отправить письмо",
+	},
+	CodeOIDCProviderDoesNotExist: {
+		Code:        CodeOIDCProviderDoesNotExist,
+		HTTPCode:    http.StatusNotFound,
+		Message:     "oidc provider does not exist",
+		UserMessage: "Вход через этот сервис недоступен",
+	},
+	CodeOIDCStateMismatch: {
+		Code:        CodeOIDCStateMismatch,
+		HTTPCode:    http.StatusBadRequest,
+		Message:     "oidc state is invalid or expired",
+		UserMessage: "Время входа истекло, попробуйте снова",
+	},
+	CodeOIDCAuthFailed: {
+		Code:        CodeOIDCAuthFailed,
+		HTTPCode:    http.StatusUnauthorized,
+		Message:     "oidc authentication failed",
+		UserMessage: "Не удалось войти через внешний сервис",
+	},
+	CodeOIDCEmailRequired: {
+		Code:        CodeOIDCEmailRequired,
+		HTTPCode:    http.StatusBadRequest,
+		Message:     "oidc provider didn't return email",
+		UserMessage: "Сервис не предоставил адрес почты",
+	},
+	CodeTooManyAttempts: {
+		Code:        CodeTooManyAttempts,
+		HTTPCode:    http.StatusTooManyRequests,
+		Message:     "too many attempts",
+		UserMessage: "Слишком много попыток, попробуйте позже",
+	},
+	CodeWrongPublishTime: {
+		Code:        CodeWrongPublishTime,
+		HTTPCode:    http.StatusBadRequest,
+		Message:     "scheduled content requires publish time in the future",
+		UserMessage: "Укажите время публикации в будущем",
+	},
+	CodeTrashItemDoesNotExist: {
+		Code:        CodeTrashItemDoesNotExist,
+		HTTPCode:    http.StatusNotFound,
+		Message:     "trash item does not exist",
+		UserMessage: "Удалённая запись не найдена",
+	},
+	CodeRevisionDoesNotExist: {
+		Code:        CodeRevisionDoesNotExist,
+		HTTPCode:    http.StatusNotFound,
+		Message:     "content revision does not exist",
+		UserMessage: "Версия не найдена",
+	},
+	CodeOIDCAccountNotVerified: {
+		Code:        CodeOIDCAccountNotVerified,
+		HTTPCode:    http.StatusConflict,
+		Message:     "account with oidc email is not verified",
+		UserMessage: "Подтвердите почту аккаунта, чтобы войти через этот сервис",
+	},
+	CodeOIDCEmailNotVerified: {
+		Code:        CodeOIDCEmailNotVerified,
+		HTTPCode:    http.StatusForbidden,
+		Message:     "oidc email is not verified",
+		UserMessage: "Подтвердите почту в сервисе, через который выполняется вход",
+	},
+	CodePlanHasPayments: {
+		Code:        CodePlanHasPayments,
+		HTTPCode:    http.StatusConflict,
+		Message:     "plan has payments",
+		UserMessage: "Тариф уже оплачивали, его нельзя удалить",
+	},
 }
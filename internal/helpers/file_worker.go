@@ -12,6 +12,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 
 	. "github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
 	cstm_errors "github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
@@ -21,6 +22,11 @@ import (
 	uuid "github.com/satori/go.uuid"
 )
 
+const (
+	tmpFileSuffix    = ".tmp"
+	stagedFileSuffix = ".upload"
+)
+
 var allowedImagesContentType = map[string]string{
 	"image/png":  "png",
 	"image/jpg":  "jpg",
@@ -31,6 +37,12 @@ var allowedVideoContentType = map[string]string{
 	"video/mp4": "mp4",
 }
 
+var streamingVideoContentType = map[string]string{
+	"video/mp4":                     "mp4",
+	"application/vnd.apple.mpegurl": "m3u8",
+	"video/mp2t":                    "ts",
+}
+
 func StoreFileWithCompression(fileHeader *multipart.FileHeader, absFilePath string, width, height uint) *cstm_errors.Error {
 	file, err := fileHeader.Open()
 	if err != nil {
@@ -38,6 +50,20 @@ func StoreFileWithCompression(fileHeader *multipart.FileHeader, absFilePath stri
 	}
 	defer file.Close()
 
+	return compressImage(file, absFilePath, width, height)
+}
+
+func StoreStagedFileWithCompression(absStagedPath, absFilePath string, width, height uint) *cstm_errors.Error {
+	file, err := os.Open(filepath.Clean(absStagedPath))
+	if err != nil {
+		return cstm_errors.New(CodeInternalError, err)
+	}
+	defer file.Close()
+
+	return compressImage(file, absFilePath, width, height)
+}
+
+func compressImage(file io.Reader, absFilePath string, width, height uint) *cstm_errors.Error {
 	// Get image
 	img, _, err := image.Decode(file)
 	if err != nil {
@@ -47,9 +73,10 @@ func StoreFileWithCompression(fileHeader *multipart.FileHeader, absFilePath stri
 	// Resize
 	resizedImage := resize.Resize(width, height, img, resize.Lanczos3)
 
-	// Create file to storage
+	// Create temporary file, so the failure doesn't corrupt existing image
+	absTmpPath := absFilePath + tmpFileSuffix
 	fileMode := int(0777)
-	newFile, err := os.OpenFile(filepath.Clean(absFilePath), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, os.FileMode(fileMode))
+	newFile, err := os.OpenFile(filepath.Clean(absTmpPath), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, os.FileMode(fileMode))
 	if err != nil {
 		return cstm_errors.New(CodeInternalError, err)
 	}
@@ -57,6 +84,11 @@ func StoreFileWithCompression(fileHeader *multipart.FileHeader, absFilePath stri
 
 	// Compress
 	if err := webpbin.Encode(newFile, resizedImage); err != nil {
+		removeFile(absTmpPath)
+		return cstm_errors.New(CodeInternalError, err)
+	}
+	if err := os.Rename(absTmpPath, absFilePath); err != nil {
+		removeFile(absTmpPath)
 		return cstm_errors.New(CodeInternalError, err)
 	}
 	return nil
@@ -70,6 +102,24 @@ func StoreLargeImage(fileHeader *multipart.FileHeader, absFilePath string) *cstm
 	return StoreFileWithCompression(fileHeader, absFilePath, LargeImageWidth, 0)
 }
 
+func StoreStagedSmallImage(absStagedPath, absFilePath string) *cstm_errors.Error {
+	return StoreStagedFileWithCompression(absStagedPath, absFilePath, SmallImageWidth, 0)
+}
+
+func StoreStagedLargeImage(absStagedPath, absFilePath string) *cstm_errors.Error {
+	return StoreStagedFileWithCompression(absStagedPath, absFilePath, LargeImageWidth, 0)
+}
+
+// GetStagedPath returns path to keep the upload until background job processes it
+func GetStagedPath(filePath string) string {
+	return filePath + stagedFileSuffix
+}
+
+// RemoveStagedFile is called when the job is done or won't be retried
+func RemoveStagedFile(absStagedPath string) {
+	removeFile(absStagedPath)
+}
+
 func StoreFile(fileHeader *multipart.FileHeader, absFilePath string) *cstm_errors.Error {
 	file, err := fileHeader.Open()
 	if err != nil {
@@ -116,6 +166,16 @@ func GetImageExtension(image *multipart.FileHeader) (string, error) {
 	return extension, nil
 }
 
+func GetVideoContentType(fileExtension string) (string, bool) {
+	fileExtension = strings.TrimPrefix(fileExtension, ".")
+	for contentType, extension := range streamingVideoContentType {
+		if extension == fileExtension {
+			return contentType, true
+		}
+	}
+	return "", false
+}
+
 func GetUniqFileName(userID uint64, fileExtension string) string {
 	randString := uuid.NewV4().String()
 	return "userid_" + strconv.Itoa(int(userID)) + "_" + randString + "." + fileExtension
@@ -135,6 +195,12 @@ func InitTree(path string) {
 	}
 }
 
+func removeFile(absFilePath string) {
+	if err := os.Remove(absFilePath); err != nil && !os.IsNotExist(err) {
+		logger.Error(err)
+	}
+}
+
 func checkFileContentType(file *multipart.FileHeader, allowedContentTypes map[string]string) *cstm_errors.Error {
 	// Check content type from header
 	if !isAllowedFileHeader(file, allowedContentTypes) {
@@ -0,0 +1,220 @@
+package helpers
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	. "github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
+)
+
+const (
+	hlsDirSuffix     = "_hls"
+	oldHLSDirSuffix  = ".old"
+	hlsPlaylistName  = "index.m3u8"
+	hlsSegmentName   = "segment_%03d.ts"
+	hlsSegmentLength = 6
+)
+
+type HLSRendition struct {
+	Name         string
+	Height       int
+	VideoBitrate int
+	AudioBitrate int
+}
+
+var hlsRenditions = []HLSRendition{
+	{Name: "360p", Height: 360, VideoBitrate: 800000, AudioBitrate: 96000},
+	{Name: "720p", Height: 720, VideoBitrate: 2800000, AudioBitrate: 128000},
+	{Name: "1080p", Height: 1080, VideoBitrate: 5000000, AudioBitrate: 192000},
+}
+
+type HLSPackager struct {
+	ffmpegPath  string
+	renditions  []HLSRendition
+	execCommand func(name string, arg ...string) *exec.Cmd
+}
+
+func NewHLSPackager(ffmpegPath string) *HLSPackager {
+	return &HLSPackager{
+		ffmpegPath:  ffmpegPath,
+		renditions:  hlsRenditions,
+		execCommand: exec.Command,
+	}
+}
+
+// Package segments source mp4 into HLS renditions near the video file
+// and writes master playlist: /videos/name_cid/movie_hls/index.m3u8.
+// Renditions are built in temporary directory, so previous HLS output
+// keeps being served until the new one is complete
+func (hp *HLSPackager) Package(absSrcPath, absVideoPath string) *errors.Error {
+	hlsDirPath := getHLSDirPath(absVideoPath)
+	tmpDirPath := hlsDirPath + tmpFileSuffix
+	// Remove leftovers of interrupted packaging
+	if err := os.RemoveAll(tmpDirPath); err != nil {
+		return errors.New(CodeInternalError, err)
+	}
+	InitTree(tmpDirPath)
+
+	for _, rendition := range hp.renditions {
+		if err := hp.packageRendition(absSrcPath, tmpDirPath, rendition); err != nil {
+			hp.removeHLSDir(tmpDirPath)
+			return err
+		}
+	}
+
+	masterPlaylist := hp.buildMasterPlaylist()
+	absPlaylistPath := filepath.Join(tmpDirPath, hlsPlaylistName)
+	fileMode := int(0666)
+	if err := ioutil.WriteFile(absPlaylistPath, []byte(masterPlaylist), os.FileMode(fileMode)); err != nil {
+		hp.removeHLSDir(tmpDirPath)
+		return errors.New(CodeInternalError, err)
+	}
+
+	if err := hp.replaceHLSDir(tmpDirPath, hlsDirPath); err != nil {
+		hp.removeHLSDir(tmpDirPath)
+		return err
+	}
+	return nil
+}
+
+// StoreStaged packages staged video and moves it to storage.
+// Video moved by previous attempt isn't packaged again
+func (hp *HLSPackager) StoreStaged(absStagedPath, absVideoPath string) *errors.Error {
+	if _, err := os.Stat(absStagedPath); err == nil {
+		if err := hp.Package(absStagedPath, absVideoPath); err != nil {
+			return err
+		}
+		if err := os.Rename(absStagedPath, absVideoPath); err != nil {
+			return errors.New(CodeInternalError, err)
+		}
+	}
+	if _, err := os.Stat(absVideoPath); err != nil {
+		return errors.New(CodeInternalError, err)
+	}
+	return nil
+}
+
+// replaceHLSDir moves packaged directory in place of the previous one
+func (hp *HLSPackager) replaceHLSDir(tmpDirPath, hlsDirPath string) *errors.Error {
+	oldDirPath := hlsDirPath + oldHLSDirSuffix
+	if err := os.RemoveAll(oldDirPath); err != nil {
+		return errors.New(CodeInternalError, err)
+	}
+
+	hasOld := true
+	if err := os.Rename(hlsDirPath, oldDirPath); err != nil {
+		if !os.IsNotExist(err) {
+			return errors.New(CodeInternalError, err)
+		}
+		hasOld = false
+	}
+
+	if err := os.Rename(tmpDirPath, hlsDirPath); err != nil {
+		if hasOld {
+			if restoreErr := os.Rename(oldDirPath, hlsDirPath); restoreErr != nil {
+				logger.Error(restoreErr)
+			}
+		}
+		return errors.New(CodeInternalError, err)
+	}
+
+	if hasOld {
+		hp.removeHLSDir(oldDirPath)
+	}
+	return nil
+}
+
+func (hp *HLSPackager) packageRendition(absSrcPath, hlsDirPath string, rendition HLSRendition) *errors.Error {
+	renditionDirPath := filepath.Join(hlsDirPath, rendition.Name)
+	InitTree(renditionDirPath)
+
+	cmd := hp.execCommand(hp.ffmpegPath,
+		"-y", "-loglevel", "error",
+		"-i", absSrcPath,
+		"-map", "0:v:0", "-map", "0:a:0?",
+		"-vf", "scale=-2:"+strconv.Itoa(rendition.Height),
+		"-c:v", "libx264", "-b:v", strconv.Itoa(rendition.VideoBitrate),
+		"-c:a", "aac", "-b:a", strconv.Itoa(rendition.AudioBitrate),
+		"-hls_time", strconv.Itoa(hlsSegmentLength),
+		"-hls_playlist_type", "vod",
+		"-hls_segment_filename", filepath.Join(renditionDirPath, hlsSegmentName),
+		filepath.Join(renditionDirPath, hlsPlaylistName))
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return errors.New(CodeInternalError,
+			fmt.Errorf("%s rendition packaging failed: %v: %s", rendition.Name, err, output))
+	}
+
+	if _, err := os.Stat(filepath.Join(renditionDirPath, hlsPlaylistName)); err != nil {
+		return errors.New(CodeInternalError, err)
+	}
+	return nil
+}
+
+func (hp *HLSPackager) buildMasterPlaylist() string {
+	var builder strings.Builder
+	builder.WriteString("#EXTM3U\n#EXT-X-VERSION:3\n")
+	for _, rendition := range hp.renditions {
+		bandwidth := rendition.VideoBitrate + rendition.AudioBitrate
+		builder.WriteString(fmt.Sprintf("#EXT-X-STREAM-INF:BANDWIDTH=%d,NAME=\"%s\"\n",
+			bandwidth, rendition.Name))
+		builder.WriteString(path.Join(rendition.Name, hlsPlaylistName) + "\n")
+	}
+	return builder.String()
+}
+
+func (hp *HLSPackager) removeHLSDir(hlsDirPath string) {
+	if err := os.RemoveAll(hlsDirPath); err != nil {
+		logger.Error(err)
+	}
+}
+
+func getHLSDirPath(videoPath string) string {
+	return strings.TrimSuffix(videoPath, filepath.Ext(videoPath)) + hlsDirSuffix
+}
+
+// GetPlaylistPath returns master playlist path of the video:
+// /videos/name_cid/movie.mp4 -> /videos/name_cid/movie_hls/index.m3u8
+func GetPlaylistPath(videoPath string) string {
+	return path.Join(getHLSDirPath(videoPath), hlsPlaylistName)
+}
+
+// GetPackagedPlaylist returns master playlist path of the video
+// if packaging has finished, HLS directory is swapped in only when
+// the master playlist is complete, so the playlist is the marker
+func GetPackagedPlaylist(videoPath string) (string, bool) {
+	wd, err := os.Getwd()
+	if err != nil {
+		logger.Error(err)
+		return "", false
+	}
+	return packagedPlaylist(wd, videoPath)
+}
+
+func packagedPlaylist(rootDir, videoPath string) (string, bool) {
+	playlistPath := GetPlaylistPath(videoPath)
+	if _, err := os.Stat(filepath.Join(rootDir, playlistPath)); err != nil {
+		return "", false
+	}
+	return playlistPath, true
+}
+
+// GetHLSDir returns HLS directory that contains file of the path
+func GetHLSDir(filePath string) (string, bool) {
+	elems := strings.Split(path.Clean("/"+filePath), "/")
+	for i := len(elems) - 1; i > 0; i-- {
+		if strings.HasSuffix(elems[i], hlsDirSuffix) {
+			return strings.Join(elems[:i+1], "/"), true
+		}
+	}
+	return "", false
+}
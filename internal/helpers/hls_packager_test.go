@@ -0,0 +1,282 @@
+package helpers
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	fixtureVideo     = "testdata/video.mp4"
+	fixtureRendition = "testdata/rendition.m3u8"
+	fixtureMaster    = "testdata/master.m3u8"
+)
+
+// fakeFFmpeg runs TestFFmpegHelperProcess instead of real ffmpeg
+func fakeFFmpeg(fail bool) func(name string, arg ...string) *exec.Cmd {
+	return func(name string, arg ...string) *exec.Cmd {
+		args := append([]string{"-test.run=TestFFmpegHelperProcess", "--"}, arg...)
+		cmd := exec.Command(os.Args[0], args...)
+		cmd.Env = append(os.Environ(), "GO_WANT_FFMPEG_HELPER_PROCESS=1")
+		if fail {
+			cmd.Env = append(cmd.Env, "FFMPEG_HELPER_FAIL=1")
+		}
+		return cmd
+	}
+}
+
+// TestFFmpegHelperProcess segments input into a single fixture segment
+func TestFFmpegHelperProcess(t *testing.T) {
+	if os.Getenv("GO_WANT_FFMPEG_HELPER_PROCESS") != "1" {
+		return
+	}
+	if os.Getenv("FFMPEG_HELPER_FAIL") == "1" {
+		fmt.Fprint(os.Stderr, "Invalid data found when processing input")
+		os.Exit(1)
+	}
+
+	args := os.Args
+	for len(args) > 0 && args[0] != "--" {
+		args = args[1:]
+	}
+	args = args[1:]
+
+	var input, segmentPattern string
+	for i := 0; i < len(args)-1; i++ {
+		switch args[i] {
+		case "-i":
+			input = args[i+1]
+		case "-hls_segment_filename":
+			segmentPattern = args[i+1]
+		}
+	}
+	playlist := args[len(args)-1]
+
+	video, err := ioutil.ReadFile(input)
+	if err != nil || len(video) < 8 || string(video[4:8]) != "ftyp" {
+		fmt.Fprint(os.Stderr, "Invalid data found when processing input")
+		os.Exit(1)
+	}
+	rendition, err := ioutil.ReadFile(fixtureRendition)
+	if err != nil {
+		os.Exit(2)
+	}
+	if err := ioutil.WriteFile(fmt.Sprintf(segmentPattern, 0), video, 0666); err != nil {
+		os.Exit(2)
+	}
+	if err := ioutil.WriteFile(playlist, rendition, 0666); err != nil {
+		os.Exit(2)
+	}
+	os.Exit(0)
+}
+
+func copyFixtureVideo(t *testing.T, fixture string) (string, func()) {
+	dir, err := ioutil.TempDir("", "shrek_1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	video, err := ioutil.ReadFile(fixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	absVideoPath := filepath.Join(dir, "movie.mp4")
+	if err := ioutil.WriteFile(absVideoPath, video, 0666); err != nil {
+		t.Fatal(err)
+	}
+	return absVideoPath, func() {
+		os.RemoveAll(dir)
+	}
+}
+
+func TestHLSPackager_Package_OK(t *testing.T) {
+	t.Parallel()
+	absVideoPath, cleanup := copyFixtureVideo(t, fixtureVideo)
+	defer cleanup()
+
+	packager := NewHLSPackager("ffmpeg")
+	packager.execCommand = fakeFFmpeg(false)
+
+	err := packager.Package(absVideoPath, absVideoPath)
+	assert.Equal(t, err, (*errors.Error)(nil))
+
+	hlsDir := filepath.Join(filepath.Dir(absVideoPath), "movie_hls")
+	master, _ := ioutil.ReadFile(filepath.Join(hlsDir, "index.m3u8"))
+	expMaster, _ := ioutil.ReadFile(fixtureMaster)
+	assert.Equal(t, string(expMaster), string(master))
+
+	expRendition, _ := ioutil.ReadFile(fixtureRendition)
+	video, _ := ioutil.ReadFile(absVideoPath)
+	for _, rendition := range hlsRenditions {
+		playlist, _ := ioutil.ReadFile(filepath.Join(hlsDir, rendition.Name, "index.m3u8"))
+		assert.Equal(t, string(expRendition), string(playlist))
+
+		segment, _ := ioutil.ReadFile(filepath.Join(hlsDir, rendition.Name, "segment_000.ts"))
+		assert.Equal(t, video, segment)
+	}
+}
+
+func TestHLSPackager_Package_RemovesOldHLS(t *testing.T) {
+	t.Parallel()
+	absVideoPath, cleanup := copyFixtureVideo(t, fixtureVideo)
+	defer cleanup()
+
+	staleSegment := filepath.Join(filepath.Dir(absVideoPath), "movie_hls", "480p", "segment_000.ts")
+	InitTree(filepath.Dir(staleSegment))
+	if err := ioutil.WriteFile(staleSegment, []byte("stale"), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	packager := NewHLSPackager("ffmpeg")
+	packager.execCommand = fakeFFmpeg(false)
+
+	err := packager.Package(absVideoPath, absVideoPath)
+	assert.Equal(t, err, (*errors.Error)(nil))
+
+	_, statErr := os.Stat(staleSegment)
+	assert.True(t, os.IsNotExist(statErr))
+}
+
+func TestHLSPackager_Package_InvalidVideo(t *testing.T) {
+	t.Parallel()
+	logger.DisableLogger()
+	absVideoPath, cleanup := copyFixtureVideo(t, fixtureRendition)
+	defer cleanup()
+
+	packager := NewHLSPackager("ffmpeg")
+	packager.execCommand = fakeFFmpeg(false)
+
+	err := packager.Package(absVideoPath, absVideoPath)
+	assert.NotNil(t, err)
+	assert.Equal(t, consts.CodeInternalError, err.Code)
+
+	_, statErr := os.Stat(filepath.Join(filepath.Dir(absVideoPath), "movie_hls"))
+	assert.True(t, os.IsNotExist(statErr))
+}
+
+func TestHLSPackager_Package_FFmpegFailed(t *testing.T) {
+	t.Parallel()
+	logger.DisableLogger()
+	absVideoPath, cleanup := copyFixtureVideo(t, fixtureVideo)
+	defer cleanup()
+
+	packager := NewHLSPackager("ffmpeg")
+	packager.execCommand = fakeFFmpeg(true)
+
+	err := packager.Package(absVideoPath, absVideoPath)
+	assert.NotNil(t, err)
+	assert.Equal(t, consts.CodeInternalError, err.Code)
+
+	_, statErr := os.Stat(filepath.Join(filepath.Dir(absVideoPath), "movie_hls"))
+	assert.True(t, os.IsNotExist(statErr))
+}
+
+func TestGetPlaylistPath(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "/videos/shrek_1/movie_hls/index.m3u8",
+		GetPlaylistPath("/videos/shrek_1/movie.mp4"))
+	assert.Equal(t, "/videos/friends_2/1/3_hls/index.m3u8",
+		GetPlaylistPath("/videos/friends_2/1/3.mp4"))
+}
+
+func TestPackagedPlaylist(t *testing.T) {
+	t.Parallel()
+	absVideoPath, cleanup := copyFixtureVideo(t, fixtureVideo)
+	defer cleanup()
+	// Video path is relative to the root like /shrek_1/movie.mp4
+	rootDir := filepath.Dir(filepath.Dir(absVideoPath))
+	videoPath := "/" + filepath.Join(filepath.Base(filepath.Dir(absVideoPath)), "movie.mp4")
+
+	_, ok := packagedPlaylist(rootDir, videoPath)
+	assert.False(t, ok)
+
+	packager := NewHLSPackager("ffmpeg")
+	packager.execCommand = fakeFFmpeg(false)
+	err := packager.Package(absVideoPath, absVideoPath)
+	assert.Equal(t, err, (*errors.Error)(nil))
+
+	playlistPath, ok := packagedPlaylist(rootDir, videoPath)
+	assert.True(t, ok)
+	assert.Equal(t, GetPlaylistPath(videoPath), playlistPath)
+}
+
+func TestGetHLSDir(t *testing.T) {
+	t.Parallel()
+	hlsDir, ok := GetHLSDir("/videos/shrek_1/movie_hls/720p/segment_001.ts")
+	assert.True(t, ok)
+	assert.Equal(t, "/videos/shrek_1/movie_hls", hlsDir)
+
+	_, ok = GetHLSDir("/videos/shrek_1/movie.mp4")
+	assert.False(t, ok)
+}
+
+func TestHLSPackager_Package_FailedKeepsOldHLS(t *testing.T) {
+	t.Parallel()
+	logger.DisableLogger()
+	absVideoPath, cleanup := copyFixtureVideo(t, fixtureVideo)
+	defer cleanup()
+
+	hlsDir := filepath.Join(filepath.Dir(absVideoPath), "movie_hls")
+	oldPlaylist := filepath.Join(hlsDir, "index.m3u8")
+	InitTree(hlsDir)
+	if err := ioutil.WriteFile(oldPlaylist, []byte("old"), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	packager := NewHLSPackager("ffmpeg")
+	packager.execCommand = fakeFFmpeg(true)
+
+	err := packager.Package(absVideoPath, absVideoPath)
+	assert.NotNil(t, err)
+
+	// Previous output is still served
+	playlist, _ := ioutil.ReadFile(oldPlaylist)
+	assert.Equal(t, "old", string(playlist))
+
+	_, statErr := os.Stat(hlsDir + ".tmp")
+	assert.True(t, os.IsNotExist(statErr))
+}
+
+func TestHLSPackager_StoreStaged_OK(t *testing.T) {
+	t.Parallel()
+	absStagedPath, cleanup := copyFixtureVideo(t, fixtureVideo)
+	defer cleanup()
+	absVideoPath := filepath.Join(filepath.Dir(absStagedPath), "shrek.mp4")
+
+	packager := NewHLSPackager("ffmpeg")
+	packager.execCommand = fakeFFmpeg(false)
+
+	err := packager.StoreStaged(absStagedPath, absVideoPath)
+	assert.Equal(t, err, (*errors.Error)(nil))
+
+	_, statErr := os.Stat(absStagedPath)
+	assert.True(t, os.IsNotExist(statErr))
+	_, statErr = os.Stat(filepath.Join(filepath.Dir(absVideoPath), "shrek_hls", "index.m3u8"))
+	assert.Nil(t, statErr)
+
+	// Retry after failed update doesn't need staged video
+	packager.execCommand = fakeFFmpeg(true)
+	err = packager.StoreStaged(absStagedPath, absVideoPath)
+	assert.Equal(t, err, (*errors.Error)(nil))
+}
+
+func TestHLSPackager_StoreStaged_NoVideo(t *testing.T) {
+	t.Parallel()
+	absStagedPath, cleanup := copyFixtureVideo(t, fixtureVideo)
+	defer cleanup()
+	os.Remove(absStagedPath)
+
+	packager := NewHLSPackager("ffmpeg")
+	packager.execCommand = fakeFFmpeg(false)
+
+	err := packager.StoreStaged(absStagedPath, absStagedPath+".mp4")
+	assert.NotNil(t, err)
+	assert.Equal(t, consts.CodeInternalError, err.Code)
+}
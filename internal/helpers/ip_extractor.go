@@ -0,0 +1,22 @@
+package helpers
+
+import (
+	"net"
+
+	"github.com/labstack/echo/v4"
+)
+
+// NewIPExtractor takes client IP from X-Real-IP only if the request came
+// from one of the trusted proxies, otherwise the peer address is used,
+// so the client can't pass another IP in headers
+func NewIPExtractor(trustedProxies []*net.IPNet) echo.IPExtractor {
+	options := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+	for _, ipRange := range trustedProxies {
+		options = append(options, echo.TrustIPRange(ipRange))
+	}
+	return echo.ExtractIPFromRealIPHeader(options...)
+}
@@ -0,0 +1,33 @@
+package helpers
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIPExtractor(t *testing.T) {
+	t.Parallel()
+	_, proxies, err := net.ParseCIDR("127.0.0.1/32")
+	if err != nil {
+		t.Fatal(err)
+	}
+	extractIP := NewIPExtractor([]*net.IPNet{proxies})
+
+	// Header set by the trusted proxy
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/session", nil)
+	req.RemoteAddr = "127.0.0.1:41234"
+	req.Header.Set(echo.HeaderXRealIP, "203.0.113.7")
+	assert.Equal(t, "203.0.113.7", extractIP(req))
+
+	// Header spoofed by the client connected directly
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/session", nil)
+	req.RemoteAddr = "198.51.100.2:41234"
+	req.Header.Set(echo.HeaderXRealIP, "203.0.113.7")
+	req.Header.Set(echo.HeaderXForwardedFor, "203.0.113.8")
+	assert.Equal(t, "198.51.100.2", extractIP(req))
+}
@@ -1,6 +1,8 @@
 package helpers
 
 import (
+	"errors"
+	"path"
 	"strconv"
 	"strings"
 )
@@ -11,3 +13,19 @@ func GetContentDirTitle(originalName string, cid uint64) string {
 	title = strings.ToLower(title)
 	return title
 }
+
+func ParseContentDirTitle(title string) (uint64, error) {
+	sepIdx := strings.LastIndex(title, "_")
+	if sepIdx == -1 {
+		return 0, errors.New("content dir title has no content id")
+	}
+	return strconv.ParseUint(title[sepIdx+1:], 10, 64)
+}
+
+func GetContentIDFromVideoPath(videoPath string) (uint64, error) {
+	// /videos/lowercaseorigin_cid/... or lowercaseorigin_cid/...
+	rltPath := strings.TrimPrefix(path.Clean("/"+videoPath), "/")
+	rltPath = strings.TrimPrefix(rltPath, "videos/")
+	dirTitle := strings.Split(rltPath, "/")[0]
+	return ParseContentDirTitle(dirTitle)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
 )
 
@@ -53,7 +54,10 @@ func BuildYearCondition(valInd, valCount int) string {
 	return fmt.Sprintf("AND (%s)", resultCondition)
 }
 
-func GetContentJoinFiltersByParams(values []interface{}, params *models.ContentFilter) (string, []interface{}) {
+// GetContentJoinFiltersByParams returns filters of the content,
+// content in statuses is matched instead of the published content if statuses are set
+func GetContentJoinFiltersByParams(values []interface{}, params *models.ContentFilter,
+	statuses []string) (string, []interface{}) {
 	var filters []string
 
 	if params.Year != nil {
@@ -96,6 +100,91 @@ func GetContentJoinFiltersByParams(values []interface{}, params *models.ContentF
 		}
 	}
 
+	if statuses != nil {
+		filter := fmt.Sprintf("AND c.status IN %s AND c.deleted_at IS NULL",
+			BuildValuesQuery(len(values)+1, len(statuses)))
+		filters = append(filters, filter)
+		for _, status := range statuses {
+			values = append(values, status)
+		}
+	} else {
+		filters = append(filters, "AND "+BuildPublishedCondition())
+	}
+
 	filtersQuery := strings.Join(filters, " ")
 	return filtersQuery, values
 }
+
+// BuildPublishedCondition matches published content and scheduled content
+// whose publish time has come but the publisher hasn't flipped it yet,
+// content in the trash is never matched
+func BuildPublishedCondition() string {
+	return fmt.Sprintf("(c.deleted_at IS NULL AND (c.status='%s' OR (c.status='%s' AND c.publish_at<=now())))",
+		consts.ContentPublished, consts.ContentScheduled)
+}
+
+var similarEntities = []string{"genre", "actor", "director", "country"}
+
+// BuildSimilarQuery returns "similar" CTE with the score of the content
+// sharing links with the content $contentInd, weights of the links
+// are values from $weightInd in genre, actor, director, country order
+func BuildSimilarQuery(contentInd, weightInd int) string {
+	var overlaps []string
+	for i, entity := range similarEntities {
+		entityTable := fmt.Sprintf("content_%s", entity) // content_genre
+		entityID := fmt.Sprintf("%s_id", entity)         // genre_id
+
+		overlap := fmt.Sprintf(`
+			SELECT linked.content_id, $%d::real AS weight
+			FROM %s AS linked
+			JOIN %s AS src ON src.%s=linked.%s AND src.content_id=$%d`,
+			weightInd+i, entityTable, entityTable, entityID, entityID, contentInd)
+		overlaps = append(overlaps, overlap)
+	}
+
+	selectQuery := `
+		WITH overlaps AS (%s
+		), similar AS (
+			SELECT content_id, SUM(weight) AS score
+			FROM overlaps
+			WHERE content_id<>$%d
+			GROUP BY content_id
+		)`
+	return fmt.Sprintf(selectQuery, strings.Join(overlaps, "\n\t\t\tUNION ALL"), contentInd)
+}
+
+// BuildContentSearchQuery returns condition and relevance rank of the content
+// matching search query $queryInd by its text, by name with typos
+// or by its directors names
+func BuildContentSearchQuery(queryInd int) (string, string) {
+	tsQuery := fmt.Sprintf("(plainto_tsquery('russian', $%d) || plainto_tsquery('english', $%d))",
+		queryInd, queryInd)
+	directorsQuery := `
+		SELECT %s
+		FROM content_director AS cd
+		JOIN directors AS d ON d.id=cd.director_id
+		WHERE cd.content_id=c.id AND %s`
+	directorCondition := BuildNameSearchCondition("d.name", "simple", queryInd)
+
+	condition := fmt.Sprintf(`(c.search_vector @@ %s
+		OR $%d <%% c.name OR $%d <%% c.original_name
+		OR EXISTS (%s))`,
+		tsQuery, queryInd, queryInd,
+		fmt.Sprintf(directorsQuery, "1", directorCondition))
+
+	rank := fmt.Sprintf(`(ts_rank(c.search_vector, %s)
+		+ GREATEST(word_similarity($%d, c.name), word_similarity($%d, c.original_name))
+		+ COALESCE((%s), 0) / 2)`,
+		tsQuery, queryInd, queryInd,
+		fmt.Sprintf(directorsQuery, fmt.Sprintf("MAX(word_similarity($%d, d.name))", queryInd),
+			directorCondition))
+	return condition, rank
+}
+
+// BuildNameSearchCondition matches name column by words
+// in the text search configuration or by trigrams to tolerate typos,
+// the condition is parenthesized to be safely joined with AND
+func BuildNameSearchCondition(column, config string, queryInd int) string {
+	return fmt.Sprintf("(to_tsvector('%s', %s) @@ plainto_tsquery('%s', $%d) OR $%d <%% %s)",
+		config, column, config, queryInd, queryInd, column)
+}
@@ -0,0 +1,15 @@
+package query_builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildNameSearchCondition(t *testing.T) {
+	t.Parallel()
+	condition := BuildNameSearchCondition("d.name", "simple", 2)
+	assert.Equal(t,
+		"(to_tsvector('simple', d.name) @@ plainto_tsquery('simple', $2) OR $2 <% d.name)",
+		condition)
+}
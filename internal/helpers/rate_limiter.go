@@ -0,0 +1,175 @@
+package helpers
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	. "github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+)
+
+// RateLimit allows IPRequests per IP and AccountRequests counted attempts
+// per account during the period, zero disables the bucket. Account that
+// exceeds its limit is locked out for Lockout
+type RateLimit struct {
+	IPRequests      int
+	AccountRequests int
+	Period          time.Duration
+	Lockout         time.Duration
+}
+
+type tokenBucket struct {
+	tokens      float64
+	updated     time.Time
+	lockedUntil time.Time
+}
+
+// RateLimiter keeps token buckets of one route group in memory
+type RateLimiter struct {
+	limit RateLimit
+	now   func() time.Time
+
+	mu             sync.Mutex
+	ipBuckets      map[string]*tokenBucket
+	accountBuckets map[string]*tokenBucket
+	lastSweep      time.Time
+}
+
+func NewRateLimiter(limit RateLimit) *RateLimiter {
+	return &RateLimiter{
+		limit:          limit,
+		now:            time.Now,
+		ipBuckets:      make(map[string]*tokenBucket),
+		accountBuckets: make(map[string]*tokenBucket),
+		lastSweep:      time.Now(),
+	}
+}
+
+// AllowIP takes a token of the IP, it returns zero duration
+// if the request is allowed or the time to wait otherwise
+func (rl *RateLimiter) AllowIP(ip string) time.Duration {
+	capacity := rl.limit.IPRequests
+	if capacity <= 0 || rl.limit.Period <= 0 {
+		return 0
+	}
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	now := rl.now()
+	bucket := rl.bucket(rl.ipBuckets, ip, capacity, now)
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return 0
+	}
+	return rl.untilToken(bucket, capacity)
+}
+
+// AllowAccount checks the account identified by email without taking
+// a token, attempts are counted by FailAccount
+func (rl *RateLimiter) AllowAccount(email string) time.Duration {
+	email = normalizeEmail(email)
+	capacity := rl.limit.AccountRequests
+	if email == "" || capacity <= 0 || rl.limit.Period <= 0 {
+		return 0
+	}
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	// Accounts without counted attempts don't get a bucket
+	if _, ok := rl.accountBuckets[email]; !ok {
+		return 0
+	}
+
+	now := rl.now()
+	bucket := rl.bucket(rl.accountBuckets, email, capacity, now)
+	if now.Before(bucket.lockedUntil) {
+		return bucket.lockedUntil.Sub(now)
+	}
+	if bucket.tokens >= 1 {
+		return 0
+	}
+	return rl.untilToken(bucket, capacity)
+}
+
+// FailAccount takes a token of the account and locks the account
+// when there are no tokens left
+func (rl *RateLimiter) FailAccount(email string) {
+	email = normalizeEmail(email)
+	capacity := rl.limit.AccountRequests
+	if email == "" || capacity <= 0 || rl.limit.Period <= 0 {
+		return
+	}
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	now := rl.now()
+	bucket := rl.bucket(rl.accountBuckets, email, capacity, now)
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+	}
+	if bucket.tokens < 1 && rl.limit.Lockout > 0 {
+		bucket.lockedUntil = now.Add(rl.limit.Lockout)
+	}
+}
+
+// ResetAccount forgets counted attempts of the account, e.g. after
+// successful login
+func (rl *RateLimiter) ResetAccount(email string) {
+	email = normalizeEmail(email)
+	if email == "" {
+		return
+	}
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	delete(rl.accountBuckets, email)
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// bucket returns refilled bucket of the key, caller must hold the lock
+func (rl *RateLimiter) bucket(buckets map[string]*tokenBucket, key string,
+	capacity int, now time.Time) *tokenBucket {
+	if now.Sub(rl.lastSweep) > RateLimitSweepEvery {
+		rl.sweep(now)
+	}
+
+	bucket, ok := buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: float64(capacity), updated: now}
+		buckets[key] = bucket
+	}
+
+	bucket.tokens += float64(now.Sub(bucket.updated)) * rl.refillRate(capacity)
+	if bucket.tokens > float64(capacity) {
+		bucket.tokens = float64(capacity)
+	}
+	bucket.updated = now
+	return bucket
+}
+
+func (rl *RateLimiter) refillRate(capacity int) float64 {
+	return float64(capacity) / float64(rl.limit.Period)
+}
+
+func (rl *RateLimiter) untilToken(bucket *tokenBucket, capacity int) time.Duration {
+	return time.Duration((1 - bucket.tokens) / rl.refillRate(capacity))
+}
+
+// sweep removes refilled buckets, caller must hold the lock
+func (rl *RateLimiter) sweep(now time.Time) {
+	for _, buckets := range []map[string]*tokenBucket{rl.ipBuckets, rl.accountBuckets} {
+		for key, bucket := range buckets {
+			if now.Sub(bucket.updated) >= rl.limit.Period && !now.Before(bucket.lockedUntil) {
+				delete(buckets, key)
+			}
+		}
+	}
+	rl.lastSweep = now
+}
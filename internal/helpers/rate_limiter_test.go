@@ -0,0 +1,118 @@
+package helpers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (fc *fakeClock) Now() time.Time {
+	return fc.now
+}
+
+func newTestRateLimiter(limit RateLimit) (*RateLimiter, *fakeClock) {
+	clock := &fakeClock{now: time.Now()}
+	limiter := NewRateLimiter(limit)
+	limiter.now = clock.Now
+	return limiter, clock
+}
+
+func TestRateLimiter_AllowIP_Refill(t *testing.T) {
+	t.Parallel()
+	limiter, clock := newTestRateLimiter(RateLimit{
+		IPRequests: 2,
+		Period:     time.Minute,
+	})
+
+	assert.Zero(t, limiter.AllowIP("127.0.0.1"))
+	assert.Zero(t, limiter.AllowIP("127.0.0.1"))
+	assert.Equal(t, 30*time.Second, limiter.AllowIP("127.0.0.1"))
+	assert.Zero(t, limiter.AllowIP("127.0.0.2"))
+
+	clock.now = clock.now.Add(30 * time.Second)
+	assert.Zero(t, limiter.AllowIP("127.0.0.1"))
+	assert.NotZero(t, limiter.AllowIP("127.0.0.1"))
+}
+
+func TestRateLimiter_AllowAccount_Lockout(t *testing.T) {
+	t.Parallel()
+	limiter, clock := newTestRateLimiter(RateLimit{
+		AccountRequests: 1,
+		Period:          time.Minute,
+		Lockout:         15 * time.Minute,
+	})
+
+	assert.Zero(t, limiter.AllowAccount("user@mail.ru"))
+	limiter.FailAccount("user@mail.ru")
+	assert.Equal(t, 15*time.Minute, limiter.AllowAccount(" USER@mail.ru"))
+
+	// Refilled tokens don't unlock the account
+	clock.now = clock.now.Add(5 * time.Minute)
+	assert.Equal(t, 10*time.Minute, limiter.AllowAccount("user@mail.ru"))
+
+	clock.now = clock.now.Add(10 * time.Minute)
+	assert.Zero(t, limiter.AllowAccount("user@mail.ru"))
+}
+
+func TestRateLimiter_AllowAccount_OnlyFailuresCounted(t *testing.T) {
+	t.Parallel()
+	limiter, _ := newTestRateLimiter(RateLimit{
+		AccountRequests: 2,
+		Period:          time.Minute,
+		Lockout:         15 * time.Minute,
+	})
+
+	// Checks alone never lock the account
+	for i := 0; i < 5; i++ {
+		assert.Zero(t, limiter.AllowAccount("user@mail.ru"))
+	}
+	assert.NotContains(t, limiter.accountBuckets, "user@mail.ru")
+
+	// Success resets the failures
+	limiter.FailAccount("user@mail.ru")
+	limiter.ResetAccount("user@mail.ru")
+	limiter.FailAccount("user@mail.ru")
+	assert.Zero(t, limiter.AllowAccount("user@mail.ru"))
+
+	limiter.FailAccount("user@mail.ru")
+	assert.Equal(t, 15*time.Minute, limiter.AllowAccount("user@mail.ru"))
+}
+
+func TestRateLimiter_Disabled(t *testing.T) {
+	t.Parallel()
+	limiter, _ := newTestRateLimiter(RateLimit{
+		IPRequests: 1,
+		Period:     time.Minute,
+		Lockout:    time.Hour,
+	})
+
+	for i := 0; i < 3; i++ {
+		limiter.FailAccount("user@mail.ru")
+		assert.Zero(t, limiter.AllowAccount("user@mail.ru"))
+		assert.Zero(t, limiter.AllowAccount(""))
+	}
+}
+
+func TestRateLimiter_Sweep(t *testing.T) {
+	t.Parallel()
+	limiter, clock := newTestRateLimiter(RateLimit{
+		IPRequests:      1,
+		AccountRequests: 1,
+		Period:          time.Minute,
+		Lockout:         time.Hour,
+	})
+
+	limiter.AllowIP("127.0.0.1")
+	limiter.FailAccount("user@mail.ru")
+
+	clock.now = clock.now.Add(2 * time.Minute)
+	limiter.AllowIP("127.0.0.2")
+
+	assert.NotContains(t, limiter.ipBuckets, "127.0.0.1")
+	assert.Contains(t, limiter.accountBuckets, "user@mail.ru")
+}
@@ -0,0 +1,89 @@
+package helpers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	. "github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+)
+
+const (
+	videoUserIDParam    = "uid"
+	videoExpiresParam   = "expires"
+	videoSignatureParam = "signature"
+)
+
+type VideoURLSigner struct {
+	secret     []byte
+	expiresDur time.Duration
+}
+
+func NewVideoURLSigner(secret string) *VideoURLSigner {
+	return &VideoURLSigner{
+		secret:     []byte(secret),
+		expiresDur: VideoURLExpiresDuration,
+	}
+}
+
+// Sign returns video url that is valid only for the user until expiration
+func (vs *VideoURLSigner) Sign(videoPath string, userID uint64) string {
+	return videoPath + "?" + vs.signQuery(videoPath, userID)
+}
+
+// SignPlaylist signs the whole HLS directory of the playlist,
+// so the same query is valid for nested playlists and segments
+func (vs *VideoURLSigner) SignPlaylist(playlistPath string, userID uint64) string {
+	hlsDir, ok := GetHLSDir(playlistPath)
+	if !ok {
+		return vs.Sign(playlistPath, userID)
+	}
+	return playlistPath + "?" + vs.signQuery(hlsDir, userID)
+}
+
+func (vs *VideoURLSigner) Verify(videoPath string, userID uint64, query url.Values) *errors.Error {
+	signedUserID, err := strconv.ParseUint(query.Get(videoUserIDParam), 10, 64)
+	if err != nil {
+		return errors.New(CodeWrongVideoSignature, err)
+	}
+	expires, err := strconv.ParseInt(query.Get(videoExpiresParam), 10, 64)
+	if err != nil {
+		return errors.New(CodeWrongVideoSignature, err)
+	}
+	if signedUserID != userID {
+		return errors.Get(CodeWrongVideoSignature)
+	}
+
+	expected := vs.signature(videoPath, signedUserID, expires)
+	if !hmac.Equal([]byte(expected), []byte(query.Get(videoSignatureParam))) {
+		return errors.Get(CodeWrongVideoSignature)
+	}
+
+	if expires < time.Now().Unix() {
+		return errors.Get(CodeVideoURLExpired)
+	}
+	return nil
+}
+
+func (vs *VideoURLSigner) signQuery(resourcePath string, userID uint64) string {
+	expires := time.Now().Add(vs.expiresDur).Unix()
+
+	query := url.Values{}
+	query.Set(videoUserIDParam, strconv.FormatUint(userID, 10))
+	query.Set(videoExpiresParam, strconv.FormatInt(expires, 10))
+	query.Set(videoSignatureParam, vs.signature(resourcePath, userID, expires))
+	return query.Encode()
+}
+
+func (vs *VideoURLSigner) signature(videoPath string, userID uint64, expires int64) string {
+	data := fmt.Sprintf("%s:%d:%d", videoPath, userID, expires)
+	mac := hmac.New(sha256.New, vs.secret)
+	// nolint: errcheck
+	mac.Write([]byte(data))
+	return hex.EncodeToString(mac.Sum(nil))
+}
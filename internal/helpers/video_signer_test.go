@@ -0,0 +1,73 @@
+package helpers
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const videoPath = "/videos/shrek_1/movie.mp4"
+
+func getQuery(t *testing.T, signedURL string) url.Values {
+	parts := strings.SplitN(signedURL, "?", 2)
+	if len(parts) != 2 {
+		t.Fatal("signed url has no query")
+	}
+	query, err := url.ParseQuery(parts[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return query
+}
+
+func TestVideoURLSigner_Verify_OK(t *testing.T) {
+	t.Parallel()
+	signer := NewVideoURLSigner("secret")
+
+	signedURL := signer.Sign(videoPath, 3)
+	assert.True(t, strings.HasPrefix(signedURL, videoPath+"?"))
+
+	err := signer.Verify(videoPath, 3, getQuery(t, signedURL))
+	assert.Equal(t, err, (*errors.Error)(nil))
+}
+
+func TestVideoURLSigner_Verify_AnotherUser(t *testing.T) {
+	t.Parallel()
+	signer := NewVideoURLSigner("secret")
+
+	signedURL := signer.Sign(videoPath, 3)
+	err := signer.Verify(videoPath, 4, getQuery(t, signedURL))
+	assert.Equal(t, err, errors.Get(consts.CodeWrongVideoSignature))
+}
+
+func TestVideoURLSigner_Verify_AnotherVideo(t *testing.T) {
+	t.Parallel()
+	signer := NewVideoURLSigner("secret")
+
+	signedURL := signer.Sign(videoPath, 3)
+	err := signer.Verify("/videos/shrek_1/other.mp4", 3, getQuery(t, signedURL))
+	assert.Equal(t, err, errors.Get(consts.CodeWrongVideoSignature))
+}
+
+func TestVideoURLSigner_Verify_AnotherSecret(t *testing.T) {
+	t.Parallel()
+	signedURL := NewVideoURLSigner("secret").Sign(videoPath, 3)
+
+	err := NewVideoURLSigner("other").Verify(videoPath, 3, getQuery(t, signedURL))
+	assert.Equal(t, err, errors.Get(consts.CodeWrongVideoSignature))
+}
+
+func TestVideoURLSigner_Verify_Expired(t *testing.T) {
+	t.Parallel()
+	signer := NewVideoURLSigner("secret")
+	signer.expiresDur = -time.Minute
+
+	signedURL := signer.Sign(videoPath, 3)
+	err := signer.Verify(videoPath, 3, getQuery(t, signedURL))
+	assert.Equal(t, err, errors.Get(consts.CodeVideoURLExpired))
+}
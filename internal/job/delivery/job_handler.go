@@ -0,0 +1,51 @@
+package delivery
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/job"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/mwares"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
+	. "github.com/go-park-mail-ru/2020_2_Slash/tools/response"
+	"github.com/labstack/echo/v4"
+)
+
+type JobHandler struct {
+	jobUcase job.JobUsecase
+}
+
+func NewJobHandler(jobUcase job.JobUsecase) *JobHandler {
+	return &JobHandler{
+		jobUcase: jobUcase,
+	}
+}
+
+func (jh *JobHandler) Configure(e *echo.Echo, mw *mwares.MiddlewareManager) {
+	e.GET("/api/v1/jobs/:id", jh.GetJobHandler(), mw.CheckAuth, mw.CheckAdmin)
+}
+
+func (jh *JobHandler) GetJobHandler() echo.HandlerFunc {
+	return func(cntx echo.Context) error {
+		id, err := strconv.ParseUint(cntx.Param("id"), 10, 64)
+		if err != nil {
+			customErr := errors.New(consts.CodeBadRequest, err)
+			logger.Error(customErr.Message)
+			return cntx.JSON(customErr.HTTPCode, Response{Error: customErr})
+		}
+
+		job, customErr := jh.jobUcase.GetByID(id)
+		if customErr != nil {
+			logger.Error(customErr.Message)
+			return cntx.JSON(customErr.HTTPCode, Response{Error: customErr})
+		}
+
+		return cntx.JSON(http.StatusOK, Response{
+			Body: &Body{
+				"job": job,
+			},
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package delivery
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/job/mocks"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/pkg/converter"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/response"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobHandler_GetJobHandler(t *testing.T) {
+	t.Parallel()
+	// Setup
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	jobUseCase := mocks.NewMockJobUsecase(ctrl)
+
+	job := &models.Job{
+		ID:          3,
+		Type:        consts.JobMovieVideo,
+		Payload:     []byte(`{"movie_id":1}`),
+		State:       consts.JobFailed,
+		Error:       "movie does not exist",
+		Attempts:    3,
+		MaxAttempts: 3,
+	}
+
+	e := echo.New()
+	strId := strconv.Itoa(int(job.ID))
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/jobs/"+strId, strings.NewReader(""))
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(strId)
+
+	jobHandler := NewJobHandler(jobUseCase)
+	handleFunc := jobHandler.GetJobHandler()
+
+	jobUseCase.
+		EXPECT().
+		GetByID(job.ID).
+		Return(job, nil)
+
+	response := &response.Response{Body: &response.Body{"job": job}}
+
+	// Assertions
+	if assert.NoError(t, handleFunc(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		expResBody, err := converter.AnyToBytesBuffer(response)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		bytes, _ := ioutil.ReadAll(rec.Body)
+
+		assert.JSONEq(t, expResBody.String(), string(bytes))
+	}
+}
+
+func TestJobHandler_GetJobHandler_NoJob(t *testing.T) {
+	t.Parallel()
+	// Setup
+	logger.DisableLogger()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	jobUseCase := mocks.NewMockJobUsecase(ctrl)
+
+	e := echo.New()
+	var id uint64 = 3
+	strId := strconv.Itoa(int(id))
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/jobs/"+strId, strings.NewReader(""))
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(strId)
+
+	jobHandler := NewJobHandler(jobUseCase)
+	handleFunc := jobHandler.GetJobHandler()
+
+	customErr := errors.Get(consts.CodeJobDoesNotExist)
+	jobUseCase.
+		EXPECT().
+		GetByID(id).
+		Return(nil, customErr)
+
+	response := &response.Response{Error: customErr}
+
+	// Assertions
+	if assert.NoError(t, handleFunc(c)) {
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+
+		expResBody, err := converter.AnyToBytesBuffer(response)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		bytes, _ := ioutil.ReadAll(rec.Body)
+
+		assert.JSONEq(t, expResBody.String(), string(bytes))
+	}
+}
@@ -0,0 +1,82 @@
+package mocks
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+)
+
+var jobColumns = []string{"id", "type", "payload", "state", "error", "attempts",
+	"max_attempts", "run_at", "created", "updated"}
+
+func jobRow(job *models.Job) *sqlmock.Rows {
+	rows := sqlmock.NewRows(jobColumns)
+	rows.AddRow(job.ID, job.Type, []byte(job.Payload), job.State, job.Error, job.Attempts,
+		job.MaxAttempts, job.RunAt, job.Created, job.Updated)
+	return rows
+}
+
+func MockJobRepoInsertReturnRows(mock sqlmock.Sqlmock, job *models.Job) {
+	mock.ExpectBegin()
+	insertAnswer := sqlmock.NewRows([]string{"id"}).AddRow(job.ID)
+	mock.ExpectQuery(`INSERT INTO jobs`).
+		WithArgs(job.Type, []byte(job.Payload), job.State, job.Error, job.Attempts,
+			job.MaxAttempts, job.RunAt, job.Created, job.Updated).
+		WillReturnRows(insertAnswer)
+	mock.ExpectCommit()
+}
+
+func MockJobRepoInsertReturnErr(mock sqlmock.Sqlmock, job *models.Job) {
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO jobs`).
+		WithArgs(job.Type, []byte(job.Payload), job.State, job.Error, job.Attempts,
+			job.MaxAttempts, job.RunAt, job.Created, job.Updated).
+		WillReturnError(errors.New("insert error"))
+	mock.ExpectRollback()
+}
+
+func MockJobRepoUpdateReturnResultOk(mock sqlmock.Sqlmock, job *models.Job) {
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE jobs`).
+		WithArgs(job.ID, job.State, job.Error, job.Attempts, job.RunAt, job.Updated).
+		WillReturnResult(sqlmock.NewResult(int64(job.ID), 1))
+	mock.ExpectCommit()
+}
+
+func MockJobRepoSelectByIDReturnRows(mock sqlmock.Sqlmock, job *models.Job) {
+	mock.ExpectQuery(`SELECT`).WithArgs(job.ID).WillReturnRows(jobRow(job))
+}
+
+func MockJobRepoSelectByIDReturnErrNoRows(mock sqlmock.Sqlmock, id uint64) {
+	mock.ExpectQuery(`SELECT`).WithArgs(id).WillReturnError(sql.ErrNoRows)
+}
+
+func MockJobRepoSelectNextQueuedReturnRows(mock sqlmock.Sqlmock, job *models.Job) {
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT (.+) FOR UPDATE SKIP LOCKED`).
+		WithArgs(job.State, sqlmock.AnyArg()).
+		WillReturnRows(jobRow(job))
+	mock.ExpectExec(`UPDATE jobs`).
+		WithArgs(job.ID, "running", job.Attempts+1, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(int64(job.ID), 1))
+	mock.ExpectCommit()
+}
+
+func MockJobRepoSelectNextQueuedReturnErrNoRows(mock sqlmock.Sqlmock) {
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT (.+) FOR UPDATE SKIP LOCKED`).
+		WithArgs("queued", sqlmock.AnyArg()).
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+}
+
+func MockJobRepoRequeueStaleReturnResult(mock sqlmock.Sqlmock, staleBefore time.Time, requeued int64) {
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE jobs`).
+		WithArgs("queued", "failed", sqlmock.AnyArg(), sqlmock.AnyArg(), "running", staleBefore).
+		WillReturnResult(sqlmock.NewResult(0, requeued))
+	mock.ExpectCommit()
+}
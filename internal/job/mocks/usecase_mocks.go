@@ -0,0 +1,123 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/job/usecase.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	errors "github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	models "github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockJobUsecase is a mock of JobUsecase interface
+type MockJobUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockJobUsecaseMockRecorder
+}
+
+// MockJobUsecaseMockRecorder is the mock recorder for MockJobUsecase
+type MockJobUsecaseMockRecorder struct {
+	mock *MockJobUsecase
+}
+
+// NewMockJobUsecase creates a new mock instance
+func NewMockJobUsecase(ctrl *gomock.Controller) *MockJobUsecase {
+	mock := &MockJobUsecase{ctrl: ctrl}
+	mock.recorder = &MockJobUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockJobUsecase) EXPECT() *MockJobUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Enqueue mocks base method
+func (m *MockJobUsecase) Enqueue(jobType string, payload interface{}) (*models.Job, *errors.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enqueue", jobType, payload)
+	ret0, _ := ret[0].(*models.Job)
+	ret1, _ := ret[1].(*errors.Error)
+	return ret0, ret1
+}
+
+// Enqueue indicates an expected call of Enqueue
+func (mr *MockJobUsecaseMockRecorder) Enqueue(jobType, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockJobUsecase)(nil).Enqueue), jobType, payload)
+}
+
+// GetByID mocks base method
+func (m *MockJobUsecase) GetByID(jobID uint64) (*models.Job, *errors.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", jobID)
+	ret0, _ := ret[0].(*models.Job)
+	ret1, _ := ret[1].(*errors.Error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID
+func (mr *MockJobUsecaseMockRecorder) GetByID(jobID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockJobUsecase)(nil).GetByID), jobID)
+}
+
+// Acquire mocks base method
+func (m *MockJobUsecase) Acquire() (*models.Job, *errors.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Acquire")
+	ret0, _ := ret[0].(*models.Job)
+	ret1, _ := ret[1].(*errors.Error)
+	return ret0, ret1
+}
+
+// Acquire indicates an expected call of Acquire
+func (mr *MockJobUsecaseMockRecorder) Acquire() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Acquire", reflect.TypeOf((*MockJobUsecase)(nil).Acquire))
+}
+
+// Complete mocks base method
+func (m *MockJobUsecase) Complete(job *models.Job) *errors.Error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", job)
+	ret0, _ := ret[0].(*errors.Error)
+	return ret0
+}
+
+// Complete indicates an expected call of Complete
+func (mr *MockJobUsecaseMockRecorder) Complete(job interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockJobUsecase)(nil).Complete), job)
+}
+
+// Fail mocks base method
+func (m *MockJobUsecase) Fail(job *models.Job, jobErr *errors.Error) *errors.Error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fail", job, jobErr)
+	ret0, _ := ret[0].(*errors.Error)
+	return ret0
+}
+
+// Fail indicates an expected call of Fail
+func (mr *MockJobUsecaseMockRecorder) Fail(job, jobErr interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fail", reflect.TypeOf((*MockJobUsecase)(nil).Fail), job, jobErr)
+}
+
+// RequeueStale mocks base method
+func (m *MockJobUsecase) RequeueStale() (int64, *errors.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequeueStale")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(*errors.Error)
+	return ret0, ret1
+}
+
+// RequeueStale indicates an expected call of RequeueStale
+func (mr *MockJobUsecaseMockRecorder) RequeueStale() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequeueStale", reflect.TypeOf((*MockJobUsecase)(nil).RequeueStale))
+}
@@ -0,0 +1,15 @@
+package job
+
+import (
+	"time"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+)
+
+type JobRepository interface {
+	Insert(job *models.Job) error
+	Update(job *models.Job) error
+	SelectByID(jobID uint64) (*models.Job, error)
+	SelectNextQueued() (*models.Job, error)
+	RequeueStale(staleBefore time.Time) (int64, error)
+}
@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	. "github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/job"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
+)
+
+type JobPgRepository struct {
+	dbConn *sql.DB
+}
+
+func NewJobPgRepository(conn *sql.DB) job.JobRepository {
+	return &JobPgRepository{
+		dbConn: conn,
+	}
+}
+
+func (jr *JobPgRepository) Insert(job *models.Job) error {
+	tx, err := jr.dbConn.BeginTx(context.Background(), &sql.TxOptions{})
+	if err != nil {
+		return err
+	}
+
+	row := tx.QueryRow(
+		`INSERT INTO jobs(type, payload, state, error, attempts, max_attempts, run_at, created, updated)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id`,
+		job.Type, []byte(job.Payload), job.State, job.Error, job.Attempts,
+		job.MaxAttempts, job.RunAt, job.Created, job.Updated)
+
+	err = row.Scan(&job.ID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			logger.Error(rollbackErr.Error())
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (jr *JobPgRepository) Update(job *models.Job) error {
+	tx, err := jr.dbConn.BeginTx(context.Background(), &sql.TxOptions{})
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(
+		`UPDATE jobs
+		SET state = $2, error = $3, attempts = $4, run_at = $5, updated = $6
+		WHERE id = $1`,
+		job.ID, job.State, job.Error, job.Attempts, job.RunAt, job.Updated)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			logger.Error(rollbackErr.Error())
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (jr *JobPgRepository) SelectByID(jobID uint64) (*models.Job, error) {
+	job := &models.Job{}
+
+	row := jr.dbConn.QueryRow(
+		`SELECT id, type, payload, state, error, attempts, max_attempts, run_at, created, updated
+		FROM jobs
+		WHERE id=$1`,
+		jobID)
+
+	err := row.Scan(&job.ID, &job.Type, &job.Payload, &job.State, &job.Error,
+		&job.Attempts, &job.MaxAttempts, &job.RunAt, &job.Created, &job.Updated)
+	if err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// SelectNextQueued takes the oldest ready job and marks it as running,
+// SKIP LOCKED lets several workers poll the queue concurrently
+func (jr *JobPgRepository) SelectNextQueued() (*models.Job, error) {
+	tx, err := jr.dbConn.BeginTx(context.Background(), &sql.TxOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	job := &models.Job{}
+	row := tx.QueryRow(
+		`SELECT id, type, payload, state, error, attempts, max_attempts, run_at, created, updated
+		FROM jobs
+		WHERE state=$1 AND run_at <= $2
+		ORDER BY run_at, id
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED`,
+		JobQueued, time.Now())
+
+	err = row.Scan(&job.ID, &job.Type, &job.Payload, &job.State, &job.Error,
+		&job.Attempts, &job.MaxAttempts, &job.RunAt, &job.Created, &job.Updated)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			logger.Error(rollbackErr.Error())
+		}
+		return nil, err
+	}
+
+	job.State = JobRunning
+	job.Attempts++
+	job.Updated = time.Now()
+	_, err = tx.Exec(
+		`UPDATE jobs
+		SET state = $2, attempts = $3, updated = $4
+		WHERE id = $1`,
+		job.ID, job.State, job.Attempts, job.Updated)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			logger.Error(rollbackErr.Error())
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// RequeueStale puts running jobs not updated since staleBefore back to the queue,
+// job interrupted on the last attempt fails, returns the number of requeued jobs
+func (jr *JobPgRepository) RequeueStale(staleBefore time.Time) (int64, error) {
+	tx, err := jr.dbConn.BeginTx(context.Background(), &sql.TxOptions{})
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	result, err := tx.Exec(
+		`UPDATE jobs
+		SET state = CASE WHEN attempts < max_attempts THEN $1 ELSE $2 END,
+		error = $3, run_at = $4, updated = $4
+		WHERE state = $5 AND updated <= $6`,
+		JobQueued, JobFailed, JobInterruptedError, now, JobRunning, staleBefore)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			logger.Error(rollbackErr.Error())
+		}
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,193 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/job/mocks"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func newJob() *models.Job {
+	now := time.Now()
+	return &models.Job{
+		ID:          1,
+		Type:        consts.JobMovieVideo,
+		Payload:     []byte(`{"movie_id":1,"video_path":"/videos/shrek_1/movie.mp4"}`),
+		State:       consts.JobQueued,
+		MaxAttempts: consts.JobMaxAttempts,
+		RunAt:       now,
+		Created:     now,
+		Updated:     now,
+	}
+}
+
+func TestJobPgRepository_Insert_OK(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	job := newJob()
+	jobPgRep := NewJobPgRepository(db)
+
+	mocks.MockJobRepoInsertReturnRows(mock, job)
+	err = jobPgRep.Insert(job)
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestJobPgRepository_Insert_Fail(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	job := newJob()
+	jobPgRep := NewJobPgRepository(db)
+
+	mocks.MockJobRepoInsertReturnErr(mock, job)
+	err = jobPgRep.Insert(job)
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestJobPgRepository_Update_OK(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	job := newJob()
+	job.State = consts.JobDone
+	jobPgRep := NewJobPgRepository(db)
+
+	mocks.MockJobRepoUpdateReturnResultOk(mock, job)
+	err = jobPgRep.Update(job)
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestJobPgRepository_SelectByID_OK(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	job := newJob()
+	jobPgRep := NewJobPgRepository(db)
+
+	mocks.MockJobRepoSelectByIDReturnRows(mock, job)
+	dbJob, err := jobPgRep.SelectByID(job.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, job, dbJob)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestJobPgRepository_SelectByID_NoJob(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	jobPgRep := NewJobPgRepository(db)
+
+	mocks.MockJobRepoSelectByIDReturnErrNoRows(mock, 1)
+	dbJob, err := jobPgRep.SelectByID(1)
+	assert.Equal(t, sql.ErrNoRows, err)
+	assert.Nil(t, dbJob)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestJobPgRepository_SelectNextQueued_OK(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	job := newJob()
+	jobPgRep := NewJobPgRepository(db)
+
+	mocks.MockJobRepoSelectNextQueuedReturnRows(mock, job)
+	dbJob, err := jobPgRep.SelectNextQueued()
+	assert.NoError(t, err)
+	assert.Equal(t, job.ID, dbJob.ID)
+	assert.Equal(t, consts.JobRunning, dbJob.State)
+	assert.Equal(t, 1, dbJob.Attempts)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestJobPgRepository_SelectNextQueued_Empty(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	jobPgRep := NewJobPgRepository(db)
+
+	mocks.MockJobRepoSelectNextQueuedReturnErrNoRows(mock)
+	dbJob, err := jobPgRep.SelectNextQueued()
+	assert.Equal(t, sql.ErrNoRows, err)
+	assert.Nil(t, dbJob)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestJobPgRepository_RequeueStale_OK(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	jobPgRep := NewJobPgRepository(db)
+	staleBefore := time.Now().Add(-consts.JobRunningTimeout)
+
+	mocks.MockJobRepoRequeueStaleReturnResult(mock, staleBefore, 2)
+	requeued, err := jobPgRep.RequeueStale(staleBefore)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), requeued)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
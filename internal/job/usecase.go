@@ -0,0 +1,15 @@
+package job
+
+import (
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+)
+
+type JobUsecase interface {
+	Enqueue(jobType string, payload interface{}) (*models.Job, *errors.Error)
+	GetByID(jobID uint64) (*models.Job, *errors.Error)
+	Acquire() (*models.Job, *errors.Error)
+	Complete(job *models.Job) *errors.Error
+	Fail(job *models.Job, jobErr *errors.Error) *errors.Error
+	RequeueStale() (int64, *errors.Error)
+}
@@ -0,0 +1,105 @@
+package usecases
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	. "github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/job"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+)
+
+type JobUsecase struct {
+	jobRepo job.JobRepository
+}
+
+func NewJobUsecase(repo job.JobRepository) job.JobUsecase {
+	return &JobUsecase{
+		jobRepo: repo,
+	}
+}
+
+func (ju *JobUsecase) Enqueue(jobType string, payload interface{}) (*models.Job, *errors.Error) {
+	rawPayload, err := json.Marshal(payload)
+	if err != nil {
+		return nil, errors.New(CodeInternalError, err)
+	}
+
+	now := time.Now()
+	job := &models.Job{
+		Type:        jobType,
+		Payload:     rawPayload,
+		State:       JobQueued,
+		MaxAttempts: JobMaxAttempts,
+		RunAt:       now,
+		Created:     now,
+		Updated:     now,
+	}
+	if err := ju.jobRepo.Insert(job); err != nil {
+		return nil, errors.New(CodeInternalError, err)
+	}
+	return job, nil
+}
+
+func (ju *JobUsecase) GetByID(jobID uint64) (*models.Job, *errors.Error) {
+	job, err := ju.jobRepo.SelectByID(jobID)
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, errors.Get(CodeJobDoesNotExist)
+	case err != nil:
+		return nil, errors.New(CodeInternalError, err)
+	}
+	return job, nil
+}
+
+// Acquire returns nil job if there is nothing to process
+func (ju *JobUsecase) Acquire() (*models.Job, *errors.Error) {
+	job, err := ju.jobRepo.SelectNextQueued()
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, nil
+	case err != nil:
+		return nil, errors.New(CodeInternalError, err)
+	}
+	return job, nil
+}
+
+func (ju *JobUsecase) Complete(job *models.Job) *errors.Error {
+	job.State = JobDone
+	job.Error = ""
+	job.Updated = time.Now()
+	if err := ju.jobRepo.Update(job); err != nil {
+		return errors.New(CodeInternalError, err)
+	}
+	return nil
+}
+
+// Fail puts job back to the queue with delay growing with each attempt,
+// job fails permanently after the last attempt
+func (ju *JobUsecase) Fail(job *models.Job, jobErr *errors.Error) *errors.Error {
+	now := time.Now()
+	job.Error = jobErr.Message
+	job.Updated = now
+	if job.IsLastAttempt() {
+		job.State = JobFailed
+	} else {
+		job.State = JobQueued
+		job.RunAt = now.Add(time.Duration(job.Attempts) * JobRetryDelay)
+	}
+
+	if err := ju.jobRepo.Update(job); err != nil {
+		return errors.New(CodeInternalError, err)
+	}
+	return nil
+}
+
+// RequeueStale returns jobs of crashed workers to the queue
+func (ju *JobUsecase) RequeueStale() (int64, *errors.Error) {
+	requeued, err := ju.jobRepo.RequeueStale(time.Now().Add(-JobRunningTimeout))
+	if err != nil {
+		return 0, errors.New(CodeInternalError, err)
+	}
+	return requeued, nil
+}
@@ -0,0 +1,183 @@
+package usecases
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/job/mocks"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var jobInst = &models.Job{
+	ID:          1,
+	Type:        consts.JobMovieVideo,
+	Payload:     []byte(`{"movie_id":1}`),
+	State:       consts.JobRunning,
+	Attempts:    1,
+	MaxAttempts: consts.JobMaxAttempts,
+}
+
+func TestJobUseCase_Enqueue_OK(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	jobRep := mocks.NewMockJobRepository(ctrl)
+	jobUseCase := NewJobUsecase(jobRep)
+
+	payload := map[string]uint64{"movie_id": 1}
+
+	jobRep.
+		EXPECT().
+		Insert(gomock.Any()).
+		DoAndReturn(func(job *models.Job) error {
+			job.ID = 1
+			return nil
+		})
+
+	job, err := jobUseCase.Enqueue(consts.JobMovieVideo, payload)
+	assert.Equal(t, err, (*errors.Error)(nil))
+	assert.Equal(t, uint64(1), job.ID)
+	assert.Equal(t, consts.JobQueued, job.State)
+	assert.Equal(t, consts.JobMaxAttempts, job.MaxAttempts)
+	assert.JSONEq(t, `{"movie_id":1}`, string(job.Payload))
+}
+
+func TestJobUseCase_GetByID_OK(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	jobRep := mocks.NewMockJobRepository(ctrl)
+	jobUseCase := NewJobUsecase(jobRep)
+
+	jobRep.
+		EXPECT().
+		SelectByID(gomock.Eq(jobInst.ID)).
+		Return(jobInst, nil)
+
+	job, err := jobUseCase.GetByID(jobInst.ID)
+	assert.Equal(t, err, (*errors.Error)(nil))
+	assert.Equal(t, jobInst, job)
+}
+
+func TestJobUseCase_GetByID_Fail(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	jobRep := mocks.NewMockJobRepository(ctrl)
+	jobUseCase := NewJobUsecase(jobRep)
+
+	jobRep.
+		EXPECT().
+		SelectByID(gomock.Eq(jobInst.ID)).
+		Return(nil, sql.ErrNoRows)
+
+	job, err := jobUseCase.GetByID(jobInst.ID)
+	assert.Equal(t, err, errors.Get(consts.CodeJobDoesNotExist))
+	assert.Nil(t, job)
+}
+
+func TestJobUseCase_Acquire_Empty(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	jobRep := mocks.NewMockJobRepository(ctrl)
+	jobUseCase := NewJobUsecase(jobRep)
+
+	jobRep.
+		EXPECT().
+		SelectNextQueued().
+		Return(nil, sql.ErrNoRows)
+
+	job, err := jobUseCase.Acquire()
+	assert.Equal(t, err, (*errors.Error)(nil))
+	assert.Nil(t, job)
+}
+
+func TestJobUseCase_Complete_OK(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	jobRep := mocks.NewMockJobRepository(ctrl)
+	jobUseCase := NewJobUsecase(jobRep)
+
+	job := &models.Job{ID: 1, State: consts.JobRunning, Error: "previous attempt error"}
+
+	jobRep.
+		EXPECT().
+		Update(gomock.Eq(job)).
+		Return(nil)
+
+	err := jobUseCase.Complete(job)
+	assert.Equal(t, err, (*errors.Error)(nil))
+	assert.Equal(t, consts.JobDone, job.State)
+	assert.Empty(t, job.Error)
+}
+
+func TestJobUseCase_Fail_Retry(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	jobRep := mocks.NewMockJobRepository(ctrl)
+	jobUseCase := NewJobUsecase(jobRep)
+
+	job := &models.Job{ID: 1, State: consts.JobRunning, Attempts: 2, MaxAttempts: 3}
+	jobErr := errors.New(consts.CodeInternalError, assert.AnError)
+
+	jobRep.
+		EXPECT().
+		Update(gomock.Eq(job)).
+		Return(nil)
+
+	err := jobUseCase.Fail(job, jobErr)
+	assert.Equal(t, err, (*errors.Error)(nil))
+	assert.Equal(t, consts.JobQueued, job.State)
+	assert.Equal(t, jobErr.Message, job.Error)
+	assert.True(t, job.RunAt.After(time.Now().Add(consts.JobRetryDelay)))
+}
+
+func TestJobUseCase_Fail_LastAttempt(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	jobRep := mocks.NewMockJobRepository(ctrl)
+	jobUseCase := NewJobUsecase(jobRep)
+
+	job := &models.Job{ID: 1, State: consts.JobRunning, Attempts: 3, MaxAttempts: 3}
+	jobErr := errors.New(consts.CodeInternalError, assert.AnError)
+
+	jobRep.
+		EXPECT().
+		Update(gomock.Eq(job)).
+		Return(nil)
+
+	err := jobUseCase.Fail(job, jobErr)
+	assert.Equal(t, err, (*errors.Error)(nil))
+	assert.Equal(t, consts.JobFailed, job.State)
+	assert.Equal(t, jobErr.Message, job.Error)
+}
+
+func TestJobUseCase_RequeueStale_OK(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	jobRep := mocks.NewMockJobRepository(ctrl)
+	jobUseCase := NewJobUsecase(jobRep)
+
+	// Only jobs not updated for the running timeout are stale
+	jobRep.
+		EXPECT().
+		RequeueStale(gomock.Any()).
+		DoAndReturn(func(staleBefore time.Time) (int64, error) {
+			assert.WithinDuration(t, time.Now().Add(-consts.JobRunningTimeout), staleBefore, time.Second)
+			return 1, nil
+		})
+
+	requeued, err := jobUseCase.RequeueStale()
+	assert.Equal(t, err, (*errors.Error)(nil))
+	assert.Equal(t, int64(1), requeued)
+}
@@ -0,0 +1,147 @@
+package workers
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	. "github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/job"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
+)
+
+type JobHandler func(job *models.Job) *errors.Error
+
+type WorkerPool struct {
+	jobUcase        job.JobUsecase
+	handlers        map[string]JobHandler
+	workersCount    int
+	pollInterval    time.Duration
+	requeueInterval time.Duration
+	stop            chan struct{}
+	wg              sync.WaitGroup
+}
+
+func NewWorkerPool(jobUcase job.JobUsecase, workersCount int) *WorkerPool {
+	return &WorkerPool{
+		jobUcase:        jobUcase,
+		handlers:        make(map[string]JobHandler),
+		workersCount:    workersCount,
+		pollInterval:    JobPollInterval,
+		requeueInterval: JobRequeueInterval,
+		stop:            make(chan struct{}),
+	}
+}
+
+// Register must be called before Start
+func (wp *WorkerPool) Register(jobType string, handler JobHandler) {
+	wp.handlers[jobType] = handler
+}
+
+func (wp *WorkerPool) Start() {
+	wp.wg.Add(1)
+	go wp.requeue()
+	for i := 0; i < wp.workersCount; i++ {
+		wp.wg.Add(1)
+		go wp.work()
+	}
+}
+
+// Stop waits for running jobs to finish
+func (wp *WorkerPool) Stop() {
+	close(wp.stop)
+	wp.wg.Wait()
+}
+
+func (wp *WorkerPool) work() {
+	defer wp.wg.Done()
+
+	ticker := time.NewTicker(wp.pollInterval)
+	defer ticker.Stop()
+	for {
+		// Drain the queue before waiting for the next tick
+		for wp.processNext() {
+			select {
+			case <-wp.stop:
+				return
+			default:
+			}
+		}
+
+		select {
+		case <-wp.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// requeue reclaims jobs left running by crashed workers on start and periodically
+func (wp *WorkerPool) requeue() {
+	defer wp.wg.Done()
+
+	ticker := time.NewTicker(wp.requeueInterval)
+	defer ticker.Stop()
+	for {
+		wp.requeueStale()
+
+		select {
+		case <-wp.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (wp *WorkerPool) requeueStale() {
+	requeued, err := wp.jobUcase.RequeueStale()
+	if err != nil {
+		logger.Error(err.Message)
+		return
+	}
+	if requeued != 0 {
+		logger.Warn(fmt.Sprintf("%d interrupted jobs requeued", requeued))
+	}
+}
+
+// processNext returns false if the queue is empty or unavailable
+func (wp *WorkerPool) processNext() bool {
+	job, err := wp.jobUcase.Acquire()
+	if err != nil {
+		logger.Error(err.Message)
+		return false
+	}
+	if job == nil {
+		return false
+	}
+
+	if err := wp.process(job); err != nil {
+		logger.Error(fmt.Sprintf("job %d (%s) attempt %d failed: %s",
+			job.ID, job.Type, job.Attempts, err.Message))
+		if err := wp.jobUcase.Fail(job, err); err != nil {
+			logger.Error(err.Message)
+		}
+		return true
+	}
+
+	if err := wp.jobUcase.Complete(job); err != nil {
+		logger.Error(err.Message)
+	}
+	return true
+}
+
+func (wp *WorkerPool) process(job *models.Job) (customErr *errors.Error) {
+	handler, has := wp.handlers[job.Type]
+	if !has {
+		return errors.New(CodeInternalError, fmt.Errorf("unknown job type %s", job.Type))
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			customErr = errors.New(CodeInternalError, fmt.Errorf("job panicked: %v", r))
+		}
+	}()
+	return handler(job)
+}
@@ -0,0 +1,116 @@
+package workers
+
+import (
+	"testing"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/job/mocks"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkerPool_ProcessNext_Done(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	jobUseCase := mocks.NewMockJobUsecase(ctrl)
+	pool := NewWorkerPool(jobUseCase, 1)
+
+	job := &models.Job{ID: 1, Type: consts.JobMovieVideo}
+	var processed *models.Job
+	pool.Register(consts.JobMovieVideo, func(job *models.Job) *errors.Error {
+		processed = job
+		return nil
+	})
+
+	jobUseCase.EXPECT().Acquire().Return(job, nil)
+	jobUseCase.EXPECT().Complete(job).Return(nil)
+
+	assert.True(t, pool.processNext())
+	assert.Equal(t, job, processed)
+}
+
+func TestWorkerPool_ProcessNext_Failed(t *testing.T) {
+	t.Parallel()
+	logger.DisableLogger()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	jobUseCase := mocks.NewMockJobUsecase(ctrl)
+	pool := NewWorkerPool(jobUseCase, 1)
+
+	job := &models.Job{ID: 1, Type: consts.JobMovieVideo}
+	jobErr := errors.Get(consts.CodeMovieDoesNotExist)
+	pool.Register(consts.JobMovieVideo, func(job *models.Job) *errors.Error {
+		return jobErr
+	})
+
+	jobUseCase.EXPECT().Acquire().Return(job, nil)
+	jobUseCase.EXPECT().Fail(job, jobErr).Return(nil)
+
+	assert.True(t, pool.processNext())
+}
+
+func TestWorkerPool_ProcessNext_Panicked(t *testing.T) {
+	t.Parallel()
+	logger.DisableLogger()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	jobUseCase := mocks.NewMockJobUsecase(ctrl)
+	pool := NewWorkerPool(jobUseCase, 1)
+
+	job := &models.Job{ID: 1, Type: consts.JobMovieVideo}
+	pool.Register(consts.JobMovieVideo, func(job *models.Job) *errors.Error {
+		panic("unexpected")
+	})
+
+	jobUseCase.EXPECT().Acquire().Return(job, nil)
+	jobUseCase.EXPECT().Fail(job, gomock.Any()).Return(nil)
+
+	assert.True(t, pool.processNext())
+}
+
+func TestWorkerPool_ProcessNext_UnknownType(t *testing.T) {
+	t.Parallel()
+	logger.DisableLogger()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	jobUseCase := mocks.NewMockJobUsecase(ctrl)
+	pool := NewWorkerPool(jobUseCase, 1)
+
+	job := &models.Job{ID: 1, Type: "unknown"}
+
+	jobUseCase.EXPECT().Acquire().Return(job, nil)
+	jobUseCase.EXPECT().Fail(job, gomock.Any()).Return(nil)
+
+	assert.True(t, pool.processNext())
+}
+
+func TestWorkerPool_ProcessNext_Empty(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	jobUseCase := mocks.NewMockJobUsecase(ctrl)
+	pool := NewWorkerPool(jobUseCase, 1)
+
+	jobUseCase.EXPECT().Acquire().Return(nil, nil)
+
+	assert.False(t, pool.processNext())
+}
+
+func TestWorkerPool_Start_RequeuesStale(t *testing.T) {
+	t.Parallel()
+	logger.DisableLogger()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	jobUseCase := mocks.NewMockJobUsecase(ctrl)
+	pool := NewWorkerPool(jobUseCase, 0)
+
+	// Jobs left running before the restart are reclaimed on start
+	jobUseCase.EXPECT().RequeueStale().Return(int64(1), nil)
+
+	pool.Start()
+	pool.Stop()
+}
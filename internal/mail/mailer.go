@@ -0,0 +1,10 @@
+package mail
+
+import (
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+)
+
+type Mailer interface {
+	Send(mail *models.Mail) *errors.Error
+}
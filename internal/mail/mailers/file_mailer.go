@@ -0,0 +1,36 @@
+package mailers
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/mail"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+)
+
+// FileMailer writes every mail to a separate .eml file,
+// it is meant for development without SMTP server
+type FileMailer struct {
+	dir  string
+	from string
+}
+
+func NewFileMailer(dir, from string) mail.Mailer {
+	return &FileMailer{
+		dir:  dir,
+		from: from,
+	}
+}
+
+func (fm *FileMailer) Send(mail *models.Mail) *errors.Error {
+	name := fmt.Sprintf("%d.eml", time.Now().UnixNano())
+	err := ioutil.WriteFile(filepath.Join(fm.dir, name), buildMessage(fm.from, mail), 0600)
+	if err != nil {
+		return errors.New(consts.CodeSendMailError, err)
+	}
+	return nil
+}
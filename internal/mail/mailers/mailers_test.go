@@ -0,0 +1,62 @@
+package mailers
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+var testMail = &models.Mail{
+	To:      "user@mail.ru",
+	Subject: "Подтверждение почты",
+	Body:    "https://www.flicksbox.ru/verify?token=abc",
+}
+
+func TestMemoryMailer_Send(t *testing.T) {
+	t.Parallel()
+	mailer := NewMemoryMailer()
+
+	assert.Nil(t, mailer.Send(testMail))
+	mails := mailer.Mails()
+	if assert.Len(t, mails, 1) {
+		assert.Equal(t, testMail, mails[0])
+	}
+}
+
+func TestFileMailer_Send(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "mails")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	mailer := NewFileMailer(dir, "noreply@flicksbox.ru")
+	assert.Nil(t, mailer.Send(testMail))
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assert.Len(t, files, 1) {
+		data, err := ioutil.ReadFile(files[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		msg := string(data)
+		assert.Contains(t, msg, "From: noreply@flicksbox.ru\r\n")
+		assert.Contains(t, msg, "To: user@mail.ru\r\n")
+		assert.True(t, strings.HasSuffix(msg, "\r\n\r\n"+testMail.Body))
+	}
+}
+
+func TestFileMailer_Send_NoDir(t *testing.T) {
+	t.Parallel()
+	mailer := NewFileMailer("/not/existing/dir", "noreply@flicksbox.ru")
+	assert.NotNil(t, mailer.Send(testMail))
+}
@@ -0,0 +1,37 @@
+package mailers
+
+import (
+	"sync"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+)
+
+// MemoryMailer keeps sent mails in memory, it is meant for tests
+type MemoryMailer struct {
+	mu    sync.Mutex
+	mails []*models.Mail
+}
+
+func NewMemoryMailer() *MemoryMailer {
+	return &MemoryMailer{}
+}
+
+func (mm *MemoryMailer) Send(mail *models.Mail) *errors.Error {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+
+	sent := *mail
+	mm.mails = append(mm.mails, &sent)
+	return nil
+}
+
+// Mails returns mails sent so far in order of sending
+func (mm *MemoryMailer) Mails() []*models.Mail {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+
+	mails := make([]*models.Mail, len(mm.mails))
+	copy(mails, mm.mails)
+	return mails
+}
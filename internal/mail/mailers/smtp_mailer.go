@@ -0,0 +1,42 @@
+package mailers
+
+import (
+	"net"
+	"net/smtp"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/mail"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+)
+
+type SMTPMailer struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+// NewSMTPMailer sends mails through SMTP server at addr,
+// authentication is skipped when username is empty
+func NewSMTPMailer(addr, from, username, password string) mail.Mailer {
+	var auth smtp.Auth
+	if username != "" {
+		// nolint: errcheck
+		host, _, _ := net.SplitHostPort(addr)
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	return &SMTPMailer{
+		addr: addr,
+		from: from,
+		auth: auth,
+	}
+}
+
+func (sm *SMTPMailer) Send(mail *models.Mail) *errors.Error {
+	err := smtp.SendMail(sm.addr, sm.auth, sm.from, []string{mail.To},
+		buildMessage(sm.from, mail))
+	if err != nil {
+		return errors.New(consts.CodeSendMailError, err)
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AuditRecord struct {
+	ID         uint64                  `json:"id"`
+	UserID     uint64                  `json:"user_id"`
+	Action     string                  `json:"action"`
+	EntityType string                  `json:"entity_type"`
+	EntityID   uint64                  `json:"entity_id"`
+	Changes    map[string]*AuditChange `json:"changes"`
+	IP         string                  `json:"ip"`
+	Created    time.Time               `json:"created"`
+}
+
+// AuditChange keeps JSON values of the entity field,
+// value is null if the field didn't exist
+type AuditChange struct {
+	Before json.RawMessage `json:"before"`
+	After  json.RawMessage `json:"after"`
+}
+
+// AuditFilter selects records of the entity in [From, To),
+// zero fields are not filtered
+type AuditFilter struct {
+	EntityType string
+	EntityID   uint64
+	From       time.Time
+	To         time.Time
+}
@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// CatalogItem is movie or TV show in import and export files,
+// relations are referenced by name
+type CatalogItem struct {
+	Row              int              `json:"-"`
+	Type             string           `json:"type"`
+	Name             string           `json:"name"`
+	OriginalName     string           `json:"original_name"`
+	Description      string           `json:"description"`
+	ShortDescription string           `json:"short_description"`
+	Year             int              `json:"year"`
+	IsFree           bool             `json:"is_free"`
+	Status           string           `json:"status,omitempty"`
+	PublishAt        *time.Time       `json:"publish_at,omitempty"`
+	Countries        []string         `json:"countries"`
+	Genres           []string         `json:"genres"`
+	Actors           []string         `json:"actors"`
+	Directors        []string         `json:"directors"`
+	Seasons          []*CatalogSeason `json:"seasons,omitempty"`
+	// ParseError is the invalid value in rows of the item, such item isn't imported
+	ParseError string `json:"-"`
+}
+
+type CatalogSeason struct {
+	Number   int               `json:"number"`
+	Episodes []*CatalogEpisode `json:"episodes"`
+}
+
+type CatalogEpisode struct {
+	Number      int    `json:"number"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// CatalogImportResult reports import of the item from the row
+type CatalogImportResult struct {
+	Row       int    `json:"row"`
+	Name      string `json:"name"`
+	ContentID uint64 `json:"content_id,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 type Content struct {
 	ContentID        uint64      `json:"content_id"`
 	Name             string      `json:"name"`
@@ -17,6 +19,8 @@ type Content struct {
 	Directors        []*Director `json:"directors"`
 	IsLiked          *bool       `json:"is_liked,omitempty"`
 	IsFavourite      *bool       `json:"is_favourite"`
+	Status           string      `json:"status,omitempty"`
+	PublishAt        *time.Time  `json:"publish_at,omitempty"`
 }
 
 func (c *Content) ReplaceBy(other *Content) {
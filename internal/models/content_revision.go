@@ -0,0 +1,117 @@
+package models
+
+import (
+	"sort"
+	"time"
+)
+
+// ContentRevision is the state of content metadata after the change
+type ContentRevision struct {
+	ID        uint64    `json:"id"`
+	ContentID uint64    `json:"content_id"`
+	Created   time.Time `json:"created"`
+	ContentRevisionData
+}
+
+// ContentRevisionData keeps metadata fields of the content,
+// links are kept as IDs of countries, genres, actors and directors
+type ContentRevisionData struct {
+	Name             string   `json:"name"`
+	OriginalName     string   `json:"original_name"`
+	Description      string   `json:"description"`
+	ShortDescription string   `json:"short_description"`
+	Year             int      `json:"year"`
+	IsFree           bool     `json:"is_free"`
+	Countries        []uint64 `json:"countries"`
+	Genres           []uint64 `json:"genres"`
+	Actors           []uint64 `json:"actors"`
+	Directors        []uint64 `json:"directors"`
+}
+
+// RevisionChange keeps values of the field before and after the revision,
+// Before is null for the first revision
+type RevisionChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+func NewContentRevisionData(content *Content) ContentRevisionData {
+	data := ContentRevisionData{
+		Name:             content.Name,
+		OriginalName:     content.OriginalName,
+		Description:      content.Description,
+		ShortDescription: content.ShortDescription,
+		Year:             content.Year,
+		IsFree:           content.IsFree != nil && *content.IsFree,
+		Countries:        []uint64{},
+		Genres:           []uint64{},
+		Actors:           []uint64{},
+		Directors:        []uint64{},
+	}
+	for _, country := range content.Countries {
+		data.Countries = append(data.Countries, country.ID)
+	}
+	for _, genre := range content.Genres {
+		data.Genres = append(data.Genres, genre.ID)
+	}
+	for _, actor := range content.Actors {
+		data.Actors = append(data.Actors, actor.ID)
+	}
+	for _, director := range content.Directors {
+		data.Directors = append(data.Directors, director.ID)
+	}
+	return data
+}
+
+// Diff returns fields changed since the previous revision,
+// all fields are changed if there is no previous revision
+func (d *ContentRevisionData) Diff(prev *ContentRevisionData) map[string]*RevisionChange {
+	first := prev == nil
+	if first {
+		prev = &ContentRevisionData{}
+	}
+
+	changes := make(map[string]*RevisionChange)
+	add := func(field string, before, after interface{}, equal bool) {
+		if equal && !first {
+			return
+		}
+		if first {
+			before = nil
+		}
+		changes[field] = &RevisionChange{Before: before, After: after}
+	}
+	add("name", prev.Name, d.Name, prev.Name == d.Name)
+	add("original_name", prev.OriginalName, d.OriginalName, prev.OriginalName == d.OriginalName)
+	add("description", prev.Description, d.Description, prev.Description == d.Description)
+	add("short_description", prev.ShortDescription, d.ShortDescription,
+		prev.ShortDescription == d.ShortDescription)
+	add("year", prev.Year, d.Year, prev.Year == d.Year)
+	add("is_free", prev.IsFree, d.IsFree, prev.IsFree == d.IsFree)
+	add("countries", prev.Countries, d.Countries, equalIDs(prev.Countries, d.Countries))
+	add("genres", prev.Genres, d.Genres, equalIDs(prev.Genres, d.Genres))
+	add("actors", prev.Actors, d.Actors, equalIDs(prev.Actors, d.Actors))
+	add("directors", prev.Directors, d.Directors, equalIDs(prev.Directors, d.Directors))
+	return changes
+}
+
+// equalIDs compares links regardless of their order
+func equalIDs(first, second []uint64) bool {
+	if len(first) != len(second) {
+		return false
+	}
+	first, second = sortedIDs(first), sortedIDs(second)
+	for i := range first {
+		if first[i] != second[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func sortedIDs(ids []uint64) []uint64 {
+	sorted := make([]uint64, len(ids))
+	copy(sorted, ids)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted
+}
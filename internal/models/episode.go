@@ -5,6 +5,7 @@ type Episode struct {
 	Name        string `json:"name"`
 	Number      int    `json:"number"`
 	Video       string `json:"video"`
+	Playlist    string `json:"playlist"`
 	Description string `json:"description"`
 	Poster      string `json:"poster"`
 	SeasonID    uint64 `json:"season_id"`
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"time"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+)
+
+// Identity links account of external OIDC provider to the user
+type Identity struct {
+	ID       uint64    `json:"id"`
+	UserID   uint64    `json:"-"`
+	Provider string    `json:"provider"`
+	Subject  string    `json:"-"`
+	Email    string    `json:"email"`
+	Created  time.Time `json:"created"`
+}
+
+// IdentityClaims are verified claims of ID token
+type IdentityClaims struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// OIDCAuthRequest is kept by the browser in signed cookie
+// between redirect to the provider and the callback
+type OIDCAuthRequest struct {
+	Provider  string    `json:"provider"`
+	State     string    `json:"state"`
+	Nonce     string    `json:"nonce"`
+	Verifier  string    `json:"verifier"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func NewOIDCAuthRequest(provider string) (*OIDCAuthRequest, error) {
+	values := make([]string, 3)
+	for i := range values {
+		value, err := randomURLString(consts.OIDCRandomBytes)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+
+	return &OIDCAuthRequest{
+		Provider:  provider,
+		State:     values[0],
+		Nonce:     values[1],
+		Verifier:  values[2],
+		ExpiresAt: time.Now().Add(consts.OIDCStateTTL),
+	}, nil
+}
+
+// CodeChallenge is PKCE S256 challenge of the verifier
+func (ar *OIDCAuthRequest) CodeChallenge() string {
+	hash := sha256.Sum256([]byte(ar.Verifier))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+func randomURLString(size int) (string, error) {
+	randBytes := make([]byte, size)
+	if _, err := rand.Read(randBytes); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(randBytes), nil
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type Job struct {
+	ID          uint64          `json:"id"`
+	Type        string          `json:"type"`
+	Payload     json.RawMessage `json:"payload"`
+	State       string          `json:"state"`
+	Error       string          `json:"error"`
+	Attempts    int             `json:"attempts"`
+	MaxAttempts int             `json:"max_attempts"`
+	RunAt       time.Time       `json:"run_at"`
+	Created     time.Time       `json:"created"`
+	Updated     time.Time       `json:"updated"`
+}
+
+func (j *Job) IsLastAttempt() bool {
+	return j.Attempts >= j.MaxAttempts
+}
+
+// ContentPostersPayload is payload of content posters job
+type ContentPostersPayload struct {
+	ContentID   uint64 `json:"content_id"`
+	PostersDir  string `json:"posters_dir"`
+	SmallPoster bool   `json:"small_poster"`
+	LargePoster bool   `json:"large_poster"`
+}
+
+// MovieVideoPayload is payload of movie video job
+type MovieVideoPayload struct {
+	MovieID   uint64 `json:"movie_id"`
+	VideoPath string `json:"video_path"`
+}
+
+// EpisodeMediaPayload is payload of episode poster and video jobs
+type EpisodeMediaPayload struct {
+	EpisodeID uint64 `json:"episode_id"`
+	Path      string `json:"path"`
+}
+
+// UserAvatarPayload is payload of user avatar job
+type UserAvatarPayload struct {
+	UserID     uint64 `json:"user_id"`
+	AvatarPath string `json:"avatar_path"`
+}
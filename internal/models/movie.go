@@ -1,7 +1,8 @@
 package models
 
 type Movie struct {
-	ID    uint64 `json:"id"`
-	Video string `json:"video"`
+	ID       uint64 `json:"id"`
+	Video    string `json:"video"`
+	Playlist string `json:"playlist"`
 	Content
 }
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+type Payment struct {
+	ID          uint64    `json:"id"`
+	OperationID string    `json:"operation_id"`
+	UserID      uint64    `json:"user_id"`
+	PlanID      uint64    `json:"plan_id"`
+	Amount      float64   `json:"amount"` // charged from the payer
+	Currency    string    `json:"currency"`
+	Status      string    `json:"status"`
+	Reason      string    `json:"reason,omitempty"`
+	Created     time.Time `json:"created"`
+}
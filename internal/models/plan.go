@@ -0,0 +1,10 @@
+package models
+
+type Plan struct {
+	ID             uint64  `json:"id"`
+	Name           string  `json:"name"`
+	Price          float64 `json:"price"`
+	Currency       string  `json:"currency"`
+	DurationMonths int     `json:"duration_months"`
+	TrialDays      int     `json:"trial_days"`
+}
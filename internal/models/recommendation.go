@@ -0,0 +1,14 @@
+package models
+
+type Recommendation struct {
+	UserID    uint64
+	ContentID uint64
+	Score     float64
+}
+
+// ContentFeature is a genre, actor, director or country link of the content
+type ContentFeature struct {
+	ContentID uint64
+	Kind      string
+	FeatureID uint64
+}
@@ -1,7 +1,9 @@
 package models
 
 type SearchResult struct {
-	TVShows []*TVShow `json:"tv_shows"`
-	Movies  []*Movie  `json:"movies"`
-	Actors  []*Actor  `json:"actors"`
+	TVShows   []*TVShow   `json:"tv_shows"`
+	Movies    []*Movie    `json:"movies"`
+	Actors    []*Actor    `json:"actors"`
+	Directors []*Director `json:"directors"`
+	Genres    []*Genre    `json:"genres"`
 }
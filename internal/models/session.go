@@ -8,18 +8,47 @@ import (
 )
 
 type Session struct {
-	ID        uint64
-	Value     string
-	UserID    uint64
-	ExpiresAt time.Time
+	ID        uint64    `json:"id"`
+	Value     string    `json:"-"`
+	UserID    uint64    `json:"-"`
+	ExpiresAt time.Time `json:"expires_at"`
+	UserAgent string    `json:"user_agent"`
+	IP        string    `json:"ip"`
+	CreatedAt time.Time `json:"created_at"`
+	// Remember selects long session lifetime
+	Remember bool `json:"remember"`
+	// Renewed marks session whose expiry was extended by the current check
+	Renewed bool `json:"-"`
+	// Current marks session of the request in the list of user sessions
+	Current bool `json:"current"`
 }
 
-func NewSession(userID uint64) *Session {
+func NewSession(userID uint64, remember bool) *Session {
 	randValue := uuid.NewV4().String()
-	expiresDur := consts.ExpiresDuration
-	return &Session{
+	now := time.Now()
+	sess := &Session{
 		Value:     randValue,
 		UserID:    userID,
-		ExpiresAt: time.Now().Add(expiresDur),
+		CreatedAt: now,
+		Remember:  remember,
 	}
+	sess.ExpiresAt = now.Add(sess.Lifetime())
+	return sess
+}
+
+func (s *Session) Lifetime() time.Duration {
+	if s.Remember {
+		return consts.RememberExpiresDuration
+	}
+	return consts.ExpiresDuration
+}
+
+// NeedsRenewal reports whether more than half of the session lifetime has passed
+func (s *Session) NeedsRenewal(now time.Time) bool {
+	return s.ExpiresAt.Sub(now) < s.Lifetime()/2
+}
+
+func (s *Session) Renew(now time.Time) {
+	s.ExpiresAt = now.Add(s.Lifetime())
+	s.Renewed = true
 }
@@ -5,7 +5,26 @@ import "time"
 type Subscription struct {
 	ID         uint64    `json:"id"`
 	UserID     uint64    `json:"user_id"`
+	PlanID     *uint64   `json:"plan_id"`
 	Expires    time.Time `json:"expires"`
+	GraceUntil time.Time `json:"grace_until"`
 	IsPaid     bool      `json:"is_paid"`
 	IsCanceled bool      `json:"is_canceled"`
 }
+
+func (s *Subscription) IsActive() bool {
+	now := time.Now()
+	return s.IsPaid && (s.Expires.After(now) || s.GraceUntil.After(now))
+}
+
+func (s *Subscription) InGracePeriod() bool {
+	return s.IsActive() && !s.Expires.After(time.Now())
+}
+
+type SubscriptionEvent struct {
+	ID      uint64    `json:"id"`
+	UserID  uint64    `json:"-"`
+	Event   string    `json:"event"`
+	Expires time.Time `json:"expires"`
+	Created time.Time `json:"created"`
+}
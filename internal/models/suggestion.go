@@ -0,0 +1,7 @@
+package models
+
+type Suggestion struct {
+	ID   uint64 `json:"id"`
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
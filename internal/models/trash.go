@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// TrashItem is deleted content, season or episode,
+// Name is the name of the content for seasons
+type TrashItem struct {
+	ID           uint64    `json:"id"`
+	Type         string    `json:"type"`
+	Name         string    `json:"name"`
+	ContentID    uint64    `json:"content_id"`
+	SeasonNumber int       `json:"season,omitempty"`
+	DeletedAt    time.Time `json:"deleted_at"`
+	OriginalName string    `json:"-"`
+	Images       string    `json:"-"`
+	Poster       string    `json:"-"`
+	Video        string    `json:"-"`
+}
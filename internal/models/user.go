@@ -1,10 +1,11 @@
 package models
 
 type User struct {
-	ID       uint64 `json:"id"`
-	Nickname string `json:"nickname"`
-	Email    string `json:"email"`
-	Password string `json:"-"`
-	Avatar   string `json:"avatar"`
-	Role     string `json:"-"`
+	ID            uint64 `json:"id"`
+	Nickname      string `json:"nickname"`
+	Email         string `json:"email"`
+	Password      string `json:"-"`
+	Avatar        string `json:"avatar"`
+	Role          string `json:"-"`
+	EmailVerified bool   `json:"email_verified"`
 }
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+)
+
+// UserToken is a one-time token for password reset or email verification,
+// only hash of the token value is stored
+type UserToken struct {
+	ID        uint64
+	UserID    uint64
+	Purpose   string
+	Hash      string
+	ExpiresAt time.Time
+}
+
+// NewUserToken returns the token value to send to the user and its stored form
+func NewUserToken(userID uint64, purpose string, ttl time.Duration) (string, *UserToken, error) {
+	randBytes := make([]byte, consts.UserTokenBytes)
+	if _, err := rand.Read(randBytes); err != nil {
+		return "", nil, err
+	}
+	value := hex.EncodeToString(randBytes)
+
+	return value, &UserToken{
+		UserID:    userID,
+		Purpose:   purpose,
+		Hash:      HashUserToken(value),
+		ExpiresAt: time.Now().Add(ttl),
+	}, nil
+}
+
+func HashUserToken(value string) string {
+	hash := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(hash[:])
+}
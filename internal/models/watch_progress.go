@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+)
+
+type WatchProgress struct {
+	UserID    uint64    `json:"-"`
+	ContentID uint64    `json:"content_id"`
+	EpisodeID *uint64   `json:"episode_id,omitempty"`
+	Position  int       `json:"position"`
+	Duration  int       `json:"duration"`
+	Watched   bool      `json:"watched"`
+	Updated   time.Time `json:"updated"`
+}
+
+func (wp *WatchProgress) IsWatched() bool {
+	return float64(wp.Position) >= float64(wp.Duration)*consts.WatchedProgressRatio
+}
+
+type ContinueWatching struct {
+	Content  *Content  `json:"content"`
+	Episode  *Episode  `json:"episode,omitempty"`
+	Season   int       `json:"season,omitempty"`
+	Position int       `json:"position"`
+	Duration int       `json:"duration"`
+	Updated  time.Time `json:"updated"`
+}
@@ -11,9 +11,11 @@ import (
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/content"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/country"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/director"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/entitlement"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/genre"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/job"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/movie"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/mwares"
@@ -26,34 +28,42 @@ import (
 )
 
 type MovieHandler struct {
-	movieUcase    movie.MovieUsecase
-	contentUcase  content.ContentUsecase
-	countryUcase  country.CountryUsecase
-	genreUcase    genre.GenreUsecase
-	actorUcase    actor.ActorUseCase
-	directorUcase director.DirectorUseCase
+	movieUcase       movie.MovieUsecase
+	contentUcase     content.ContentUsecase
+	countryUcase     country.CountryUsecase
+	genreUcase       genre.GenreUsecase
+	actorUcase       actor.ActorUseCase
+	directorUcase    director.DirectorUseCase
+	entitlementUcase entitlement.EntitlementUsecase
+	videoSigner      *helpers.VideoURLSigner
+	jobUcase         job.JobUsecase
 }
 
 func NewMovieHandler(movieUcase movie.MovieUsecase, contentUcase content.ContentUsecase,
 	countryUcase country.CountryUsecase, genreUcase genre.GenreUsecase,
-	actorUcase actor.ActorUseCase, directorUcase director.DirectorUseCase) *MovieHandler {
+	actorUcase actor.ActorUseCase, directorUcase director.DirectorUseCase,
+	entitlementUcase entitlement.EntitlementUsecase,
+	videoSigner *helpers.VideoURLSigner, jobUcase job.JobUsecase) *MovieHandler {
 	return &MovieHandler{
-		movieUcase:    movieUcase,
-		contentUcase:  contentUcase,
-		countryUcase:  countryUcase,
-		genreUcase:    genreUcase,
-		actorUcase:    actorUcase,
-		directorUcase: directorUcase,
+		movieUcase:       movieUcase,
+		contentUcase:     contentUcase,
+		countryUcase:     countryUcase,
+		genreUcase:       genreUcase,
+		actorUcase:       actorUcase,
+		directorUcase:    directorUcase,
+		entitlementUcase: entitlementUcase,
+		videoSigner:      videoSigner,
+		jobUcase:         jobUcase,
 	}
 }
 
 func (mh *MovieHandler) Configure(e *echo.Echo, mw *mwares.MiddlewareManager) {
-	e.POST("/api/v1/movies", mh.CreateMovieHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF)
-	e.PUT("/api/v1/movies/:mid", mh.UpdateMovieHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF)
-	e.DELETE("/api/v1/movies/:mid", mh.DeleteMovieHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF)
+	e.POST("/api/v1/movies", mh.CreateMovieHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF, mw.Audit(AuditMovie, ""))
+	e.PUT("/api/v1/movies/:mid", mh.UpdateMovieHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF, mw.Audit(AuditMovie, "mid"))
+	e.DELETE("/api/v1/movies/:mid", mh.DeleteMovieHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF, mw.Audit(AuditMovie, "mid"))
 	e.GET("/api/v1/movies/:mid", mh.GetMovieHandler(), mw.GetAuth)
 	e.PUT("/api/v1/movies/:mid/video", mh.UpdateMovieVideoHandler(),
-		middleware.BodyLimit("1000M"), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF)
+		middleware.BodyLimit("1000M"), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF, mw.Audit(AuditMovie, "mid"))
 	e.GET("/api/v1/movies", mh.GetMoviesHandler(), mw.GetAuth)
 	e.GET("/api/v1/movies/latest", mh.GetLatestMoviesHandler(), mw.GetAuth)
 	e.GET("/api/v1/movies/top", mh.GetTopMovieListHandler(), mw.GetAuth)
@@ -238,12 +248,8 @@ func (mh *MovieHandler) DeleteMovieHandler() echo.HandlerFunc {
 			return cntx.JSON(err.HTTPCode, Response{Error: err})
 		}
 
-		if err := mh.movieUcase.DeleteByID(movie.ID); err != nil {
-			logger.Error(err.Message)
-			return cntx.JSON(err.HTTPCode, Response{Error: err})
-		}
-
-		// Other related data are deleted in CASCADE
+		// Movie and other related data are kept in the trash
+		// and deleted in CASCADE on purge
 		if err := mh.contentUcase.DeleteByID(movie.ContentID); err != nil {
 			logger.Error(err.Message)
 			return cntx.JSON(err.HTTPCode, Response{Error: err})
@@ -273,6 +279,22 @@ func (mh *MovieHandler) GetMovieHandler() echo.HandlerFunc {
 			return cntx.JSON(err.HTTPCode, Response{Error: err})
 		}
 
+		// Hide video of paid content from users without subscription
+		hasAccess, err := mh.entitlementUcase.HasAccess(userID, &movie.Content)
+		if err != nil {
+			logger.Error(err.Message)
+			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		}
+		if !hasAccess {
+			movie.Video = ""
+		} else if movie.Video != "" {
+			// Playlist is given only after packaging, video is played until then
+			if playlistPath, ok := helpers.GetPackagedPlaylist(movie.Video); ok {
+				movie.Playlist = mh.videoSigner.SignPlaylist(playlistPath, userID)
+			}
+			movie.Video = mh.videoSigner.Sign(movie.Video, userID)
+		}
+
 		return cntx.JSON(http.StatusOK, Response{
 			Body: &Body{
 				"movie": movie,
@@ -292,9 +314,6 @@ func (mh *MovieHandler) UpdateMovieVideoHandler() echo.HandlerFunc {
 			return cntx.JSON(err.HTTPCode, Response{Error: err})
 		}
 
-		// nolint: errcheck
-		userID, _ := cntx.Get("userID").(uint64)
-
 		movieID, parseErr := strconv.ParseUint(cntx.Param("mid"), 10, 64)
 		if parseErr != nil {
 			customErr := errors.New(CodeInternalError, parseErr)
@@ -302,11 +321,18 @@ func (mh *MovieHandler) UpdateMovieVideoHandler() echo.HandlerFunc {
 			return cntx.JSON(customErr.HTTPCode, Response{Error: customErr})
 		}
 
-		movie, err := mh.movieUcase.GetFullByID(movieID, userID)
+		// Video is uploaded to drafts too, so public GetFullByID doesn't fit
+		movie, err := mh.movieUcase.GetByID(movieID)
+		if err != nil {
+			logger.Error(err.Message)
+			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		}
+		content, err := mh.contentUcase.GetByID(movie.ContentID)
 		if err != nil {
 			logger.Error(err.Message)
 			return cntx.JSON(err.HTTPCode, Response{Error: err})
 		}
+		movie.Content = *content
 
 		path, osErr := os.Getwd()
 		if osErr != nil {
@@ -321,9 +347,10 @@ func (mh *MovieHandler) UpdateMovieVideoHandler() echo.HandlerFunc {
 		videosDirPath := filepath.Join(path, videosDir)
 		helpers.InitStorage(videosDirPath)
 
-		// Store video
+		// Stage video, it is processed by background job
 		absVideoPath := filepath.Join(videosDirPath, videoName)
-		if err := helpers.StoreFile(video, absVideoPath); err != nil {
+		absStagedPath := helpers.GetStagedPath(absVideoPath)
+		if err := helpers.StoreFile(video, absStagedPath); err != nil {
 			if movie.Video == "" {
 				removeErr := os.RemoveAll(videosDirPath)
 				if removeErr != nil {
@@ -334,22 +361,20 @@ func (mh *MovieHandler) UpdateMovieVideoHandler() echo.HandlerFunc {
 			return cntx.JSON(err.HTTPCode, Response{Error: err})
 		}
 
-		// Update movie
 		rltVideoPath := filepath.Join(videosDir, videoName)
-		if err := mh.movieUcase.UpdateVideo(movie, rltVideoPath); err != nil {
-			if movie.Video == "" {
-				removeErr := os.RemoveAll(videosDirPath)
-				if removeErr != nil {
-					logger.Error(removeErr)
-				}
-			}
+		job, err := mh.jobUcase.Enqueue(JobMovieVideo, &models.MovieVideoPayload{
+			MovieID:   movie.ID,
+			VideoPath: rltVideoPath,
+		})
+		if err != nil {
+			helpers.RemoveStagedFile(absStagedPath)
 			logger.Error(err.Message)
 			return cntx.JSON(err.HTTPCode, Response{Error: err})
 		}
 
-		return cntx.JSON(http.StatusOK, Response{
+		return cntx.JSON(http.StatusAccepted, Response{
 			Body: &Body{
-				"video": rltVideoPath,
+				"job": job,
 			},
 		})
 	}
@@ -371,7 +396,7 @@ func (mh *MovieHandler) GetMoviesHandler() echo.HandlerFunc {
 		// nolint: errcheck
 		userID, _ := cntx.Get("userID").(uint64)
 
-		movies, err := mh.movieUcase.ListByParams(&req.ContentFilter,
+		movies, err := mh.movieUcase.ListByParams(&req.ContentFilter, nil,
 			&req.Pagination, userID)
 		if err != nil {
 			logger.Error(err.Message)
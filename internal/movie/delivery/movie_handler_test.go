@@ -13,8 +13,11 @@ import (
 	contentMocks "github.com/go-park-mail-ru/2020_2_Slash/internal/content/mocks"
 	countryMocks "github.com/go-park-mail-ru/2020_2_Slash/internal/country/mocks"
 	directorMocks "github.com/go-park-mail-ru/2020_2_Slash/internal/director/mocks"
+	entitlementMocks "github.com/go-park-mail-ru/2020_2_Slash/internal/entitlement/mocks"
 	genreMocks "github.com/go-park-mail-ru/2020_2_Slash/internal/genre/mocks"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	jobMocks "github.com/go-park-mail-ru/2020_2_Slash/internal/job/mocks"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
 	movieMocks "github.com/go-park-mail-ru/2020_2_Slash/internal/movie/mocks"
 	"github.com/go-park-mail-ru/2020_2_Slash/pkg/converter"
@@ -83,6 +86,9 @@ func TestMovieHandler_CreateMovieHandler(t *testing.T) {
 	genreUseCase := genreMocks.NewMockGenreUsecase(ctrl)
 	actorUseCase := actorMocks.NewMockActorUseCase(ctrl)
 	directorUseCase := directorMocks.NewMockDirectorUseCase(ctrl)
+	entitlementUseCase := entitlementMocks.NewMockEntitlementUsecase(ctrl)
+	videoSigner := helpers.NewVideoURLSigner("secret")
+	jobUseCase := jobMocks.NewMockJobUsecase(ctrl)
 
 	isFree := true
 	var contentInst *models.Content = &models.Content{
@@ -134,7 +140,7 @@ func TestMovieHandler_CreateMovieHandler(t *testing.T) {
 	c.Set("userID", 3)
 
 	movieHandler := NewMovieHandler(movieUseCase, contentUseCase,
-		countryUseCase, genreUseCase, actorUseCase, directorUseCase)
+		countryUseCase, genreUseCase, actorUseCase, directorUseCase, entitlementUseCase, videoSigner, jobUseCase)
 	handleFunc := movieHandler.CreateMovieHandler()
 	movieHandler.Configure(e, nil)
 
@@ -191,6 +197,9 @@ func TestMovieHandler_UpdateMovieHandler(t *testing.T) {
 	genreUseCase := genreMocks.NewMockGenreUsecase(ctrl)
 	actorUseCase := actorMocks.NewMockActorUseCase(ctrl)
 	directorUseCase := directorMocks.NewMockDirectorUseCase(ctrl)
+	entitlementUseCase := entitlementMocks.NewMockEntitlementUsecase(ctrl)
+	videoSigner := helpers.NewVideoURLSigner("secret")
+	jobUseCase := jobMocks.NewMockJobUsecase(ctrl)
 
 	isFree := true
 	var contentInst *models.Content = &models.Content{
@@ -244,7 +253,7 @@ func TestMovieHandler_UpdateMovieHandler(t *testing.T) {
 	c.Set("userID", 3)
 
 	movieHandler := NewMovieHandler(movieUseCase, contentUseCase,
-		countryUseCase, genreUseCase, actorUseCase, directorUseCase)
+		countryUseCase, genreUseCase, actorUseCase, directorUseCase, entitlementUseCase, videoSigner, jobUseCase)
 	handleFunc := movieHandler.UpdateMovieHandler()
 	movieHandler.Configure(e, nil)
 
@@ -306,6 +315,9 @@ func TestMovieHandler_DeleteMovieHandler(t *testing.T) {
 	genreUseCase := genreMocks.NewMockGenreUsecase(ctrl)
 	actorUseCase := actorMocks.NewMockActorUseCase(ctrl)
 	directorUseCase := directorMocks.NewMockDirectorUseCase(ctrl)
+	entitlementUseCase := entitlementMocks.NewMockEntitlementUsecase(ctrl)
+	videoSigner := helpers.NewVideoURLSigner("secret")
+	jobUseCase := jobMocks.NewMockJobUsecase(ctrl)
 
 	var contentInst *models.Content = &models.Content{
 		Name:             "Шрек",
@@ -334,7 +346,7 @@ func TestMovieHandler_DeleteMovieHandler(t *testing.T) {
 	c.Set("userID", 3)
 
 	movieHandler := NewMovieHandler(movieUseCase, contentUseCase,
-		countryUseCase, genreUseCase, actorUseCase, directorUseCase)
+		countryUseCase, genreUseCase, actorUseCase, directorUseCase, entitlementUseCase, videoSigner, jobUseCase)
 	handleFunc := movieHandler.DeleteMovieHandler()
 	movieHandler.Configure(e, nil)
 
@@ -343,11 +355,6 @@ func TestMovieHandler_DeleteMovieHandler(t *testing.T) {
 		GetByID(movieInst.ID).
 		Return(movieInst, nil)
 
-	movieUseCase.
-		EXPECT().
-		DeleteByID(movieInst.ID).
-		Return(nil)
-
 	contentUseCase.
 		EXPECT().
 		DeleteByID(movieInst.ContentID).
@@ -382,6 +389,9 @@ func TestMovieHandler_DeleteMovieHandler_NoMovie(t *testing.T) {
 	genreUseCase := genreMocks.NewMockGenreUsecase(ctrl)
 	actorUseCase := actorMocks.NewMockActorUseCase(ctrl)
 	directorUseCase := directorMocks.NewMockDirectorUseCase(ctrl)
+	entitlementUseCase := entitlementMocks.NewMockEntitlementUsecase(ctrl)
+	videoSigner := helpers.NewVideoURLSigner("secret")
+	jobUseCase := jobMocks.NewMockJobUsecase(ctrl)
 
 	var contentInst *models.Content = &models.Content{
 		Name:             "Шрек",
@@ -410,7 +420,7 @@ func TestMovieHandler_DeleteMovieHandler_NoMovie(t *testing.T) {
 	c.Set("userID", 3)
 
 	movieHandler := NewMovieHandler(movieUseCase, contentUseCase,
-		countryUseCase, genreUseCase, actorUseCase, directorUseCase)
+		countryUseCase, genreUseCase, actorUseCase, directorUseCase, entitlementUseCase, videoSigner, jobUseCase)
 	handleFunc := movieHandler.DeleteMovieHandler()
 	movieHandler.Configure(e, nil)
 
@@ -447,6 +457,9 @@ func TestMovieHandler_GetMovieHandler(t *testing.T) {
 	genreUseCase := genreMocks.NewMockGenreUsecase(ctrl)
 	actorUseCase := actorMocks.NewMockActorUseCase(ctrl)
 	directorUseCase := directorMocks.NewMockDirectorUseCase(ctrl)
+	entitlementUseCase := entitlementMocks.NewMockEntitlementUsecase(ctrl)
+	videoSigner := helpers.NewVideoURLSigner("secret")
+	jobUseCase := jobMocks.NewMockJobUsecase(ctrl)
 
 	var contentInst *models.Content = &models.Content{
 		Name:             "Шрек",
@@ -476,7 +489,7 @@ func TestMovieHandler_GetMovieHandler(t *testing.T) {
 	c.Set("userID", 3)
 
 	movieHandler := NewMovieHandler(movieUseCase, contentUseCase,
-		countryUseCase, genreUseCase, actorUseCase, directorUseCase)
+		countryUseCase, genreUseCase, actorUseCase, directorUseCase, entitlementUseCase, videoSigner, jobUseCase)
 	handleFunc := movieHandler.GetMovieHandler()
 	movieHandler.Configure(e, nil)
 
@@ -485,6 +498,11 @@ func TestMovieHandler_GetMovieHandler(t *testing.T) {
 		GetFullByID(movieInst.ID, userID).
 		Return(movieInst, nil)
 
+	entitlementUseCase.
+		EXPECT().
+		HasAccess(userID, &movieInst.Content).
+		Return(true, nil)
+
 	response := &response.Response{Body: &response.Body{"movie": movieInst}}
 
 	// Assertions
@@ -514,6 +532,9 @@ func TestMovieHandler_UpdateMovieVideoHandler(t *testing.T) {
 	genreUseCase := genreMocks.NewMockGenreUsecase(ctrl)
 	actorUseCase := actorMocks.NewMockActorUseCase(ctrl)
 	directorUseCase := directorMocks.NewMockDirectorUseCase(ctrl)
+	entitlementUseCase := entitlementMocks.NewMockEntitlementUsecase(ctrl)
+	videoSigner := helpers.NewVideoURLSigner("secret")
+	jobUseCase := jobMocks.NewMockJobUsecase(ctrl)
 
 	e := echo.New()
 	strId := strconv.Itoa(1)
@@ -525,7 +546,7 @@ func TestMovieHandler_UpdateMovieVideoHandler(t *testing.T) {
 	c.SetParamValues(strId)
 
 	movieHandler := NewMovieHandler(movieUseCase, contentUseCase,
-		countryUseCase, genreUseCase, actorUseCase, directorUseCase)
+		countryUseCase, genreUseCase, actorUseCase, directorUseCase, entitlementUseCase, videoSigner, jobUseCase)
 	handleFunc := movieHandler.UpdateMovieVideoHandler()
 	movieHandler.Configure(e, nil)
 
@@ -563,6 +584,9 @@ func TestMovieHandler_GetMoviesHandler(t *testing.T) {
 	genreUseCase := genreMocks.NewMockGenreUsecase(ctrl)
 	actorUseCase := actorMocks.NewMockActorUseCase(ctrl)
 	directorUseCase := directorMocks.NewMockDirectorUseCase(ctrl)
+	entitlementUseCase := entitlementMocks.NewMockEntitlementUsecase(ctrl)
+	videoSigner := helpers.NewVideoURLSigner("secret")
+	jobUseCase := jobMocks.NewMockJobUsecase(ctrl)
 
 	pgnt := &models.Pagination{
 		From:  0,
@@ -601,7 +625,7 @@ func TestMovieHandler_GetMoviesHandler(t *testing.T) {
 	c.Set("userID", userID)
 
 	movieHandler := NewMovieHandler(movieUseCase, contentUseCase,
-		countryUseCase, genreUseCase, actorUseCase, directorUseCase)
+		countryUseCase, genreUseCase, actorUseCase, directorUseCase, entitlementUseCase, videoSigner, jobUseCase)
 	handleFunc := movieHandler.GetMoviesHandler()
 	movieHandler.Configure(e, nil)
 
@@ -619,7 +643,7 @@ func TestMovieHandler_GetMoviesHandler(t *testing.T) {
 
 	movieUseCase.
 		EXPECT().
-		ListByParams(params, pgnt, userID).
+		ListByParams(params, nil, pgnt, userID).
 		Return(movies, nil)
 
 	response := &response.Response{Body: &response.Body{"movies": movies}}
@@ -639,6 +663,47 @@ func TestMovieHandler_GetMoviesHandler(t *testing.T) {
 	}
 }
 
+func TestMovieHandler_GetMoviesHandler_StatusesIgnored(t *testing.T) {
+	t.Parallel()
+	// Setup
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	movieUseCase := movieMocks.NewMockMovieUsecase(ctrl)
+	contentUseCase := contentMocks.NewMockContentUsecase(ctrl)
+	countryUseCase := countryMocks.NewMockCountryUsecase(ctrl)
+	genreUseCase := genreMocks.NewMockGenreUsecase(ctrl)
+	actorUseCase := actorMocks.NewMockActorUseCase(ctrl)
+	directorUseCase := directorMocks.NewMockDirectorUseCase(ctrl)
+	entitlementUseCase := entitlementMocks.NewMockEntitlementUsecase(ctrl)
+	videoSigner := helpers.NewVideoURLSigner("secret")
+	jobUseCase := jobMocks.NewMockJobUsecase(ctrl)
+
+	var userID uint64 = 0
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/movies?-=draft&status=draft",
+		strings.NewReader(`{"Statuses":["draft"],"statuses":["archived"]}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("userID", userID)
+
+	movieHandler := NewMovieHandler(movieUseCase, contentUseCase,
+		countryUseCase, genreUseCase, actorUseCase, directorUseCase, entitlementUseCase, videoSigner, jobUseCase)
+	handleFunc := movieHandler.GetMoviesHandler()
+
+	// only published content is listed whatever the request contains
+	movieUseCase.
+		EXPECT().
+		ListByParams(&models.ContentFilter{}, nil, &models.Pagination{}, userID).
+		Return([]*models.Movie{}, nil)
+
+	// Assertions
+	if assert.NoError(t, handleFunc(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+}
+
 func TestMovieHandler_GetLatestMoviesHandler(t *testing.T) {
 	t.Parallel()
 	// Setup
@@ -650,6 +715,9 @@ func TestMovieHandler_GetLatestMoviesHandler(t *testing.T) {
 	genreUseCase := genreMocks.NewMockGenreUsecase(ctrl)
 	actorUseCase := actorMocks.NewMockActorUseCase(ctrl)
 	directorUseCase := directorMocks.NewMockDirectorUseCase(ctrl)
+	entitlementUseCase := entitlementMocks.NewMockEntitlementUsecase(ctrl)
+	videoSigner := helpers.NewVideoURLSigner("secret")
+	jobUseCase := jobMocks.NewMockJobUsecase(ctrl)
 
 	pgnt := &models.Pagination{
 		From:  0,
@@ -670,7 +738,7 @@ func TestMovieHandler_GetLatestMoviesHandler(t *testing.T) {
 	c.Set("userID", userID)
 
 	movieHandler := NewMovieHandler(movieUseCase, contentUseCase,
-		countryUseCase, genreUseCase, actorUseCase, directorUseCase)
+		countryUseCase, genreUseCase, actorUseCase, directorUseCase, entitlementUseCase, videoSigner, jobUseCase)
 	handleFunc := movieHandler.GetLatestMoviesHandler()
 	movieHandler.Configure(e, nil)
 
@@ -719,6 +787,9 @@ func TestMovieHandler_GetMoviesByRatingHandler(t *testing.T) {
 	genreUseCase := genreMocks.NewMockGenreUsecase(ctrl)
 	actorUseCase := actorMocks.NewMockActorUseCase(ctrl)
 	directorUseCase := directorMocks.NewMockDirectorUseCase(ctrl)
+	entitlementUseCase := entitlementMocks.NewMockEntitlementUsecase(ctrl)
+	videoSigner := helpers.NewVideoURLSigner("secret")
+	jobUseCase := jobMocks.NewMockJobUsecase(ctrl)
 
 	pgnt := &models.Pagination{
 		From:  0,
@@ -739,7 +810,7 @@ func TestMovieHandler_GetMoviesByRatingHandler(t *testing.T) {
 	c.Set("userID", userID)
 
 	movieHandler := NewMovieHandler(movieUseCase, contentUseCase,
-		countryUseCase, genreUseCase, actorUseCase, directorUseCase)
+		countryUseCase, genreUseCase, actorUseCase, directorUseCase, entitlementUseCase, videoSigner, jobUseCase)
 	handleFunc := movieHandler.GetTopMovieListHandler()
 	movieHandler.Configure(e, nil)
 
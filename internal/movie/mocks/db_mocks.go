@@ -96,11 +96,13 @@ func MockMovieRepoSelectByParamsReturnRows(mock sqlmock.Sqlmock, params *models.
 
 	rows := sqlmock.NewRows([]string{"m.id", "m.video", "c.id", "c.name",
 		"c.original_name", "c.description", "c.short_description", "c.rating",
-		"c.year", "c.is_free", "c.images", "c.type", "r.likes", "is_favourite"})
+		"c.year", "c.is_free", "c.images", "c.type", "c.status", "c.publish_at",
+		"r.likes", "is_favourite"})
 	for _, movie := range movies {
 		rows.AddRow(movie.ID, movie.Video, movie.ContentID, movie.Name,
 			movie.OriginalName, movie.Description, movie.ShortDescription, movie.Rating,
-			movie.Year, movie.Images, movie.Type, movie.IsFree, movie.IsLiked, movie.IsFavourite)
+			movie.Year, movie.Images, movie.Type, movie.IsFree, movie.Status, movie.PublishAt,
+			movie.IsLiked, movie.IsFavourite)
 	}
 	query := `
 		SELECT m.id, m.video, c.id, c.name`
@@ -142,8 +144,8 @@ func MockMovieRepoSelectByRatingReturnRows(mock sqlmock.Sqlmock, pgnt *models.Pa
 	mock.ExpectQuery(query).WithArgs(curUserID, pgnt.Count, pgnt.From).WillReturnRows(rows)
 }
 
-func MockMovieRepoSelectWhereNameLikeReturnRows(mock sqlmock.Sqlmock, pgnt *models.Pagination, curUserID uint64,
-	movies []*models.Movie, name string) {
+func MockMovieRepoSelectByQueryReturnRows(mock sqlmock.Sqlmock, pgnt *models.Pagination, curUserID uint64,
+	movies []*models.Movie, searchQuery string) {
 
 	rows := sqlmock.NewRows([]string{"m.id", "m.video", "c.id", "c.name",
 		"c.original_name", "c.description", "c.short_description", "c.rating",
@@ -156,6 +158,5 @@ func MockMovieRepoSelectWhereNameLikeReturnRows(mock sqlmock.Sqlmock, pgnt *mode
 	query := `
 		SELECT m.id, m.video, c.id, c.name`
 
-	searchName := "%" + name + "%"
-	mock.ExpectQuery(query).WithArgs(curUserID, searchName, pgnt.Count, pgnt.From).WillReturnRows(rows)
+	mock.ExpectQuery(query).WithArgs(curUserID, searchQuery, pgnt.Count, pgnt.From).WillReturnRows(rows)
 }
@@ -1,7 +1,7 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: repository.go
+// Source: internal/movie/repository.go
 
-// Package mock_movie is a generated GoMock package.
+// Package mocks is a generated GoMock package.
 package mocks
 
 import (
@@ -121,18 +121,18 @@ func (mr *MockMovieRepositoryMockRecorder) SelectByContentID(contentID interface
 }
 
 // SelectByParams mocks base method
-func (m *MockMovieRepository) SelectByParams(params *models.ContentFilter, pgnt *models.Pagination, curUserID uint64) ([]*models.Movie, error) {
+func (m *MockMovieRepository) SelectByParams(params *models.ContentFilter, statuses []string, pgnt *models.Pagination, curUserID uint64) ([]*models.Movie, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SelectByParams", params, pgnt, curUserID)
+	ret := m.ctrl.Call(m, "SelectByParams", params, statuses, pgnt, curUserID)
 	ret0, _ := ret[0].([]*models.Movie)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SelectByParams indicates an expected call of SelectByParams
-func (mr *MockMovieRepositoryMockRecorder) SelectByParams(params, pgnt, curUserID interface{}) *gomock.Call {
+func (mr *MockMovieRepositoryMockRecorder) SelectByParams(params, statuses, pgnt, curUserID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectByParams", reflect.TypeOf((*MockMovieRepository)(nil).SelectByParams), params, pgnt, curUserID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectByParams", reflect.TypeOf((*MockMovieRepository)(nil).SelectByParams), params, statuses, pgnt, curUserID)
 }
 
 // SelectLatest mocks base method
@@ -165,17 +165,17 @@ func (mr *MockMovieRepositoryMockRecorder) SelectByRating(pgnt, curUserID interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectByRating", reflect.TypeOf((*MockMovieRepository)(nil).SelectByRating), pgnt, curUserID)
 }
 
-// SelectWhereNameLike mocks base method
-func (m *MockMovieRepository) SelectWhereNameLike(curUserID uint64, name string, limit, offset uint64) ([]*models.Movie, error) {
+// SelectByQuery mocks base method
+func (m *MockMovieRepository) SelectByQuery(query string, pgnt *models.Pagination, curUserID uint64) ([]*models.Movie, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SelectWhereNameLike", curUserID, name, limit, offset)
+	ret := m.ctrl.Call(m, "SelectByQuery", query, pgnt, curUserID)
 	ret0, _ := ret[0].([]*models.Movie)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// SelectWhereNameLike indicates an expected call of SelectWhereNameLike
-func (mr *MockMovieRepositoryMockRecorder) SelectWhereNameLike(curUserID, name, limit, offset interface{}) *gomock.Call {
+// SelectByQuery indicates an expected call of SelectByQuery
+func (mr *MockMovieRepositoryMockRecorder) SelectByQuery(query, pgnt, curUserID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectWhereNameLike", reflect.TypeOf((*MockMovieRepository)(nil).SelectWhereNameLike), curUserID, name, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectByQuery", reflect.TypeOf((*MockMovieRepository)(nil).SelectByQuery), query, pgnt, curUserID)
 }
@@ -62,6 +62,20 @@ func (mr *MockMovieUsecaseMockRecorder) UpdateVideo(movie, newVideoPath interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVideo", reflect.TypeOf((*MockMovieUsecase)(nil).UpdateVideo), movie, newVideoPath)
 }
 
+// ProcessVideoJob mocks base method
+func (m *MockMovieUsecase) ProcessVideoJob(job *models.Job) *errors.Error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessVideoJob", job)
+	ret0, _ := ret[0].(*errors.Error)
+	return ret0
+}
+
+// ProcessVideoJob indicates an expected call of ProcessVideoJob
+func (mr *MockMovieUsecaseMockRecorder) ProcessVideoJob(job interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessVideoJob", reflect.TypeOf((*MockMovieUsecase)(nil).ProcessVideoJob), job)
+}
+
 // DeleteByID mocks base method
 func (m *MockMovieUsecase) DeleteByID(movieID uint64) *errors.Error {
 	m.ctrl.T.Helper()
@@ -122,18 +136,18 @@ func (mr *MockMovieUsecaseMockRecorder) GetByContentID(contentID interface{}) *g
 }
 
 // ListByParams mocks base method
-func (m *MockMovieUsecase) ListByParams(params *models.ContentFilter, pgnt *models.Pagination, curUserID uint64) ([]*models.Movie, *errors.Error) {
+func (m *MockMovieUsecase) ListByParams(params *models.ContentFilter, statuses []string, pgnt *models.Pagination, curUserID uint64) ([]*models.Movie, *errors.Error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListByParams", params, pgnt, curUserID)
+	ret := m.ctrl.Call(m, "ListByParams", params, statuses, pgnt, curUserID)
 	ret0, _ := ret[0].([]*models.Movie)
 	ret1, _ := ret[1].(*errors.Error)
 	return ret0, ret1
 }
 
 // ListByParams indicates an expected call of ListByParams
-func (mr *MockMovieUsecaseMockRecorder) ListByParams(params, pgnt, curUserID interface{}) *gomock.Call {
+func (mr *MockMovieUsecaseMockRecorder) ListByParams(params, statuses, pgnt, curUserID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByParams", reflect.TypeOf((*MockMovieUsecase)(nil).ListByParams), params, pgnt, curUserID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByParams", reflect.TypeOf((*MockMovieUsecase)(nil).ListByParams), params, statuses, pgnt, curUserID)
 }
 
 // ListLatest mocks base method
@@ -11,9 +11,9 @@ type MovieRepository interface {
 	SelectByID(movieID uint64) (*models.Movie, error)
 	SelectFullByID(movieID uint64, curUserID uint64) (*models.Movie, error)
 	SelectByContentID(contentID uint64) (*models.Movie, error)
-	SelectByParams(params *models.ContentFilter, pgnt *models.Pagination,
+	SelectByParams(params *models.ContentFilter, statuses []string, pgnt *models.Pagination,
 		curUserID uint64) ([]*models.Movie, error)
 	SelectLatest(pgnt *models.Pagination, curUserID uint64) ([]*models.Movie, error)
 	SelectByRating(pgnt *models.Pagination, curUserID uint64) ([]*models.Movie, error)
-	SelectWhereNameLike(curUserID uint64, name string, limit, offset uint64) ([]*models.Movie, error)
+	SelectByQuery(query string, pgnt *models.Pagination, curUserID uint64) ([]*models.Movie, error)
 }
@@ -100,9 +100,10 @@ func (mr *MoviePgRepository) SelectByID(movieID uint64) (*models.Movie, error) {
 	movie := &models.Movie{}
 
 	row := mr.dbConn.QueryRow(
-		`SELECT id, video, content_id
-		FROM movies
-		WHERE id=$1`,
+		`SELECT m.id, m.video, m.content_id
+		FROM movies AS m
+		JOIN content AS c ON c.id=m.content_id
+		WHERE m.id=$1 AND c.deleted_at IS NULL`,
 		movieID)
 
 	if err := row.Scan(&movie.ID, &movie.Video, &movie.ContentID); err != nil {
@@ -122,7 +123,8 @@ func (mr *MoviePgRepository) SelectFullByID(movieID uint64, curUserID uint64) (*
 		FROM content AS c
 		JOIN movies as m ON m.content_id=c.id AND m.id=$1
 		LEFT OUTER JOIN rates as r ON r.user_id=$2 AND r.content_id=c.id
-		LEFT OUTER JOIN favourites as f ON f.user_id=$2 AND f.content_id=c.id`,
+		LEFT OUTER JOIN favourites as f ON f.user_id=$2 AND f.content_id=c.id
+		WHERE `+queryBuilder.BuildPublishedCondition(),
 		movieID, curUserID)
 
 	err := row.Scan(&movie.ID, &movie.Video, &cnt.ContentID, &cnt.Name,
@@ -151,12 +153,13 @@ func (mr *MoviePgRepository) SelectByContentID(contentID uint64) (*models.Movie,
 	return movie, nil
 }
 
-func (mr *MoviePgRepository) SelectByParams(params *models.ContentFilter,
+func (mr *MoviePgRepository) SelectByParams(params *models.ContentFilter, statuses []string,
 	pgnt *models.Pagination, curUserID uint64) ([]*models.Movie, error) {
 
 	selectQuery := `
 		SELECT m.id, m.video, c.id, c.name, c.original_name, c.description,
-		c.short_description, c.rating, c.year, c.images, c.type, c.is_free, r.likes,
+		c.short_description, c.rating, c.year, c.images, c.type, c.is_free, c.status,
+		c.publish_at, r.likes,
 		CASE WHEN f.content_id IS NULL THEN false ELSE true END AS is_favourite
 		FROM content as c`
 
@@ -180,7 +183,7 @@ func (mr *MoviePgRepository) SelectByParams(params *models.ContentFilter,
 		values = append(values, params.IsFree)
 	}
 
-	filtersJoinQuery, values := queryBuilder.GetContentJoinFiltersByParams(values, params)
+	filtersJoinQuery, values := queryBuilder.GetContentJoinFiltersByParams(values, params, statuses)
 
 	resultQuery := strings.Join([]string{
 		selectQuery,
@@ -203,7 +206,8 @@ func (mr *MoviePgRepository) SelectByParams(params *models.ContentFilter,
 
 		err := rows.Scan(&movie.ID, &movie.Video, &cnt.ContentID, &cnt.Name,
 			&cnt.OriginalName, &cnt.Description, &cnt.ShortDescription, &cnt.Rating,
-			&cnt.Year, &cnt.Images, &cnt.Type, &cnt.IsFree, &cnt.IsLiked, &cnt.IsFavourite)
+			&cnt.Year, &cnt.Images, &cnt.Type, &cnt.IsFree, &cnt.Status, &cnt.PublishAt,
+			&cnt.IsLiked, &cnt.IsFavourite)
 		if err != nil {
 			return nil, err
 		}
@@ -230,6 +234,7 @@ func (mr *MoviePgRepository) SelectLatest(pgnt *models.Pagination, curUserID uin
 		JOIN movies as m ON m.content_id=c.id
 		LEFT OUTER JOIN rates as r ON r.user_id=$1 AND r.content_id=c.id
 		LEFT OUTER JOIN favourites as f ON f.user_id=$1 AND f.content_id=c.id
+		WHERE ` + queryBuilder.BuildPublishedCondition() + `
 		ORDER BY c.year DESC`
 	values = append(values, curUserID)
 
@@ -285,6 +290,7 @@ func (mr *MoviePgRepository) SelectByRating(pgnt *models.Pagination, curUserID u
 		JOIN movies as m ON m.content_id=c.id
 		LEFT OUTER JOIN rates as r ON r.user_id=$1 AND r.content_id=c.id
 		LEFT OUTER JOIN favourites as f ON f.user_id=$1 AND f.content_id=c.id
+		WHERE ` + queryBuilder.BuildPublishedCondition() + `
 		ORDER BY c.rating DESC`
 	values = append(values, curUserID)
 
@@ -327,8 +333,12 @@ func (mr *MoviePgRepository) SelectByRating(pgnt *models.Pagination, curUserID u
 	return movies, nil
 }
 
-func (mr *MoviePgRepository) SelectWhereNameLike(curUserID uint64, name string, limit, offset uint64) ([]*models.Movie, error) {
-	selectQuery := `
+func (mr *MoviePgRepository) SelectByQuery(query string, pgnt *models.Pagination,
+	curUserID uint64) ([]*models.Movie, error) {
+	var values []interface{}
+
+	searchCondition, searchRank := queryBuilder.BuildContentSearchQuery(2)
+	selectQuery := fmt.Sprintf(`
 		SELECT m.id, m.video, c.id, c.name, c.original_name,
 		c.description, c.short_description,
 		c.rating, c.year, c.images, c.type, c.is_free, r.likes,
@@ -337,18 +347,15 @@ func (mr *MoviePgRepository) SelectWhereNameLike(curUserID uint64, name string,
 		JOIN movies as m ON m.content_id=c.id
 		LEFT OUTER JOIN rates as r ON r.user_id=$1 AND r.content_id=c.id
 		LEFT OUTER JOIN favourites as f ON f.user_id=$1 AND f.content_id=c.id
-		WHERE c.name ILIKE $2 OR c.original_name ILIKE $2
-		ORDER BY m.id`
-
-	var values []interface{}
-	values = append(values, curUserID)
-	searchName := "%" + name + "%"
-	values = append(values, searchName)
+		WHERE %s AND %s
+		ORDER BY %s DESC, c.rating DESC, c.id`,
+		searchCondition, queryBuilder.BuildPublishedCondition(), searchRank)
+	values = append(values, curUserID, query)
 
 	var pgntQuery string
-	if limit != 0 {
+	if pgnt.Count != 0 {
 		pgntQuery = "LIMIT $3 OFFSET $4"
-		values = append(values, limit, offset)
+		values = append(values, pgnt.Count, pgnt.From)
 	}
 
 	resultQuery := strings.Join([]string{
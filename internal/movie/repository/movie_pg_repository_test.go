@@ -269,7 +269,7 @@ func TestMoviePgRepository_SelectByParams_OK(t *testing.T) {
 	}
 
 	mocks.MockMovieRepoSelectByParamsReturnRows(mock, params, pgnt, userID, movies)
-	dbMovies, err := moviePgRep.SelectByParams(params, pgnt, userID)
+	dbMovies, err := moviePgRep.SelectByParams(params, nil, pgnt, userID)
 	assert.Equal(t, movies, dbMovies)
 	assert.NoError(t, err)
 
@@ -354,7 +354,7 @@ func TestMoviePgRepository_SelectByRating_OK(t *testing.T) {
 	}
 }
 
-func TestMoviePgRepository_SelectWhereNameLike_OK(t *testing.T) {
+func TestMoviePgRepository_SelectByQuery_OK(t *testing.T) {
 	t.Parallel()
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -381,10 +381,10 @@ func TestMoviePgRepository_SelectWhereNameLike_OK(t *testing.T) {
 		Count: 1,
 	}
 	var userID uint64 = 1
-	name := "Shreck"
+	query := "Shreck"
 
-	mocks.MockMovieRepoSelectWhereNameLikeReturnRows(mock, pgnt, userID, movies, name)
-	dbMovies, err := moviePgRep.SelectWhereNameLike(userID, name, pgnt.Count, pgnt.From)
+	mocks.MockMovieRepoSelectByQueryReturnRows(mock, pgnt, userID, movies, query)
+	dbMovies, err := moviePgRep.SelectByQuery(query, pgnt, userID)
 	assert.Equal(t, movies, dbMovies)
 	assert.NoError(t, err)
 
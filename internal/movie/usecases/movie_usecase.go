@@ -2,11 +2,13 @@ package usecases
 
 import (
 	"database/sql"
+	"encoding/json"
 	"os"
 	"path/filepath"
 
 	. "github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/content"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/movie"
@@ -15,13 +17,15 @@ import (
 type MovieUsecase struct {
 	movieRepo    movie.MovieRepository
 	contentUcase content.ContentUsecase
+	hlsPackager  *helpers.HLSPackager
 }
 
 func NewMovieUsecase(repo movie.MovieRepository,
-	contentUcase content.ContentUsecase) movie.MovieUsecase {
+	contentUcase content.ContentUsecase, hlsPackager *helpers.HLSPackager) movie.MovieUsecase {
 	return &MovieUsecase{
 		movieRepo:    repo,
 		contentUcase: contentUcase,
+		hlsPackager:  hlsPackager,
 	}
 }
 
@@ -57,6 +61,39 @@ func (mu *MovieUsecase) UpdateVideo(movie *models.Movie, newVideoPath string) *e
 	return nil
 }
 
+// ProcessVideoJob segments staged video into HLS and sets it to the movie
+func (mu *MovieUsecase) ProcessVideoJob(job *models.Job) *errors.Error {
+	payload := &models.MovieVideoPayload{}
+	if err := json.Unmarshal(job.Payload, payload); err != nil {
+		return errors.New(CodeInternalError, err)
+	}
+
+	path, osErr := os.Getwd()
+	if osErr != nil {
+		return errors.New(CodeInternalError, osErr)
+	}
+	absVideoPath := filepath.Join(path, payload.VideoPath)
+	absStagedPath := helpers.GetStagedPath(absVideoPath)
+
+	err := mu.processVideo(payload, absStagedPath, absVideoPath)
+	if err == nil || job.IsLastAttempt() {
+		helpers.RemoveStagedFile(absStagedPath)
+	}
+	return err
+}
+
+func (mu *MovieUsecase) processVideo(payload *models.MovieVideoPayload, absStagedPath, absVideoPath string) *errors.Error {
+	movie, err := mu.GetByID(payload.MovieID)
+	if err != nil {
+		return err
+	}
+
+	if err := mu.hlsPackager.StoreStaged(absStagedPath, absVideoPath); err != nil {
+		return err
+	}
+	return mu.UpdateVideo(movie, payload.VideoPath)
+}
+
 func (mu *MovieUsecase) DeleteByID(movieID uint64) *errors.Error {
 	movie, err := mu.GetByID(movieID)
 	if err != nil {
@@ -120,10 +157,10 @@ func (mu *MovieUsecase) GetByContentID(contentID uint64) (*models.Movie, *errors
 	return movie, nil
 }
 
-func (mu *MovieUsecase) ListByParams(params *models.ContentFilter, pgnt *models.Pagination,
+func (mu *MovieUsecase) ListByParams(params *models.ContentFilter, statuses []string, pgnt *models.Pagination,
 	curUserID uint64) ([]*models.Movie, *errors.Error) {
 
-	movies, err := mu.movieRepo.SelectByParams(params, pgnt, curUserID)
+	movies, err := mu.movieRepo.SelectByParams(params, statuses, pgnt, curUserID)
 	if err != nil {
 		return nil, errors.New(CodeInternalError, err)
 	}
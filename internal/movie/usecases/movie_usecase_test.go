@@ -30,7 +30,7 @@ func TestMovieUseCase_Create_OK(t *testing.T) {
 
 	movieRep := mocks.NewMockMovieRepository(ctrl)
 	contentUseCase := contentMocks.NewMockContentUsecase(ctrl)
-	movieUseCase := NewMovieUsecase(movieRep, contentUseCase)
+	movieUseCase := NewMovieUsecase(movieRep, contentUseCase, nil)
 
 	movieRep.
 		EXPECT().
@@ -58,7 +58,7 @@ func TestMovieUseCase_Create_Fail(t *testing.T) {
 
 	movieRep := mocks.NewMockMovieRepository(ctrl)
 	contentUseCase := contentMocks.NewMockContentUsecase(ctrl)
-	movieUseCase := NewMovieUsecase(movieRep, contentUseCase)
+	movieUseCase := NewMovieUsecase(movieRep, contentUseCase, nil)
 
 	movieRep.
 		EXPECT().
@@ -76,7 +76,7 @@ func TestMovieUseCase_UpdateVideo_OK(t *testing.T) {
 
 	movieRep := mocks.NewMockMovieRepository(ctrl)
 	contentUseCase := contentMocks.NewMockContentUsecase(ctrl)
-	movieUseCase := NewMovieUsecase(movieRep, contentUseCase)
+	movieUseCase := NewMovieUsecase(movieRep, contentUseCase, nil)
 
 	newVideoPath := "video/movie.mp4"
 
@@ -96,7 +96,7 @@ func TestMovieUseCase_GetByID_OK(t *testing.T) {
 
 	movieRep := mocks.NewMockMovieRepository(ctrl)
 	contentUseCase := contentMocks.NewMockContentUsecase(ctrl)
-	movieUseCase := NewMovieUsecase(movieRep, contentUseCase)
+	movieUseCase := NewMovieUsecase(movieRep, contentUseCase, nil)
 
 	movieRep.
 		EXPECT().
@@ -115,7 +115,7 @@ func TestMovieUseCase_GetByID_Fail(t *testing.T) {
 
 	movieRep := mocks.NewMockMovieRepository(ctrl)
 	contentUseCase := contentMocks.NewMockContentUsecase(ctrl)
-	movieUseCase := NewMovieUsecase(movieRep, contentUseCase)
+	movieUseCase := NewMovieUsecase(movieRep, contentUseCase, nil)
 
 	movieRep.
 		EXPECT().
@@ -134,7 +134,7 @@ func TestMovieUseCase_GetFullByID_OK(t *testing.T) {
 
 	movieRep := mocks.NewMockMovieRepository(ctrl)
 	contentUseCase := contentMocks.NewMockContentUsecase(ctrl)
-	movieUseCase := NewMovieUsecase(movieRep, contentUseCase)
+	movieUseCase := NewMovieUsecase(movieRep, contentUseCase, nil)
 	var userID uint64 = 1
 
 	movieRep.
@@ -159,7 +159,7 @@ func TestMovieUseCase_GetByContentID_Fail(t *testing.T) {
 
 	movieRep := mocks.NewMockMovieRepository(ctrl)
 	contentUseCase := contentMocks.NewMockContentUsecase(ctrl)
-	movieUseCase := NewMovieUsecase(movieRep, contentUseCase)
+	movieUseCase := NewMovieUsecase(movieRep, contentUseCase, nil)
 
 	movieRep.
 		EXPECT().
@@ -178,7 +178,7 @@ func TestMovieUseCase_ListByParams_OK(t *testing.T) {
 
 	movieRep := mocks.NewMockMovieRepository(ctrl)
 	contentUseCase := contentMocks.NewMockContentUsecase(ctrl)
-	movieUseCase := NewMovieUsecase(movieRep, contentUseCase)
+	movieUseCase := NewMovieUsecase(movieRep, contentUseCase, nil)
 
 	var contentInst *models.Content = &models.Content{
 		Name:             "Шрек",
@@ -219,10 +219,10 @@ func TestMovieUseCase_ListByParams_OK(t *testing.T) {
 
 	movieRep.
 		EXPECT().
-		SelectByParams(gomock.Eq(params), gomock.Eq(pgnt), gomock.Eq(userID)).
+		SelectByParams(gomock.Eq(params), gomock.Nil(), gomock.Eq(pgnt), gomock.Eq(userID)).
 		Return(movies, nil)
 
-	dbMovies, err := movieUseCase.ListByParams(params, pgnt, userID)
+	dbMovies, err := movieUseCase.ListByParams(params, nil, pgnt, userID)
 	assert.Equal(t, err, (*errors.Error)(nil))
 	assert.Equal(t, dbMovies, movies)
 }
@@ -234,7 +234,7 @@ func TestMovieUseCase_ListLatest_OK(t *testing.T) {
 
 	movieRep := mocks.NewMockMovieRepository(ctrl)
 	contentUseCase := contentMocks.NewMockContentUsecase(ctrl)
-	movieUseCase := NewMovieUsecase(movieRep, contentUseCase)
+	movieUseCase := NewMovieUsecase(movieRep, contentUseCase, nil)
 
 	content := []*models.Content{
 		&models.Content{
@@ -271,7 +271,7 @@ func TestMovieUseCase_ListByRating_OK(t *testing.T) {
 
 	movieRep := mocks.NewMockMovieRepository(ctrl)
 	contentUseCase := contentMocks.NewMockContentUsecase(ctrl)
-	movieUseCase := NewMovieUsecase(movieRep, contentUseCase)
+	movieUseCase := NewMovieUsecase(movieRep, contentUseCase, nil)
 
 	content := []*models.Content{
 		&models.Content{
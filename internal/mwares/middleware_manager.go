@@ -1,16 +1,25 @@
 package mwares
 
 import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"math"
 	"net/http"
 	"regexp"
 	"strconv"
 	"time"
 
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/audit"
 	. "github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/mwares/monitoring"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/session"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/user"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools"
 	"github.com/go-park-mail-ru/2020_2_Slash/tools/CSRFManager"
 	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
 	. "github.com/go-park-mail-ru/2020_2_Slash/tools/response"
@@ -18,19 +27,24 @@ import (
 )
 
 type MiddlewareManager struct {
-	sessUcase session.SessionUsecase
-	userUcase user.UserUsecase
-	mntng     *monitoring.Monitoring
-	origins   []string
+	sessUcase  session.SessionUsecase
+	userUcase  user.UserUsecase
+	mntng      *monitoring.Monitoring
+	limiters   map[string]*helpers.RateLimiter
+	auditUcase audit.AuditUsecase
+	origins    []string
 }
 
 func NewMiddlewareManager(sessUcase session.SessionUsecase,
-	userUcase user.UserUsecase, mntng *monitoring.Monitoring) *MiddlewareManager {
+	userUcase user.UserUsecase, mntng *monitoring.Monitoring,
+	limiters map[string]*helpers.RateLimiter, auditUcase audit.AuditUsecase) *MiddlewareManager {
 	return &MiddlewareManager{
-		sessUcase: sessUcase,
-		userUcase: userUcase,
-		mntng:     mntng,
-		origins:   []string{"https://www.flicksbox.ru", "http://www.flicksbox.ru:3000"},
+		sessUcase:  sessUcase,
+		userUcase:  userUcase,
+		mntng:      mntng,
+		limiters:   limiters,
+		auditUcase: auditUcase,
+		origins:    []string{"https://www.flicksbox.ru", "http://www.flicksbox.ru:3000"},
 	}
 }
 
@@ -136,6 +150,7 @@ func (mw *MiddlewareManager) CheckAuth(next echo.HandlerFunc) echo.HandlerFunc {
 			return cntx.JSON(customErr.HTTPCode, Response{Error: customErr})
 		}
 
+		reissueCredentials(cntx, sess)
 		cntx.Set("sessValue", sess.Value)
 		cntx.Set("userID", sess.UserID)
 		return next(cntx)
@@ -154,6 +169,7 @@ func (mw *MiddlewareManager) GetAuth(next echo.HandlerFunc) echo.HandlerFunc {
 			return next(cntx)
 		}
 
+		reissueCredentials(cntx, sess)
 		cntx.Set("sessValue", sess.Value)
 		cntx.Set("userID", sess.UserID)
 		return next(cntx)
@@ -221,3 +237,200 @@ func (mw *MiddlewareManager) CheckCSRF(next echo.HandlerFunc) echo.HandlerFunc {
 		return next(cntx)
 	}
 }
+
+// RateLimit throttles requests of the route group by client IP
+// and by email passed in JSON body, group without limits is not throttled.
+// Only attempts the handler marked by RateLimitCountKey are counted against
+// the account, other successful requests reset its count
+func (mw *MiddlewareManager) RateLimit(group string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(cntx echo.Context) error {
+			limiter, ok := mw.limiters[group]
+			if !ok {
+				return next(cntx)
+			}
+
+			if wait := limiter.AllowIP(cntx.RealIP()); wait > 0 {
+				return mw.rejectAttempt(cntx, group, RateLimitByIP, wait)
+			}
+
+			email, err := peekField(cntx.Request(), "email")
+			if err != nil {
+				customErr := errors.New(CodeBadRequest, err)
+				logger.Error(customErr.Message)
+				return cntx.JSON(customErr.HTTPCode, Response{Error: customErr})
+			}
+			if wait := limiter.AllowAccount(email); wait > 0 {
+				return mw.rejectAttempt(cntx, group, RateLimitByAccount, wait)
+			}
+
+			if err := next(cntx); err != nil {
+				return err
+			}
+
+			if counted, _ := cntx.Get(RateLimitCountKey).(bool); counted {
+				limiter.FailAccount(email)
+			} else if cntx.Response().Status < http.StatusBadRequest {
+				limiter.ResetAccount(email)
+			}
+			return nil
+		}
+	}
+}
+
+func (mw *MiddlewareManager) rejectAttempt(cntx echo.Context, group, key string,
+	wait time.Duration) error {
+	mw.mntng.RateLimited.WithLabelValues(group, key).Inc()
+
+	retryAfter := int(math.Ceil(wait.Seconds()))
+	cntx.Response().Header().Set("Retry-After", strconv.Itoa(retryAfter))
+
+	customErr := errors.Get(CodeTooManyAttempts)
+	logger.Info(customErr.Message, " by ", key, " for ", group)
+	return cntx.JSON(customErr.HTTPCode, Response{Error: customErr})
+}
+
+// peekField reads string field from JSON body and restores the body for the handler,
+// malformed JSON is left for the handler to report
+func peekField(req *http.Request, field string) (string, error) {
+	if req.Body == nil {
+		return "", nil
+	}
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return "", err
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	fields := map[string]interface{}{}
+	// nolint: errcheck
+	json.Unmarshal(body, &fields)
+	value, _ := fields[field].(string)
+	return value, nil
+}
+
+// ResolveAuditEntityType takes the audited entity type from the field of JSON body
+// for routes shared by several entities, it goes before Audit
+func (mw *MiddlewareManager) ResolveAuditEntityType(field string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(cntx echo.Context) error {
+			entityType, err := peekField(cntx.Request(), field)
+			if err != nil {
+				customErr := errors.New(CodeBadRequest, err)
+				logger.Error(customErr.Message)
+				return cntx.JSON(customErr.HTTPCode, Response{Error: customErr})
+			}
+			if entityType != "" {
+				cntx.Set(AuditEntityTypeKey, entityType)
+			}
+			return next(cntx)
+		}
+	}
+}
+
+// Audit records successful mutation of the entity identified by idParam,
+// ID of the created entity is taken from the response, entity type
+// is replaced by the one resolved by ResolveAuditEntityType
+func (mw *MiddlewareManager) Audit(entityType, idParam string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(cntx echo.Context) error {
+			var entityID uint64
+			if idParam != "" {
+				// Invalid ID is reported by the handler
+				// nolint: errcheck
+				entityID, _ = strconv.ParseUint(cntx.Param(idParam), 10, 64)
+			}
+			// Type is resolved before the handler runs,
+			// so both snapshots are taken by the same loader
+			recordType := entityType
+			if resolvedType, ok := cntx.Get(AuditEntityTypeKey).(string); ok {
+				recordType = resolvedType
+			}
+			before := mw.auditUcase.Snapshot(recordType, entityID)
+
+			res := cntx.Response()
+			respBody := &bytes.Buffer{}
+			if entityID == 0 {
+				writer := res.Writer
+				res.Writer = &bodyDumpWriter{Writer: io.MultiWriter(writer, respBody), ResponseWriter: writer}
+				defer func() { res.Writer = writer }()
+			}
+
+			if err := next(cntx); err != nil {
+				return err
+			}
+			if res.Status < http.StatusOK || res.Status >= http.StatusMultipleChoices {
+				return nil
+			}
+
+			action := AuditUpdate
+			switch {
+			case cntx.Request().Method == http.MethodDelete:
+				action = AuditDelete
+			case entityID == 0:
+				action = AuditCreate
+				entityID = createdEntityID(respBody.Bytes())
+			}
+
+			// nolint: errcheck
+			userID, _ := cntx.Get("userID").(uint64)
+			record := &models.AuditRecord{
+				UserID:     userID,
+				Action:     action,
+				EntityType: recordType,
+				EntityID:   entityID,
+				IP:         cntx.RealIP(),
+			}
+			after := mw.auditUcase.Snapshot(recordType, entityID)
+			if customErr := mw.auditUcase.Record(record, before, after); customErr != nil {
+				logger.Error(customErr.Message)
+			}
+			return nil
+		}
+	}
+}
+
+type bodyDumpWriter struct {
+	io.Writer
+	http.ResponseWriter
+}
+
+func (w *bodyDumpWriter) Write(b []byte) (int, error) {
+	return w.Writer.Write(b)
+}
+
+// createdEntityID reads ID of the only entity in the response body
+func createdEntityID(respBody []byte) uint64 {
+	resp := &struct {
+		Body map[string]json.RawMessage `json:"body"`
+	}{}
+	if err := json.Unmarshal(respBody, resp); err != nil || len(resp.Body) != 1 {
+		return 0
+	}
+
+	for _, entityJSON := range resp.Body {
+		entity := &struct {
+			ID uint64 `json:"id"`
+		}{}
+		if err := json.Unmarshal(entityJSON, entity); err == nil {
+			return entity.ID
+		}
+	}
+	return 0
+}
+
+// reissueCredentials sends the cookie and the CSRF token
+// with the new expiry when the session was renewed by the check
+func reissueCredentials(cntx echo.Context, sess *models.Session) {
+	if !sess.Renewed {
+		return
+	}
+
+	token, err := CSRFManager.CreateToken(sess)
+	if err != nil {
+		logger.Error(err.Message)
+		return
+	}
+	cntx.Response().Header().Set("X-Csrf-Token", token)
+	cntx.SetCookie(tools.CreateCookie(sess))
+}
@@ -0,0 +1,284 @@
+package mwares
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	auditMocks "github.com/go-park-mail-ru/2020_2_Slash/internal/audit/mocks"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/mwares/monitoring"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/response"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRateLimitedServer(limit helpers.RateLimit) (*echo.Echo, *monitoring.Monitoring) {
+	logger.InitLogger("/dev/null", 10)
+	mntng := &monitoring.Monitoring{
+		RateLimited: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "rate_limited",
+		}, []string{"group", "key"}),
+	}
+	mw := NewMiddlewareManager(nil, nil, mntng, map[string]*helpers.RateLimiter{
+		consts.LoginRateLimit: helpers.NewRateLimiter(limit),
+	}, nil)
+
+	e := echo.New()
+	e.POST("/login", func(cntx echo.Context) error {
+		// Handler must receive the whole body after the middleware
+		body, err := ioutil.ReadAll(cntx.Request().Body)
+		if err != nil {
+			return err
+		}
+		if strings.Contains(string(body), "wrong") {
+			cntx.Set(consts.RateLimitCountKey, true)
+			return cntx.String(http.StatusBadRequest, string(body))
+		}
+		return cntx.String(http.StatusOK, string(body))
+	}, mw.RateLimit(consts.LoginRateLimit))
+	e.POST("/register", func(cntx echo.Context) error {
+		return cntx.NoContent(http.StatusOK)
+	}, mw.RateLimit(consts.RegisterRateLimit))
+	return e, mntng
+}
+
+func post(e *echo.Echo, target, ip, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(echo.HeaderXRealIP, ip)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestMiddlewareManager_RateLimit_Account(t *testing.T) {
+	t.Parallel()
+	e, mntng := newRateLimitedServer(helpers.RateLimit{
+		IPRequests:      10,
+		AccountRequests: 2,
+		Period:          time.Minute,
+		Lockout:         15 * time.Minute,
+	})
+	body := `{"email":"user@mail.ru","password":"123456"}`
+	wrongBody := `{"email":"user@mail.ru","password":"wrong"}`
+
+	// Successful attempts aren't counted
+	for i := 0; i < 3; i++ {
+		rec := post(e, "/login", "10.0.0.1", body)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, body, rec.Body.String())
+	}
+
+	// Success resets failed attempts
+	rec := post(e, "/login", "10.0.0.1", wrongBody)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	rec = post(e, "/login", "10.0.0.1", body)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	for i := 0; i < 2; i++ {
+		rec = post(e, "/login", "10.0.0.1", wrongBody)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	}
+
+	// Account stays locked for another IP
+	rec = post(e, "/login", "10.0.0.2", body)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "900", rec.Header().Get("Retry-After"))
+	assert.Equal(t, float64(1),
+		testutil.ToFloat64(mntng.RateLimited.WithLabelValues(consts.LoginRateLimit, consts.RateLimitByAccount)))
+
+	rec = post(e, "/login", "10.0.0.2", `{"email":"another@mail.ru"}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestMiddlewareManager_RateLimit_IP(t *testing.T) {
+	t.Parallel()
+	e, mntng := newRateLimitedServer(helpers.RateLimit{
+		IPRequests: 1,
+		Period:     time.Minute,
+	})
+
+	rec := post(e, "/login", "10.0.0.1", `{"email":"user@mail.ru"}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = post(e, "/login", "10.0.0.1", `{"email":"another@mail.ru"}`)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "60", rec.Header().Get("Retry-After"))
+	assert.Equal(t, float64(1),
+		testutil.ToFloat64(mntng.RateLimited.WithLabelValues(consts.LoginRateLimit, consts.RateLimitByIP)))
+
+	// Group without limits isn't throttled
+	rec = post(e, "/register", "10.0.0.1", `{"email":"user@mail.ru"}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestMiddlewareManager_Audit_Create(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	auditUseCase := auditMocks.NewMockAuditUsecase(ctrl)
+	mw := NewMiddlewareManager(nil, nil, nil, nil, auditUseCase)
+
+	var userID uint64 = 3
+	genre := &models.Genre{ID: 2, Name: "drama"}
+
+	e := echo.New()
+	e.POST("/genres", func(cntx echo.Context) error {
+		cntx.Set("userID", userID)
+		return cntx.JSON(http.StatusOK, response.Response{
+			Body: &response.Body{"genre": genre},
+		})
+	}, mw.Audit(consts.AuditGenre, ""))
+
+	expRecord := &models.AuditRecord{
+		UserID:     userID,
+		Action:     consts.AuditCreate,
+		EntityType: consts.AuditGenre,
+		EntityID:   genre.ID,
+		IP:         "10.0.0.1",
+	}
+
+	gomock.InOrder(
+		auditUseCase.
+			EXPECT().
+			Snapshot(consts.AuditGenre, uint64(0)).
+			Return(nil),
+		auditUseCase.
+			EXPECT().
+			Snapshot(consts.AuditGenre, genre.ID).
+			Return(genre),
+		auditUseCase.
+			EXPECT().
+			Record(expRecord, nil, genre).
+			Return(nil),
+	)
+
+	rec := post(e, "/genres", "10.0.0.1", `{"name":"drama"}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"id":2`)
+}
+
+func TestMiddlewareManager_Audit_SpoofedIP(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	auditUseCase := auditMocks.NewMockAuditUsecase(ctrl)
+	mw := NewMiddlewareManager(nil, nil, nil, nil, auditUseCase)
+
+	var userID uint64 = 3
+	genre := &models.Genre{ID: 2, Name: "drama"}
+
+	e := echo.New()
+	e.IPExtractor = helpers.NewIPExtractor(nil)
+	e.PUT("/genres/:id", func(cntx echo.Context) error {
+		cntx.Set("userID", userID)
+		return cntx.NoContent(http.StatusOK)
+	}, mw.Audit(consts.AuditGenre, "id"))
+
+	// Client connected directly can't choose the recorded IP
+	expRecord := &models.AuditRecord{
+		UserID:     userID,
+		Action:     consts.AuditUpdate,
+		EntityType: consts.AuditGenre,
+		EntityID:   genre.ID,
+		IP:         "198.51.100.2",
+	}
+
+	auditUseCase.
+		EXPECT().
+		Snapshot(consts.AuditGenre, genre.ID).
+		Return(genre).
+		Times(2)
+	auditUseCase.
+		EXPECT().
+		Record(expRecord, genre, genre).
+		Return(nil)
+
+	req := httptest.NewRequest(http.MethodPut, "/genres/2", strings.NewReader(`{"name":"drama"}`))
+	req.RemoteAddr = "198.51.100.2:41234"
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(echo.HeaderXRealIP, "10.0.0.1")
+	req.Header.Set(echo.HeaderXForwardedFor, "10.0.0.1")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestMiddlewareManager_Audit_Failed(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	auditUseCase := auditMocks.NewMockAuditUsecase(ctrl)
+	mw := NewMiddlewareManager(nil, nil, nil, nil, auditUseCase)
+
+	genre := &models.Genre{ID: 2, Name: "drama"}
+
+	e := echo.New()
+	e.PUT("/genres/:gid", func(cntx echo.Context) error {
+		return cntx.NoContent(http.StatusConflict)
+	}, mw.Audit(consts.AuditGenre, "gid"))
+
+	// Rejected mutation isn't recorded
+	auditUseCase.
+		EXPECT().
+		Snapshot(consts.AuditGenre, genre.ID).
+		Return(genre)
+
+	req := httptest.NewRequest(http.MethodPut, "/genres/2", strings.NewReader(`{"name":"comedy"}`))
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestMiddlewareManager_Audit_ResolvedEntityType(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	auditUseCase := auditMocks.NewMockAuditUsecase(ctrl)
+	mw := NewMiddlewareManager(nil, nil, nil, nil, auditUseCase)
+
+	var userID uint64 = 3
+	season := &models.Season{ID: 4, Number: 2}
+
+	e := echo.New()
+	e.POST("/trash/:id/restore", func(cntx echo.Context) error {
+		cntx.Set("userID", userID)
+		return cntx.NoContent(http.StatusOK)
+	}, mw.ResolveAuditEntityType("type"), mw.Audit(consts.AuditTrash, "id"))
+
+	expRecord := &models.AuditRecord{
+		UserID:     userID,
+		Action:     consts.AuditUpdate,
+		EntityType: consts.AuditSeason,
+		EntityID:   season.ID,
+		IP:         "10.0.0.1",
+	}
+
+	gomock.InOrder(
+		auditUseCase.
+			EXPECT().
+			Snapshot(consts.AuditSeason, season.ID).
+			Return(&models.Season{ID: 4, Number: 1}),
+		auditUseCase.
+			EXPECT().
+			Snapshot(consts.AuditSeason, season.ID).
+			Return(season),
+		auditUseCase.
+			EXPECT().
+			Record(expRecord, &models.Season{ID: 4, Number: 1}, season).
+			Return(nil),
+	)
+
+	rec := post(e, "/trash/4/restore", "10.0.0.1", `{"type":"season"}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
@@ -7,8 +7,11 @@ import (
 )
 
 type Monitoring struct {
-	Hits     *prometheus.CounterVec
-	Duration *prometheus.HistogramVec
+	Hits       *prometheus.CounterVec
+	Duration   *prometheus.HistogramVec
+	VideoBytes *prometheus.CounterVec
+	// RateLimited counts rejected attempts per route group and bucket key
+	RateLimited *prometheus.CounterVec
 }
 
 func NewMonitoring(server *echo.Echo) *Monitoring {
@@ -20,12 +23,25 @@ func NewMonitoring(server *echo.Echo) *Monitoring {
 		Name: "duration",
 	}, []string{"status", "path", "method"})
 
+	videoBytes := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "video_bytes",
+		Help: "Bytes of video served per content",
+	}, []string{"content_id"})
+
+	rateLimited := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "rate_limited",
+		Help: "Attempts rejected by rate limits",
+	}, []string{"group", "key"})
+
 	var monitoring = &Monitoring{
-		Hits:     hits,
-		Duration: duration,
+		Hits:        hits,
+		Duration:    duration,
+		VideoBytes:  videoBytes,
+		RateLimited: rateLimited,
 	}
 
-	prometheus.MustRegister(monitoring.Hits, monitoring.Duration)
+	prometheus.MustRegister(monitoring.Hits, monitoring.Duration, monitoring.VideoBytes,
+		monitoring.RateLimited)
 	server.GET("/metrics", echo.WrapHandler(promhttp.Handler()))
 	return monitoring
 }
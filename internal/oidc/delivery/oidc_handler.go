@@ -0,0 +1,140 @@
+package delivery
+
+import (
+	"net/http"
+	"time"
+
+	. "github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/mwares"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/oidc"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/session"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/CSRFManager"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
+	reader "github.com/go-park-mail-ru/2020_2_Slash/tools/request_reader"
+	. "github.com/go-park-mail-ru/2020_2_Slash/tools/response"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+type OIDCHandler struct {
+	oidcUcase oidc.OIDCUsecase
+	sessUcase session.SessionUsecase
+}
+
+func NewOIDCHandler(oidcUcase oidc.OIDCUsecase,
+	sessUcase session.SessionUsecase) *OIDCHandler {
+	return &OIDCHandler{
+		oidcUcase: oidcUcase,
+		sessUcase: sessUcase,
+	}
+}
+
+func (oh *OIDCHandler) Configure(e *echo.Echo, mw *mwares.MiddlewareManager) {
+	e.GET("/api/v1/oidc/providers", oh.GetProvidersHandler())
+	e.GET("/api/v1/oidc/:provider/login", oh.LoginHandler())
+	e.POST("/api/v1/oidc/:provider/callback", oh.CallbackHandler(),
+		middleware.BodyLimit("4K"), mw.RateLimit(LoginRateLimit))
+}
+
+func (oh *OIDCHandler) GetProvidersHandler() echo.HandlerFunc {
+	return func(cntx echo.Context) error {
+		return cntx.JSON(http.StatusOK, Response{
+			Body: &Body{
+				"providers": oh.oidcUcase.ListProviders(),
+			},
+		})
+	}
+}
+
+// LoginHandler returns provider URL the client should be redirected to,
+// the authorization request is kept in the state cookie until callback
+func (oh *OIDCHandler) LoginHandler() echo.HandlerFunc {
+	return func(cntx echo.Context) error {
+		authURL, stateCookie, err := oh.oidcUcase.Begin(cntx.Param("provider"))
+		if err != nil {
+			logger.Error(err.Message)
+			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		}
+
+		cntx.SetCookie(createStateCookie(stateCookie, OIDCStateTTL))
+		return cntx.JSON(http.StatusOK, Response{
+			Body: &Body{
+				"auth_url": authURL,
+			},
+		})
+	}
+}
+
+// CallbackHandler exchanges the code received by frontend on the redirect URL
+func (oh *OIDCHandler) CallbackHandler() echo.HandlerFunc {
+	type Request struct {
+		Code     string `json:"code" validate:"required"`
+		State    string `json:"state" validate:"required"`
+		Remember bool   `json:"remember"`
+	}
+
+	return func(cntx echo.Context) error {
+		req := &Request{}
+		if err := reader.NewRequestReader(cntx).Read(req); err != nil {
+			logger.Error(err.Message)
+			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		}
+
+		stateCookie, cookieErr := cntx.Cookie(OIDCStateCookieName)
+		if cookieErr != nil {
+			err := errors.New(CodeOIDCStateMismatch, cookieErr)
+			logger.Error(err.Message)
+			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		}
+		// State can be used only once
+		cntx.SetCookie(createStateCookie("", -time.Second))
+
+		dbUser, err := oh.oidcUcase.Complete(cntx.Param("provider"), stateCookie.Value,
+			req.State, req.Code)
+		if err != nil {
+			logger.Error(err.Message)
+			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		}
+
+		sess := models.NewSession(dbUser.ID, req.Remember)
+		sess.UserAgent = cntx.Request().UserAgent()
+		sess.IP = cntx.RealIP()
+		if err = oh.sessUcase.Create(sess); err != nil {
+			logger.Error(err.Message)
+			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		}
+
+		token, err := CSRFManager.CreateToken(sess)
+		if err != nil {
+			logger.Info(err.Message)
+			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		}
+		cntx.Response().Header().Set("X-Csrf-Token", token)
+
+		cntx.SetCookie(tools.CreateCookie(sess))
+		return cntx.JSON(http.StatusOK, Response{
+			Body: &Body{
+				"user": dbUser,
+			},
+		})
+	}
+}
+
+// createStateCookie with non-positive ttl removes the cookie
+func createStateCookie(value string, ttl time.Duration) *http.Cookie {
+	cookie := &http.Cookie{
+		Name:     OIDCStateCookieName,
+		Value:    value,
+		Path:     OIDCStateCookiePath,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   int(ttl.Seconds()),
+	}
+	if ttl <= 0 {
+		cookie.MaxAge = -1
+	}
+	return cookie
+}
@@ -0,0 +1,138 @@
+package delivery
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/oidc/mocks"
+	sessMocks "github.com/go-park-mail-ru/2020_2_Slash/internal/session/mocks"
+	"github.com/go-park-mail-ru/2020_2_Slash/pkg/converter"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/response"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+const testStateCookie = "payload.signature"
+
+var oidcUser = &models.User{
+	ID:       1,
+	Nickname: "test_user",
+	Email:    "test_user@mail.ru",
+	Role:     consts.User,
+}
+
+func setupOIDCHandler(t *testing.T, httpMethod, target, body string) (
+	echo.Context, *OIDCHandler, *mocks.MockOIDCUsecase, *sessMocks.MockSessionUsecase,
+	*httptest.ResponseRecorder, *gomock.Controller) {
+	ctrl := gomock.NewController(t)
+	oidcUcase := mocks.NewMockOIDCUsecase(ctrl)
+	sessUcase := sessMocks.NewMockSessionUsecase(ctrl)
+	logger.InitLogger("/dev/null", 10)
+
+	e := echo.New()
+	req := httptest.NewRequest(httpMethod, target, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("provider")
+	c.SetParamValues("google")
+
+	handler := NewOIDCHandler(oidcUcase, sessUcase)
+	handler.Configure(e, nil)
+	return c, handler, oidcUcase, sessUcase, rec, ctrl
+}
+
+func TestOIDCHandler_GetProvidersHandler(t *testing.T) {
+	t.Parallel()
+	c, handler, oidcUcase, _, rec, ctrl := setupOIDCHandler(t, http.MethodGet,
+		"/api/v1/oidc/providers", "")
+	defer ctrl.Finish()
+
+	oidcUcase.
+		EXPECT().
+		ListProviders().
+		Return([]string{"google"})
+
+	response := &response.Response{Body: &response.Body{"providers": []string{"google"}}}
+
+	expResponse, err := converter.AnyToBytesBuffer(response)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	handleFunc := handler.GetProvidersHandler()
+	assert.NoError(t, handleFunc(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, expResponse.String(), rec.Body.String())
+}
+
+func TestOIDCHandler_LoginHandler(t *testing.T) {
+	t.Parallel()
+	c, handler, oidcUcase, _, rec, ctrl := setupOIDCHandler(t, http.MethodGet,
+		"/api/v1/oidc/google/login", "")
+	defer ctrl.Finish()
+
+	authURL := "https://accounts.google.com/o/oauth2/v2/auth?state=state"
+	oidcUcase.
+		EXPECT().
+		Begin("google").
+		Return(authURL, testStateCookie, nil)
+
+	handleFunc := handler.LoginHandler()
+	assert.NoError(t, handleFunc(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "auth_url")
+
+	cookies := rec.Result().Cookies()
+	assert.Len(t, cookies, 1)
+	assert.Equal(t, consts.OIDCStateCookieName, cookies[0].Name)
+	assert.Equal(t, testStateCookie, cookies[0].Value)
+	assert.True(t, cookies[0].HttpOnly)
+}
+
+func TestOIDCHandler_CallbackHandler_OK(t *testing.T) {
+	t.Parallel()
+	c, handler, oidcUcase, sessUcase, rec, ctrl := setupOIDCHandler(t, http.MethodPost,
+		"/api/v1/oidc/google/callback", `{"code":"code","state":"state"}`)
+	defer ctrl.Finish()
+	c.Request().AddCookie(&http.Cookie{Name: consts.OIDCStateCookieName, Value: testStateCookie})
+
+	oidcUcase.
+		EXPECT().
+		Complete("google", testStateCookie, "state", "code").
+		Return(oidcUser, nil)
+
+	sessUcase.
+		EXPECT().
+		Create(gomock.Any()).
+		Return(nil)
+
+	handleFunc := handler.CallbackHandler()
+	assert.NoError(t, handleFunc(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotEmpty(t, rec.Header().Get("X-Csrf-Token"))
+
+	var names []string
+	for _, cookie := range rec.Result().Cookies() {
+		names = append(names, cookie.Name)
+	}
+	assert.ElementsMatch(t, []string{consts.OIDCStateCookieName, consts.SessionName}, names)
+}
+
+func TestOIDCHandler_CallbackHandler_NoStateCookie(t *testing.T) {
+	t.Parallel()
+	c, handler, _, _, rec, ctrl := setupOIDCHandler(t, http.MethodPost,
+		"/api/v1/oidc/google/callback", `{"code":"code","state":"state"}`)
+	defer ctrl.Finish()
+
+	handleFunc := handler.CallbackHandler()
+	assert.NoError(t, handleFunc(c))
+	assert.Equal(t, errors.Get(consts.CodeOIDCStateMismatch).HTTPCode, rec.Code)
+}
@@ -0,0 +1,37 @@
+package mocks
+
+import (
+	"database/sql"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+)
+
+func MockIdentityInsertReturnRows(mock sqlmock.Sqlmock, identity *models.Identity) {
+	mock.ExpectBegin()
+	rows := sqlmock.NewRows([]string{"id", "created"}).
+		AddRow(identity.ID, identity.Created)
+	mock.
+		ExpectQuery(`INSERT INTO user_identities`).
+		WithArgs(identity.UserID, identity.Provider, identity.Subject, identity.Email).
+		WillReturnRows(rows)
+	mock.ExpectCommit()
+}
+
+func MockIdentitySelectReturnRows(mock sqlmock.Sqlmock, identity *models.Identity) {
+	rows := sqlmock.NewRows([]string{"id", "user_id", "provider", "subject",
+		"email", "created"})
+	rows.AddRow(identity.ID, identity.UserID, identity.Provider, identity.Subject,
+		identity.Email, identity.Created)
+	mock.
+		ExpectQuery(`SELECT`).
+		WithArgs(identity.Provider, identity.Subject).
+		WillReturnRows(rows)
+}
+
+func MockIdentitySelectReturnErrNoRows(mock sqlmock.Sqlmock, provider, subject string) {
+	mock.
+		ExpectQuery(`SELECT`).
+		WithArgs(provider, subject).
+		WillReturnError(sql.ErrNoRows)
+}
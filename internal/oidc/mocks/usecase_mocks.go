@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	errors "github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	models "github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockOIDCUsecase is a mock of OIDCUsecase interface
+type MockOIDCUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockOIDCUsecaseMockRecorder
+}
+
+// MockOIDCUsecaseMockRecorder is the mock recorder for MockOIDCUsecase
+type MockOIDCUsecaseMockRecorder struct {
+	mock *MockOIDCUsecase
+}
+
+// NewMockOIDCUsecase creates a new mock instance
+func NewMockOIDCUsecase(ctrl *gomock.Controller) *MockOIDCUsecase {
+	mock := &MockOIDCUsecase{ctrl: ctrl}
+	mock.recorder = &MockOIDCUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockOIDCUsecase) EXPECT() *MockOIDCUsecaseMockRecorder {
+	return m.recorder
+}
+
+// ListProviders mocks base method
+func (m *MockOIDCUsecase) ListProviders() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProviders")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// ListProviders indicates an expected call of ListProviders
+func (mr *MockOIDCUsecaseMockRecorder) ListProviders() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProviders", reflect.TypeOf((*MockOIDCUsecase)(nil).ListProviders))
+}
+
+// Begin mocks base method
+func (m *MockOIDCUsecase) Begin(provider string) (string, string, *errors.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Begin", provider)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(*errors.Error)
+	return ret0, ret1, ret2
+}
+
+// Begin indicates an expected call of Begin
+func (mr *MockOIDCUsecaseMockRecorder) Begin(provider interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Begin", reflect.TypeOf((*MockOIDCUsecase)(nil).Begin), provider)
+}
+
+// Complete mocks base method
+func (m *MockOIDCUsecase) Complete(provider, stateCookie, state, code string) (*models.User, *errors.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", provider, stateCookie, state, code)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(*errors.Error)
+	return ret0, ret1
+}
+
+// Complete indicates an expected call of Complete
+func (mr *MockOIDCUsecaseMockRecorder) Complete(provider, stateCookie, state, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockOIDCUsecase)(nil).Complete), provider, stateCookie, state, code)
+}
@@ -0,0 +1,14 @@
+package oidc
+
+import (
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+)
+
+type Provider interface {
+	Name() string
+	// AuthCodeURL returns provider page where user grants access
+	AuthCodeURL(state, nonce, codeChallenge string) (string, *errors.Error)
+	// Authenticate exchanges code for ID token and returns its verified claims
+	Authenticate(code, codeVerifier, nonce string) (*models.IdentityClaims, *errors.Error)
+}
@@ -0,0 +1,198 @@
+package providers
+
+import (
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/oidc"
+)
+
+type discoveryDocument struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type tokenResponse struct {
+	IDToken string `json:"id_token"`
+	Error   string `json:"error"`
+}
+
+// OIDCProvider implements authorization code flow with PKCE
+// for any provider which supports OpenID Connect discovery.
+// Discovery document and keys are loaded on first use
+type OIDCProvider struct {
+	name         string
+	issuer       string
+	clientID     string
+	clientSecret string
+	redirectURL  string
+	scopes       []string
+	client       *http.Client
+
+	mu        sync.Mutex
+	discovery *discoveryDocument
+	keys      map[string]*rsa.PublicKey
+}
+
+func NewOIDCProvider(name, issuer, clientID, clientSecret, redirectURL string,
+	scopes []string) oidc.Provider {
+	return &OIDCProvider{
+		name:         name,
+		issuer:       strings.TrimSuffix(issuer, "/"),
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		redirectURL:  redirectURL,
+		scopes:       scopes,
+		client:       &http.Client{Timeout: consts.OIDCHTTPTimeout},
+	}
+}
+
+func (op *OIDCProvider) Name() string {
+	return op.name
+}
+
+func (op *OIDCProvider) AuthCodeURL(state, nonce, codeChallenge string) (string, *errors.Error) {
+	discovery, err := op.getDiscovery()
+	if err != nil {
+		return "", errors.New(consts.CodeInternalError, err)
+	}
+
+	query := url.Values{}
+	query.Set("response_type", "code")
+	query.Set("client_id", op.clientID)
+	query.Set("redirect_uri", op.redirectURL)
+	query.Set("scope", strings.Join(op.scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", codeChallenge)
+	query.Set("code_challenge_method", "S256")
+
+	separator := "?"
+	if strings.Contains(discovery.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	return discovery.AuthorizationEndpoint + separator + query.Encode(), nil
+}
+
+func (op *OIDCProvider) Authenticate(code, codeVerifier,
+	nonce string) (*models.IdentityClaims, *errors.Error) {
+	idToken, err := op.exchange(code, codeVerifier)
+	if err != nil {
+		return nil, errors.New(consts.CodeOIDCAuthFailed, err)
+	}
+
+	claims, err := op.verifyIDToken(idToken, nonce)
+	if err != nil {
+		return nil, errors.New(consts.CodeOIDCAuthFailed, err)
+	}
+	return claims, nil
+}
+
+func (op *OIDCProvider) exchange(code, codeVerifier string) (string, error) {
+	discovery, err := op.getDiscovery()
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", op.redirectURL)
+	form.Set("client_id", op.clientID)
+	form.Set("code_verifier", codeVerifier)
+	if op.clientSecret != "" {
+		form.Set("client_secret", op.clientSecret)
+	}
+
+	resp, err := op.client.PostForm(discovery.TokenEndpoint, form)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	token := &tokenResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(token); err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token endpoint returned %d: %s", resp.StatusCode, token.Error)
+	}
+	if token.IDToken == "" {
+		return "", fmt.Errorf("token response has no id_token")
+	}
+	return token.IDToken, nil
+}
+
+func (op *OIDCProvider) getDiscovery() (*discoveryDocument, error) {
+	op.mu.Lock()
+	defer op.mu.Unlock()
+
+	if op.discovery != nil {
+		return op.discovery, nil
+	}
+
+	discovery := &discoveryDocument{}
+	if err := op.getJSON(op.issuer+consts.OIDCDiscoveryPath, discovery); err != nil {
+		return nil, err
+	}
+	if strings.TrimSuffix(discovery.Issuer, "/") != op.issuer {
+		return nil, fmt.Errorf("discovery issuer %q doesn't match %q", discovery.Issuer, op.issuer)
+	}
+	op.discovery = discovery
+	return discovery, nil
+}
+
+// getKey returns signing key by id, keys are reloaded once
+// for unknown id because the provider may rotate them
+func (op *OIDCProvider) getKey(keyID string) (*rsa.PublicKey, error) {
+	discovery, err := op.getDiscovery()
+	if err != nil {
+		return nil, err
+	}
+
+	op.mu.Lock()
+	defer op.mu.Unlock()
+
+	if key, ok := op.keys[keyID]; ok {
+		return key, nil
+	}
+
+	keySet := &jsonWebKeySet{}
+	if err := op.getJSON(discovery.JWKSURI, keySet); err != nil {
+		return nil, err
+	}
+	keys, err := keySet.rsaKeys()
+	if err != nil {
+		return nil, err
+	}
+	op.keys = keys
+
+	key, ok := op.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %q", keyID)
+	}
+	return key, nil
+}
+
+func (op *OIDCProvider) getJSON(url string, result interface{}) error {
+	resp, err := op.client.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned %d", url, resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
@@ -0,0 +1,167 @@
+package providers
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/oidc"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/oidc/oidctest"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testClientID    = "flicksbox"
+	testRedirectURL = "https://www.flicksbox.ru/oauth/test"
+)
+
+func setupProvider(t *testing.T) (*oidctest.Issuer, oidc.Provider) {
+	issuer, err := oidctest.NewIssuer(testClientID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider := NewOIDCProvider("test", issuer.URL, testClientID, "secret",
+		testRedirectURL, []string{"openid", "email"})
+	return issuer, provider
+}
+
+func login(t *testing.T, issuer *oidctest.Issuer, provider oidc.Provider,
+	authReq *models.OIDCAuthRequest) string {
+	authURL, customErr := provider.AuthCodeURL(authReq.State, authReq.Nonce, authReq.CodeChallenge())
+	if customErr != nil {
+		t.Fatal(customErr.Message)
+	}
+	code, state, err := issuer.Login(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, authReq.State, state)
+	return code
+}
+
+func TestOIDCProvider_Authenticate_OK(t *testing.T) {
+	t.Parallel()
+	issuer, provider := setupProvider(t)
+	defer issuer.Close()
+
+	authReq, err := models.NewOIDCAuthRequest(provider.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	code := login(t, issuer, provider, authReq)
+
+	claims, customErr := provider.Authenticate(code, authReq.Verifier, authReq.Nonce)
+	assert.Nil(t, customErr)
+	assert.Equal(t, &models.IdentityClaims{
+		Subject:       issuer.Subject,
+		Email:         issuer.Email,
+		EmailVerified: true,
+		Name:          issuer.Name,
+	}, claims)
+}
+
+func TestOIDCProvider_Authenticate_WrongVerifier(t *testing.T) {
+	t.Parallel()
+	issuer, provider := setupProvider(t)
+	defer issuer.Close()
+
+	authReq, err := models.NewOIDCAuthRequest(provider.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	code := login(t, issuer, provider, authReq)
+
+	_, customErr := provider.Authenticate(code, "intercepted", authReq.Nonce)
+	assert.Equal(t, errors.Get(consts.CodeOIDCAuthFailed).Code, customErr.Code)
+}
+
+func TestOIDCProvider_Authenticate_WrongNonce(t *testing.T) {
+	t.Parallel()
+	issuer, provider := setupProvider(t)
+	defer issuer.Close()
+
+	authReq, err := models.NewOIDCAuthRequest(provider.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	code := login(t, issuer, provider, authReq)
+
+	_, customErr := provider.Authenticate(code, authReq.Verifier, "replayed")
+	assert.Equal(t, errors.Get(consts.CodeOIDCAuthFailed).Code, customErr.Code)
+}
+
+func TestOIDCProvider_Authenticate_ExpiredToken(t *testing.T) {
+	t.Parallel()
+	issuer, provider := setupProvider(t)
+	defer issuer.Close()
+	issuer.TokenTTL = -time.Hour
+
+	authReq, err := models.NewOIDCAuthRequest(provider.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	code := login(t, issuer, provider, authReq)
+
+	_, customErr := provider.Authenticate(code, authReq.Verifier, authReq.Nonce)
+	assert.Equal(t, errors.Get(consts.CodeOIDCAuthFailed).Code, customErr.Code)
+}
+
+func TestOIDCProvider_VerifyIDToken_AnotherAudience(t *testing.T) {
+	t.Parallel()
+	issuer, _ := setupProvider(t)
+	defer issuer.Close()
+	provider := NewOIDCProvider("test", issuer.URL, testClientID, "", testRedirectURL,
+		[]string{"openid"}).(*OIDCProvider)
+
+	idToken, err := issuer.SignIDToken(map[string]interface{}{
+		"iss":   issuer.URL,
+		"sub":   "1",
+		"aud":   []string{"another_client"},
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"nonce": "nonce",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = provider.verifyIDToken(idToken, "nonce")
+	assert.Error(t, err)
+}
+
+func TestOIDCProvider_VerifyIDToken_TamperedPayload(t *testing.T) {
+	t.Parallel()
+	issuer, _ := setupProvider(t)
+	defer issuer.Close()
+	provider := NewOIDCProvider("test", issuer.URL, testClientID, "", testRedirectURL,
+		[]string{"openid"}).(*OIDCProvider)
+
+	claims := map[string]interface{}{
+		"iss":            issuer.URL,
+		"sub":            "1",
+		"aud":            testClientID,
+		"exp":            time.Now().Add(time.Hour).Unix(),
+		"nonce":          "nonce",
+		"email_verified": "true",
+	}
+	idToken, err := issuer.SignIDToken(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verified, err := provider.verifyIDToken(idToken, "nonce")
+	if assert.NoError(t, err) {
+		assert.True(t, verified.EmailVerified)
+	}
+
+	claims["sub"] = "2"
+	forged, err := issuer.SignIDToken(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := strings.Split(idToken, ".")
+	forgedParts := strings.Split(forged, ".")
+	_, err = provider.verifyIDToken(parts[0]+"."+forgedParts[1]+"."+parts[2], "nonce")
+	assert.Error(t, err)
+}
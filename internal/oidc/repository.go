@@ -0,0 +1,8 @@
+package oidc
+
+import "github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+
+type IdentityRepository interface {
+	Insert(identity *models.Identity) error
+	SelectByProviderSubject(provider, subject string) (*models.Identity, error)
+}
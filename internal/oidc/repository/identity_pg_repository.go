@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/oidc"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
+)
+
+type IdentityPgRepository struct {
+	dbConn *sql.DB
+}
+
+func NewIdentityPgRepository(conn *sql.DB) oidc.IdentityRepository {
+	return &IdentityPgRepository{
+		dbConn: conn,
+	}
+}
+
+func (ir *IdentityPgRepository) Insert(identity *models.Identity) error {
+	tx, err := ir.dbConn.BeginTx(context.Background(), &sql.TxOptions{})
+	if err != nil {
+		return err
+	}
+
+	err = tx.QueryRow(
+		`INSERT INTO user_identities(user_id, provider, subject, email)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created`,
+		identity.UserID, identity.Provider, identity.Subject,
+		identity.Email).Scan(&identity.ID, &identity.Created)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			logger.Error(rollbackErr.Error())
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (ir *IdentityPgRepository) SelectByProviderSubject(provider,
+	subject string) (*models.Identity, error) {
+	identity := &models.Identity{}
+
+	row := ir.dbConn.QueryRow(
+		`SELECT id, user_id, provider, subject, email, created
+		FROM user_identities
+		WHERE provider=$1 AND subject=$2`, provider, subject)
+
+	err := row.Scan(&identity.ID, &identity.UserID, &identity.Provider,
+		&identity.Subject, &identity.Email, &identity.Created)
+	if err != nil {
+		return nil, err
+	}
+	return identity, nil
+}
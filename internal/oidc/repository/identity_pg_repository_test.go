@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/oidc/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+var identityInst = &models.Identity{
+	ID:       1,
+	UserID:   3,
+	Provider: "google",
+	Subject:  "1234567890",
+	Email:    "user@mail.ru",
+	Created:  time.Date(2020, 12, 1, 0, 0, 0, 0, time.UTC),
+}
+
+func TestIdentityPgRepository_Insert_OK(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	identityPgRep := NewIdentityPgRepository(db)
+
+	identity := &models.Identity{
+		UserID:   identityInst.UserID,
+		Provider: identityInst.Provider,
+		Subject:  identityInst.Subject,
+		Email:    identityInst.Email,
+	}
+	mocks.MockIdentityInsertReturnRows(mock, identityInst)
+	err = identityPgRep.Insert(identity)
+
+	assert.NoError(t, err)
+	assert.Equal(t, identityInst, identity)
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestIdentityPgRepository_SelectByProviderSubject_OK(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	identityPgRep := NewIdentityPgRepository(db)
+
+	mocks.MockIdentitySelectReturnRows(mock, identityInst)
+	dbIdentity, err := identityPgRep.SelectByProviderSubject(identityInst.Provider,
+		identityInst.Subject)
+
+	assert.NoError(t, err)
+	assert.Equal(t, identityInst, dbIdentity)
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestIdentityPgRepository_SelectByProviderSubject_NoRows(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	identityPgRep := NewIdentityPgRepository(db)
+
+	mocks.MockIdentitySelectReturnErrNoRows(mock, "google", "unknown")
+	_, err = identityPgRep.SelectByProviderSubject("google", "unknown")
+
+	assert.Equal(t, sql.ErrNoRows, err)
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
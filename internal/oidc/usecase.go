@@ -0,0 +1,14 @@
+package oidc
+
+import (
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+)
+
+type OIDCUsecase interface {
+	ListProviders() []string
+	// Begin returns provider login page and state to keep in cookie until the callback
+	Begin(provider string) (authURL string, stateCookie string, err *errors.Error)
+	// Complete checks the callback against the state and returns linked user
+	Complete(provider, stateCookie, state, code string) (*models.User, *errors.Error)
+}
@@ -0,0 +1,231 @@
+package usecases
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	. "github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/oidc"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/user"
+)
+
+const (
+	minNicknameLength = 3
+	maxNicknameLength = 32
+)
+
+type OIDCUsecase struct {
+	identityRepo oidc.IdentityRepository
+	userUcase    user.UserUsecase
+	providers    map[string]oidc.Provider
+	stateSecret  []byte
+}
+
+func NewOIDCUsecase(repo oidc.IdentityRepository, userUcase user.UserUsecase,
+	providers []oidc.Provider, stateSecret string) oidc.OIDCUsecase {
+	providersByName := make(map[string]oidc.Provider, len(providers))
+	for _, provider := range providers {
+		providersByName[provider.Name()] = provider
+	}
+	return &OIDCUsecase{
+		identityRepo: repo,
+		userUcase:    userUcase,
+		providers:    providersByName,
+		stateSecret:  []byte(stateSecret),
+	}
+}
+
+func (ou *OIDCUsecase) ListProviders() []string {
+	names := make([]string, 0, len(ou.providers))
+	for name := range ou.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (ou *OIDCUsecase) Begin(providerName string) (string, string, *errors.Error) {
+	provider, customErr := ou.getProvider(providerName)
+	if customErr != nil {
+		return "", "", customErr
+	}
+
+	authReq, err := models.NewOIDCAuthRequest(providerName)
+	if err != nil {
+		return "", "", errors.New(CodeInternalError, err)
+	}
+	authURL, customErr := provider.AuthCodeURL(authReq.State, authReq.Nonce,
+		authReq.CodeChallenge())
+	if customErr != nil {
+		return "", "", customErr
+	}
+
+	stateCookie, customErr := ou.encodeAuthRequest(authReq)
+	if customErr != nil {
+		return "", "", customErr
+	}
+	return authURL, stateCookie, nil
+}
+
+func (ou *OIDCUsecase) Complete(providerName, stateCookie, state,
+	code string) (*models.User, *errors.Error) {
+	provider, customErr := ou.getProvider(providerName)
+	if customErr != nil {
+		return nil, customErr
+	}
+
+	authReq, customErr := ou.decodeAuthRequest(stateCookie)
+	if customErr != nil {
+		return nil, customErr
+	}
+	if authReq.Provider != providerName || authReq.ExpiresAt.Before(time.Now()) ||
+		!hmac.Equal([]byte(authReq.State), []byte(state)) {
+		return nil, errors.Get(CodeOIDCStateMismatch)
+	}
+
+	claims, customErr := provider.Authenticate(code, authReq.Verifier, authReq.Nonce)
+	if customErr != nil {
+		return nil, customErr
+	}
+	return ou.resolveUser(providerName, claims)
+}
+
+// resolveUser finds user linked to the identity, links user with the same
+// email verified by both the provider and the user or registers a new one
+func (ou *OIDCUsecase) resolveUser(providerName string,
+	claims *models.IdentityClaims) (*models.User, *errors.Error) {
+	identity, err := ou.identityRepo.SelectByProviderSubject(providerName, claims.Subject)
+	switch {
+	case err == nil:
+		return ou.userUcase.GetByID(identity.UserID)
+	case err != sql.ErrNoRows:
+		return nil, errors.New(CodeInternalError, err)
+	}
+
+	if claims.Email == "" {
+		return nil, errors.Get(CodeOIDCEmailRequired)
+	}
+	// Neither new nor existing account is bound to unconfirmed email
+	if !claims.EmailVerified {
+		return nil, errors.Get(CodeOIDCEmailNotVerified)
+	}
+
+	identity = &models.Identity{
+		Provider: providerName,
+		Subject:  claims.Subject,
+		Email:    claims.Email,
+	}
+	dbUser, customErr := ou.userUcase.GetByEmail(claims.Email)
+	switch {
+	case customErr == nil:
+		// Account registered with someone else's email may have
+		// the password of the registrant, so it isn't linked
+		if !dbUser.EmailVerified {
+			return nil, errors.Get(CodeOIDCAccountNotVerified)
+		}
+	case customErr.Code == CodeUserDoesNotExist:
+		return ou.createUser(claims, identity)
+	default:
+		return nil, customErr
+	}
+
+	identity.UserID = dbUser.ID
+	if err := ou.identityRepo.Insert(identity); err != nil {
+		return nil, errors.New(CodeInternalError, err)
+	}
+	return dbUser, nil
+}
+
+// createUser registers user with random password together with the identity,
+// password can be set later through password reset
+func (ou *OIDCUsecase) createUser(claims *models.IdentityClaims,
+	identity *models.Identity) (*models.User, *errors.Error) {
+	password, err := randomHex(OIDCRandomBytes)
+	if err != nil {
+		return nil, errors.New(CodeInternalError, err)
+	}
+
+	// Empty nickname is replaced with email name by userblock
+	nickname := strings.TrimSpace(claims.Name)
+	if length := utf8.RuneCountInString(nickname); length < minNicknameLength ||
+		length > maxNicknameLength {
+		nickname = ""
+	}
+
+	newUser := &models.User{
+		Nickname:      nickname,
+		Email:         claims.Email,
+		Password:      password,
+		Role:          User,
+		EmailVerified: true,
+	}
+	if customErr := ou.userUcase.CreateWithIdentity(newUser, identity); customErr != nil {
+		return nil, customErr
+	}
+	return newUser, nil
+}
+
+func (ou *OIDCUsecase) getProvider(name string) (oidc.Provider, *errors.Error) {
+	provider, ok := ou.providers[name]
+	if !ok {
+		return nil, errors.Get(CodeOIDCProviderDoesNotExist)
+	}
+	return provider, nil
+}
+
+// encodeAuthRequest serializes the request as "<base64 json>.<hmac>"
+func (ou *OIDCUsecase) encodeAuthRequest(authReq *models.OIDCAuthRequest) (string, *errors.Error) {
+	data, err := json.Marshal(authReq)
+	if err != nil {
+		return "", errors.New(CodeInternalError, err)
+	}
+	payload := base64.RawURLEncoding.EncodeToString(data)
+	return payload + "." + ou.signature(payload), nil
+}
+
+func (ou *OIDCUsecase) decodeAuthRequest(stateCookie string) (*models.OIDCAuthRequest, *errors.Error) {
+	sepIdx := strings.LastIndex(stateCookie, ".")
+	if sepIdx < 0 {
+		return nil, errors.Get(CodeOIDCStateMismatch)
+	}
+	payload, signature := stateCookie[:sepIdx], stateCookie[sepIdx+1:]
+	if !hmac.Equal([]byte(ou.signature(payload)), []byte(signature)) {
+		return nil, errors.Get(CodeOIDCStateMismatch)
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, errors.New(CodeOIDCStateMismatch, err)
+	}
+	authReq := &models.OIDCAuthRequest{}
+	if err := json.Unmarshal(data, authReq); err != nil {
+		return nil, errors.New(CodeOIDCStateMismatch, err)
+	}
+	return authReq, nil
+}
+
+func (ou *OIDCUsecase) signature(payload string) string {
+	mac := hmac.New(sha256.New, ou.stateSecret)
+	// nolint: errcheck
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func randomHex(size int) (string, error) {
+	randBytes := make([]byte, size)
+	if _, err := rand.Read(randBytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(randBytes), nil
+}
@@ -0,0 +1,264 @@
+package usecases
+
+import (
+	"database/sql"
+	"testing"
+
+	. "github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/oidc"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/oidc/mocks"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/oidc/oidctest"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/oidc/providers"
+	userMocks "github.com/go-park-mail-ru/2020_2_Slash/internal/user/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testProvider    = "test"
+	testClientID    = "flicksbox"
+	testStateSecret = "state-secret"
+)
+
+var identityUser = &models.User{
+	ID:            3,
+	Nickname:      "Test User",
+	Email:         "user@mail.ru",
+	Role:          User,
+	EmailVerified: true,
+}
+
+type testEnv struct {
+	issuer       *oidctest.Issuer
+	identityRepo *mocks.MockIdentityRepository
+	userUcase    *userMocks.MockUserUsecase
+	oidcUcase    oidc.OIDCUsecase
+}
+
+func setup(t *testing.T, ctrl *gomock.Controller) *testEnv {
+	issuer, err := oidctest.NewIssuer(testClientID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider := providers.NewOIDCProvider(testProvider, issuer.URL, testClientID,
+		"secret", "https://www.flicksbox.ru/oauth/test", []string{"openid", "email"})
+
+	env := &testEnv{
+		issuer:       issuer,
+		identityRepo: mocks.NewMockIdentityRepository(ctrl),
+		userUcase:    userMocks.NewMockUserUsecase(ctrl),
+	}
+	env.oidcUcase = NewOIDCUsecase(env.identityRepo, env.userUcase,
+		[]oidc.Provider{provider}, testStateSecret)
+	return env
+}
+
+// login passes the authorization on the issuer and returns code, state and state cookie
+func (env *testEnv) login(t *testing.T) (string, string, string) {
+	authURL, stateCookie, customErr := env.oidcUcase.Begin(testProvider)
+	if customErr != nil {
+		t.Fatal(customErr.Message)
+	}
+	code, state, err := env.issuer.Login(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return code, state, stateCookie
+}
+
+func TestOIDCUsecase_ListProviders(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	oidcUcase := NewOIDCUsecase(nil, nil, []oidc.Provider{
+		providers.NewOIDCProvider("yandex", "", "", "", "", nil),
+		providers.NewOIDCProvider("google", "", "", "", "", nil),
+	}, testStateSecret)
+
+	assert.Equal(t, []string{"google", "yandex"}, oidcUcase.ListProviders())
+}
+
+func TestOIDCUsecase_Begin_UnknownProvider(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	env := setup(t, ctrl)
+	defer env.issuer.Close()
+
+	_, _, customErr := env.oidcUcase.Begin("unknown")
+	assert.Equal(t, errors.Get(CodeOIDCProviderDoesNotExist), customErr)
+}
+
+func TestOIDCUsecase_Complete_LinkedIdentity(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	env := setup(t, ctrl)
+	defer env.issuer.Close()
+
+	code, state, stateCookie := env.login(t)
+
+	env.identityRepo.
+		EXPECT().
+		SelectByProviderSubject(testProvider, env.issuer.Subject).
+		Return(&models.Identity{ID: 1, UserID: identityUser.ID}, nil)
+
+	env.userUcase.
+		EXPECT().
+		GetByID(identityUser.ID).
+		Return(identityUser, nil)
+
+	dbUser, customErr := env.oidcUcase.Complete(testProvider, stateCookie, state, code)
+	assert.Nil(t, customErr)
+	assert.Equal(t, identityUser, dbUser)
+}
+
+func TestOIDCUsecase_Complete_NewUser(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	env := setup(t, ctrl)
+	defer env.issuer.Close()
+
+	code, state, stateCookie := env.login(t)
+
+	env.identityRepo.
+		EXPECT().
+		SelectByProviderSubject(testProvider, env.issuer.Subject).
+		Return(nil, sql.ErrNoRows)
+
+	env.userUcase.
+		EXPECT().
+		GetByEmail(env.issuer.Email).
+		Return(nil, errors.Get(CodeUserDoesNotExist))
+
+	env.userUcase.
+		EXPECT().
+		CreateWithIdentity(gomock.Any(), &models.Identity{
+			Provider: testProvider,
+			Subject:  env.issuer.Subject,
+			Email:    env.issuer.Email,
+		}).
+		DoAndReturn(func(newUser *models.User, identity *models.Identity) *errors.Error {
+			assert.Equal(t, env.issuer.Name, newUser.Nickname)
+			assert.Equal(t, env.issuer.Email, newUser.Email)
+			assert.NotEmpty(t, newUser.Password)
+			assert.True(t, newUser.EmailVerified)
+			newUser.ID = identityUser.ID
+			identity.UserID = newUser.ID
+			return nil
+		})
+
+	dbUser, customErr := env.oidcUcase.Complete(testProvider, stateCookie, state, code)
+	assert.Nil(t, customErr)
+	assert.Equal(t, identityUser.ID, dbUser.ID)
+}
+
+func TestOIDCUsecase_Complete_LinkExistingUser(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	env := setup(t, ctrl)
+	defer env.issuer.Close()
+
+	code, state, stateCookie := env.login(t)
+
+	env.identityRepo.
+		EXPECT().
+		SelectByProviderSubject(testProvider, env.issuer.Subject).
+		Return(nil, sql.ErrNoRows)
+
+	env.userUcase.
+		EXPECT().
+		GetByEmail(env.issuer.Email).
+		Return(identityUser, nil)
+
+	env.identityRepo.
+		EXPECT().
+		Insert(gomock.Any()).
+		Return(nil)
+
+	dbUser, customErr := env.oidcUcase.Complete(testProvider, stateCookie, state, code)
+	assert.Nil(t, customErr)
+	assert.Equal(t, identityUser, dbUser)
+}
+
+func TestOIDCUsecase_Complete_UnverifiedEmail(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	env := setup(t, ctrl)
+	defer env.issuer.Close()
+	env.issuer.EmailVerified = false
+
+	code, state, stateCookie := env.login(t)
+
+	env.identityRepo.
+		EXPECT().
+		SelectByProviderSubject(testProvider, env.issuer.Subject).
+		Return(nil, sql.ErrNoRows)
+
+	dbUser, customErr := env.oidcUcase.Complete(testProvider, stateCookie, state, code)
+	assert.Nil(t, dbUser)
+	assert.Equal(t, errors.Get(CodeOIDCEmailNotVerified), customErr)
+}
+
+func TestOIDCUsecase_Complete_UnverifiedAccount(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	env := setup(t, ctrl)
+	defer env.issuer.Close()
+
+	code, state, stateCookie := env.login(t)
+	unverifiedUser := &models.User{
+		ID:    4,
+		Email: env.issuer.Email,
+		Role:  User,
+	}
+
+	env.identityRepo.
+		EXPECT().
+		SelectByProviderSubject(testProvider, env.issuer.Subject).
+		Return(nil, sql.ErrNoRows)
+
+	env.userUcase.
+		EXPECT().
+		GetByEmail(env.issuer.Email).
+		Return(unverifiedUser, nil)
+
+	dbUser, customErr := env.oidcUcase.Complete(testProvider, stateCookie, state, code)
+	assert.Nil(t, dbUser)
+	assert.Equal(t, errors.Get(CodeOIDCAccountNotVerified), customErr)
+}
+
+func TestOIDCUsecase_Complete_StateMismatch(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	env := setup(t, ctrl)
+	defer env.issuer.Close()
+
+	code, _, stateCookie := env.login(t)
+
+	dbUser, customErr := env.oidcUcase.Complete(testProvider, stateCookie, "forged", code)
+	assert.Nil(t, dbUser)
+	assert.Equal(t, errors.Get(CodeOIDCStateMismatch), customErr)
+}
+
+func TestOIDCUsecase_Complete_TamperedCookie(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	env := setup(t, ctrl)
+	defer env.issuer.Close()
+
+	code, state, stateCookie := env.login(t)
+
+	dbUser, customErr := env.oidcUcase.Complete(testProvider, "x"+stateCookie, state, code)
+	assert.Nil(t, dbUser)
+	assert.Equal(t, errors.Get(CodeOIDCStateMismatch), customErr)
+}
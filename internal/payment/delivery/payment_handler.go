@@ -0,0 +1,84 @@
+package delivery
+
+import (
+	"net/http"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/mwares"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/payment"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
+	reader "github.com/go-park-mail-ru/2020_2_Slash/tools/request_reader"
+	. "github.com/go-park-mail-ru/2020_2_Slash/tools/response"
+	"github.com/labstack/echo/v4"
+)
+
+type PaymentHandler struct {
+	paymentUcase payment.PaymentUsecase
+}
+
+func NewPaymentHandler(paymentUcase payment.PaymentUsecase) *PaymentHandler {
+	return &PaymentHandler{
+		paymentUcase: paymentUcase,
+	}
+}
+
+func (ph *PaymentHandler) Configure(e *echo.Echo, mw *mwares.MiddlewareManager) {
+	e.GET("/api/v1/admin/payments", ph.GetPaymentsHandler(), mw.CheckAuth, mw.CheckAdmin)
+	e.POST("/api/v1/payments/checkout", ph.CheckoutHandler(), mw.CheckAuth, mw.CheckCSRF)
+}
+
+func (ph *PaymentHandler) CheckoutHandler() echo.HandlerFunc {
+	type Request struct {
+		PlanID uint64 `json:"plan_id" validate:"required"`
+	}
+
+	return func(cntx echo.Context) error {
+		req := &Request{}
+		if err := reader.NewRequestReader(cntx).Read(req); err != nil {
+			logger.Error(err.Message)
+			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		}
+
+		// nolint: errcheck
+		userID, _ := cntx.Get("userID").(uint64)
+		checkoutURL, err := ph.paymentUcase.Checkout(userID, req.PlanID)
+		if err != nil {
+			logger.Error(err.Message)
+			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		}
+
+		return cntx.JSON(http.StatusOK, Response{
+			Body: &Body{
+				"checkout_url": checkoutURL,
+			},
+		})
+	}
+}
+
+func (ph *PaymentHandler) GetPaymentsHandler() echo.HandlerFunc {
+	type Request struct {
+		UserID uint64 `query:"user_id"`
+		Status string `query:"status" validate:"omitempty,oneof=held pending accepted rejected"`
+		models.Pagination
+	}
+
+	return func(cntx echo.Context) error {
+		req := &Request{}
+		if err := reader.NewRequestReader(cntx).Read(req); err != nil {
+			logger.Error(err.Message)
+			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		}
+
+		payments, err := ph.paymentUcase.List(req.UserID, req.Status, &req.Pagination)
+		if err != nil {
+			logger.Error(err.Message)
+			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		}
+
+		return cntx.JSON(http.StatusOK, Response{
+			Body: &Body{
+				"payments": payments,
+			},
+		})
+	}
+}
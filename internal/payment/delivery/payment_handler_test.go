@@ -0,0 +1,112 @@
+package delivery
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/payment/mocks"
+	"github.com/go-park-mail-ru/2020_2_Slash/pkg/converter"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/response"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaymentHandler_GetPaymentsHandler(t *testing.T) {
+	t.Parallel()
+	// Setup
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	paymentUseCase := mocks.NewMockPaymentUsecase(ctrl)
+
+	payments := []*models.Payment{
+		&models.Payment{
+			ID:          1,
+			OperationID: "1234567",
+			UserID:      3,
+			Amount:      299,
+			Currency:    "643",
+			Status:      consts.PaymentAccepted,
+		},
+	}
+	pgnt := &models.Pagination{From: 0, Count: 10}
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet,
+		"/api/v1/admin/payments?user_id=3&status=accepted&from=0&count=10", strings.NewReader(""))
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	paymentHandler := NewPaymentHandler(paymentUseCase)
+	handleFunc := paymentHandler.GetPaymentsHandler()
+
+	paymentUseCase.
+		EXPECT().
+		List(uint64(3), consts.PaymentAccepted, pgnt).
+		Return(payments, nil)
+
+	response := &response.Response{Body: &response.Body{"payments": payments}}
+
+	// Assertions
+	if assert.NoError(t, handleFunc(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		expResBody, err := converter.AnyToBytesBuffer(response)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		bytes, _ := ioutil.ReadAll(rec.Body)
+
+		assert.JSONEq(t, expResBody.String(), string(bytes))
+	}
+}
+
+func TestPaymentHandler_CheckoutHandler(t *testing.T) {
+	t.Parallel()
+	// Setup
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	paymentUseCase := mocks.NewMockPaymentUsecase(ctrl)
+
+	var userID uint64 = 3
+	var planID uint64 = 2
+	checkoutURL := consts.FakeCheckoutURL + "?amount=299.00&currency=643&label=3%3A2"
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/payments/checkout",
+		strings.NewReader(`{"plan_id": 2}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("userID", userID)
+
+	paymentHandler := NewPaymentHandler(paymentUseCase)
+	handleFunc := paymentHandler.CheckoutHandler()
+
+	paymentUseCase.
+		EXPECT().
+		Checkout(userID, planID).
+		Return(checkoutURL, nil)
+
+	response := &response.Response{Body: &response.Body{"checkout_url": checkoutURL}}
+
+	// Assertions
+	if assert.NoError(t, handleFunc(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		expResBody, err := converter.AnyToBytesBuffer(response)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		bytes, _ := ioutil.ReadAll(rec.Body)
+
+		assert.JSONEq(t, expResBody.String(), string(bytes))
+	}
+}
@@ -0,0 +1,54 @@
+package mocks
+
+import (
+	"database/sql"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+)
+
+func MockPaymentRepoInsertReturnRows(mock sqlmock.Sqlmock, payment *models.Payment) {
+	mock.ExpectBegin()
+	rows := sqlmock.NewRows([]string{"id", "created"})
+	rows.AddRow(payment.ID, payment.Created)
+	mock.ExpectQuery(`INSERT INTO payments`).
+		WithArgs(payment.OperationID, payment.UserID, payment.PlanID, payment.Amount,
+			payment.Currency, payment.Status, payment.Reason).
+		WillReturnRows(rows)
+	mock.ExpectCommit()
+}
+
+func MockPaymentRepoInsertReturnErrNoRows(mock sqlmock.Sqlmock, payment *models.Payment) {
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO payments`).
+		WithArgs(payment.OperationID, payment.UserID, payment.PlanID, payment.Amount,
+			payment.Currency, payment.Status, payment.Reason).
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+}
+
+func MockPaymentRepoUpdateStatusReturnResult(mock sqlmock.Sqlmock, payment *models.Payment,
+	prevStatus string, rowsAffected int64) {
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE payments`).
+		WithArgs(payment.ID, payment.Status, payment.Reason, prevStatus).
+		WillReturnResult(sqlmock.NewResult(0, rowsAffected))
+	if rowsAffected == 0 {
+		mock.ExpectRollback()
+		return
+	}
+	mock.ExpectCommit()
+}
+
+func MockPaymentRepoSelectAllReturnRows(mock sqlmock.Sqlmock, userID uint64, status string,
+	pgnt *models.Pagination, payments []*models.Payment) {
+	rows := sqlmock.NewRows([]string{"id", "operation_id", "user_id", "plan_id", "amount",
+		"currency", "status", "reason", "created"})
+	for _, payment := range payments {
+		rows.AddRow(payment.ID, payment.OperationID, payment.UserID, payment.PlanID, payment.Amount,
+			payment.Currency, payment.Status, payment.Reason, payment.Created)
+	}
+	mock.ExpectQuery(`SELECT (.+) FROM payments WHERE user_id=\$1 AND status=\$2 (.+) LIMIT \$3 OFFSET \$4`).
+		WithArgs(userID, status, pgnt.Count, pgnt.From).
+		WillReturnRows(rows)
+}
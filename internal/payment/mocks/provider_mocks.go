@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/payment/provider.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	errors "github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	models "github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	gomock "github.com/golang/mock/gomock"
+	url "net/url"
+	reflect "reflect"
+)
+
+// MockPaymentProvider is a mock of PaymentProvider interface
+type MockPaymentProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentProviderMockRecorder
+}
+
+// MockPaymentProviderMockRecorder is the mock recorder for MockPaymentProvider
+type MockPaymentProviderMockRecorder struct {
+	mock *MockPaymentProvider
+}
+
+// NewMockPaymentProvider creates a new mock instance
+func NewMockPaymentProvider(ctrl *gomock.Controller) *MockPaymentProvider {
+	mock := &MockPaymentProvider{ctrl: ctrl}
+	mock.recorder = &MockPaymentProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockPaymentProvider) EXPECT() *MockPaymentProviderMockRecorder {
+	return m.recorder
+}
+
+// VerifyNotification mocks base method
+func (m *MockPaymentProvider) VerifyNotification(form url.Values) *errors.Error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyNotification", form)
+	ret0, _ := ret[0].(*errors.Error)
+	return ret0
+}
+
+// VerifyNotification indicates an expected call of VerifyNotification
+func (mr *MockPaymentProviderMockRecorder) VerifyNotification(form interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyNotification", reflect.TypeOf((*MockPaymentProvider)(nil).VerifyNotification), form)
+}
+
+// ParseNotification mocks base method
+func (m *MockPaymentProvider) ParseNotification(form url.Values) (*models.Payment, *errors.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseNotification", form)
+	ret0, _ := ret[0].(*models.Payment)
+	ret1, _ := ret[1].(*errors.Error)
+	return ret0, ret1
+}
+
+// ParseNotification indicates an expected call of ParseNotification
+func (mr *MockPaymentProviderMockRecorder) ParseNotification(form interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseNotification", reflect.TypeOf((*MockPaymentProvider)(nil).ParseNotification), form)
+}
+
+// CheckoutURL mocks base method
+func (m *MockPaymentProvider) CheckoutURL(userID uint64, plan *models.Plan) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckoutURL", userID, plan)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// CheckoutURL indicates an expected call of CheckoutURL
+func (mr *MockPaymentProviderMockRecorder) CheckoutURL(userID, plan interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckoutURL", reflect.TypeOf((*MockPaymentProvider)(nil).CheckoutURL), userID, plan)
+}
@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/payment/usecase.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	errors "github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	models "github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockPaymentUsecase is a mock of PaymentUsecase interface
+type MockPaymentUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentUsecaseMockRecorder
+}
+
+// MockPaymentUsecaseMockRecorder is the mock recorder for MockPaymentUsecase
+type MockPaymentUsecaseMockRecorder struct {
+	mock *MockPaymentUsecase
+}
+
+// NewMockPaymentUsecase creates a new mock instance
+func NewMockPaymentUsecase(ctrl *gomock.Controller) *MockPaymentUsecase {
+	mock := &MockPaymentUsecase{ctrl: ctrl}
+	mock.recorder = &MockPaymentUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockPaymentUsecase) EXPECT() *MockPaymentUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Process mocks base method
+func (m *MockPaymentUsecase) Process(payment *models.Payment) (*models.Subscription, *errors.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Process", payment)
+	ret0, _ := ret[0].(*models.Subscription)
+	ret1, _ := ret[1].(*errors.Error)
+	return ret0, ret1
+}
+
+// Process indicates an expected call of Process
+func (mr *MockPaymentUsecaseMockRecorder) Process(payment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Process", reflect.TypeOf((*MockPaymentUsecase)(nil).Process), payment)
+}
+
+// List mocks base method
+func (m *MockPaymentUsecase) List(userID uint64, status string, pgnt *models.Pagination) ([]*models.Payment, *errors.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", userID, status, pgnt)
+	ret0, _ := ret[0].([]*models.Payment)
+	ret1, _ := ret[1].(*errors.Error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List
+func (mr *MockPaymentUsecaseMockRecorder) List(userID, status, pgnt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockPaymentUsecase)(nil).List), userID, status, pgnt)
+}
+
+// Checkout mocks base method
+func (m *MockPaymentUsecase) Checkout(userID, planID uint64) (string, *errors.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Checkout", userID, planID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(*errors.Error)
+	return ret0, ret1
+}
+
+// Checkout indicates an expected call of Checkout
+func (mr *MockPaymentUsecaseMockRecorder) Checkout(userID, planID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Checkout", reflect.TypeOf((*MockPaymentUsecase)(nil).Checkout), userID, planID)
+}
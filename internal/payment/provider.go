@@ -0,0 +1,17 @@
+package payment
+
+import (
+	"net/url"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+)
+
+type PaymentProvider interface {
+	// VerifyNotification checks that notification is sent by the provider
+	VerifyNotification(form url.Values) *errors.Error
+	// ParseNotification extracts user, plan and amount from notification
+	ParseNotification(form url.Values) (*models.Payment, *errors.Error)
+	// CheckoutURL returns provider page where user pays for the plan
+	CheckoutURL(userID uint64, plan *models.Plan) string
+}
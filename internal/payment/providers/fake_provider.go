@@ -0,0 +1,83 @@
+package providers
+
+import (
+	"crypto/subtle"
+	"net/url"
+	"strconv"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/payment"
+)
+
+// FakeProvider lets local environment and integration tests pay without network,
+// notification is trusted if it carries the configured secret
+type FakeProvider struct {
+	secret string
+}
+
+func NewFakeProvider(secret string) payment.PaymentProvider {
+	return &FakeProvider{
+		secret: secret,
+	}
+}
+
+// NewFakeNotification returns form of the notification about paid plan
+// that FakeProvider accepts
+func NewFakeNotification(secret, operationID string, userID uint64, plan *models.Plan) url.Values {
+	return url.Values{
+		"operation_id": {operationID},
+		"label":        {buildLabel(userID, plan.ID)},
+		"amount":       {formatAmount(plan.Price)},
+		"currency":     {plan.Currency},
+		"secret":       {secret},
+	}
+}
+
+func (fp *FakeProvider) VerifyNotification(form url.Values) *errors.Error {
+	if subtle.ConstantTimeCompare([]byte(form.Get("secret")), []byte(fp.secret)) != 1 {
+		return errors.Get(consts.CodeWrongPaymentHash)
+	}
+	return nil
+}
+
+// ParseNotification converts notification into payment,
+// "held" field makes it held until the next notification
+func (fp *FakeProvider) ParseNotification(form url.Values) (*models.Payment, *errors.Error) {
+	operationID := form.Get("operation_id")
+	if operationID == "" {
+		return nil, errors.Get(consts.CodeBadRequest)
+	}
+	userID, planID, customErr := parseLabel(form.Get("label"))
+	if customErr != nil {
+		return nil, customErr
+	}
+	amount, err := strconv.ParseFloat(form.Get("amount"), 64)
+	if err != nil {
+		return nil, errors.Get(consts.CodeParsePaymentAmountError)
+	}
+
+	payment := &models.Payment{
+		OperationID: operationID,
+		UserID:      userID,
+		PlanID:      planID,
+		Amount:      amount,
+		Currency:    form.Get("currency"),
+		Status:      consts.PaymentPending,
+	}
+	if held, _ := strconv.ParseBool(form.Get("held")); held {
+		payment.Status = consts.PaymentHeld
+		payment.Reason = errors.Get(consts.CodeUnacceptedPayment).Message
+	}
+	return payment, nil
+}
+
+func (fp *FakeProvider) CheckoutURL(userID uint64, plan *models.Plan) string {
+	query := url.Values{
+		"label":    {buildLabel(userID, plan.ID)},
+		"amount":   {formatAmount(plan.Price)},
+		"currency": {plan.Currency},
+	}
+	return consts.FakeCheckoutURL + "?" + query.Encode()
+}
@@ -0,0 +1,59 @@
+package providers
+
+import (
+	"testing"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeProvider_Notification(t *testing.T) {
+	t.Parallel()
+	provider := NewFakeProvider(secret)
+	plan := &models.Plan{ID: 2, Name: "Месяц", Price: 299, Currency: "643"}
+
+	form := NewFakeNotification(secret, "1", 3, plan)
+	assert.Equal(t, (*errors.Error)(nil), provider.VerifyNotification(form))
+
+	payment, err := provider.ParseNotification(form)
+	assert.Equal(t, (*errors.Error)(nil), err)
+	assert.Equal(t, &models.Payment{
+		OperationID: "1",
+		UserID:      3,
+		PlanID:      plan.ID,
+		Amount:      plan.Price,
+		Currency:    plan.Currency,
+		Status:      consts.PaymentPending,
+	}, payment)
+
+	form = NewFakeNotification("wrong", "1", 3, plan)
+	assert.Equal(t, errors.Get(consts.CodeWrongPaymentHash), provider.VerifyNotification(form))
+}
+
+func TestNewPaymentProvider_Unknown(t *testing.T) {
+	t.Parallel()
+	provider, err := NewPaymentProvider("unknown", "", secret, true)
+	assert.Error(t, err)
+	assert.Nil(t, provider)
+}
+
+func TestNewPaymentProvider_OutsideDev(t *testing.T) {
+	t.Parallel()
+	provider, err := NewPaymentProvider(consts.FakePaymentProvider, "", secret, false)
+	assert.Error(t, err)
+	assert.Nil(t, provider)
+
+	provider, err = NewPaymentProvider(consts.YooMoneyPaymentProvider, "", " \n", false)
+	assert.Error(t, err)
+	assert.Nil(t, provider)
+
+	provider, err = NewPaymentProvider(consts.YooMoneyPaymentProvider, "", secret, false)
+	assert.NoError(t, err)
+	assert.NotNil(t, provider)
+
+	provider, err = NewPaymentProvider(consts.FakePaymentProvider, "", "", true)
+	assert.NoError(t, err)
+	assert.NotNil(t, provider)
+}
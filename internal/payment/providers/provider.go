@@ -0,0 +1,62 @@
+package providers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/payment"
+)
+
+// NewPaymentProvider refuses the fake provider and empty secret outside dev,
+// anyone could confirm payments with them
+func NewPaymentProvider(name, receiver, secret string, isDev bool) (payment.PaymentProvider, error) {
+	if !isDev {
+		if name == consts.FakePaymentProvider {
+			return nil, fmt.Errorf("%s payment provider is allowed only in dev", name)
+		}
+		if strings.TrimSpace(secret) == "" {
+			return nil, fmt.Errorf("%s payment secret is empty", name)
+		}
+	}
+
+	switch name {
+	case consts.YooMoneyPaymentProvider:
+		return NewYooMoneyProvider(receiver, secret), nil
+	case consts.FakePaymentProvider:
+		return NewFakeProvider(secret), nil
+	}
+	return nil, fmt.Errorf("unknown payment provider %q", name)
+}
+
+func buildLabel(userID, planID uint64) string {
+	return strings.Join([]string{
+		strconv.FormatUint(userID, 10),
+		strconv.FormatUint(planID, 10),
+	}, consts.PaymentLabelSeparator)
+}
+
+func parseLabel(label string) (uint64, uint64, *errors.Error) {
+	if label == "" {
+		return 0, 0, errors.Get(consts.CodeEmptyLabelError)
+	}
+	parts := strings.SplitN(label, consts.PaymentLabelSeparator, 2)
+	userID, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return 0, 0, errors.Get(consts.CodeParseUserIDError)
+	}
+	if len(parts) != 2 {
+		return 0, 0, errors.Get(consts.CodeParsePlanIDError)
+	}
+	planID, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return 0, 0, errors.Get(consts.CodeParsePlanIDError)
+	}
+	return userID, planID, nil
+}
+
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
@@ -0,0 +1,116 @@
+package providers
+
+import (
+	// nolint: gosec
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/hex"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/payment"
+)
+
+// YooMoneyProvider accepts wallet HTTP notifications signed with SHA1
+type YooMoneyProvider struct {
+	receiver string
+	secret   string
+}
+
+func NewYooMoneyProvider(receiver, secret string) payment.PaymentProvider {
+	return &YooMoneyProvider{
+		receiver: receiver,
+		secret:   secret,
+	}
+}
+
+func (yp *YooMoneyProvider) VerifyNotification(form url.Values) *errors.Error {
+	parametersString := strings.Join([]string{
+		form.Get("notification_type"), form.Get("operation_id"),
+		form.Get("amount"), form.Get("currency"), form.Get("datetime"),
+		form.Get("sender"), form.Get("codepro"), yp.secret, form.Get("label"),
+	}, "&")
+
+	// nolint: gosec
+	hash := sha1.Sum([]byte(parametersString))
+	hexString := hex.EncodeToString(hash[:])
+
+	if subtle.ConstantTimeCompare([]byte(hexString), []byte(form.Get("sha1_hash"))) != 1 {
+		return errors.Get(consts.CodeWrongPaymentHash)
+	}
+	return nil
+}
+
+// ParseNotification converts notification into payment,
+// not accepted by the provider payment is held,
+// amount of the payment is what the payer was charged,
+// credited amount is less by the commission
+func (yp *YooMoneyProvider) ParseNotification(form url.Values) (*models.Payment, *errors.Error) {
+	operationID := form.Get("operation_id")
+	if operationID == "" {
+		return nil, errors.Get(consts.CodeBadRequest)
+	}
+	userID, planID, customErr := parseLabel(form.Get("label"))
+	if customErr != nil {
+		return nil, customErr
+	}
+	amount, err := strconv.ParseFloat(form.Get("withdraw_amount"), 64)
+	if err != nil {
+		return nil, errors.Get(consts.CodeParsePaymentAmountError)
+	}
+
+	payment := &models.Payment{
+		OperationID: operationID,
+		UserID:      userID,
+		PlanID:      planID,
+		Amount:      amount,
+		Currency:    form.Get("currency"),
+		Status:      consts.PaymentPending,
+	}
+	if customErr := checkUnaccepted(form.Get("unaccepted")); customErr != nil {
+		payment.Status = consts.PaymentHeld
+		payment.Reason = customErr.Message
+	} else if customErr := checkCodepro(form.Get("codepro")); customErr != nil {
+		payment.Status = consts.PaymentHeld
+		payment.Reason = customErr.Message
+	}
+	return payment, nil
+}
+
+func (yp *YooMoneyProvider) CheckoutURL(userID uint64, plan *models.Plan) string {
+	query := url.Values{
+		"receiver":      {yp.receiver},
+		"quickpay-form": {"shop"},
+		"targets":       {plan.Name},
+		"paymentType":   {"AC"},
+		"sum":           {formatAmount(plan.Price)},
+		"label":         {buildLabel(userID, plan.ID)},
+	}
+	return consts.YooMoneyCheckoutURL + "?" + query.Encode()
+}
+
+func checkUnaccepted(value string) *errors.Error {
+	unaccepted, err := strconv.ParseBool(value)
+	if err != nil {
+		return errors.Get(consts.CodeParseUnacceptedError)
+	}
+	if unaccepted {
+		return errors.Get(consts.CodeUnacceptedPayment)
+	}
+	return nil
+}
+
+func checkCodepro(value string) *errors.Error {
+	codePro, err := strconv.ParseBool(value)
+	if err != nil {
+		return errors.Get(consts.CodeParseCodeProError)
+	}
+	if codePro {
+		return errors.Get(consts.CodeProtectedPayment)
+	}
+	return nil
+}
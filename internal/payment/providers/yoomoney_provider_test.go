@@ -0,0 +1,94 @@
+package providers
+
+import (
+	// nolint: gosec
+	"crypto/sha1"
+	"encoding/hex"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+const secret = "notification_secret"
+
+func newYooMoneyNotification(label, unaccepted string) url.Values {
+	form := url.Values{
+		"notification_type": {"card-incoming"},
+		"operation_id":      {"1234567"},
+		"amount":            {"293.02"},
+		"withdraw_amount":   {"299.00"},
+		"currency":          {"643"},
+		"datetime":          {"2020-12-01T12:00:00Z"},
+		"sender":            {""},
+		"codepro":           {"false"},
+		"label":             {label},
+		"unaccepted":        {unaccepted},
+	}
+	// nolint: gosec
+	hash := sha1.Sum([]byte(strings.Join([]string{
+		form.Get("notification_type"), form.Get("operation_id"),
+		form.Get("amount"), form.Get("currency"), form.Get("datetime"),
+		form.Get("sender"), form.Get("codepro"), secret, form.Get("label"),
+	}, "&")))
+	form.Set("sha1_hash", hex.EncodeToString(hash[:]))
+	return form
+}
+
+func TestYooMoneyProvider_VerifyNotification(t *testing.T) {
+	t.Parallel()
+	provider := NewYooMoneyProvider("4100", secret)
+
+	form := newYooMoneyNotification("3:2", "false")
+	assert.Equal(t, (*errors.Error)(nil), provider.VerifyNotification(form))
+
+	form.Set("amount", "1.00")
+	assert.Equal(t, errors.Get(consts.CodeWrongPaymentHash), provider.VerifyNotification(form))
+}
+
+func TestYooMoneyProvider_ParseNotification(t *testing.T) {
+	t.Parallel()
+	provider := NewYooMoneyProvider("4100", secret)
+
+	// Commission doesn't lower the paid amount
+	payment, err := provider.ParseNotification(newYooMoneyNotification("3:2", "false"))
+	assert.Equal(t, (*errors.Error)(nil), err)
+	assert.Equal(t, &models.Payment{
+		OperationID: "1234567",
+		UserID:      3,
+		PlanID:      2,
+		Amount:      299,
+		Currency:    "643",
+		Status:      consts.PaymentPending,
+	}, payment)
+
+	payment, err = provider.ParseNotification(newYooMoneyNotification("3:2", "true"))
+	assert.Equal(t, (*errors.Error)(nil), err)
+	assert.Equal(t, consts.PaymentHeld, payment.Status)
+
+	form := newYooMoneyNotification("3:2", "false")
+	form.Set("withdraw_amount", "abc")
+	_, err = provider.ParseNotification(form)
+	assert.Equal(t, errors.Get(consts.CodeParsePaymentAmountError), err)
+
+	_, err = provider.ParseNotification(newYooMoneyNotification("3", "false"))
+	assert.Equal(t, errors.Get(consts.CodeParsePlanIDError), err)
+}
+
+func TestYooMoneyProvider_CheckoutURL(t *testing.T) {
+	t.Parallel()
+	provider := NewYooMoneyProvider("4100", secret)
+	plan := &models.Plan{ID: 2, Name: "Месяц", Price: 299, Currency: "643"}
+
+	checkoutURL, err := url.Parse(provider.CheckoutURL(3, plan))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "4100", checkoutURL.Query().Get("receiver"))
+	assert.Equal(t, "299.00", checkoutURL.Query().Get("sum"))
+	assert.Equal(t, "3:2", checkoutURL.Query().Get("label"))
+}
@@ -0,0 +1,10 @@
+package payment
+
+import "github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+
+type PaymentRepository interface {
+	Insert(payment *models.Payment) error
+	UpdateStatus(payment *models.Payment, prevStatus string) error
+	SelectByOperationID(operationID string) (*models.Payment, error)
+	SelectAll(userID uint64, status string, pgnt *models.Pagination) ([]*models.Payment, error)
+}
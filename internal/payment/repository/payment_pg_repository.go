@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/payment"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
+)
+
+type PaymentPgRepository struct {
+	dbConn *sql.DB
+}
+
+func NewPaymentPgRepository(conn *sql.DB) payment.PaymentRepository {
+	return &PaymentPgRepository{
+		dbConn: conn,
+	}
+}
+
+// Insert returns sql.ErrNoRows if payment with the same operation is already stored
+func (rep *PaymentPgRepository) Insert(payment *models.Payment) error {
+	tx, err := rep.dbConn.BeginTx(context.Background(), &sql.TxOptions{})
+	if err != nil {
+		return err
+	}
+
+	err = tx.QueryRow(`
+		INSERT INTO payments(operation_id, user_id, plan_id, amount, currency, status, reason)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (operation_id) DO NOTHING
+		RETURNING id, created`,
+		payment.OperationID, payment.UserID, payment.PlanID, payment.Amount,
+		payment.Currency, payment.Status, payment.Reason).
+		Scan(&payment.ID, &payment.Created)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			logger.Error(rollbackErr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpdateStatus returns sql.ErrNoRows if payment status is not prevStatus anymore
+func (rep *PaymentPgRepository) UpdateStatus(payment *models.Payment, prevStatus string) error {
+	tx, err := rep.dbConn.BeginTx(context.Background(), &sql.TxOptions{})
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.Exec(`
+		UPDATE payments
+		SET status = $2, reason = $3
+		WHERE id = $1 AND status = $4`,
+		payment.ID, payment.Status, payment.Reason, prevStatus)
+	if err == nil {
+		var rowsAffected int64
+		rowsAffected, err = result.RowsAffected()
+		if err == nil && rowsAffected == 0 {
+			err = sql.ErrNoRows
+		}
+	}
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			logger.Error(rollbackErr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (rep *PaymentPgRepository) SelectByOperationID(operationID string) (*models.Payment, error) {
+	payment := &models.Payment{}
+	err := rep.dbConn.QueryRow(`
+		SELECT id, operation_id, user_id, plan_id, amount, currency, status, reason, created
+		FROM payments
+		WHERE operation_id=$1`, operationID).
+		Scan(&payment.ID, &payment.OperationID, &payment.UserID, &payment.PlanID, &payment.Amount,
+			&payment.Currency, &payment.Status, &payment.Reason, &payment.Created)
+	if err != nil {
+		return nil, err
+	}
+	return payment, nil
+}
+
+// SelectAll returns payments filtered by user and status if they are set
+func (rep *PaymentPgRepository) SelectAll(userID uint64, status string,
+	pgnt *models.Pagination) ([]*models.Payment, error) {
+	var values []interface{}
+	var conditions []string
+
+	if userID != 0 {
+		values = append(values, userID)
+		conditions = append(conditions, fmt.Sprintf("user_id=$%d", len(values)))
+	}
+	if status != "" {
+		values = append(values, status)
+		conditions = append(conditions, fmt.Sprintf("status=$%d", len(values)))
+	}
+
+	selectQuery := `
+		SELECT id, operation_id, user_id, plan_id, amount, currency, status, reason, created
+		FROM payments`
+
+	var whereQuery string
+	if len(conditions) != 0 {
+		whereQuery = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	orderQuery := "ORDER BY created DESC, id DESC"
+
+	var pgntQuery string
+	if pgnt.Count != 0 {
+		pgntQuery = fmt.Sprintf("LIMIT $%d OFFSET $%d", len(values)+1, len(values)+2)
+		values = append(values, pgnt.Count, pgnt.From)
+	}
+
+	resultQuery := strings.Join([]string{
+		selectQuery,
+		whereQuery,
+		orderQuery,
+		pgntQuery,
+	}, " ")
+
+	rows, err := rep.dbConn.Query(resultQuery, values...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var payments []*models.Payment
+	for rows.Next() {
+		payment := &models.Payment{}
+		err := rows.Scan(&payment.ID, &payment.OperationID, &payment.UserID, &payment.PlanID, &payment.Amount,
+			&payment.Currency, &payment.Status, &payment.Reason, &payment.Created)
+		if err != nil {
+			return nil, err
+		}
+		payments = append(payments, payment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return payments, nil
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/payment/mocks"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+var testPayment = &models.Payment{
+	ID:          1,
+	OperationID: "1234567",
+	UserID:      3,
+	Amount:      299,
+	Currency:    "643",
+	Status:      consts.PaymentAccepted,
+	Created:     time.Now(),
+}
+
+func TestPaymentPgRepository_Insert_OK(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	paymentPgRep := NewPaymentPgRepository(db)
+
+	payment := *testPayment
+	mocks.MockPaymentRepoInsertReturnRows(mock, &payment)
+	err = paymentPgRep.Insert(&payment)
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestPaymentPgRepository_Insert_Repeated(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	paymentPgRep := NewPaymentPgRepository(db)
+
+	payment := *testPayment
+	mocks.MockPaymentRepoInsertReturnErrNoRows(mock, &payment)
+	err = paymentPgRep.Insert(&payment)
+	assert.Equal(t, sql.ErrNoRows, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestPaymentPgRepository_UpdateStatus_StatusChanged(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	logger.DisableLogger()
+
+	paymentPgRep := NewPaymentPgRepository(db)
+
+	mocks.MockPaymentRepoUpdateStatusReturnResult(mock, testPayment, consts.PaymentPending, 0)
+	err = paymentPgRep.UpdateStatus(testPayment, consts.PaymentPending)
+	assert.Equal(t, sql.ErrNoRows, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestPaymentPgRepository_SelectAll_OK(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	paymentPgRep := NewPaymentPgRepository(db)
+
+	payments := []*models.Payment{testPayment}
+	pgnt := &models.Pagination{From: 0, Count: 10}
+
+	mocks.MockPaymentRepoSelectAllReturnRows(mock, testPayment.UserID, testPayment.Status, pgnt, payments)
+	dbPayments, err := paymentPgRep.SelectAll(testPayment.UserID, testPayment.Status, pgnt)
+	assert.NoError(t, err)
+	assert.Equal(t, payments, dbPayments)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
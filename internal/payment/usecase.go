@@ -0,0 +1,12 @@
+package payment
+
+import (
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+)
+
+type PaymentUsecase interface {
+	Process(payment *models.Payment) (*models.Subscription, *errors.Error)
+	List(userID uint64, status string, pgnt *models.Pagination) ([]*models.Payment, *errors.Error)
+	Checkout(userID, planID uint64) (string, *errors.Error)
+}
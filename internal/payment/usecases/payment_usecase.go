@@ -0,0 +1,148 @@
+package usecases
+
+import (
+	"database/sql"
+	"math"
+
+	. "github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/payment"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/plan"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/subscription"
+)
+
+type PaymentUsecase struct {
+	paymentRepo       payment.PaymentRepository
+	subscriptionUcase subscription.SubscriptionUseCase
+	planUcase         plan.PlanUsecase
+	provider          payment.PaymentProvider
+}
+
+func NewPaymentUsecase(repo payment.PaymentRepository,
+	subscriptionUcase subscription.SubscriptionUseCase,
+	planUcase plan.PlanUsecase, provider payment.PaymentProvider) payment.PaymentUsecase {
+	return &PaymentUsecase{
+		paymentRepo:       repo,
+		subscriptionUcase: subscriptionUcase,
+		planUcase:         planUcase,
+		provider:          provider,
+	}
+}
+
+// Process records payment notification and renews subscription once per operation
+func (pu *PaymentUsecase) Process(payment *models.Payment) (*models.Subscription, *errors.Error) {
+	var priceErr *errors.Error
+	if payment.Status == PaymentPending {
+		if priceErr = pu.checkPrice(payment); priceErr != nil {
+			if priceErr.Code == CodeInternalError {
+				return nil, priceErr
+			}
+			payment.Status = PaymentRejected
+			payment.Reason = priceErr.Message
+		}
+	}
+
+	err := pu.paymentRepo.Insert(payment)
+	switch {
+	case err == sql.ErrNoRows:
+		// Notification is repeated
+		dbPayment, customErr := pu.resolveRepeated(payment)
+		if customErr != nil {
+			return nil, customErr
+		}
+		if dbPayment != payment {
+			priceErr = nil
+		}
+		payment = dbPayment
+	case err != nil:
+		return nil, errors.New(CodeInternalError, err)
+	}
+
+	switch payment.Status {
+	case PaymentHeld:
+		return nil, errors.Get(CodeUnacceptedPayment)
+	case PaymentRejected:
+		if priceErr != nil {
+			return nil, priceErr
+		}
+		return nil, errors.Get(CodePaymentRejected)
+	case PaymentAccepted:
+		return pu.subscriptionUcase.GetByUserID(payment.UserID)
+	}
+	return pu.accept(payment)
+}
+
+func (pu *PaymentUsecase) List(userID uint64, status string,
+	pgnt *models.Pagination) ([]*models.Payment, *errors.Error) {
+	payments, err := pu.paymentRepo.SelectAll(userID, status, pgnt)
+	if err != nil {
+		return nil, errors.New(CodeInternalError, err)
+	}
+	if len(payments) == 0 {
+		return []*models.Payment{}, nil
+	}
+	return payments, nil
+}
+
+// Checkout returns provider page where user pays for the plan,
+// first subscription of the user starts with the trial of the plan
+func (pu *PaymentUsecase) Checkout(userID, planID uint64) (string, *errors.Error) {
+	plan, customErr := pu.planUcase.GetByID(planID)
+	if customErr != nil {
+		return "", customErr
+	}
+	if customErr := pu.subscriptionUcase.StartTrial(userID, plan); customErr != nil {
+		return "", customErr
+	}
+	return pu.provider.CheckoutURL(userID, plan), nil
+}
+
+// resolveRepeated returns stored payment,
+// held one is replaced by the new notification
+func (pu *PaymentUsecase) resolveRepeated(payment *models.Payment) (*models.Payment, *errors.Error) {
+	dbPayment, err := pu.paymentRepo.SelectByOperationID(payment.OperationID)
+	if err != nil {
+		return nil, errors.New(CodeInternalError, err)
+	}
+	if dbPayment.Status != PaymentHeld || payment.Status == PaymentHeld {
+		return dbPayment, nil
+	}
+
+	payment.ID = dbPayment.ID
+	payment.Created = dbPayment.Created
+	err = pu.paymentRepo.UpdateStatus(payment, PaymentHeld)
+	if err == sql.ErrNoRows {
+		// Concurrent notification has already replaced it
+		return pu.resolveRepeated(payment)
+	} else if err != nil {
+		return nil, errors.New(CodeInternalError, err)
+	}
+	return payment, nil
+}
+
+// accept renews subscription, payment is accepted in the same transaction
+// so that concurrent notifications don't renew subscription twice
+func (pu *PaymentUsecase) accept(payment *models.Payment) (*models.Subscription, *errors.Error) {
+	plan, customErr := pu.planUcase.GetByID(payment.PlanID)
+	if customErr != nil {
+		return nil, customErr
+	}
+	return pu.subscriptionUcase.Renew(payment, plan)
+}
+
+// checkPrice compares the amount charged from the payer
+// with the price of the paid plan
+func (pu *PaymentUsecase) checkPrice(payment *models.Payment) *errors.Error {
+	plan, customErr := pu.planUcase.GetByID(payment.PlanID)
+	if customErr != nil {
+		return customErr
+	}
+	if payment.Currency != plan.Currency {
+		return errors.Get(CodeWrongPaymentCurrency)
+	}
+	if math.Round(payment.Amount*100) < math.Round(plan.Price*100) {
+		return errors.Get(CodeWrongPaymentAmount)
+	}
+	return nil
+}
@@ -0,0 +1,247 @@
+package usecases
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/payment/mocks"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/payment/providers"
+	planMocks "github.com/go-park-mail-ru/2020_2_Slash/internal/plan/mocks"
+	subscriptionMocks "github.com/go-park-mail-ru/2020_2_Slash/internal/subscription/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	price    = 299.0
+	currency = "643"
+)
+
+var userID uint64 = 3
+
+var testPlan = &models.Plan{
+	ID:             2,
+	Name:           "Месяц",
+	Price:          price,
+	Currency:       currency,
+	DurationMonths: 1,
+}
+
+var testSubscription = &models.Subscription{
+	ID:      1,
+	UserID:  userID,
+	Expires: time.Now().AddDate(0, 1, 0),
+	IsPaid:  true,
+}
+
+func newPayment(status string, amount float64) *models.Payment {
+	return &models.Payment{
+		OperationID: "1234567",
+		UserID:      userID,
+		PlanID:      testPlan.ID,
+		Amount:      amount,
+		Currency:    currency,
+		Status:      status,
+	}
+}
+
+func setupPaymentUsecase(t *testing.T) (*gomock.Controller, *mocks.MockPaymentRepository,
+	*subscriptionMocks.MockSubscriptionUseCase, *planMocks.MockPlanUsecase, *PaymentUsecase) {
+	ctrl := gomock.NewController(t)
+	paymentRep := mocks.NewMockPaymentRepository(ctrl)
+	subUseCase := subscriptionMocks.NewMockSubscriptionUseCase(ctrl)
+	planUseCase := planMocks.NewMockPlanUsecase(ctrl)
+	// nolint: errcheck
+	paymentUseCase := NewPaymentUsecase(paymentRep, subUseCase, planUseCase,
+		providers.NewFakeProvider("secret")).(*PaymentUsecase)
+	return ctrl, paymentRep, subUseCase, planUseCase, paymentUseCase
+}
+
+func TestPaymentUseCase_Process_New(t *testing.T) {
+	t.Parallel()
+	ctrl, paymentRep, subUseCase, planUseCase, paymentUseCase := setupPaymentUsecase(t)
+	defer ctrl.Finish()
+
+	payment := newPayment(consts.PaymentPending, price)
+
+	planUseCase.
+		EXPECT().
+		GetByID(gomock.Eq(testPlan.ID)).
+		Return(testPlan, nil).
+		Times(2)
+
+	paymentRep.
+		EXPECT().
+		Insert(gomock.Eq(payment)).
+		Return(nil)
+
+	subUseCase.
+		EXPECT().
+		Renew(gomock.Eq(payment), gomock.Eq(testPlan)).
+		Return(testSubscription, nil)
+
+	dbSubscription, err := paymentUseCase.Process(payment)
+	assert.Equal(t, err, (*errors.Error)(nil))
+	assert.Equal(t, testSubscription, dbSubscription)
+}
+
+func TestPaymentUseCase_Process_Repeated(t *testing.T) {
+	t.Parallel()
+	ctrl, paymentRep, subUseCase, planUseCase, paymentUseCase := setupPaymentUsecase(t)
+	defer ctrl.Finish()
+
+	payment := newPayment(consts.PaymentPending, price)
+	dbPayment := newPayment(consts.PaymentAccepted, price)
+
+	planUseCase.
+		EXPECT().
+		GetByID(gomock.Eq(testPlan.ID)).
+		Return(testPlan, nil)
+
+	paymentRep.
+		EXPECT().
+		Insert(gomock.Eq(payment)).
+		Return(sql.ErrNoRows)
+
+	paymentRep.
+		EXPECT().
+		SelectByOperationID(gomock.Eq(payment.OperationID)).
+		Return(dbPayment, nil)
+
+	subUseCase.
+		EXPECT().
+		GetByUserID(gomock.Eq(userID)).
+		Return(testSubscription, nil)
+
+	dbSubscription, err := paymentUseCase.Process(payment)
+	assert.Equal(t, err, (*errors.Error)(nil))
+	assert.Equal(t, testSubscription, dbSubscription)
+}
+
+func TestPaymentUseCase_Process_WrongAmount(t *testing.T) {
+	t.Parallel()
+	ctrl, paymentRep, _, planUseCase, paymentUseCase := setupPaymentUsecase(t)
+	defer ctrl.Finish()
+
+	payment := newPayment(consts.PaymentPending, price-1)
+
+	planUseCase.
+		EXPECT().
+		GetByID(gomock.Eq(testPlan.ID)).
+		Return(testPlan, nil)
+
+	paymentRep.
+		EXPECT().
+		Insert(gomock.Any()).
+		Return(nil)
+
+	dbSubscription, err := paymentUseCase.Process(payment)
+	assert.Equal(t, errors.Get(consts.CodeWrongPaymentAmount), err)
+	assert.Equal(t, (*models.Subscription)(nil), dbSubscription)
+	assert.Equal(t, consts.PaymentRejected, payment.Status)
+}
+
+func TestPaymentUseCase_Process_UnknownPlan(t *testing.T) {
+	t.Parallel()
+	ctrl, paymentRep, _, planUseCase, paymentUseCase := setupPaymentUsecase(t)
+	defer ctrl.Finish()
+
+	payment := newPayment(consts.PaymentPending, price)
+
+	planUseCase.
+		EXPECT().
+		GetByID(gomock.Eq(testPlan.ID)).
+		Return(nil, errors.Get(consts.CodePlanDoesNotExist))
+
+	paymentRep.
+		EXPECT().
+		Insert(gomock.Any()).
+		Return(nil)
+
+	dbSubscription, err := paymentUseCase.Process(payment)
+	assert.Equal(t, errors.Get(consts.CodePlanDoesNotExist), err)
+	assert.Equal(t, (*models.Subscription)(nil), dbSubscription)
+	assert.Equal(t, consts.PaymentRejected, payment.Status)
+}
+
+func TestPaymentUseCase_Process_HeldThenAccepted(t *testing.T) {
+	t.Parallel()
+	ctrl, paymentRep, subUseCase, planUseCase, paymentUseCase := setupPaymentUsecase(t)
+	defer ctrl.Finish()
+
+	payment := newPayment(consts.PaymentPending, price)
+	dbPayment := newPayment(consts.PaymentHeld, price)
+	dbPayment.ID = 5
+
+	planUseCase.
+		EXPECT().
+		GetByID(gomock.Eq(testPlan.ID)).
+		Return(testPlan, nil).
+		Times(2)
+
+	paymentRep.
+		EXPECT().
+		Insert(gomock.Eq(payment)).
+		Return(sql.ErrNoRows)
+
+	paymentRep.
+		EXPECT().
+		SelectByOperationID(gomock.Eq(payment.OperationID)).
+		Return(dbPayment, nil)
+
+	paymentRep.
+		EXPECT().
+		UpdateStatus(gomock.Eq(payment), gomock.Eq(consts.PaymentHeld)).
+		Return(nil)
+
+	subUseCase.
+		EXPECT().
+		Renew(gomock.Eq(payment), gomock.Eq(testPlan)).
+		Return(testSubscription, nil)
+
+	dbSubscription, err := paymentUseCase.Process(payment)
+	assert.Equal(t, err, (*errors.Error)(nil))
+	assert.Equal(t, testSubscription, dbSubscription)
+	assert.Equal(t, dbPayment.ID, payment.ID)
+}
+
+func TestPaymentUseCase_List_Empty(t *testing.T) {
+	t.Parallel()
+	ctrl, paymentRep, _, _, paymentUseCase := setupPaymentUsecase(t)
+	defer ctrl.Finish()
+
+	pgnt := &models.Pagination{From: 0, Count: 10}
+
+	paymentRep.
+		EXPECT().
+		SelectAll(gomock.Eq(userID), gomock.Eq(consts.PaymentAccepted), gomock.Eq(pgnt)).
+		Return(nil, nil)
+
+	payments, err := paymentUseCase.List(userID, consts.PaymentAccepted, pgnt)
+	assert.Equal(t, err, (*errors.Error)(nil))
+	assert.Equal(t, []*models.Payment{}, payments)
+}
+
+func TestPaymentUseCase_Checkout(t *testing.T) {
+	t.Parallel()
+	ctrl, _, subUseCase, planUseCase, paymentUseCase := setupPaymentUsecase(t)
+	defer ctrl.Finish()
+
+	planUseCase.
+		EXPECT().
+		GetByID(gomock.Eq(testPlan.ID)).
+		Return(testPlan, nil)
+
+	subUseCase.
+		EXPECT().
+		StartTrial(gomock.Eq(userID), gomock.Eq(testPlan)).
+		Return(nil)
+
+	checkoutURL, err := paymentUseCase.Checkout(userID, testPlan.ID)
+	assert.Equal(t, err, (*errors.Error)(nil))
+	assert.Equal(t, consts.FakeCheckoutURL+"?amount=299.00&currency=643&label=3%3A2", checkoutURL)
+}
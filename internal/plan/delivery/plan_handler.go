@@ -0,0 +1,138 @@
+package delivery
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/mwares"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/plan"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
+	reader "github.com/go-park-mail-ru/2020_2_Slash/tools/request_reader"
+	. "github.com/go-park-mail-ru/2020_2_Slash/tools/response"
+	"github.com/labstack/echo/v4"
+)
+
+type PlanHandler struct {
+	planUcase plan.PlanUsecase
+}
+
+func NewPlanHandler(planUcase plan.PlanUsecase) *PlanHandler {
+	return &PlanHandler{
+		planUcase: planUcase,
+	}
+}
+
+func (ph *PlanHandler) Configure(e *echo.Echo, mw *mwares.MiddlewareManager) {
+	e.POST("/api/v1/plans", ph.CreatePlanHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF, mw.Audit(consts.AuditPlan, ""))
+	e.PUT("/api/v1/plans/:pid", ph.UpdatePlanHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF, mw.Audit(consts.AuditPlan, "pid"))
+	e.DELETE("/api/v1/plans/:pid", ph.DeletePlanHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF, mw.Audit(consts.AuditPlan, "pid"))
+	e.GET("/api/v1/plans", ph.GetPlansListHandler())
+}
+
+type planRequest struct {
+	Name           string  `json:"name" validate:"required,lte=64"`
+	Price          float64 `json:"price" validate:"gt=0"`
+	Currency       string  `json:"currency" validate:"required,lte=8"`
+	DurationMonths int     `json:"duration_months" validate:"gt=0"`
+	TrialDays      int     `json:"trial_days" validate:"gte=0"`
+}
+
+func (req *planRequest) toPlan() *models.Plan {
+	return &models.Plan{
+		Name:           req.Name,
+		Price:          req.Price,
+		Currency:       req.Currency,
+		DurationMonths: req.DurationMonths,
+		TrialDays:      req.TrialDays,
+	}
+}
+
+func (ph *PlanHandler) CreatePlanHandler() echo.HandlerFunc {
+	return func(cntx echo.Context) error {
+		req := &planRequest{}
+		if err := reader.NewRequestReader(cntx).Read(req); err != nil {
+			logger.Error(err.Message)
+			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		}
+
+		plan := req.toPlan()
+		if err := ph.planUcase.Create(plan); err != nil {
+			logger.Error(err.Message)
+			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		}
+
+		return cntx.JSON(http.StatusCreated, Response{
+			Body: &Body{
+				"plan": plan,
+			},
+		})
+	}
+}
+
+func (ph *PlanHandler) UpdatePlanHandler() echo.HandlerFunc {
+	return func(cntx echo.Context) error {
+		req := &planRequest{}
+		if err := reader.NewRequestReader(cntx).Read(req); err != nil {
+			logger.Error(err.Message)
+			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		}
+
+		planID, parseErr := strconv.ParseUint(cntx.Param("pid"), 10, 64)
+		if parseErr != nil {
+			customErr := errors.New(consts.CodeInternalError, parseErr)
+			logger.Error(customErr)
+			return cntx.JSON(customErr.HTTPCode, Response{Error: customErr})
+		}
+
+		plan, err := ph.planUcase.UpdateByID(planID, req.toPlan())
+		if err != nil {
+			logger.Error(err.Message)
+			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		}
+
+		return cntx.JSON(http.StatusOK, Response{
+			Body: &Body{
+				"plan": plan,
+			},
+		})
+	}
+}
+
+func (ph *PlanHandler) DeletePlanHandler() echo.HandlerFunc {
+	return func(cntx echo.Context) error {
+		planID, parseErr := strconv.ParseUint(cntx.Param("pid"), 10, 64)
+		if parseErr != nil {
+			customErr := errors.New(consts.CodeInternalError, parseErr)
+			logger.Error(customErr)
+			return cntx.JSON(customErr.HTTPCode, Response{Error: customErr})
+		}
+
+		if err := ph.planUcase.DeleteByID(planID); err != nil {
+			logger.Error(err.Message)
+			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		}
+
+		return cntx.JSON(http.StatusOK, Response{
+			Message: "success",
+		})
+	}
+}
+
+func (ph *PlanHandler) GetPlansListHandler() echo.HandlerFunc {
+	return func(cntx echo.Context) error {
+		plans, err := ph.planUcase.List()
+		if err != nil {
+			logger.Error(err.Message)
+			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		}
+
+		return cntx.JSON(http.StatusOK, Response{
+			Body: &Body{
+				"plans": plans,
+			},
+		})
+	}
+}
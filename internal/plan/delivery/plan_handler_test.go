@@ -0,0 +1,148 @@
+package delivery
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/plan/mocks"
+	"github.com/go-park-mail-ru/2020_2_Slash/pkg/converter"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/response"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+var testPlan = &models.Plan{
+	Name:           "Месяц",
+	Price:          299,
+	Currency:       "643",
+	DurationMonths: 1,
+	TrialDays:      7,
+}
+
+func TestPlanHandler_CreatePlanHandler(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	planUseCase := mocks.NewMockPlanUsecase(ctrl)
+
+	planJSON, err := converter.AnyToBytesBuffer(testPlan)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/plans", strings.NewReader(planJSON.String()))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	planHandler := NewPlanHandler(planUseCase)
+	handleFunc := planHandler.CreatePlanHandler()
+
+	planUseCase.
+		EXPECT().
+		Create(gomock.Eq(testPlan)).
+		Return(nil)
+
+	response := &response.Response{Body: &response.Body{"plan": testPlan}}
+
+	// Assertions
+	if assert.NoError(t, handleFunc(c)) {
+		assert.Equal(t, http.StatusCreated, rec.Code)
+
+		expResBody, err := converter.AnyToBytesBuffer(response)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		bytes, _ := ioutil.ReadAll(rec.Body)
+
+		assert.JSONEq(t, expResBody.String(), string(bytes))
+	}
+}
+
+func TestPlanHandler_CreatePlanHandler_NameAlreadyExists(t *testing.T) {
+	t.Parallel()
+	logger.DisableLogger()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	planUseCase := mocks.NewMockPlanUsecase(ctrl)
+
+	planJSON, err := converter.AnyToBytesBuffer(testPlan)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/plans", strings.NewReader(planJSON.String()))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	planHandler := NewPlanHandler(planUseCase)
+	handleFunc := planHandler.CreatePlanHandler()
+
+	customErr := errors.Get(consts.CodePlanNameAlreadyExists)
+	planUseCase.
+		EXPECT().
+		Create(gomock.Eq(testPlan)).
+		Return(customErr)
+
+	response := &response.Response{Error: customErr}
+
+	// Assertions
+	if assert.NoError(t, handleFunc(c)) {
+		assert.Equal(t, customErr.HTTPCode, rec.Code)
+
+		expResBody, err := converter.AnyToBytesBuffer(response)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		bytes, _ := ioutil.ReadAll(rec.Body)
+
+		assert.JSONEq(t, expResBody.String(), string(bytes))
+	}
+}
+
+func TestPlanHandler_GetPlansListHandler(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	planUseCase := mocks.NewMockPlanUsecase(ctrl)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/plans", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	planHandler := NewPlanHandler(planUseCase)
+	handleFunc := planHandler.GetPlansListHandler()
+
+	plans := []*models.Plan{testPlan}
+	planUseCase.
+		EXPECT().
+		List().
+		Return(plans, nil)
+
+	response := &response.Response{Body: &response.Body{"plans": plans}}
+
+	// Assertions
+	if assert.NoError(t, handleFunc(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		expResBody, err := converter.AnyToBytesBuffer(response)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		bytes, _ := ioutil.ReadAll(rec.Body)
+
+		assert.JSONEq(t, expResBody.String(), string(bytes))
+	}
+}
@@ -0,0 +1,56 @@
+package mocks
+
+import (
+	"database/sql"
+	"database/sql/driver"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+)
+
+func MockPlanRepoInsertReturnRows(mock sqlmock.Sqlmock, plan *models.Plan) {
+	mock.ExpectBegin()
+	insertAnswer := sqlmock.NewRows([]string{"id"}).AddRow(plan.ID)
+	mock.ExpectQuery(`INSERT INTO plans`).
+		WithArgs(plan.Name, plan.Price, plan.Currency, plan.DurationMonths, plan.TrialDays).
+		WillReturnRows(insertAnswer)
+	mock.ExpectCommit()
+}
+
+func MockPlanRepoUpdateReturnResultOk(mock sqlmock.Sqlmock, plan *models.Plan) {
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE plans`).
+		WithArgs(plan.ID, plan.Name, plan.Price, plan.Currency, plan.DurationMonths, plan.TrialDays).
+		WillReturnResult(sqlmock.NewResult(int64(plan.ID), 1))
+	mock.ExpectCommit()
+}
+
+func MockPlanRepoDeleteReturnResultOk(mock sqlmock.Sqlmock, id uint64) {
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM plans`).
+		WithArgs(id).WillReturnResult(driver.ResultNoRows)
+	mock.ExpectCommit()
+}
+
+func MockPlanRepoHasPaymentsReturnRows(mock sqlmock.Sqlmock, id uint64, hasPayments bool) {
+	rows := sqlmock.NewRows([]string{"exists"}).AddRow(hasPayments)
+	mock.ExpectQuery(`SELECT EXISTS`).WithArgs(id).WillReturnRows(rows)
+}
+
+func MockPlanRepoSelectByIDReturnRows(mock sqlmock.Sqlmock, plan *models.Plan) {
+	rows := sqlmock.NewRows([]string{"id", "name", "price", "currency", "duration_months", "trial_days"})
+	rows.AddRow(plan.ID, plan.Name, plan.Price, plan.Currency, plan.DurationMonths, plan.TrialDays)
+	mock.ExpectQuery(`SELECT`).WithArgs(plan.ID).WillReturnRows(rows)
+}
+
+func MockPlanRepoSelectByIDReturnErrNoRows(mock sqlmock.Sqlmock, id uint64) {
+	mock.ExpectQuery(`SELECT`).WithArgs(id).WillReturnError(sql.ErrNoRows)
+}
+
+func MockPlanRepoSelectAllReturnRows(mock sqlmock.Sqlmock, plans []*models.Plan) {
+	rows := sqlmock.NewRows([]string{"id", "name", "price", "currency", "duration_months", "trial_days"})
+	for _, plan := range plans {
+		rows.AddRow(plan.ID, plan.Name, plan.Price, plan.Currency, plan.DurationMonths, plan.TrialDays)
+	}
+	mock.ExpectQuery(`SELECT`).WillReturnRows(rows)
+}
@@ -0,0 +1,136 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/plan/repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockPlanRepository is a mock of PlanRepository interface
+type MockPlanRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPlanRepositoryMockRecorder
+}
+
+// MockPlanRepositoryMockRecorder is the mock recorder for MockPlanRepository
+type MockPlanRepositoryMockRecorder struct {
+	mock *MockPlanRepository
+}
+
+// NewMockPlanRepository creates a new mock instance
+func NewMockPlanRepository(ctrl *gomock.Controller) *MockPlanRepository {
+	mock := &MockPlanRepository{ctrl: ctrl}
+	mock.recorder = &MockPlanRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockPlanRepository) EXPECT() *MockPlanRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Insert mocks base method
+func (m *MockPlanRepository) Insert(plan *models.Plan) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", plan)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert
+func (mr *MockPlanRepositoryMockRecorder) Insert(plan interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockPlanRepository)(nil).Insert), plan)
+}
+
+// Update mocks base method
+func (m *MockPlanRepository) Update(plan *models.Plan) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", plan)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update
+func (mr *MockPlanRepositoryMockRecorder) Update(plan interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPlanRepository)(nil).Update), plan)
+}
+
+// DeleteByID mocks base method
+func (m *MockPlanRepository) DeleteByID(planID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", planID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID
+func (mr *MockPlanRepositoryMockRecorder) DeleteByID(planID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockPlanRepository)(nil).DeleteByID), planID)
+}
+
+// SelectByID mocks base method
+func (m *MockPlanRepository) SelectByID(planID uint64) (*models.Plan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectByID", planID)
+	ret0, _ := ret[0].(*models.Plan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectByID indicates an expected call of SelectByID
+func (mr *MockPlanRepositoryMockRecorder) SelectByID(planID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectByID", reflect.TypeOf((*MockPlanRepository)(nil).SelectByID), planID)
+}
+
+// SelectByName mocks base method
+func (m *MockPlanRepository) SelectByName(name string) (*models.Plan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectByName", name)
+	ret0, _ := ret[0].(*models.Plan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectByName indicates an expected call of SelectByName
+func (mr *MockPlanRepositoryMockRecorder) SelectByName(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectByName", reflect.TypeOf((*MockPlanRepository)(nil).SelectByName), name)
+}
+
+// SelectAll mocks base method
+func (m *MockPlanRepository) SelectAll() ([]*models.Plan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectAll")
+	ret0, _ := ret[0].([]*models.Plan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectAll indicates an expected call of SelectAll
+func (mr *MockPlanRepositoryMockRecorder) SelectAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectAll", reflect.TypeOf((*MockPlanRepository)(nil).SelectAll))
+}
+
+// HasPayments mocks base method
+func (m *MockPlanRepository) HasPayments(planID uint64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasPayments", planID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasPayments indicates an expected call of HasPayments
+func (mr *MockPlanRepositoryMockRecorder) HasPayments(planID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasPayments", reflect.TypeOf((*MockPlanRepository)(nil).HasPayments), planID)
+}
@@ -0,0 +1,108 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/plan/usecase.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	errors "github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	models "github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockPlanUsecase is a mock of PlanUsecase interface
+type MockPlanUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockPlanUsecaseMockRecorder
+}
+
+// MockPlanUsecaseMockRecorder is the mock recorder for MockPlanUsecase
+type MockPlanUsecaseMockRecorder struct {
+	mock *MockPlanUsecase
+}
+
+// NewMockPlanUsecase creates a new mock instance
+func NewMockPlanUsecase(ctrl *gomock.Controller) *MockPlanUsecase {
+	mock := &MockPlanUsecase{ctrl: ctrl}
+	mock.recorder = &MockPlanUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockPlanUsecase) EXPECT() *MockPlanUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method
+func (m *MockPlanUsecase) Create(plan *models.Plan) *errors.Error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", plan)
+	ret0, _ := ret[0].(*errors.Error)
+	return ret0
+}
+
+// Create indicates an expected call of Create
+func (mr *MockPlanUsecaseMockRecorder) Create(plan interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPlanUsecase)(nil).Create), plan)
+}
+
+// UpdateByID mocks base method
+func (m *MockPlanUsecase) UpdateByID(planID uint64, newPlanData *models.Plan) (*models.Plan, *errors.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateByID", planID, newPlanData)
+	ret0, _ := ret[0].(*models.Plan)
+	ret1, _ := ret[1].(*errors.Error)
+	return ret0, ret1
+}
+
+// UpdateByID indicates an expected call of UpdateByID
+func (mr *MockPlanUsecaseMockRecorder) UpdateByID(planID, newPlanData interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateByID", reflect.TypeOf((*MockPlanUsecase)(nil).UpdateByID), planID, newPlanData)
+}
+
+// DeleteByID mocks base method
+func (m *MockPlanUsecase) DeleteByID(planID uint64) *errors.Error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", planID)
+	ret0, _ := ret[0].(*errors.Error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID
+func (mr *MockPlanUsecaseMockRecorder) DeleteByID(planID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockPlanUsecase)(nil).DeleteByID), planID)
+}
+
+// GetByID mocks base method
+func (m *MockPlanUsecase) GetByID(planID uint64) (*models.Plan, *errors.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", planID)
+	ret0, _ := ret[0].(*models.Plan)
+	ret1, _ := ret[1].(*errors.Error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID
+func (mr *MockPlanUsecaseMockRecorder) GetByID(planID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockPlanUsecase)(nil).GetByID), planID)
+}
+
+// List mocks base method
+func (m *MockPlanUsecase) List() ([]*models.Plan, *errors.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List")
+	ret0, _ := ret[0].([]*models.Plan)
+	ret1, _ := ret[1].(*errors.Error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List
+func (mr *MockPlanUsecaseMockRecorder) List() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockPlanUsecase)(nil).List))
+}
@@ -0,0 +1,14 @@
+package plan
+
+import "github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+
+type PlanRepository interface {
+	Insert(plan *models.Plan) error
+	Update(plan *models.Plan) error
+	DeleteByID(planID uint64) error
+	SelectByID(planID uint64) (*models.Plan, error)
+	SelectByName(name string) (*models.Plan, error)
+	SelectAll() ([]*models.Plan, error)
+	// HasPayments reports whether the plan is referenced by payments history
+	HasPayments(planID uint64) (bool, error)
+}
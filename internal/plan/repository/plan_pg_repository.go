@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/plan"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
+)
+
+type PlanPgRepository struct {
+	dbConn *sql.DB
+}
+
+func NewPlanPgRepository(conn *sql.DB) plan.PlanRepository {
+	return &PlanPgRepository{
+		dbConn: conn,
+	}
+}
+
+func (pr *PlanPgRepository) Insert(plan *models.Plan) error {
+	tx, err := pr.dbConn.BeginTx(context.Background(), &sql.TxOptions{})
+	if err != nil {
+		return err
+	}
+
+	err = tx.QueryRow(`
+		INSERT INTO plans(name, price, currency, duration_months, trial_days)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id`,
+		plan.Name, plan.Price, plan.Currency, plan.DurationMonths, plan.TrialDays).
+		Scan(&plan.ID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			logger.Error(rollbackErr.Error())
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (pr *PlanPgRepository) Update(plan *models.Plan) error {
+	tx, err := pr.dbConn.BeginTx(context.Background(), &sql.TxOptions{})
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		UPDATE plans
+		SET name = $2, price = $3, currency = $4,
+		    duration_months = $5, trial_days = $6
+		WHERE id = $1`,
+		plan.ID, plan.Name, plan.Price, plan.Currency,
+		plan.DurationMonths, plan.TrialDays)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			logger.Error(rollbackErr.Error())
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (pr *PlanPgRepository) DeleteByID(planID uint64) error {
+	tx, err := pr.dbConn.BeginTx(context.Background(), &sql.TxOptions{})
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		DELETE FROM plans
+		WHERE id = $1`, planID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			logger.Error(rollbackErr.Error())
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (pr *PlanPgRepository) SelectByID(planID uint64) (*models.Plan, error) {
+	plan := &models.Plan{}
+	err := pr.dbConn.QueryRow(`
+		SELECT id, name, price, currency, duration_months, trial_days
+		FROM plans
+		WHERE id=$1`, planID).
+		Scan(&plan.ID, &plan.Name, &plan.Price, &plan.Currency,
+			&plan.DurationMonths, &plan.TrialDays)
+	if err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+func (pr *PlanPgRepository) HasPayments(planID uint64) (bool, error) {
+	var exists bool
+	err := pr.dbConn.QueryRow(`
+		SELECT EXISTS (
+			SELECT 1
+			FROM payments
+			WHERE plan_id=$1
+		)`, planID).Scan(&exists)
+	return exists, err
+}
+
+func (pr *PlanPgRepository) SelectByName(name string) (*models.Plan, error) {
+	plan := &models.Plan{}
+	err := pr.dbConn.QueryRow(`
+		SELECT id, name, price, currency, duration_months, trial_days
+		FROM plans
+		WHERE name=$1`, name).
+		Scan(&plan.ID, &plan.Name, &plan.Price, &plan.Currency,
+			&plan.DurationMonths, &plan.TrialDays)
+	if err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+func (pr *PlanPgRepository) SelectAll() ([]*models.Plan, error) {
+	rows, err := pr.dbConn.Query(`
+		SELECT id, name, price, currency, duration_months, trial_days
+		FROM plans
+		ORDER BY price, id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var plans []*models.Plan
+	for rows.Next() {
+		plan := &models.Plan{}
+		err := rows.Scan(&plan.ID, &plan.Name, &plan.Price, &plan.Currency,
+			&plan.DurationMonths, &plan.TrialDays)
+		if err != nil {
+			return nil, err
+		}
+		plans = append(plans, plan)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return plans, nil
+}
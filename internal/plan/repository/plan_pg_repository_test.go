@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/plan/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+var testPlan = &models.Plan{
+	ID:             1,
+	Name:           "Месяц",
+	Price:          299,
+	Currency:       "643",
+	DurationMonths: 1,
+	TrialDays:      7,
+}
+
+func TestPlanPgRepository_Insert_OK(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	planPgRep := NewPlanPgRepository(db)
+	newPlan := *testPlan
+
+	mocks.MockPlanRepoInsertReturnRows(mock, testPlan)
+	newPlan.ID = 0
+	err = planPgRep.Insert(&newPlan)
+	assert.NoError(t, err)
+	assert.Equal(t, testPlan.ID, newPlan.ID)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestPlanPgRepository_Update_OK(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	planPgRep := NewPlanPgRepository(db)
+
+	mocks.MockPlanRepoUpdateReturnResultOk(mock, testPlan)
+	err = planPgRep.Update(testPlan)
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestPlanPgRepository_DeleteByID_OK(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	planPgRep := NewPlanPgRepository(db)
+
+	mocks.MockPlanRepoDeleteReturnResultOk(mock, testPlan.ID)
+	err = planPgRep.DeleteByID(testPlan.ID)
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestPlanPgRepository_HasPayments_OK(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	planPgRep := NewPlanPgRepository(db)
+
+	mocks.MockPlanRepoHasPaymentsReturnRows(mock, testPlan.ID, true)
+	hasPayments, err := planPgRep.HasPayments(testPlan.ID)
+	assert.NoError(t, err)
+	assert.True(t, hasPayments)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestPlanPgRepository_SelectByID_OK(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	planPgRep := NewPlanPgRepository(db)
+
+	mocks.MockPlanRepoSelectByIDReturnRows(mock, testPlan)
+	dbPlan, err := planPgRep.SelectByID(testPlan.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, testPlan, dbPlan)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestPlanPgRepository_SelectByID_NoRows(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	planPgRep := NewPlanPgRepository(db)
+
+	mocks.MockPlanRepoSelectByIDReturnErrNoRows(mock, testPlan.ID)
+	dbPlan, err := planPgRep.SelectByID(testPlan.ID)
+	assert.Equal(t, sql.ErrNoRows, err)
+	assert.Equal(t, (*models.Plan)(nil), dbPlan)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestPlanPgRepository_SelectAll_OK(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	planPgRep := NewPlanPgRepository(db)
+	plans := []*models.Plan{testPlan}
+
+	mocks.MockPlanRepoSelectAllReturnRows(mock, plans)
+	dbPlans, err := planPgRep.SelectAll()
+	assert.NoError(t, err)
+	assert.Equal(t, plans, dbPlans)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
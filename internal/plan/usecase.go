@@ -0,0 +1,14 @@
+package plan
+
+import (
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+)
+
+type PlanUsecase interface {
+	Create(plan *models.Plan) *errors.Error
+	UpdateByID(planID uint64, newPlanData *models.Plan) (*models.Plan, *errors.Error)
+	DeleteByID(planID uint64) *errors.Error
+	GetByID(planID uint64) (*models.Plan, *errors.Error)
+	List() ([]*models.Plan, *errors.Error)
+}
@@ -0,0 +1,103 @@
+package usecases
+
+import (
+	"database/sql"
+
+	. "github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/plan"
+)
+
+type PlanUsecase struct {
+	planRepo plan.PlanRepository
+}
+
+func NewPlanUsecase(repo plan.PlanRepository) plan.PlanUsecase {
+	return &PlanUsecase{
+		planRepo: repo,
+	}
+}
+
+func (pu *PlanUsecase) Create(plan *models.Plan) *errors.Error {
+	if err := pu.checkByName(plan.Name); err != nil {
+		return err
+	}
+
+	if err := pu.planRepo.Insert(plan); err != nil {
+		return errors.New(CodeInternalError, err)
+	}
+	return nil
+}
+
+func (pu *PlanUsecase) UpdateByID(planID uint64, newPlanData *models.Plan) (*models.Plan, *errors.Error) {
+	plan, err := pu.GetByID(planID)
+	if err != nil {
+		return nil, err
+	}
+
+	if plan.Name != newPlanData.Name {
+		if err := pu.checkByName(newPlanData.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	newPlanData.ID = plan.ID
+	if err := pu.planRepo.Update(newPlanData); err != nil {
+		return nil, errors.New(CodeInternalError, err)
+	}
+	return newPlanData, nil
+}
+
+func (pu *PlanUsecase) DeleteByID(planID uint64) *errors.Error {
+	if _, err := pu.GetByID(planID); err != nil {
+		return err
+	}
+
+	hasPayments, err := pu.planRepo.HasPayments(planID)
+	if err != nil {
+		return errors.New(CodeInternalError, err)
+	}
+	if hasPayments {
+		return errors.Get(CodePlanHasPayments)
+	}
+
+	if err := pu.planRepo.DeleteByID(planID); err != nil {
+		return errors.New(CodeInternalError, err)
+	}
+	return nil
+}
+
+func (pu *PlanUsecase) GetByID(planID uint64) (*models.Plan, *errors.Error) {
+	plan, err := pu.planRepo.SelectByID(planID)
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, errors.Get(CodePlanDoesNotExist)
+	case err != nil:
+		return nil, errors.New(CodeInternalError, err)
+	}
+	return plan, nil
+}
+
+func (pu *PlanUsecase) List() ([]*models.Plan, *errors.Error) {
+	plans, err := pu.planRepo.SelectAll()
+	if err != nil {
+		return nil, errors.New(CodeInternalError, err)
+	}
+	if len(plans) == 0 {
+		return []*models.Plan{}, nil
+	}
+	return plans, nil
+}
+
+// checkByName returns error if plan name is taken
+func (pu *PlanUsecase) checkByName(name string) *errors.Error {
+	_, err := pu.planRepo.SelectByName(name)
+	switch {
+	case err == sql.ErrNoRows:
+		return nil
+	case err != nil:
+		return errors.New(CodeInternalError, err)
+	}
+	return errors.Get(CodePlanNameAlreadyExists)
+}
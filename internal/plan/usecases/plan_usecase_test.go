@@ -0,0 +1,140 @@
+package usecases
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/plan/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var testPlan = &models.Plan{
+	ID:             1,
+	Name:           "Месяц",
+	Price:          299,
+	Currency:       "643",
+	DurationMonths: 1,
+}
+
+func setupPlanUsecase(t *testing.T) (*gomock.Controller, *mocks.MockPlanRepository, *PlanUsecase) {
+	ctrl := gomock.NewController(t)
+	planRep := mocks.NewMockPlanRepository(ctrl)
+	// nolint: errcheck
+	planUseCase := NewPlanUsecase(planRep).(*PlanUsecase)
+	return ctrl, planRep, planUseCase
+}
+
+func TestPlanUseCase_Create_OK(t *testing.T) {
+	t.Parallel()
+	ctrl, planRep, planUseCase := setupPlanUsecase(t)
+	defer ctrl.Finish()
+
+	planRep.
+		EXPECT().
+		SelectByName(gomock.Eq(testPlan.Name)).
+		Return(nil, sql.ErrNoRows)
+
+	planRep.
+		EXPECT().
+		Insert(gomock.Eq(testPlan)).
+		Return(nil)
+
+	err := planUseCase.Create(testPlan)
+	assert.Equal(t, err, (*errors.Error)(nil))
+}
+
+func TestPlanUseCase_Create_NameAlreadyExists(t *testing.T) {
+	t.Parallel()
+	ctrl, planRep, planUseCase := setupPlanUsecase(t)
+	defer ctrl.Finish()
+
+	planRep.
+		EXPECT().
+		SelectByName(gomock.Eq(testPlan.Name)).
+		Return(testPlan, nil)
+
+	err := planUseCase.Create(testPlan)
+	assert.Equal(t, errors.Get(consts.CodePlanNameAlreadyExists), err)
+}
+
+func TestPlanUseCase_UpdateByID_OK(t *testing.T) {
+	t.Parallel()
+	ctrl, planRep, planUseCase := setupPlanUsecase(t)
+	defer ctrl.Finish()
+
+	newPlanData := &models.Plan{
+		Name:           testPlan.Name,
+		Price:          349,
+		Currency:       testPlan.Currency,
+		DurationMonths: testPlan.DurationMonths,
+		TrialDays:      14,
+	}
+
+	planRep.
+		EXPECT().
+		SelectByID(gomock.Eq(testPlan.ID)).
+		Return(testPlan, nil)
+
+	planRep.
+		EXPECT().
+		Update(gomock.Any()).
+		Return(nil)
+
+	dbPlan, err := planUseCase.UpdateByID(testPlan.ID, newPlanData)
+	assert.Equal(t, err, (*errors.Error)(nil))
+	assert.Equal(t, testPlan.ID, dbPlan.ID)
+	assert.Equal(t, newPlanData.Price, dbPlan.Price)
+}
+
+func TestPlanUseCase_GetByID_DoesNotExist(t *testing.T) {
+	t.Parallel()
+	ctrl, planRep, planUseCase := setupPlanUsecase(t)
+	defer ctrl.Finish()
+
+	planRep.
+		EXPECT().
+		SelectByID(gomock.Eq(testPlan.ID)).
+		Return(nil, sql.ErrNoRows)
+
+	dbPlan, err := planUseCase.GetByID(testPlan.ID)
+	assert.Equal(t, errors.Get(consts.CodePlanDoesNotExist), err)
+	assert.Equal(t, (*models.Plan)(nil), dbPlan)
+}
+
+func TestPlanUseCase_DeleteByID_HasPayments(t *testing.T) {
+	t.Parallel()
+	ctrl, planRep, planUseCase := setupPlanUsecase(t)
+	defer ctrl.Finish()
+
+	planRep.
+		EXPECT().
+		SelectByID(gomock.Eq(testPlan.ID)).
+		Return(testPlan, nil)
+
+	planRep.
+		EXPECT().
+		HasPayments(gomock.Eq(testPlan.ID)).
+		Return(true, nil)
+
+	err := planUseCase.DeleteByID(testPlan.ID)
+	assert.Equal(t, errors.Get(consts.CodePlanHasPayments), err)
+}
+
+func TestPlanUseCase_List_Empty(t *testing.T) {
+	t.Parallel()
+	ctrl, planRep, planUseCase := setupPlanUsecase(t)
+	defer ctrl.Finish()
+
+	planRep.
+		EXPECT().
+		SelectAll().
+		Return(nil, nil)
+
+	plans, err := planUseCase.List()
+	assert.Equal(t, err, (*errors.Error)(nil))
+	assert.Equal(t, []*models.Plan{}, plans)
+}
@@ -0,0 +1,96 @@
+package delivery
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/mwares"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/progress"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
+	reader "github.com/go-park-mail-ru/2020_2_Slash/tools/request_reader"
+	. "github.com/go-park-mail-ru/2020_2_Slash/tools/response"
+	"github.com/labstack/echo/v4"
+)
+
+type ProgressHandler struct {
+	progressUcase progress.ProgressUsecase
+}
+
+func NewProgressHandler(progressUcase progress.ProgressUsecase) *ProgressHandler {
+	return &ProgressHandler{
+		progressUcase: progressUcase,
+	}
+}
+
+func (ph *ProgressHandler) Configure(e *echo.Echo, mw *mwares.MiddlewareManager) {
+	e.PUT("/api/v1/progress", ph.SaveProgressHandler(), mw.CheckAuth, mw.CheckCSRF)
+	e.GET("/api/v1/progress/continue", ph.GetContinueWatchingHandler(), mw.CheckAuth)
+}
+
+func (ph *ProgressHandler) SaveProgressHandler() echo.HandlerFunc {
+	type Request struct {
+		ContentID uint64  `json:"content_id" validate:"required"`
+		EpisodeID *uint64 `json:"episode_id"`
+		Position  int     `json:"position" validate:"min=0"`
+		Duration  int     `json:"duration" validate:"required,min=1"`
+	}
+
+	return func(cntx echo.Context) error {
+		req := &Request{}
+		if customErr := reader.NewRequestReader(cntx).Read(req); customErr != nil {
+			logger.Error(customErr.Message)
+			return cntx.JSON(customErr.HTTPCode, Response{Error: customErr})
+		}
+
+		// nolint: errcheck
+		userID, _ := cntx.Get("userID").(uint64)
+		progress := &models.WatchProgress{
+			UserID:    userID,
+			ContentID: req.ContentID,
+			EpisodeID: req.EpisodeID,
+			Position:  req.Position,
+			Duration:  req.Duration,
+			Updated:   time.Now(),
+		}
+
+		if customErr := ph.progressUcase.Save(progress); customErr != nil {
+			logger.Error(customErr.Message)
+			return cntx.JSON(customErr.HTTPCode, Response{Error: customErr})
+		}
+
+		return cntx.JSON(http.StatusOK, Response{
+			Body: &Body{
+				"progress": progress,
+			},
+		})
+	}
+}
+
+func (ph *ProgressHandler) GetContinueWatchingHandler() echo.HandlerFunc {
+	type Request struct {
+		models.Pagination
+	}
+
+	return func(cntx echo.Context) error {
+		req := &Request{}
+		if customErr := reader.NewRequestReader(cntx).Read(req); customErr != nil {
+			logger.Error(customErr.Message)
+			return cntx.JSON(customErr.HTTPCode, Response{Error: customErr})
+		}
+
+		// nolint: errcheck
+		userID, _ := cntx.Get("userID").(uint64)
+		continueWatching, customErr := ph.progressUcase.GetContinueWatching(userID, &req.Pagination)
+		if customErr != nil {
+			logger.Error(customErr.Message)
+			return cntx.JSON(customErr.HTTPCode, Response{Error: customErr})
+		}
+
+		return cntx.JSON(http.StatusOK, Response{
+			Body: &Body{
+				"continue_watching": continueWatching,
+			},
+		})
+	}
+}
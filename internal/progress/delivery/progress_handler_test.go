@@ -0,0 +1,165 @@
+package delivery
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/progress/mocks"
+	"github.com/go-park-mail-ru/2020_2_Slash/pkg/converter"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/response"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgressHandler_SaveProgressHandler_OK(t *testing.T) {
+	t.Parallel()
+	// Setup
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	progressUseCase := mocks.NewMockProgressUsecase(ctrl)
+
+	var userID uint64 = 3
+	var episodeID uint64 = 5
+	reqJSON := `{"content_id":2,"episode_id":5,"position":600,"duration":1400}`
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/progress", strings.NewReader(reqJSON))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("userID", userID)
+
+	progressHandler := NewProgressHandler(progressUseCase)
+	handleFunc := progressHandler.SaveProgressHandler()
+
+	var savedProgress *models.WatchProgress
+	progressUseCase.
+		EXPECT().
+		Save(gomock.Any()).
+		DoAndReturn(func(progress *models.WatchProgress) *errors.Error {
+			savedProgress = progress
+			return nil
+		})
+
+	// Assertions
+	if assert.NoError(t, handleFunc(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, userID, savedProgress.UserID)
+		assert.Equal(t, uint64(2), savedProgress.ContentID)
+		assert.Equal(t, episodeID, *savedProgress.EpisodeID)
+		assert.Equal(t, 600, savedProgress.Position)
+		assert.Equal(t, 1400, savedProgress.Duration)
+
+		expResBody, err := converter.AnyToBytesBuffer(&response.Response{
+			Body: &response.Body{"progress": savedProgress},
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		bytes, _ := ioutil.ReadAll(rec.Body)
+
+		assert.JSONEq(t, expResBody.String(), string(bytes))
+	}
+}
+
+func TestProgressHandler_SaveProgressHandler_WrongProgress(t *testing.T) {
+	t.Parallel()
+	// Setup
+	logger.DisableLogger()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	progressUseCase := mocks.NewMockProgressUsecase(ctrl)
+
+	reqJSON := `{"content_id":1,"episode_id":5,"position":600,"duration":5400}`
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/progress", strings.NewReader(reqJSON))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("userID", uint64(3))
+
+	progressHandler := NewProgressHandler(progressUseCase)
+	handleFunc := progressHandler.SaveProgressHandler()
+
+	customErr := errors.Get(consts.CodeWrongWatchProgress)
+	progressUseCase.
+		EXPECT().
+		Save(gomock.Any()).
+		Return(customErr)
+
+	response := &response.Response{Error: customErr}
+
+	// Assertions
+	if assert.NoError(t, handleFunc(c)) {
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+		expResBody, err := converter.AnyToBytesBuffer(response)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		bytes, _ := ioutil.ReadAll(rec.Body)
+
+		assert.JSONEq(t, expResBody.String(), string(bytes))
+	}
+}
+
+func TestProgressHandler_GetContinueWatchingHandler(t *testing.T) {
+	t.Parallel()
+	// Setup
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	progressUseCase := mocks.NewMockProgressUsecase(ctrl)
+
+	var userID uint64 = 3
+	pagination := &models.Pagination{From: 0, Count: 10}
+	continueWatching := []*models.ContinueWatching{
+		{
+			Content:  &models.Content{ContentID: 2, Type: consts.TVShowContentType},
+			Episode:  &models.Episode{ID: 6, Number: 1, SeasonID: 2},
+			Season:   2,
+			Position: 60,
+			Duration: 1400,
+		},
+	}
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/progress/continue?from=0&count=10", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("userID", userID)
+
+	progressHandler := NewProgressHandler(progressUseCase)
+	handleFunc := progressHandler.GetContinueWatchingHandler()
+
+	progressUseCase.
+		EXPECT().
+		GetContinueWatching(userID, pagination).
+		Return(continueWatching, nil)
+
+	response := &response.Response{Body: &response.Body{"continue_watching": continueWatching}}
+
+	// Assertions
+	if assert.NoError(t, handleFunc(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		expResBody, err := converter.AnyToBytesBuffer(response)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		bytes, _ := ioutil.ReadAll(rec.Body)
+
+		assert.JSONEq(t, expResBody.String(), string(bytes))
+	}
+}
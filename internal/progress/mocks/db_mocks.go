@@ -0,0 +1,57 @@
+package mocks
+
+import (
+	"database/sql"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+)
+
+func MockUpsertSuccess(mock sqlmock.Sqlmock, progress *models.WatchProgress) {
+	mock.ExpectBegin()
+	res := sqlmock.NewResult(0, 1)
+	mock.ExpectExec(`INSERT INTO watch_progress`).
+		WithArgs(progress.UserID, progress.ContentID, progress.EpisodeID, progress.Position,
+			progress.Duration, progress.Watched, progress.Updated).
+		WillReturnResult(res)
+	mock.ExpectCommit()
+}
+
+func MockUpsertError(mock sqlmock.Sqlmock, progress *models.WatchProgress, err error) {
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO watch_progress`).
+		WithArgs(progress.UserID, progress.ContentID, progress.EpisodeID, progress.Position,
+			progress.Duration, progress.Watched, progress.Updated).
+		WillReturnError(err)
+	mock.ExpectRollback()
+}
+
+func MockSelectContinueWatchingReturnRows(mock sqlmock.Sqlmock, userID uint64,
+	items []*models.ContinueWatching, limit uint64, offset uint64) {
+	rows := sqlmock.NewRows([]string{"c.id", "c.name", "c.original_name", "c.description",
+		"c.short_description", "c.rating", "c.year", "c.images", "c.type", "c.is_free",
+		"e.id", "e.number", "e.name", "e.video", "e.description", "e.poster", "e.season_id",
+		"e.season_number", "position", "duration", "p.updated"})
+	for _, item := range items {
+		var episodeID, episodeNumber, seasonID, seasonNumber sql.NullInt64
+		var episodeName, video, description, poster sql.NullString
+		if episode := item.Episode; episode != nil {
+			episodeID = sql.NullInt64{Int64: int64(episode.ID), Valid: true}
+			episodeNumber = sql.NullInt64{Int64: int64(episode.Number), Valid: true}
+			episodeName = sql.NullString{String: episode.Name, Valid: true}
+			video = sql.NullString{String: episode.Video, Valid: true}
+			description = sql.NullString{String: episode.Description, Valid: true}
+			poster = sql.NullString{String: episode.Poster, Valid: true}
+			seasonID = sql.NullInt64{Int64: int64(episode.SeasonID), Valid: true}
+			seasonNumber = sql.NullInt64{Int64: int64(item.Season), Valid: true}
+		}
+		cnt := item.Content
+		rows.AddRow(cnt.ContentID, cnt.Name, cnt.OriginalName, cnt.Description,
+			cnt.ShortDescription, cnt.Rating, cnt.Year, cnt.Images, cnt.Type, cnt.IsFree,
+			episodeID, episodeNumber, episodeName, video, description, poster, seasonID,
+			seasonNumber, item.Position, item.Duration, item.Updated)
+	}
+	mock.ExpectQuery(`SELECT`).
+		WithArgs(userID, limit, offset).
+		WillReturnRows(rows)
+}
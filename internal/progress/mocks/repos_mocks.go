@@ -0,0 +1,63 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/progress/repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockProgressRepository is a mock of ProgressRepository interface
+type MockProgressRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockProgressRepositoryMockRecorder
+}
+
+// MockProgressRepositoryMockRecorder is the mock recorder for MockProgressRepository
+type MockProgressRepositoryMockRecorder struct {
+	mock *MockProgressRepository
+}
+
+// NewMockProgressRepository creates a new mock instance
+func NewMockProgressRepository(ctrl *gomock.Controller) *MockProgressRepository {
+	mock := &MockProgressRepository{ctrl: ctrl}
+	mock.recorder = &MockProgressRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockProgressRepository) EXPECT() *MockProgressRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Upsert mocks base method
+func (m *MockProgressRepository) Upsert(progress *models.WatchProgress) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", progress)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert
+func (mr *MockProgressRepositoryMockRecorder) Upsert(progress interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockProgressRepository)(nil).Upsert), progress)
+}
+
+// SelectContinueWatching mocks base method
+func (m *MockProgressRepository) SelectContinueWatching(userID, limit, offset uint64) ([]*models.ContinueWatching, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectContinueWatching", userID, limit, offset)
+	ret0, _ := ret[0].([]*models.ContinueWatching)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectContinueWatching indicates an expected call of SelectContinueWatching
+func (mr *MockProgressRepositoryMockRecorder) SelectContinueWatching(userID, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectContinueWatching", reflect.TypeOf((*MockProgressRepository)(nil).SelectContinueWatching), userID, limit, offset)
+}
@@ -0,0 +1,64 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/progress/usecase.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	errors "github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	models "github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockProgressUsecase is a mock of ProgressUsecase interface
+type MockProgressUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockProgressUsecaseMockRecorder
+}
+
+// MockProgressUsecaseMockRecorder is the mock recorder for MockProgressUsecase
+type MockProgressUsecaseMockRecorder struct {
+	mock *MockProgressUsecase
+}
+
+// NewMockProgressUsecase creates a new mock instance
+func NewMockProgressUsecase(ctrl *gomock.Controller) *MockProgressUsecase {
+	mock := &MockProgressUsecase{ctrl: ctrl}
+	mock.recorder = &MockProgressUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockProgressUsecase) EXPECT() *MockProgressUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Save mocks base method
+func (m *MockProgressUsecase) Save(progress *models.WatchProgress) *errors.Error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", progress)
+	ret0, _ := ret[0].(*errors.Error)
+	return ret0
+}
+
+// Save indicates an expected call of Save
+func (mr *MockProgressUsecaseMockRecorder) Save(progress interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockProgressUsecase)(nil).Save), progress)
+}
+
+// GetContinueWatching mocks base method
+func (m *MockProgressUsecase) GetContinueWatching(userID uint64, pagination *models.Pagination) ([]*models.ContinueWatching, *errors.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContinueWatching", userID, pagination)
+	ret0, _ := ret[0].([]*models.ContinueWatching)
+	ret1, _ := ret[1].(*errors.Error)
+	return ret0, ret1
+}
+
+// GetContinueWatching indicates an expected call of GetContinueWatching
+func (mr *MockProgressUsecaseMockRecorder) GetContinueWatching(userID, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContinueWatching", reflect.TypeOf((*MockProgressUsecase)(nil).GetContinueWatching), userID, pagination)
+}
@@ -0,0 +1,8 @@
+package progress
+
+import "github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+
+type ProgressRepository interface {
+	Upsert(progress *models.WatchProgress) error
+	SelectContinueWatching(userID uint64, limit uint64, offset uint64) ([]*models.ContinueWatching, error)
+}
@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	queryBuilder "github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/query_builder"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/progress"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
+)
+
+type ProgressPgRepository struct {
+	dbConn *sql.DB
+}
+
+func NewProgressPgRepository(conn *sql.DB) progress.ProgressRepository {
+	return &ProgressPgRepository{
+		dbConn: conn,
+	}
+}
+
+func (rep *ProgressPgRepository) Upsert(progress *models.WatchProgress) error {
+	tx, err := rep.dbConn.BeginTx(context.Background(), &sql.TxOptions{})
+	if err != nil {
+		return err
+	}
+
+	// Movie and episode progress are unique by different partial indexes
+	conflictTarget := "(user_id, content_id) WHERE episode_id IS NULL"
+	if progress.EpisodeID != nil {
+		conflictTarget = "(user_id, episode_id) WHERE episode_id IS NOT NULL"
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO watch_progress(user_id, content_id, episode_id, position, duration, watched, updated)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT `+conflictTarget+` DO UPDATE
+		SET position = EXCLUDED.position,
+		    duration = EXCLUDED.duration,
+		    watched = EXCLUDED.watched,
+		    updated = EXCLUDED.updated`,
+		progress.UserID, progress.ContentID, progress.EpisodeID, progress.Position,
+		progress.Duration, progress.Watched, progress.Updated)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			logger.Error(rollbackErr.Error())
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// SelectContinueWatching returns the latest progress of every content
+// user started, tv show is continued with its first unwatched episode
+// starting from the latest one. Watched movies, finished tv shows and
+// episodes in the trash are skipped
+func (rep *ProgressPgRepository) SelectContinueWatching(userID uint64,
+	limit uint64, offset uint64) ([]*models.ContinueWatching, error) {
+	var values []interface{}
+	selectQuery := `
+		SELECT c.id, c.name, c.original_name, c.description, c.short_description,
+		c.rating, c.year, c.images, c.type, c.is_free,
+		e.id, e.number, e.name, e.video, e.description, e.poster, e.season_id, e.season_number,
+		CASE WHEN p.episode_id IS NULL THEN p.position ELSE COALESCE(ep.position, 0) END,
+		CASE WHEN p.episode_id IS NULL THEN p.duration ELSE COALESCE(ep.duration, 0) END,
+		p.updated
+		FROM (
+			SELECT DISTINCT ON (content_id) content_id, episode_id, position, duration, watched, updated
+			FROM watch_progress
+			WHERE user_id=$1 AND (episode_id IS NULL OR episode_id IN (
+				SELECT e.id
+				FROM episodes AS e
+				JOIN seasons AS s ON s.id=e.season_id
+				WHERE e.deleted_at IS NULL AND s.deleted_at IS NULL
+			))
+			ORDER BY content_id, updated DESC
+		) AS p
+		JOIN content AS c ON c.id=p.content_id
+		LEFT JOIN episodes AS pe ON pe.id=p.episode_id
+		LEFT JOIN seasons AS ps ON ps.id=pe.season_id
+		LEFT JOIN LATERAL (
+			SELECT ne.id, ne.number, ne.name, ne.video, ne.description, ne.poster,
+			ne.season_id, ns.number AS season_number
+			FROM episodes AS ne
+			JOIN seasons AS ns ON ns.id=ne.season_id
+			WHERE ns.tv_show_id=ps.tv_show_id
+			AND ne.deleted_at IS NULL AND ns.deleted_at IS NULL
+			AND (ns.number, ne.number) >= (ps.number, pe.number)
+			AND NOT EXISTS (
+				SELECT 1
+				FROM watch_progress AS w
+				WHERE w.user_id=$1 AND w.episode_id=ne.id AND w.watched
+			)
+			ORDER BY ns.number, ne.number
+			LIMIT 1
+		) AS e ON TRUE
+		LEFT JOIN watch_progress AS ep ON ep.user_id=$1 AND ep.episode_id=e.id
+		WHERE CASE WHEN p.episode_id IS NULL THEN NOT p.watched ELSE e.id IS NOT NULL END
+		AND ` + queryBuilder.BuildPublishedCondition() + `
+		ORDER BY p.updated DESC`
+	values = append(values, userID)
+
+	var pgntQuery string
+	if limit != 0 {
+		pgntQuery = "LIMIT $2 OFFSET $3"
+		values = append(values, limit, offset)
+	}
+
+	resultQuery := strings.Join([]string{
+		selectQuery,
+		pgntQuery,
+	}, " ")
+
+	rows, err := rep.dbConn.Query(resultQuery, values...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var continueWatching []*models.ContinueWatching
+
+	for rows.Next() {
+		item := &models.ContinueWatching{}
+		cnt := &models.Content{}
+		var episodeID, episodeNumber, seasonID, seasonNumber sql.NullInt64
+		var episodeName, video, description, poster sql.NullString
+
+		err := rows.Scan(&cnt.ContentID, &cnt.Name, &cnt.OriginalName,
+			&cnt.Description, &cnt.ShortDescription, &cnt.Rating, &cnt.Year,
+			&cnt.Images, &cnt.Type, &cnt.IsFree, &episodeID, &episodeNumber,
+			&episodeName, &video, &description, &poster, &seasonID, &seasonNumber,
+			&item.Position, &item.Duration, &item.Updated)
+		if err != nil {
+			return nil, err
+		}
+
+		item.Content = cnt
+		if episodeID.Valid {
+			item.Episode = &models.Episode{
+				ID:          uint64(episodeID.Int64),
+				Name:        episodeName.String,
+				Number:      int(episodeNumber.Int64),
+				Video:       video.String,
+				Description: description.String,
+				Poster:      poster.String,
+				SeasonID:    uint64(seasonID.Int64),
+			}
+			item.Season = int(seasonNumber.Int64)
+		}
+		continueWatching = append(continueWatching, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return continueWatching, nil
+}
@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/progress/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgressPgRepository_Upsert_Movie(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	progress := &models.WatchProgress{
+		UserID:    3,
+		ContentID: 2,
+		Position:  120,
+		Duration:  5400,
+		Updated:   time.Now(),
+	}
+
+	progressPgRep := NewProgressPgRepository(db)
+
+	mocks.MockUpsertSuccess(mock, progress)
+	err = progressPgRep.Upsert(progress)
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestProgressPgRepository_Upsert_Episode(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	var episodeID uint64 = 7
+	progress := &models.WatchProgress{
+		UserID:    3,
+		ContentID: 2,
+		EpisodeID: &episodeID,
+		Position:  1300,
+		Duration:  1400,
+		Watched:   true,
+		Updated:   time.Now(),
+	}
+
+	progressPgRep := NewProgressPgRepository(db)
+
+	mocks.MockUpsertSuccess(mock, progress)
+	err = progressPgRep.Upsert(progress)
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestProgressPgRepository_Upsert_Fail(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	progress := &models.WatchProgress{
+		UserID:    3,
+		ContentID: 2,
+		Position:  120,
+		Duration:  5400,
+		Updated:   time.Now(),
+	}
+	upsertErr := errors.New("foreign key violation")
+
+	progressPgRep := NewProgressPgRepository(db)
+
+	mocks.MockUpsertError(mock, progress, upsertErr)
+	err = progressPgRep.Upsert(progress)
+	assert.Equal(t, upsertErr, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestProgressPgRepository_SelectContinueWatching_Success(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	var userID uint64 = 3
+	isFree := true
+	updated := time.Now()
+	items := []*models.ContinueWatching{
+		{
+			Content: &models.Content{
+				ContentID: 1,
+				Name:      "tvshow",
+				Year:      2020,
+				Images:    "/images/tvshow_1",
+				Type:      "tvshow",
+				IsFree:    &isFree,
+			},
+			Episode: &models.Episode{
+				ID:       4,
+				Name:     "episode",
+				Number:   2,
+				Video:    "/videos/tvshow_1/1/2.mp4",
+				Poster:   "/images/tvshow_1/1/2.png",
+				SeasonID: 5,
+			},
+			Season:   1,
+			Position: 600,
+			Duration: 1400,
+			Updated:  updated,
+		},
+		{
+			Content: &models.Content{
+				ContentID: 2,
+				Name:      "movie",
+				Year:      2019,
+				Images:    "/images/movie_2",
+				Type:      "movie",
+				IsFree:    &isFree,
+			},
+			Position: 120,
+			Duration: 5400,
+			Updated:  updated.Add(-time.Hour),
+		},
+	}
+
+	progressPgRep := NewProgressPgRepository(db)
+
+	mocks.MockSelectContinueWatchingReturnRows(mock, userID, items, 10, 0)
+	dbItems, err := progressPgRep.SelectContinueWatching(userID, 10, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, items, dbItems)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
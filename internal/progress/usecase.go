@@ -0,0 +1,12 @@
+package progress
+
+import (
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+)
+
+type ProgressUsecase interface {
+	Save(progress *models.WatchProgress) *errors.Error
+	GetContinueWatching(userID uint64,
+		pagination *models.Pagination) ([]*models.ContinueWatching, *errors.Error)
+}
@@ -0,0 +1,79 @@
+package usecases
+
+import (
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/content"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/episode"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/progress"
+)
+
+type ProgressUsecase struct {
+	progressRepo progress.ProgressRepository
+	contentUcase content.ContentUsecase
+	episodeUcase episode.EpisodeUsecase
+}
+
+func NewProgressUsecase(repo progress.ProgressRepository, contentUcase content.ContentUsecase,
+	episodeUcase episode.EpisodeUsecase) progress.ProgressUsecase {
+	return &ProgressUsecase{
+		progressRepo: repo,
+		contentUcase: contentUcase,
+		episodeUcase: episodeUcase,
+	}
+}
+
+func (pu *ProgressUsecase) Save(progress *models.WatchProgress) *errors.Error {
+	if progress.Position > progress.Duration {
+		return errors.Get(consts.CodeWrongWatchProgress)
+	}
+
+	content, customErr := pu.contentUcase.GetByID(progress.ContentID)
+	if customErr != nil {
+		return customErr
+	}
+
+	// Movie progress is tracked by content, tv show progress by its episodes
+	switch content.Type {
+	case consts.MovieContentType:
+		if progress.EpisodeID != nil {
+			return errors.Get(consts.CodeWrongWatchProgress)
+		}
+	case consts.TVShowContentType:
+		if progress.EpisodeID == nil {
+			return errors.Get(consts.CodeWrongWatchProgress)
+		}
+		if _, customErr := pu.episodeUcase.GetByID(*progress.EpisodeID); customErr != nil {
+			return customErr
+		}
+		episodeContent, customErr := pu.episodeUcase.GetContentByEID(*progress.EpisodeID)
+		if customErr != nil {
+			return customErr
+		}
+		if episodeContent.ContentID != progress.ContentID {
+			return errors.Get(consts.CodeWrongWatchProgress)
+		}
+	}
+
+	progress.Watched = progress.IsWatched()
+	if err := pu.progressRepo.Upsert(progress); err != nil {
+		return errors.New(consts.CodeInternalError, err)
+	}
+	return nil
+}
+
+// GetContinueWatching returns started movies and episodes to continue
+// with, watched episode is replaced by the next unwatched one of the tv show
+func (pu *ProgressUsecase) GetContinueWatching(userID uint64,
+	pagination *models.Pagination) ([]*models.ContinueWatching, *errors.Error) {
+	continueWatching, err := pu.progressRepo.
+		SelectContinueWatching(userID, pagination.Count, pagination.From)
+	if err != nil {
+		return nil, errors.New(consts.CodeInternalError, err)
+	}
+	if continueWatching == nil {
+		continueWatching = []*models.ContinueWatching{}
+	}
+	return continueWatching, nil
+}
@@ -0,0 +1,231 @@
+package usecases
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	contentMocks "github.com/go-park-mail-ru/2020_2_Slash/internal/content/mocks"
+	episodeMocks "github.com/go-park-mail-ru/2020_2_Slash/internal/episode/mocks"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/progress/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var movieContent = &models.Content{
+	ContentID: 1,
+	Name:      "movie",
+	Type:      consts.MovieContentType,
+}
+
+var tvshowContent = &models.Content{
+	ContentID: 2,
+	Name:      "tvshow",
+	Type:      consts.TVShowContentType,
+}
+
+var testEpisode = &models.Episode{
+	ID:       5,
+	Number:   8,
+	SeasonID: 1,
+}
+
+var nextEpisode = &models.Episode{
+	ID:       6,
+	Number:   1,
+	SeasonID: 2,
+}
+
+func setupProgressUsecase(t *testing.T) (*gomock.Controller, *mocks.MockProgressRepository,
+	*contentMocks.MockContentUsecase, *episodeMocks.MockEpisodeUsecase, *ProgressUsecase) {
+	ctrl := gomock.NewController(t)
+	progressRep := mocks.NewMockProgressRepository(ctrl)
+	contentUseCase := contentMocks.NewMockContentUsecase(ctrl)
+	episodeUseCase := episodeMocks.NewMockEpisodeUsecase(ctrl)
+	// nolint: errcheck
+	progressUseCase := NewProgressUsecase(progressRep, contentUseCase, episodeUseCase).(*ProgressUsecase)
+	return ctrl, progressRep, contentUseCase, episodeUseCase, progressUseCase
+}
+
+func TestProgressUseCase_Save_Movie(t *testing.T) {
+	t.Parallel()
+	ctrl, progressRep, contentUseCase, _, progressUseCase := setupProgressUsecase(t)
+	defer ctrl.Finish()
+
+	progress := &models.WatchProgress{
+		UserID:    3,
+		ContentID: movieContent.ContentID,
+		Position:  5000,
+		Duration:  5400,
+	}
+
+	contentUseCase.
+		EXPECT().
+		GetByID(movieContent.ContentID).
+		Return(movieContent, nil)
+	progressRep.
+		EXPECT().
+		Upsert(progress).
+		Return(nil)
+
+	err := progressUseCase.Save(progress)
+	assert.Equal(t, err, (*errors.Error)(nil))
+	assert.True(t, progress.Watched)
+}
+
+func TestProgressUseCase_Save_Episode(t *testing.T) {
+	t.Parallel()
+	ctrl, progressRep, contentUseCase, episodeUseCase, progressUseCase := setupProgressUsecase(t)
+	defer ctrl.Finish()
+
+	progress := &models.WatchProgress{
+		UserID:    3,
+		ContentID: tvshowContent.ContentID,
+		EpisodeID: &testEpisode.ID,
+		Position:  600,
+		Duration:  1400,
+	}
+
+	contentUseCase.
+		EXPECT().
+		GetByID(tvshowContent.ContentID).
+		Return(tvshowContent, nil)
+	episodeUseCase.
+		EXPECT().
+		GetByID(testEpisode.ID).
+		Return(testEpisode, nil)
+	episodeUseCase.
+		EXPECT().
+		GetContentByEID(testEpisode.ID).
+		Return(tvshowContent, nil)
+	progressRep.
+		EXPECT().
+		Upsert(progress).
+		Return(nil)
+
+	err := progressUseCase.Save(progress)
+	assert.Equal(t, err, (*errors.Error)(nil))
+	assert.False(t, progress.Watched)
+}
+
+func TestProgressUseCase_Save_EpisodeOfOtherTVShow(t *testing.T) {
+	t.Parallel()
+	ctrl, _, contentUseCase, episodeUseCase, progressUseCase := setupProgressUsecase(t)
+	defer ctrl.Finish()
+
+	progress := &models.WatchProgress{
+		UserID:    3,
+		ContentID: tvshowContent.ContentID,
+		EpisodeID: &testEpisode.ID,
+		Position:  600,
+		Duration:  1400,
+	}
+
+	contentUseCase.
+		EXPECT().
+		GetByID(tvshowContent.ContentID).
+		Return(tvshowContent, nil)
+	episodeUseCase.
+		EXPECT().
+		GetByID(testEpisode.ID).
+		Return(testEpisode, nil)
+	episodeUseCase.
+		EXPECT().
+		GetContentByEID(testEpisode.ID).
+		Return(&models.Content{ContentID: 10}, nil)
+
+	err := progressUseCase.Save(progress)
+	assert.Equal(t, errors.Get(consts.CodeWrongWatchProgress), err)
+}
+
+func TestProgressUseCase_Save_MovieWithEpisode(t *testing.T) {
+	t.Parallel()
+	ctrl, _, contentUseCase, _, progressUseCase := setupProgressUsecase(t)
+	defer ctrl.Finish()
+
+	progress := &models.WatchProgress{
+		UserID:    3,
+		ContentID: movieContent.ContentID,
+		EpisodeID: &testEpisode.ID,
+		Position:  600,
+		Duration:  5400,
+	}
+
+	contentUseCase.
+		EXPECT().
+		GetByID(movieContent.ContentID).
+		Return(movieContent, nil)
+
+	err := progressUseCase.Save(progress)
+	assert.Equal(t, errors.Get(consts.CodeWrongWatchProgress), err)
+}
+
+func TestProgressUseCase_Save_PositionAfterDuration(t *testing.T) {
+	t.Parallel()
+	ctrl, _, _, _, progressUseCase := setupProgressUsecase(t)
+	defer ctrl.Finish()
+
+	progress := &models.WatchProgress{
+		UserID:    3,
+		ContentID: movieContent.ContentID,
+		Position:  6000,
+		Duration:  5400,
+	}
+
+	err := progressUseCase.Save(progress)
+	assert.Equal(t, errors.Get(consts.CodeWrongWatchProgress), err)
+}
+
+func TestProgressUseCase_GetContinueWatching(t *testing.T) {
+	t.Parallel()
+	ctrl, progressRep, _, _, progressUseCase := setupProgressUsecase(t)
+	defer ctrl.Finish()
+
+	var userID uint64 = 3
+	pagination := &models.Pagination{From: 0, Count: 10}
+	now := time.Now()
+
+	items := []*models.ContinueWatching{
+		{
+			Content: tvshowContent,
+			Episode: nextEpisode,
+			Season:  2,
+			Updated: now,
+		},
+		{
+			Content:  movieContent,
+			Position: 120,
+			Duration: 5400,
+			Updated:  now.Add(-time.Hour),
+		},
+	}
+
+	progressRep.
+		EXPECT().
+		SelectContinueWatching(userID, pagination.Count, pagination.From).
+		Return(items, nil)
+
+	continueWatching, err := progressUseCase.GetContinueWatching(userID, pagination)
+	assert.Equal(t, err, (*errors.Error)(nil))
+	assert.Equal(t, items, continueWatching)
+}
+
+func TestProgressUseCase_GetContinueWatching_Empty(t *testing.T) {
+	t.Parallel()
+	ctrl, progressRep, _, _, progressUseCase := setupProgressUsecase(t)
+	defer ctrl.Finish()
+
+	var userID uint64 = 3
+	pagination := &models.Pagination{From: 10, Count: 10}
+
+	progressRep.
+		EXPECT().
+		SelectContinueWatching(userID, pagination.Count, pagination.From).
+		Return(nil, nil)
+
+	continueWatching, err := progressUseCase.GetContinueWatching(userID, pagination)
+	assert.Equal(t, err, (*errors.Error)(nil))
+	assert.Equal(t, []*models.ContinueWatching{}, continueWatching)
+}
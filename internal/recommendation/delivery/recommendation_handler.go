@@ -0,0 +1,55 @@
+package delivery
+
+import (
+	"net/http"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/mwares"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/recommendation"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
+	reader "github.com/go-park-mail-ru/2020_2_Slash/tools/request_reader"
+	. "github.com/go-park-mail-ru/2020_2_Slash/tools/response"
+	"github.com/labstack/echo/v4"
+)
+
+type RecommendationHandler struct {
+	recommendationUcase recommendation.RecommendationUsecase
+}
+
+func NewRecommendationHandler(recommendationUcase recommendation.RecommendationUsecase) *RecommendationHandler {
+	return &RecommendationHandler{
+		recommendationUcase: recommendationUcase,
+	}
+}
+
+func (rh *RecommendationHandler) Configure(e *echo.Echo, mw *mwares.MiddlewareManager) {
+	e.GET("/api/v1/recommendations", rh.GetRecommendationsHandler(), mw.CheckAuth)
+}
+
+func (rh *RecommendationHandler) GetRecommendationsHandler() echo.HandlerFunc {
+	type Request struct {
+		models.Pagination
+	}
+
+	return func(cntx echo.Context) error {
+		req := &Request{}
+		if customErr := reader.NewRequestReader(cntx).Read(req); customErr != nil {
+			logger.Error(customErr.Message)
+			return cntx.JSON(customErr.HTTPCode, Response{Error: customErr})
+		}
+
+		// nolint: errcheck
+		userID, _ := cntx.Get("userID").(uint64)
+		recommendations, customErr := rh.recommendationUcase.ListByUser(userID, &req.Pagination)
+		if customErr != nil {
+			logger.Error(customErr.Message)
+			return cntx.JSON(customErr.HTTPCode, Response{Error: customErr})
+		}
+
+		return cntx.JSON(http.StatusOK, Response{
+			Body: &Body{
+				"recommendations": recommendations,
+			},
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package delivery
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/recommendation/mocks"
+	"github.com/go-park-mail-ru/2020_2_Slash/pkg/converter"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/response"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecommendationHandler_GetRecommendationsHandler(t *testing.T) {
+	t.Parallel()
+	// Setup
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	recommendationUseCase := mocks.NewMockRecommendationUsecase(ctrl)
+
+	var userID uint64 = 3
+	pagination := &models.Pagination{From: 10, Count: 5}
+	recommendations := []*models.Content{
+		{ContentID: 20, Name: "Ведьмак", Type: "tvshow"},
+		{ContentID: 30, Name: "Джокер", Type: "movie"},
+	}
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/recommendations?from=10&count=5", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("userID", userID)
+
+	recommendationHandler := NewRecommendationHandler(recommendationUseCase)
+	handleFunc := recommendationHandler.GetRecommendationsHandler()
+
+	recommendationUseCase.
+		EXPECT().
+		ListByUser(userID, pagination).
+		Return(recommendations, nil)
+
+	response := &response.Response{Body: &response.Body{"recommendations": recommendations}}
+
+	// Assertions
+	if assert.NoError(t, handleFunc(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		expResBody, err := converter.AnyToBytesBuffer(response)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		bytes, _ := ioutil.ReadAll(rec.Body)
+
+		assert.JSONEq(t, expResBody.String(), string(bytes))
+	}
+}
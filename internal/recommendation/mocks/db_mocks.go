@@ -0,0 +1,69 @@
+package mocks
+
+import (
+	"database/sql/driver"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+)
+
+func MockSelectIDsReturnRows(mock sqlmock.Sqlmock, ids []uint64) {
+	rows := sqlmock.NewRows([]string{"id"})
+	for _, id := range ids {
+		rows.AddRow(id)
+	}
+	mock.ExpectQuery(`SELECT`).
+		WillReturnRows(rows)
+}
+
+func MockSelectSimilarUsersContentReturnRows(mock sqlmock.Sqlmock, userID uint64,
+	similarities map[uint64]float64) {
+	rows := sqlmock.NewRows([]string{"content_id", "similarity"})
+	for contentID, similarity := range similarities {
+		rows.AddRow(contentID, similarity)
+	}
+	mock.ExpectQuery(`WITH liked`).
+		WithArgs(userID).
+		WillReturnRows(rows)
+}
+
+func MockReplaceByUserSuccess(mock sqlmock.Sqlmock, userID uint64,
+	recommendations []*models.Recommendation) {
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM recommendations`).
+		WithArgs(userID).
+		WillReturnResult(sqlmock.NewResult(0, 10))
+	stmt := mock.ExpectPrepare(`COPY`)
+	for _, rec := range recommendations {
+		stmt.ExpectExec().
+			WithArgs(rec.UserID, rec.ContentID, rec.Score).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+	}
+	mock.ExpectExec(`COPY`).WithArgs().WillReturnResult(driver.ResultNoRows)
+	mock.ExpectCommit()
+}
+
+func MockReplaceByUserError(mock sqlmock.Sqlmock, rec *models.Recommendation, err error) {
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM recommendations`).
+		WithArgs(rec.UserID).
+		WillReturnResult(sqlmock.NewResult(0, 10))
+	mock.ExpectPrepare(`COPY`).
+		ExpectExec().
+		WithArgs(rec.UserID, rec.ContentID, rec.Score).
+		WillReturnError(err)
+	mock.ExpectRollback()
+}
+
+func MockSelectByUserReturnRows(mock sqlmock.Sqlmock, userID uint64,
+	contents []*models.Content, limit uint64, offset uint64) {
+	rows := sqlmock.NewRows([]string{"c.id", "c.name", "c.original_name", "c.description",
+		"c.short_description", "c.rating", "c.year", "c.images", "c.type", "c.is_free"})
+	for _, cnt := range contents {
+		rows.AddRow(cnt.ContentID, cnt.Name, cnt.OriginalName, cnt.Description,
+			cnt.ShortDescription, cnt.Rating, cnt.Year, cnt.Images, cnt.Type, cnt.IsFree)
+	}
+	mock.ExpectQuery(`SELECT`).
+		WithArgs(userID, limit, offset).
+		WillReturnRows(rows)
+}
@@ -0,0 +1,152 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/recommendation/repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockRecommendationRepository is a mock of RecommendationRepository interface
+type MockRecommendationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecommendationRepositoryMockRecorder
+}
+
+// MockRecommendationRepositoryMockRecorder is the mock recorder for MockRecommendationRepository
+type MockRecommendationRepositoryMockRecorder struct {
+	mock *MockRecommendationRepository
+}
+
+// NewMockRecommendationRepository creates a new mock instance
+func NewMockRecommendationRepository(ctrl *gomock.Controller) *MockRecommendationRepository {
+	mock := &MockRecommendationRepository{ctrl: ctrl}
+	mock.recorder = &MockRecommendationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockRecommendationRepository) EXPECT() *MockRecommendationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// SelectUsersWithLikes mocks base method
+func (m *MockRecommendationRepository) SelectUsersWithLikes() ([]uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectUsersWithLikes")
+	ret0, _ := ret[0].([]uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectUsersWithLikes indicates an expected call of SelectUsersWithLikes
+func (mr *MockRecommendationRepositoryMockRecorder) SelectUsersWithLikes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectUsersWithLikes", reflect.TypeOf((*MockRecommendationRepository)(nil).SelectUsersWithLikes))
+}
+
+// SelectLikedContent mocks base method
+func (m *MockRecommendationRepository) SelectLikedContent(userID uint64) ([]uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectLikedContent", userID)
+	ret0, _ := ret[0].([]uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectLikedContent indicates an expected call of SelectLikedContent
+func (mr *MockRecommendationRepositoryMockRecorder) SelectLikedContent(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectLikedContent", reflect.TypeOf((*MockRecommendationRepository)(nil).SelectLikedContent), userID)
+}
+
+// SelectSeenContent mocks base method
+func (m *MockRecommendationRepository) SelectSeenContent(userID uint64) ([]uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectSeenContent", userID)
+	ret0, _ := ret[0].([]uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectSeenContent indicates an expected call of SelectSeenContent
+func (mr *MockRecommendationRepositoryMockRecorder) SelectSeenContent(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectSeenContent", reflect.TypeOf((*MockRecommendationRepository)(nil).SelectSeenContent), userID)
+}
+
+// SelectSimilarUsersContent mocks base method
+func (m *MockRecommendationRepository) SelectSimilarUsersContent(userID uint64) (map[uint64]float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectSimilarUsersContent", userID)
+	ret0, _ := ret[0].(map[uint64]float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectSimilarUsersContent indicates an expected call of SelectSimilarUsersContent
+func (mr *MockRecommendationRepositoryMockRecorder) SelectSimilarUsersContent(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectSimilarUsersContent", reflect.TypeOf((*MockRecommendationRepository)(nil).SelectSimilarUsersContent), userID)
+}
+
+// SelectContentFeatures mocks base method
+func (m *MockRecommendationRepository) SelectContentFeatures() ([]*models.ContentFeature, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectContentFeatures")
+	ret0, _ := ret[0].([]*models.ContentFeature)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectContentFeatures indicates an expected call of SelectContentFeatures
+func (mr *MockRecommendationRepositoryMockRecorder) SelectContentFeatures() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectContentFeatures", reflect.TypeOf((*MockRecommendationRepository)(nil).SelectContentFeatures))
+}
+
+// ReplaceByUser mocks base method
+func (m *MockRecommendationRepository) ReplaceByUser(userID uint64, recommendations []*models.Recommendation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceByUser", userID, recommendations)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceByUser indicates an expected call of ReplaceByUser
+func (mr *MockRecommendationRepositoryMockRecorder) ReplaceByUser(userID, recommendations interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceByUser", reflect.TypeOf((*MockRecommendationRepository)(nil).ReplaceByUser), userID, recommendations)
+}
+
+// DeleteWithoutLikes mocks base method
+func (m *MockRecommendationRepository) DeleteWithoutLikes() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWithoutLikes")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWithoutLikes indicates an expected call of DeleteWithoutLikes
+func (mr *MockRecommendationRepositoryMockRecorder) DeleteWithoutLikes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWithoutLikes", reflect.TypeOf((*MockRecommendationRepository)(nil).DeleteWithoutLikes))
+}
+
+// SelectByUser mocks base method
+func (m *MockRecommendationRepository) SelectByUser(userID, limit, offset uint64) ([]*models.Content, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectByUser", userID, limit, offset)
+	ret0, _ := ret[0].([]*models.Content)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectByUser indicates an expected call of SelectByUser
+func (mr *MockRecommendationRepositoryMockRecorder) SelectByUser(userID, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectByUser", reflect.TypeOf((*MockRecommendationRepository)(nil).SelectByUser), userID, limit, offset)
+}
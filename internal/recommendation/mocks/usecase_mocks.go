@@ -0,0 +1,64 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/recommendation/usecase.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	errors "github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	models "github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockRecommendationUsecase is a mock of RecommendationUsecase interface
+type MockRecommendationUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecommendationUsecaseMockRecorder
+}
+
+// MockRecommendationUsecaseMockRecorder is the mock recorder for MockRecommendationUsecase
+type MockRecommendationUsecaseMockRecorder struct {
+	mock *MockRecommendationUsecase
+}
+
+// NewMockRecommendationUsecase creates a new mock instance
+func NewMockRecommendationUsecase(ctrl *gomock.Controller) *MockRecommendationUsecase {
+	mock := &MockRecommendationUsecase{ctrl: ctrl}
+	mock.recorder = &MockRecommendationUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockRecommendationUsecase) EXPECT() *MockRecommendationUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Rebuild mocks base method
+func (m *MockRecommendationUsecase) Rebuild() *errors.Error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rebuild")
+	ret0, _ := ret[0].(*errors.Error)
+	return ret0
+}
+
+// Rebuild indicates an expected call of Rebuild
+func (mr *MockRecommendationUsecaseMockRecorder) Rebuild() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rebuild", reflect.TypeOf((*MockRecommendationUsecase)(nil).Rebuild))
+}
+
+// ListByUser mocks base method
+func (m *MockRecommendationUsecase) ListByUser(userID uint64, pagination *models.Pagination) ([]*models.Content, *errors.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUser", userID, pagination)
+	ret0, _ := ret[0].([]*models.Content)
+	ret1, _ := ret[1].(*errors.Error)
+	return ret0, ret1
+}
+
+// ListByUser indicates an expected call of ListByUser
+func (mr *MockRecommendationUsecaseMockRecorder) ListByUser(userID, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUser", reflect.TypeOf((*MockRecommendationUsecase)(nil).ListByUser), userID, pagination)
+}
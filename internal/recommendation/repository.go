@@ -0,0 +1,14 @@
+package recommendation
+
+import "github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+
+type RecommendationRepository interface {
+	SelectUsersWithLikes() ([]uint64, error)
+	SelectLikedContent(userID uint64) ([]uint64, error)
+	SelectSeenContent(userID uint64) ([]uint64, error)
+	SelectSimilarUsersContent(userID uint64) (map[uint64]float64, error)
+	SelectContentFeatures() ([]*models.ContentFeature, error)
+	ReplaceByUser(userID uint64, recommendations []*models.Recommendation) error
+	DeleteWithoutLikes() error
+	SelectByUser(userID uint64, limit uint64, offset uint64) ([]*models.Content, error)
+}
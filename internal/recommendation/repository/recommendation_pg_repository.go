@@ -0,0 +1,277 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	. "github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	queryBuilder "github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/query_builder"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/recommendation"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
+	"github.com/lib/pq"
+)
+
+type RecommendationPgRepository struct {
+	dbConn *sql.DB
+}
+
+func NewRecommendationPgRepository(conn *sql.DB) recommendation.RecommendationRepository {
+	return &RecommendationPgRepository{
+		dbConn: conn,
+	}
+}
+
+// likedQuery selects liked and favourite content of every user
+const likedQuery = `
+	SELECT user_id, content_id
+	FROM rates
+	WHERE likes=true
+	UNION
+	SELECT user_id, content_id
+	FROM favourites`
+
+func (rep *RecommendationPgRepository) SelectUsersWithLikes() ([]uint64, error) {
+	return rep.selectIDs(`
+		SELECT DISTINCT user_id
+		FROM (` + likedQuery + `) AS liked
+		ORDER BY user_id`)
+}
+
+// SelectLikedContent returns liked and favourite content of the user
+func (rep *RecommendationPgRepository) SelectLikedContent(userID uint64) ([]uint64, error) {
+	return rep.selectIDs(`
+		SELECT content_id
+		FROM rates
+		WHERE user_id=$1 AND likes=true
+		UNION
+		SELECT content_id
+		FROM favourites
+		WHERE user_id=$1`, userID)
+}
+
+// SelectSeenContent returns rated, favourite and started content of the user
+func (rep *RecommendationPgRepository) SelectSeenContent(userID uint64) ([]uint64, error) {
+	return rep.selectIDs(`
+		SELECT content_id
+		FROM rates
+		WHERE user_id=$1
+		UNION
+		SELECT content_id
+		FROM favourites
+		WHERE user_id=$1
+		UNION
+		SELECT content_id
+		FROM watch_progress
+		WHERE user_id=$1`, userID)
+}
+
+// SelectSimilarUsersContent returns content liked by users sharing likes with the user,
+// every content is scored by the sum of cosine similarities of their likes to the user ones
+func (rep *RecommendationPgRepository) SelectSimilarUsersContent(userID uint64) (map[uint64]float64, error) {
+	rows, err := rep.dbConn.Query(`
+		WITH liked AS (`+likedQuery+`),
+		user_liked AS (
+			SELECT content_id
+			FROM liked
+			WHERE user_id=$1
+		),
+		similar AS (
+			SELECT l.user_id, COUNT(*) AS common
+			FROM liked AS l
+			JOIN user_liked AS ul ON ul.content_id=l.content_id
+			WHERE l.user_id<>$1
+			GROUP BY l.user_id
+		),
+		similar_liked AS (
+			SELECT l.user_id, l.content_id, COUNT(*) OVER (PARTITION BY l.user_id) AS total
+			FROM liked AS l
+			JOIN similar AS s ON s.user_id=l.user_id
+		)
+		SELECT sl.content_id,
+		SUM(s.common / sqrt((SELECT COUNT(*) FROM user_liked) * sl.total))
+		FROM similar_liked AS sl
+		JOIN similar AS s ON s.user_id=sl.user_id
+		GROUP BY sl.content_id`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	similarities := make(map[uint64]float64)
+	for rows.Next() {
+		var contentID uint64
+		var similarity float64
+		if err := rows.Scan(&contentID, &similarity); err != nil {
+			return nil, err
+		}
+		similarities[contentID] = similarity
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return similarities, nil
+}
+
+func (rep *RecommendationPgRepository) selectIDs(query string, args ...interface{}) ([]uint64, error) {
+	rows, err := rep.dbConn.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []uint64
+	for rows.Next() {
+		var id uint64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (rep *RecommendationPgRepository) SelectContentFeatures() ([]*models.ContentFeature, error) {
+	rows, err := rep.dbConn.Query(`
+		SELECT content_id, $1::text, genre_id FROM content_genre
+		UNION ALL
+		SELECT content_id, $2::text, actor_id FROM content_actor
+		UNION ALL
+		SELECT content_id, $3::text, director_id FROM content_director
+		UNION ALL
+		SELECT content_id, $4::text, country_id FROM content_country`,
+		GenreFeature, ActorFeature, DirectorFeature, CountryFeature)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var features []*models.ContentFeature
+	for rows.Next() {
+		feature := &models.ContentFeature{}
+		if err := rows.Scan(&feature.ContentID, &feature.Kind, &feature.FeatureID); err != nil {
+			return nil, err
+		}
+		features = append(features, feature)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return features, nil
+}
+
+// ReplaceByUser swaps recommendations of the user in one transaction,
+// so the user never sees partially rebuilt scores
+func (rep *RecommendationPgRepository) ReplaceByUser(userID uint64,
+	recommendations []*models.Recommendation) error {
+	tx, err := rep.dbConn.BeginTx(context.Background(), &sql.TxOptions{})
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`DELETE FROM recommendations WHERE user_id=$1`, userID)
+	if err == nil {
+		err = insertRecommendations(tx, recommendations)
+	}
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			logger.Error(rollbackErr.Error())
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func insertRecommendations(tx *sql.Tx, recommendations []*models.Recommendation) error {
+	stmt, err := tx.Prepare(pq.CopyIn("recommendations", "user_id", "content_id", "score"))
+	if err != nil {
+		return err
+	}
+
+	for _, rec := range recommendations {
+		_, err = stmt.Exec(rec.UserID, rec.ContentID, rec.Score)
+		if err != nil {
+			return err
+		}
+	}
+
+	if _, err = stmt.Exec(); err != nil {
+		return err
+	}
+	if err = stmt.Close(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteWithoutLikes deletes recommendations of users
+// who have no liked content anymore
+func (rep *RecommendationPgRepository) DeleteWithoutLikes() error {
+	_, err := rep.dbConn.Exec(`
+		DELETE FROM recommendations AS rec
+		WHERE NOT EXISTS (
+			SELECT 1
+			FROM (` + likedQuery + `) AS liked
+			WHERE liked.user_id=rec.user_id
+		)`)
+	return err
+}
+
+// SelectByUser skips content user rated or added to favourites
+// after the last rebuild
+func (rep *RecommendationPgRepository) SelectByUser(userID uint64,
+	limit uint64, offset uint64) ([]*models.Content, error) {
+	var values []interface{}
+	selectQuery := `
+		SELECT c.id, c.name, c.original_name, c.description, c.short_description,
+		c.rating, c.year, c.images, c.type, c.is_free
+		FROM recommendations AS rec
+		JOIN content AS c ON c.id=rec.content_id
+		WHERE rec.user_id=$1 AND ` + queryBuilder.BuildPublishedCondition() + `
+		AND NOT EXISTS (SELECT 1 FROM rates AS r WHERE r.user_id=$1 AND r.content_id=c.id)
+		AND NOT EXISTS (SELECT 1 FROM favourites AS f WHERE f.user_id=$1 AND f.content_id=c.id)
+		ORDER BY rec.score DESC, c.id`
+	values = append(values, userID)
+
+	var pgntQuery string
+	if limit != 0 {
+		pgntQuery = "LIMIT $2 OFFSET $3"
+		values = append(values, limit, offset)
+	}
+
+	resultQuery := strings.Join([]string{
+		selectQuery,
+		pgntQuery,
+	}, " ")
+
+	rows, err := rep.dbConn.Query(resultQuery, values...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var contents []*models.Content
+	for rows.Next() {
+		cnt := &models.Content{}
+		err := rows.Scan(&cnt.ContentID, &cnt.Name, &cnt.OriginalName,
+			&cnt.Description, &cnt.ShortDescription, &cnt.Rating, &cnt.Year,
+			&cnt.Images, &cnt.Type, &cnt.IsFree)
+		if err != nil {
+			return nil, err
+		}
+		contents = append(contents, cnt)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return contents, nil
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/recommendation/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecommendationPgRepository_SelectLikedContent_OK(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	recommendationPgRep := NewRecommendationPgRepository(db)
+
+	mocks.MockSelectIDsReturnRows(mock, []uint64{10, 20})
+	liked, err := recommendationPgRep.SelectLikedContent(1)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{10, 20}, liked)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestRecommendationPgRepository_SelectSimilarUsersContent_OK(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	similarities := map[uint64]float64{10: 0.5, 20: 0.25}
+
+	recommendationPgRep := NewRecommendationPgRepository(db)
+
+	mocks.MockSelectSimilarUsersContentReturnRows(mock, 1, similarities)
+	dbSimilarities, err := recommendationPgRep.SelectSimilarUsersContent(1)
+	assert.NoError(t, err)
+	assert.Equal(t, similarities, dbSimilarities)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestRecommendationPgRepository_ReplaceByUser_OK(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	recommendations := []*models.Recommendation{
+		{UserID: 1, ContentID: 20, Score: 2.4},
+		{UserID: 1, ContentID: 30, Score: 1},
+	}
+
+	recommendationPgRep := NewRecommendationPgRepository(db)
+
+	mocks.MockReplaceByUserSuccess(mock, 1, recommendations)
+	err = recommendationPgRep.ReplaceByUser(1, recommendations)
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestRecommendationPgRepository_ReplaceByUser_Fail(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	rec := &models.Recommendation{UserID: 1, ContentID: 20, Score: 2.4}
+	insertErr := errors.New("foreign key violation")
+
+	recommendationPgRep := NewRecommendationPgRepository(db)
+
+	mocks.MockReplaceByUserError(mock, rec, insertErr)
+	err = recommendationPgRep.ReplaceByUser(rec.UserID, []*models.Recommendation{rec})
+	assert.Equal(t, insertErr, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestRecommendationPgRepository_SelectByUser_OK(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	var userID uint64 = 1
+	isFree := false
+	contents := []*models.Content{
+		{
+			ContentID:        20,
+			Name:             "Ведьмак",
+			OriginalName:     "The Witcher",
+			Description:      "desc",
+			ShortDescription: "short desc",
+			Rating:           10,
+			Year:             2019,
+			Images:           "/images/witcher_20",
+			Type:             "tvshow",
+			IsFree:           &isFree,
+		},
+	}
+
+	recommendationPgRep := NewRecommendationPgRepository(db)
+
+	mocks.MockSelectByUserReturnRows(mock, userID, contents, 10, 0)
+	dbContents, err := recommendationPgRep.SelectByUser(userID, 10, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, contents, dbContents)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
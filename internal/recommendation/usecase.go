@@ -0,0 +1,11 @@
+package recommendation
+
+import (
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+)
+
+type RecommendationUsecase interface {
+	Rebuild() *errors.Error
+	ListByUser(userID uint64, pagination *models.Pagination) ([]*models.Content, *errors.Error)
+}
@@ -0,0 +1,146 @@
+package usecases
+
+import (
+	"sort"
+
+	. "github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/recommendation"
+)
+
+type RecommendationUsecase struct {
+	recommendationRepo recommendation.RecommendationRepository
+}
+
+func NewRecommendationUsecase(repo recommendation.RecommendationRepository) recommendation.RecommendationUsecase {
+	return &RecommendationUsecase{
+		recommendationRepo: repo,
+	}
+}
+
+type feature struct {
+	kind string
+	id   uint64
+}
+
+// Rebuild scores content user has not seen yet by its links shared with
+// the content user liked and by the content liked by users with similar taste,
+// recommendations are rebuilt user by user
+func (ru *RecommendationUsecase) Rebuild() *errors.Error {
+	contentFeatures, err := ru.recommendationRepo.SelectContentFeatures()
+	if err != nil {
+		return errors.New(CodeInternalError, err)
+	}
+	featuresByContent := make(map[uint64][]feature)
+	for _, cf := range contentFeatures {
+		featuresByContent[cf.ContentID] = append(featuresByContent[cf.ContentID],
+			feature{kind: cf.Kind, id: cf.FeatureID})
+	}
+
+	usersID, err := ru.recommendationRepo.SelectUsersWithLikes()
+	if err != nil {
+		return errors.New(CodeInternalError, err)
+	}
+	for _, userID := range usersID {
+		if customErr := ru.rebuildByUser(userID, featuresByContent); customErr != nil {
+			return customErr
+		}
+	}
+
+	if err := ru.recommendationRepo.DeleteWithoutLikes(); err != nil {
+		return errors.New(CodeInternalError, err)
+	}
+	return nil
+}
+
+func (ru *RecommendationUsecase) rebuildByUser(userID uint64,
+	featuresByContent map[uint64][]feature) *errors.Error {
+	userLiked, err := ru.recommendationRepo.SelectLikedContent(userID)
+	if err != nil {
+		return errors.New(CodeInternalError, err)
+	}
+	// Likes could be removed since the users were selected
+	if len(userLiked) == 0 {
+		return nil
+	}
+	seen, err := ru.recommendationRepo.SelectSeenContent(userID)
+	if err != nil {
+		return errors.New(CodeInternalError, err)
+	}
+	similarities, err := ru.recommendationRepo.SelectSimilarUsersContent(userID)
+	if err != nil {
+		return errors.New(CodeInternalError, err)
+	}
+
+	scores := make(map[uint64]float64)
+	addFeatureScores(scores, userLiked, featuresByContent)
+	for contentID, similarity := range similarities {
+		scores[contentID] += RecommendationCoOccurrenceWeight * similarity
+	}
+	for _, contentID := range seen {
+		delete(scores, contentID)
+	}
+
+	if err := ru.recommendationRepo.ReplaceByUser(userID,
+		topRecommendations(userID, scores)); err != nil {
+		return errors.New(CodeInternalError, err)
+	}
+	return nil
+}
+
+func (ru *RecommendationUsecase) ListByUser(userID uint64,
+	pagination *models.Pagination) ([]*models.Content, *errors.Error) {
+	contents, err := ru.recommendationRepo.SelectByUser(userID, pagination.Count, pagination.From)
+	if err != nil {
+		return nil, errors.New(CodeInternalError, err)
+	}
+	if len(contents) == 0 {
+		return []*models.Content{}, nil
+	}
+	return contents, nil
+}
+
+// addFeatureScores scores content by how often its genres, actors, directors
+// and countries occur among the content user liked
+func addFeatureScores(scores map[uint64]float64, userLiked []uint64,
+	featuresByContent map[uint64][]feature) {
+	profile := make(map[feature]float64)
+	for _, contentID := range userLiked {
+		for _, f := range featuresByContent[contentID] {
+			profile[f]++
+		}
+	}
+
+	for contentID, features := range featuresByContent {
+		var score float64
+		for _, f := range features {
+			score += RecommendationFeatureWeights[f.kind] * profile[f]
+		}
+		if score > 0 {
+			scores[contentID] += score / float64(len(userLiked))
+		}
+	}
+}
+
+func topRecommendations(userID uint64, scores map[uint64]float64) []*models.Recommendation {
+	recommendations := make([]*models.Recommendation, 0, len(scores))
+	for contentID, score := range scores {
+		recommendations = append(recommendations, &models.Recommendation{
+			UserID:    userID,
+			ContentID: contentID,
+			Score:     score,
+		})
+	}
+
+	sort.Slice(recommendations, func(i, j int) bool {
+		if recommendations[i].Score != recommendations[j].Score {
+			return recommendations[i].Score > recommendations[j].Score
+		}
+		return recommendations[i].ContentID < recommendations[j].ContentID
+	})
+	if len(recommendations) > RecommendationsPerUser {
+		recommendations = recommendations[:RecommendationsPerUser]
+	}
+	return recommendations
+}
@@ -0,0 +1,152 @@
+package usecases
+
+import (
+	"database/sql"
+	"math"
+	"sort"
+	"testing"
+
+	. "github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/recommendation/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var likedContent = map[uint64][]uint64{
+	1: {10},
+	2: {10, 20},
+}
+
+// Users share the content 10, the second one liked the content 20 as well
+var similarUsersContent = map[uint64]map[uint64]float64{
+	1: {10: 1 / math.Sqrt(2), 20: 1 / math.Sqrt(2)},
+	2: {10: 1 / math.Sqrt(2)},
+}
+
+var contentFeatures = []*models.ContentFeature{
+	{ContentID: 10, Kind: GenreFeature, FeatureID: 1},
+	{ContentID: 10, Kind: DirectorFeature, FeatureID: 5},
+	{ContentID: 20, Kind: GenreFeature, FeatureID: 1},
+	{ContentID: 30, Kind: GenreFeature, FeatureID: 1},
+	{ContentID: 30, Kind: ActorFeature, FeatureID: 7},
+	{ContentID: 40, Kind: CountryFeature, FeatureID: 3},
+}
+
+func TestRecommendationUseCase_Rebuild_OK(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	recommendationRep := mocks.NewMockRecommendationRepository(ctrl)
+	recommendationUseCase := NewRecommendationUsecase(recommendationRep)
+
+	recommendationRep.
+		EXPECT().
+		SelectContentFeatures().
+		Return(contentFeatures, nil)
+	recommendationRep.
+		EXPECT().
+		SelectUsersWithLikes().
+		Return([]uint64{1, 2}, nil)
+
+	var rebuilt []*models.Recommendation
+	for userID, userLiked := range likedContent {
+		recommendationRep.
+			EXPECT().
+			SelectLikedContent(userID).
+			Return(userLiked, nil)
+		recommendationRep.
+			EXPECT().
+			SelectSeenContent(userID).
+			Return(userLiked, nil)
+		recommendationRep.
+			EXPECT().
+			SelectSimilarUsersContent(userID).
+			Return(similarUsersContent[userID], nil)
+		recommendationRep.
+			EXPECT().
+			ReplaceByUser(userID, gomock.Any()).
+			DoAndReturn(func(userID uint64, recommendations []*models.Recommendation) error {
+				rebuilt = append(rebuilt, recommendations...)
+				return nil
+			})
+	}
+	recommendationRep.
+		EXPECT().
+		DeleteWithoutLikes().
+		Return(nil)
+
+	err := recommendationUseCase.Rebuild()
+	assert.Equal(t, err, (*errors.Error)(nil))
+
+	// The second user liked the same content as the first one and the content 20
+	similarity := 1 / math.Sqrt(2)
+	expected := []*models.Recommendation{
+		{UserID: 1, ContentID: 20, Score: 1 + RecommendationCoOccurrenceWeight*similarity},
+		{UserID: 1, ContentID: 30, Score: 1},
+		{UserID: 2, ContentID: 30, Score: 1},
+	}
+
+	sort.Slice(rebuilt, func(i, j int) bool {
+		if rebuilt[i].UserID != rebuilt[j].UserID {
+			return rebuilt[i].UserID < rebuilt[j].UserID
+		}
+		return rebuilt[i].ContentID < rebuilt[j].ContentID
+	})
+	if assert.Len(t, rebuilt, len(expected)) {
+		for i, rec := range expected {
+			assert.Equal(t, rec.UserID, rebuilt[i].UserID)
+			assert.Equal(t, rec.ContentID, rebuilt[i].ContentID)
+			assert.InDelta(t, rec.Score, rebuilt[i].Score, 1e-9)
+		}
+	}
+}
+
+func TestRecommendationUseCase_Rebuild_Fail(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	recommendationRep := mocks.NewMockRecommendationRepository(ctrl)
+	recommendationUseCase := NewRecommendationUsecase(recommendationRep)
+
+	recommendationRep.
+		EXPECT().
+		SelectContentFeatures().
+		Return(nil, sql.ErrConnDone)
+
+	err := recommendationUseCase.Rebuild()
+	assert.Equal(t, errors.New(CodeInternalError, sql.ErrConnDone), err)
+}
+
+func TestRecommendationUseCase_ListByUser_Empty(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	recommendationRep := mocks.NewMockRecommendationRepository(ctrl)
+	recommendationUseCase := NewRecommendationUsecase(recommendationRep)
+
+	var userID uint64 = 3
+	pagination := &models.Pagination{From: 0, Count: 10}
+
+	recommendationRep.
+		EXPECT().
+		SelectByUser(userID, pagination.Count, pagination.From).
+		Return(nil, nil)
+
+	contents, err := recommendationUseCase.ListByUser(userID, pagination)
+	assert.Equal(t, err, (*errors.Error)(nil))
+	assert.Equal(t, []*models.Content{}, contents)
+}
+
+func TestTopRecommendations_Limit(t *testing.T) {
+	t.Parallel()
+	scores := make(map[uint64]float64)
+	for i := uint64(1); i <= RecommendationsPerUser+10; i++ {
+		scores[i] = float64(i)
+	}
+
+	recommendations := topRecommendations(1, scores)
+	assert.Len(t, recommendations, RecommendationsPerUser)
+	assert.Equal(t, uint64(RecommendationsPerUser+10), recommendations[0].ContentID)
+}
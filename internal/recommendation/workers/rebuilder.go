@@ -0,0 +1,68 @@
+package workers
+
+import (
+	"sync"
+	"time"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/recommendation"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
+)
+
+type Rebuilder struct {
+	recommendationUcase recommendation.RecommendationUsecase
+	lock                *helpers.AdvisoryLock
+	interval            time.Duration
+	stop                chan struct{}
+	wg                  sync.WaitGroup
+}
+
+func NewRebuilder(recommendationUcase recommendation.RecommendationUsecase,
+	lock *helpers.AdvisoryLock, interval time.Duration) *Rebuilder {
+	return &Rebuilder{
+		recommendationUcase: recommendationUcase,
+		lock:                lock,
+		interval:            interval,
+		stop:                make(chan struct{}),
+	}
+}
+
+// Start rebuilds recommendations right away and then every interval,
+// instance that doesn't hold the lock skips the rebuild
+func (rb *Rebuilder) Start() {
+	rb.wg.Add(1)
+	go rb.work()
+}
+
+// Stop waits for running rebuild to finish
+func (rb *Rebuilder) Stop() {
+	close(rb.stop)
+	rb.wg.Wait()
+}
+
+func (rb *Rebuilder) work() {
+	defer rb.wg.Done()
+
+	ticker := time.NewTicker(rb.interval)
+	defer ticker.Stop()
+	for {
+		rb.rebuild()
+
+		select {
+		case <-rb.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (rb *Rebuilder) rebuild() {
+	_, err := rb.lock.Do(func() {
+		if err := rb.recommendationUcase.Rebuild(); err != nil {
+			logger.Error(err.Message)
+		}
+	})
+	if err != nil {
+		logger.Error(err)
+	}
+}
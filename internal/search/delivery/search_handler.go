@@ -22,6 +22,7 @@ func NewSearchHandler(usecase search.SearchUsecase) *SearchHandler {
 
 func (sh *SearchHandler) Configure(e *echo.Echo, mw *mwares.MiddlewareManager) {
 	e.GET("/api/v1/search", sh.SearchHandler(), mw.GetAuth)
+	e.GET("/api/v1/search/suggest", sh.SuggestHandler())
 }
 
 func (sh *SearchHandler) SearchHandler() echo.HandlerFunc {
@@ -53,3 +54,30 @@ func (sh *SearchHandler) SearchHandler() echo.HandlerFunc {
 		})
 	}
 }
+
+func (sh *SearchHandler) SuggestHandler() echo.HandlerFunc {
+	type Request struct {
+		Query string `query:"q" validate:"required"`
+		Count uint64 `query:"count"`
+	}
+
+	return func(cntx echo.Context) error {
+		req := &Request{}
+		if err := reader.NewRequestReader(cntx).Read(req); err != nil {
+			logger.Error(err.Message)
+			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		}
+
+		suggestions, customErr := sh.searchUsecase.Suggest(req.Query, req.Count)
+		if customErr != nil {
+			logger.Error(customErr.Message)
+			return cntx.JSON(customErr.HTTPCode, Response{Error: customErr})
+		}
+
+		return cntx.JSON(http.StatusOK, Response{
+			Body: &Body{
+				"suggestions": suggestions,
+			},
+		})
+	}
+}
@@ -87,3 +87,59 @@ func TestSearchHandler_CreateHandler(t *testing.T) {
 		assert.JSONEq(t, expResBody.String(), string(bytes))
 	}
 }
+
+type SuggestRequest struct {
+	Query string `query:"q"`
+	Count uint64 `query:"count"`
+}
+
+func TestSearchHandler_SuggestHandler(t *testing.T) {
+	// Setup
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	searchUseCase := mocks.NewMockSearchUsecase(ctrl)
+	logger.DisableLogger()
+
+	testRequest := &SuggestRequest{
+		Query: "шр",
+		Count: 5,
+	}
+
+	suggestJSON, err := converter.AnyToBytesBuffer(testRequest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, searchHandler, rec := setupSearchHandler(searchUseCase,
+		http.MethodGet, suggestJSON.String(), 0)
+	handleFunc := searchHandler.SuggestHandler()
+
+	suggestions := []*models.Suggestion{
+		&models.Suggestion{
+			ID:   1,
+			Type: "movie",
+			Name: "Шрек",
+		},
+	}
+
+	searchUseCase.
+		EXPECT().
+		Suggest(testRequest.Query, testRequest.Count).
+		Return(suggestions, nil)
+
+	response := &response.Response{Body: &response.Body{"suggestions": suggestions}}
+
+	// Assertions
+	if assert.NoError(t, handleFunc(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		expResBody, err := converter.AnyToBytesBuffer(response)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		bytes, _ := ioutil.ReadAll(rec.Body)
+
+		assert.JSONEq(t, expResBody.String(), string(bytes))
+	}
+}
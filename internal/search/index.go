@@ -0,0 +1,9 @@
+package search
+
+import "github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+
+type SuggestIndex interface {
+	Rebuild() error
+	Invalidate()
+	Suggest(prefix string, limit int) []*models.Suggestion
+}
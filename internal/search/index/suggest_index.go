@@ -0,0 +1,195 @@
+package index
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	. "github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/search"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
+)
+
+const (
+	// Prefix of the whole name ranks higher than prefix of one of its words
+	nameRank = iota
+	wordRank
+)
+
+type rankedSuggestion struct {
+	suggestion *models.Suggestion
+	rank       int
+}
+
+type trieNode struct {
+	children map[rune]*trieNode
+	// Best suggestions of the subtree, at most SuggestionsMaxCount
+	top []*rankedSuggestion
+}
+
+func newTrieNode() *trieNode {
+	return &trieNode{
+		children: make(map[rune]*trieNode),
+	}
+}
+
+type SuggestIndex struct {
+	searchRepo search.SearchRepository
+
+	mu   sync.RWMutex
+	root *trieNode
+
+	rebuildMu  sync.Mutex
+	rebuilding bool
+	dirty      bool
+}
+
+func NewSuggestIndex(repo search.SearchRepository) search.SuggestIndex {
+	return &SuggestIndex{
+		searchRepo: repo,
+		root:       newTrieNode(),
+	}
+}
+
+// Rebuild loads all names from the database and replaces the index
+func (si *SuggestIndex) Rebuild() error {
+	suggestions, err := si.searchRepo.SelectSuggestions()
+	if err != nil {
+		return err
+	}
+
+	root := newTrieNode()
+	for _, suggestion := range suggestions {
+		name := normalize(suggestion.Name)
+		insert(root, name, suggestion, nameRank)
+		for _, word := range words(name) {
+			insert(root, word, suggestion, wordRank)
+		}
+	}
+
+	si.mu.Lock()
+	si.root = root
+	si.mu.Unlock()
+	return nil
+}
+
+// Invalidate schedules rebuild in background,
+// changes made during running rebuild cause one more rebuild
+func (si *SuggestIndex) Invalidate() {
+	si.rebuildMu.Lock()
+	defer si.rebuildMu.Unlock()
+
+	if si.rebuilding {
+		si.dirty = true
+		return
+	}
+	si.rebuilding = true
+	go si.rebuildLoop()
+}
+
+func (si *SuggestIndex) rebuildLoop() {
+	for {
+		if err := si.Rebuild(); err != nil {
+			logger.Error(err)
+		}
+
+		si.rebuildMu.Lock()
+		if !si.dirty {
+			si.rebuilding = false
+			si.rebuildMu.Unlock()
+			return
+		}
+		si.dirty = false
+		si.rebuildMu.Unlock()
+	}
+}
+
+func (si *SuggestIndex) Suggest(prefix string, limit int) []*models.Suggestion {
+	si.mu.RLock()
+	node := si.root
+	si.mu.RUnlock()
+
+	for _, r := range normalize(prefix) {
+		node = node.children[r]
+		if node == nil {
+			return []*models.Suggestion{}
+		}
+	}
+
+	if limit > len(node.top) {
+		limit = len(node.top)
+	}
+	suggestions := make([]*models.Suggestion, 0, limit)
+	for _, ranked := range node.top[:limit] {
+		suggestions = append(suggestions, ranked.suggestion)
+	}
+	return suggestions
+}
+
+func insert(root *trieNode, key string, suggestion *models.Suggestion, rank int) {
+	node := root
+	for _, r := range key {
+		child, ok := node.children[r]
+		if !ok {
+			child = newTrieNode()
+			node.children[r] = child
+		}
+		node = child
+		node.add(suggestion, rank)
+	}
+}
+
+func (node *trieNode) add(suggestion *models.Suggestion, rank int) {
+	for _, ranked := range node.top {
+		if ranked.suggestion == suggestion {
+			if rank < ranked.rank {
+				ranked.rank = rank
+				node.sort()
+			}
+			return
+		}
+	}
+
+	node.top = append(node.top, &rankedSuggestion{
+		suggestion: suggestion,
+		rank:       rank,
+	})
+	node.sort()
+	if len(node.top) > SuggestionsMaxCount {
+		node.top = node.top[:SuggestionsMaxCount]
+	}
+}
+
+func (node *trieNode) sort() {
+	sort.SliceStable(node.top, func(i, j int) bool {
+		lhs, rhs := node.top[i], node.top[j]
+		if lhs.rank != rhs.rank {
+			return lhs.rank < rhs.rank
+		}
+		if len(lhs.suggestion.Name) != len(rhs.suggestion.Name) {
+			return len(lhs.suggestion.Name) < len(rhs.suggestion.Name)
+		}
+		return lhs.suggestion.Name < rhs.suggestion.Name
+	})
+}
+
+func normalize(str string) string {
+	str = strings.ToLower(strings.TrimSpace(str))
+	return strings.ReplaceAll(str, "ё", "е")
+}
+
+// words returns suffixes of the name starting at each of its words
+func words(name string) []string {
+	var suffixes []string
+	isWordStart := true
+	for i, r := range name {
+		isLetter := unicode.IsLetter(r) || unicode.IsDigit(r)
+		if isLetter && isWordStart {
+			suffixes = append(suffixes, name[i:])
+		}
+		isWordStart = !isLetter
+	}
+	return suffixes
+}
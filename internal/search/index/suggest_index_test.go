@@ -0,0 +1,73 @@
+package index
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/search/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var shrek = &models.Suggestion{ID: 1, Type: consts.MovieContentType, Name: "Шрек"}
+var shrekTwo = &models.Suggestion{ID: 2, Type: consts.MovieContentType, Name: "Шрек 2"}
+var witcher = &models.Suggestion{ID: 3, Type: consts.TVShowContentType, Name: "Ведьмак"}
+var myers = &models.Suggestion{ID: 4, Type: consts.ActorSuggestionType, Name: "Mike Myers"}
+var adamson = &models.Suggestion{ID: 5, Type: consts.DirectorSuggestionType, Name: "Эндрю Адамсон"}
+
+func setupSuggestIndex(t *testing.T, suggestions []*models.Suggestion) (*gomock.Controller, *SuggestIndex) {
+	ctrl := gomock.NewController(t)
+	searchRep := mocks.NewMockSearchRepository(ctrl)
+	searchRep.EXPECT().SelectSuggestions().Return(suggestions, nil)
+
+	// nolint: errcheck
+	suggestIndex := NewSuggestIndex(searchRep).(*SuggestIndex)
+	if err := suggestIndex.Rebuild(); err != nil {
+		t.Fatal(err)
+	}
+	return ctrl, suggestIndex
+}
+
+func TestSuggestIndex_Suggest_NamePrefix(t *testing.T) {
+	t.Parallel()
+	ctrl, suggestIndex := setupSuggestIndex(t, []*models.Suggestion{shrekTwo, witcher, shrek})
+	defer ctrl.Finish()
+
+	assert.Equal(t, []*models.Suggestion{shrek, shrekTwo}, suggestIndex.Suggest("ШР", 10))
+	assert.Equal(t, []*models.Suggestion{shrek}, suggestIndex.Suggest("шр", 1))
+	assert.Equal(t, []*models.Suggestion{witcher}, suggestIndex.Suggest("ведь", 10))
+}
+
+func TestSuggestIndex_Suggest_WordPrefix(t *testing.T) {
+	t.Parallel()
+	ctrl, suggestIndex := setupSuggestIndex(t, []*models.Suggestion{myers, adamson})
+	defer ctrl.Finish()
+
+	assert.Equal(t, []*models.Suggestion{myers}, suggestIndex.Suggest("mye", 10))
+	assert.Equal(t, []*models.Suggestion{adamson}, suggestIndex.Suggest("адам", 10))
+	assert.Equal(t, []*models.Suggestion{}, suggestIndex.Suggest("yers", 10))
+}
+
+func TestSuggestIndex_Suggest_NameBeforeWord(t *testing.T) {
+	t.Parallel()
+	mikeTyson := &models.Suggestion{ID: 6, Type: consts.ActorSuggestionType, Name: "Tyson Mike"}
+	ctrl, suggestIndex := setupSuggestIndex(t, []*models.Suggestion{mikeTyson, myers})
+	defer ctrl.Finish()
+
+	assert.Equal(t, []*models.Suggestion{myers, mikeTyson}, suggestIndex.Suggest("mik", 10))
+}
+
+func TestSuggestIndex_Rebuild_Fail(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	searchRep := mocks.NewMockSearchRepository(ctrl)
+	suggestIndex := NewSuggestIndex(searchRep)
+
+	searchRep.EXPECT().SelectSuggestions().Return(nil, errors.New("db fail"))
+
+	assert.Error(t, suggestIndex.Rebuild())
+	assert.Equal(t, []*models.Suggestion{}, suggestIndex.Suggest("шр", 10))
+}
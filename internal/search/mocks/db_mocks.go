@@ -0,0 +1,17 @@
+package mocks
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+)
+
+func MockSearchRepoSelectSuggestionsReturnRows(mock sqlmock.Sqlmock, suggestions []*models.Suggestion) {
+	rows := sqlmock.NewRows([]string{"id", "type", "name"})
+	for _, suggestion := range suggestions {
+		rows.AddRow(suggestion.ID, suggestion.Type, suggestion.Name)
+	}
+	mock.ExpectQuery(`SELECT`).
+		WithArgs(consts.ActorSuggestionType, consts.DirectorSuggestionType).
+		WillReturnRows(rows)
+}
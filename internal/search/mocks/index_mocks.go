@@ -0,0 +1,74 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/search/index.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockSuggestIndex is a mock of SuggestIndex interface
+type MockSuggestIndex struct {
+	ctrl     *gomock.Controller
+	recorder *MockSuggestIndexMockRecorder
+}
+
+// MockSuggestIndexMockRecorder is the mock recorder for MockSuggestIndex
+type MockSuggestIndexMockRecorder struct {
+	mock *MockSuggestIndex
+}
+
+// NewMockSuggestIndex creates a new mock instance
+func NewMockSuggestIndex(ctrl *gomock.Controller) *MockSuggestIndex {
+	mock := &MockSuggestIndex{ctrl: ctrl}
+	mock.recorder = &MockSuggestIndexMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockSuggestIndex) EXPECT() *MockSuggestIndexMockRecorder {
+	return m.recorder
+}
+
+// Rebuild mocks base method
+func (m *MockSuggestIndex) Rebuild() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rebuild")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rebuild indicates an expected call of Rebuild
+func (mr *MockSuggestIndexMockRecorder) Rebuild() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rebuild", reflect.TypeOf((*MockSuggestIndex)(nil).Rebuild))
+}
+
+// Invalidate mocks base method
+func (m *MockSuggestIndex) Invalidate() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Invalidate")
+}
+
+// Invalidate indicates an expected call of Invalidate
+func (mr *MockSuggestIndexMockRecorder) Invalidate() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invalidate", reflect.TypeOf((*MockSuggestIndex)(nil).Invalidate))
+}
+
+// Suggest mocks base method
+func (m *MockSuggestIndex) Suggest(prefix string, limit int) []*models.Suggestion {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Suggest", prefix, limit)
+	ret0, _ := ret[0].([]*models.Suggestion)
+	return ret0
+}
+
+// Suggest indicates an expected call of Suggest
+func (mr *MockSuggestIndexMockRecorder) Suggest(prefix, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Suggest", reflect.TypeOf((*MockSuggestIndex)(nil).Suggest), prefix, limit)
+}
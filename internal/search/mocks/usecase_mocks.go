@@ -48,3 +48,18 @@ func (mr *MockSearchUsecaseMockRecorder) Search(curUserID, query, pagination int
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockSearchUsecase)(nil).Search), curUserID, query, pagination)
 }
+
+// Suggest mocks base method
+func (m *MockSearchUsecase) Suggest(query string, count uint64) ([]*models.Suggestion, *errors.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Suggest", query, count)
+	ret0, _ := ret[0].([]*models.Suggestion)
+	ret1, _ := ret[1].(*errors.Error)
+	return ret0, ret1
+}
+
+// Suggest indicates an expected call of Suggest
+func (mr *MockSearchUsecaseMockRecorder) Suggest(query, count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Suggest", reflect.TypeOf((*MockSearchUsecase)(nil).Suggest), query, count)
+}
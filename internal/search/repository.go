@@ -0,0 +1,7 @@
+package search
+
+import "github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+
+type SearchRepository interface {
+	SelectSuggestions() ([]*models.Suggestion, error)
+}
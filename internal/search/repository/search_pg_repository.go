@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"database/sql"
+
+	. "github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	queryBuilder "github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/query_builder"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/search"
+)
+
+type SearchPgRepository struct {
+	dbConn *sql.DB
+}
+
+func NewSearchPgRepository(conn *sql.DB) search.SearchRepository {
+	return &SearchPgRepository{
+		dbConn: conn,
+	}
+}
+
+// SelectSuggestions returns every published content title, actor and director name
+func (rep *SearchPgRepository) SelectSuggestions() ([]*models.Suggestion, error) {
+	rows, err := rep.dbConn.Query(`
+		SELECT c.id, c.type::text, c.name
+		FROM content AS c
+		WHERE `+queryBuilder.BuildPublishedCondition()+`
+		UNION ALL
+		SELECT id, $1::text, name
+		FROM actors
+		UNION ALL
+		SELECT id, $2::text, name
+		FROM directors`,
+		ActorSuggestionType, DirectorSuggestionType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var suggestions []*models.Suggestion
+	for rows.Next() {
+		suggestion := &models.Suggestion{}
+		err := rows.Scan(&suggestion.ID, &suggestion.Type, &suggestion.Name)
+		if err != nil {
+			return nil, err
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return suggestions, nil
+}
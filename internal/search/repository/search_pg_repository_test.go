@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/search/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchPgRepository_SelectSuggestions_OK(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	suggestions := []*models.Suggestion{
+		&models.Suggestion{ID: 1, Type: consts.MovieContentType, Name: "Шрек"},
+		&models.Suggestion{ID: 2, Type: consts.ActorSuggestionType, Name: "Mike Myers"},
+		&models.Suggestion{ID: 3, Type: consts.DirectorSuggestionType, Name: "Andrew Adamson"},
+	}
+
+	searchPgRep := NewSearchPgRepository(db)
+
+	mocks.MockSearchRepoSelectSuggestionsReturnRows(mock, suggestions)
+	dbSuggestions, err := searchPgRep.SelectSuggestions()
+	assert.Equal(t, suggestions, dbSuggestions)
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -8,4 +8,5 @@ import (
 type SearchUsecase interface {
 	Search(curUserID uint64, query string, pagination *models.Pagination) (
 		*models.SearchResult, *errors.Error)
+	Suggest(query string, count uint64) ([]*models.Suggestion, *errors.Error)
 }
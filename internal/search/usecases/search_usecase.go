@@ -2,8 +2,12 @@ package usecases
 
 import (
 	"database/sql"
+	"strings"
+
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/actor"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/director"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/genre"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/movie"
@@ -12,53 +16,100 @@ import (
 )
 
 type SearchUsecase struct {
-	tvshowsRep tvshow.TVShowRepository
-	actorsRep  actor.ActorRepository
-	moviesRep  movie.MovieRepository
+	tvshowsRep   tvshow.TVShowRepository
+	actorsRep    actor.ActorRepository
+	moviesRep    movie.MovieRepository
+	directorsRep director.DirectorRepository
+	genresRep    genre.GenreRepository
+	suggestIndex search.SuggestIndex
 }
 
 func NewSearchUsecase(actorsRep actor.ActorRepository,
 	moviesRep movie.MovieRepository,
-	tvshowsRep tvshow.TVShowRepository) search.SearchUsecase {
+	tvshowsRep tvshow.TVShowRepository,
+	directorsRep director.DirectorRepository,
+	genresRep genre.GenreRepository,
+	suggestIndex search.SuggestIndex) search.SearchUsecase {
 	return &SearchUsecase{
-		tvshowsRep: tvshowsRep,
-		actorsRep:  actorsRep,
-		moviesRep:  moviesRep,
+		tvshowsRep:   tvshowsRep,
+		actorsRep:    actorsRep,
+		moviesRep:    moviesRep,
+		directorsRep: directorsRep,
+		genresRep:    genresRep,
+		suggestIndex: suggestIndex,
 	}
 }
 
 func (uc SearchUsecase) Search(curUserID uint64, query string,
 	pagination *models.Pagination) (*models.SearchResult, *errors.Error) {
+	query = strings.TrimSpace(query)
 	if query == "" {
-		return &models.SearchResult{}, nil
+		return &models.SearchResult{
+			TVShows:   []*models.TVShow{},
+			Movies:    []*models.Movie{},
+			Actors:    []*models.Actor{},
+			Directors: []*models.Director{},
+			Genres:    []*models.Genre{},
+		}, nil
 	}
 
-	movies, err := uc.moviesRep.SelectWhereNameLike(curUserID, query, pagination.Count, pagination.From)
+	movies, err := uc.moviesRep.SelectByQuery(query, pagination, curUserID)
 	if err == sql.ErrNoRows || (err == nil && movies == nil) {
 		movies = []*models.Movie{}
 	} else if err != nil {
 		return nil, errors.New(consts.CodeInternalError, err)
 	}
 
-	actors, err := uc.actorsRep.SelectWhereNameLike(query, pagination.Count, pagination.From)
+	tvShows, err := uc.tvshowsRep.SelectByQuery(query, pagination, curUserID)
+	if err == sql.ErrNoRows || (err == nil && tvShows == nil) {
+		tvShows = []*models.TVShow{}
+	} else if err != nil {
+		return nil, errors.New(consts.CodeInternalError, err)
+	}
+
+	actors, err := uc.actorsRep.SelectByQuery(query, pagination)
 	if err == sql.ErrNoRows || (err == nil && actors == nil) {
 		actors = []*models.Actor{}
 	} else if err != nil {
 		return nil, errors.New(consts.CodeInternalError, err)
 	}
 
-	tvShows, err := uc.tvshowsRep.SelectWhereNameLike(query, pagination, curUserID)
-	if err == sql.ErrNoRows || (err == nil && tvShows == nil) {
-		tvShows = []*models.TVShow{}
+	directors, err := uc.directorsRep.SelectByQuery(query, pagination)
+	if err == sql.ErrNoRows || (err == nil && directors == nil) {
+		directors = []*models.Director{}
+	} else if err != nil {
+		return nil, errors.New(consts.CodeInternalError, err)
+	}
+
+	genres, err := uc.genresRep.SelectByQuery(query, pagination)
+	if err == sql.ErrNoRows || (err == nil && genres == nil) {
+		genres = []*models.Genre{}
 	} else if err != nil {
 		return nil, errors.New(consts.CodeInternalError, err)
 	}
 
 	result := &models.SearchResult{
-		TVShows: tvShows,
-		Movies:  movies,
-		Actors:  actors,
+		TVShows:   tvShows,
+		Movies:    movies,
+		Actors:    actors,
+		Directors: directors,
+		Genres:    genres,
 	}
 
 	return result, nil
 }
+
+func (uc SearchUsecase) Suggest(query string, count uint64) ([]*models.Suggestion, *errors.Error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return []*models.Suggestion{}, nil
+	}
+
+	if count == 0 {
+		count = consts.SuggestionsDefaultCount
+	} else if count > consts.SuggestionsMaxCount {
+		count = consts.SuggestionsMaxCount
+	}
+
+	return uc.suggestIndex.Suggest(query, int(count)), nil
+}
@@ -0,0 +1,138 @@
+package usecases
+
+import (
+	"errors"
+	"testing"
+
+	actorMocks "github.com/go-park-mail-ru/2020_2_Slash/internal/actor/mocks"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	directorMocks "github.com/go-park-mail-ru/2020_2_Slash/internal/director/mocks"
+	genreMocks "github.com/go-park-mail-ru/2020_2_Slash/internal/genre/mocks"
+	customErrors "github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	movieMocks "github.com/go-park-mail-ru/2020_2_Slash/internal/movie/mocks"
+	searchMocks "github.com/go-park-mail-ru/2020_2_Slash/internal/search/mocks"
+	tvshowMocks "github.com/go-park-mail-ru/2020_2_Slash/internal/tvshow/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+type searchRepos struct {
+	movies    *movieMocks.MockMovieRepository
+	tvshows   *tvshowMocks.MockTVShowRepository
+	actors    *actorMocks.MockActorRepository
+	directors *directorMocks.MockDirectorRepository
+	genres    *genreMocks.MockGenreRepository
+	index     *searchMocks.MockSuggestIndex
+}
+
+func setupSearchUsecase(t *testing.T) (*gomock.Controller, *searchRepos, *SearchUsecase) {
+	ctrl := gomock.NewController(t)
+	repos := &searchRepos{
+		movies:    movieMocks.NewMockMovieRepository(ctrl),
+		tvshows:   tvshowMocks.NewMockTVShowRepository(ctrl),
+		actors:    actorMocks.NewMockActorRepository(ctrl),
+		directors: directorMocks.NewMockDirectorRepository(ctrl),
+		genres:    genreMocks.NewMockGenreRepository(ctrl),
+		index:     searchMocks.NewMockSuggestIndex(ctrl),
+	}
+	// nolint: errcheck
+	searchUseCase := NewSearchUsecase(repos.actors, repos.movies, repos.tvshows,
+		repos.directors, repos.genres, repos.index).(*SearchUsecase)
+	return ctrl, repos, searchUseCase
+}
+
+func TestSearchUseCase_Search_OK(t *testing.T) {
+	t.Parallel()
+	ctrl, repos, searchUseCase := setupSearchUsecase(t)
+	defer ctrl.Finish()
+
+	var userID uint64 = 1
+	query := "Шрек"
+	pgnt := &models.Pagination{From: 0, Count: 10}
+
+	movies := []*models.Movie{
+		&models.Movie{ID: 1, Content: models.Content{Name: "Шрек"}},
+	}
+	actors := []*models.Actor{
+		&models.Actor{ID: 2, Name: "Mike Myers"},
+	}
+
+	repos.movies.EXPECT().SelectByQuery(query, pgnt, userID).Return(movies, nil)
+	repos.tvshows.EXPECT().SelectByQuery(query, pgnt, userID).Return(nil, nil)
+	repos.actors.EXPECT().SelectByQuery(query, pgnt).Return(actors, nil)
+	repos.directors.EXPECT().SelectByQuery(query, pgnt).Return(nil, nil)
+	repos.genres.EXPECT().SelectByQuery(query, pgnt).Return(nil, nil)
+
+	result, err := searchUseCase.Search(userID, "  "+query+" ", pgnt)
+	assert.Equal(t, err, (*customErrors.Error)(nil))
+	assert.Equal(t, &models.SearchResult{
+		TVShows:   []*models.TVShow{},
+		Movies:    movies,
+		Actors:    actors,
+		Directors: []*models.Director{},
+		Genres:    []*models.Genre{},
+	}, result)
+}
+
+func TestSearchUseCase_Search_EmptyQuery(t *testing.T) {
+	t.Parallel()
+	ctrl, _, searchUseCase := setupSearchUsecase(t)
+	defer ctrl.Finish()
+
+	pgnt := &models.Pagination{From: 0, Count: 10}
+
+	result, err := searchUseCase.Search(1, "   ", pgnt)
+	assert.Equal(t, err, (*customErrors.Error)(nil))
+	assert.Equal(t, 0, len(result.Movies))
+	assert.Equal(t, 0, len(result.TVShows))
+	assert.Equal(t, 0, len(result.Actors))
+	assert.Equal(t, 0, len(result.Directors))
+	assert.Equal(t, 0, len(result.Genres))
+}
+
+func TestSearchUseCase_Search_DBFail(t *testing.T) {
+	t.Parallel()
+	ctrl, repos, searchUseCase := setupSearchUsecase(t)
+	defer ctrl.Finish()
+
+	var userID uint64 = 1
+	query := "Шрек"
+	pgnt := &models.Pagination{From: 0, Count: 10}
+
+	repos.movies.EXPECT().SelectByQuery(query, pgnt, userID).Return(nil, errors.New("db fail"))
+
+	result, err := searchUseCase.Search(userID, query, pgnt)
+	assert.Equal(t, (*models.SearchResult)(nil), result)
+	assert.Equal(t, consts.CodeInternalError, err.Code)
+}
+
+func TestSearchUseCase_Suggest_OK(t *testing.T) {
+	t.Parallel()
+	ctrl, repos, searchUseCase := setupSearchUsecase(t)
+	defer ctrl.Finish()
+
+	suggestions := []*models.Suggestion{
+		&models.Suggestion{ID: 1, Type: consts.MovieContentType, Name: "Шрек"},
+	}
+
+	repos.index.EXPECT().Suggest("шр", 5).Return(suggestions)
+
+	result, err := searchUseCase.Suggest(" шр ", 5)
+	assert.Equal(t, err, (*customErrors.Error)(nil))
+	assert.Equal(t, suggestions, result)
+}
+
+func TestSearchUseCase_Suggest_CountBounds(t *testing.T) {
+	t.Parallel()
+	ctrl, repos, searchUseCase := setupSearchUsecase(t)
+	defer ctrl.Finish()
+
+	repos.index.EXPECT().Suggest("шр", consts.SuggestionsDefaultCount).Return([]*models.Suggestion{})
+	repos.index.EXPECT().Suggest("шр", consts.SuggestionsMaxCount).Return([]*models.Suggestion{})
+
+	_, err := searchUseCase.Suggest("шр", 0)
+	assert.Equal(t, err, (*customErrors.Error)(nil))
+	_, err = searchUseCase.Suggest("шр", consts.SuggestionsMaxCount+1)
+	assert.Equal(t, err, (*customErrors.Error)(nil))
+}
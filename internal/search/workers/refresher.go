@@ -0,0 +1,50 @@
+package workers
+
+import (
+	"sync"
+	"time"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/search"
+)
+
+type Refresher struct {
+	suggestIndex search.SuggestIndex
+	interval     time.Duration
+	stop         chan struct{}
+	wg           sync.WaitGroup
+}
+
+func NewRefresher(suggestIndex search.SuggestIndex, interval time.Duration) *Refresher {
+	return &Refresher{
+		suggestIndex: suggestIndex,
+		interval:     interval,
+		stop:         make(chan struct{}),
+	}
+}
+
+// Start rebuilds suggestions every interval, so names changed
+// by other instances or outside of the app become suggested
+func (rf *Refresher) Start() {
+	rf.wg.Add(1)
+	go rf.work()
+}
+
+func (rf *Refresher) Stop() {
+	close(rf.stop)
+	rf.wg.Wait()
+}
+
+func (rf *Refresher) work() {
+	defer rf.wg.Done()
+
+	ticker := time.NewTicker(rf.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-rf.stop:
+			return
+		case <-ticker.C:
+			rf.suggestIndex.Invalidate()
+		}
+	}
+}
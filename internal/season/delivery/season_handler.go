@@ -24,9 +24,9 @@ func NewSeasonHandler(usecase season.SeasonUsecase) *SeasonHandler {
 }
 
 func (sh *SeasonHandler) Configure(e *echo.Echo, mw *mwares.MiddlewareManager) {
-	e.POST("/api/v1/seasons", sh.CreateHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF)
-	e.PUT("/api/v1/seasons/:id", sh.ChangeHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF)
-	e.DELETE("/api/v1/seasons/:id", sh.DeleteHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF)
+	e.POST("/api/v1/seasons", sh.CreateHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF, mw.Audit(consts.AuditSeason, ""))
+	e.PUT("/api/v1/seasons/:id", sh.ChangeHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF, mw.Audit(consts.AuditSeason, "id"))
+	e.DELETE("/api/v1/seasons/:id", sh.DeleteHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF, mw.Audit(consts.AuditSeason, "id"))
 	e.GET("/api/v1/seasons/:id", sh.GetHandler(), mw.GetAuth)
 }
 
@@ -126,7 +126,7 @@ func (sh *SeasonHandler) GetHandler() echo.HandlerFunc {
 			return cntx.JSON(customErr.HTTPCode, Response{Error: customErr})
 		}
 
-		season, customErr := sh.seasonUsecase.Get(seasonID)
+		season, customErr := sh.seasonUsecase.GetPublished(seasonID)
 		if customErr != nil {
 			logger.Error(customErr.Message)
 			return cntx.JSON(customErr.HTTPCode, Response{Error: customErr})
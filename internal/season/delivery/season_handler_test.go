@@ -238,7 +238,7 @@ func TestSeasonHandler_GetHandler(t *testing.T) {
 
 	seasonUseCase.
 		EXPECT().
-		Get(testSeason.ID).
+		GetPublished(testSeason.ID).
 		Return(testSeason, nil)
 
 	seasonUseCase.
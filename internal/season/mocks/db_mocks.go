@@ -82,7 +82,7 @@ func ExpectSelectEpisodesReturnErrNoRows(mock sqlmock.Sqlmock, season *models.Se
 func ExpectDeleteSuccess(mock sqlmock.Sqlmock, season *models.Season) {
 	mock.ExpectBegin()
 	mock.
-		ExpectExec(`DELETE FROM seasons`).
+		ExpectExec(`UPDATE seasons SET deleted_at`).
 		WithArgs(season.ID).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 	mock.ExpectCommit()
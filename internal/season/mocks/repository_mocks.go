@@ -76,6 +76,21 @@ func (mr *MockSeasonRepositoryMockRecorder) SelectByID(id interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectByID", reflect.TypeOf((*MockSeasonRepository)(nil).SelectByID), id)
 }
 
+// SelectPublishedByID mocks base method
+func (m *MockSeasonRepository) SelectPublishedByID(id uint64) (*models.Season, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectPublishedByID", id)
+	ret0, _ := ret[0].(*models.Season)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectPublishedByID indicates an expected call of SelectPublishedByID
+func (mr *MockSeasonRepositoryMockRecorder) SelectPublishedByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectPublishedByID", reflect.TypeOf((*MockSeasonRepository)(nil).SelectPublishedByID), id)
+}
+
 // Select mocks base method
 func (m *MockSeasonRepository) Select(season *models.Season) (*models.Season, error) {
 	m.ctrl.T.Helper()
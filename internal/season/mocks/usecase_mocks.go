@@ -77,6 +77,21 @@ func (mr *MockSeasonUsecaseMockRecorder) Get(id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockSeasonUsecase)(nil).Get), id)
 }
 
+// GetPublished mocks base method
+func (m *MockSeasonUsecase) GetPublished(id uint64) (*models.Season, *errors.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublished", id)
+	ret0, _ := ret[0].(*models.Season)
+	ret1, _ := ret[1].(*errors.Error)
+	return ret0, ret1
+}
+
+// GetPublished indicates an expected call of GetPublished
+func (mr *MockSeasonUsecaseMockRecorder) GetPublished(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublished", reflect.TypeOf((*MockSeasonUsecase)(nil).GetPublished), id)
+}
+
 // GetEpisodes mocks base method
 func (m *MockSeasonUsecase) GetEpisodes(id uint64) ([]*models.Episode, *errors.Error) {
 	m.ctrl.T.Helper()
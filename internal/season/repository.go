@@ -6,6 +6,7 @@ type SeasonRepository interface {
 	Insert(season *models.Season) error
 	Update(season *models.Season) error
 	SelectByID(id uint64) (*models.Season, error)
+	SelectPublishedByID(id uint64) (*models.Season, error)
 	Select(season *models.Season) (*models.Season, error)
 	SelectEpisodes(id uint64) ([]*models.Episode, error)
 	Delete(id uint64) error
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
 
+	queryBuilder "github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/query_builder"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/season"
 )
@@ -72,9 +73,11 @@ func (rep *SeasonPgRepository) Update(season *models.Season) error {
 func (rep *SeasonPgRepository) SelectByID(id uint64) (*models.Season, error) {
 	season := &models.Season{}
 	row := rep.db.QueryRow(`
-		SELECT id, number, episodes, tv_show_id
-		FROM seasons
-		WHERE id=$1`, id)
+		SELECT s.id, s.number, s.episodes, s.tv_show_id
+		FROM seasons AS s
+		JOIN tv_shows AS tv ON tv.id=s.tv_show_id
+		JOIN content AS c ON c.id=tv.content_id
+		WHERE s.id=$1 AND s.deleted_at IS NULL AND c.deleted_at IS NULL`, id)
 	err := row.Scan(&season.ID, &season.Number, &season.EpisodesNumber, &season.TVShowID)
 	if err != nil {
 		return nil, err
@@ -82,6 +85,24 @@ func (rep *SeasonPgRepository) SelectByID(id uint64) (*models.Season, error) {
 	return season, nil
 }
 
+// SelectPublishedByID finds season only if its tv show is published
+func (rep *SeasonPgRepository) SelectPublishedByID(id uint64) (*models.Season, error) {
+	season := &models.Season{}
+	row := rep.db.QueryRow(`
+		SELECT s.id, s.number, s.episodes, s.tv_show_id
+		FROM seasons AS s
+		JOIN tv_shows AS tv ON tv.id=s.tv_show_id
+		JOIN content AS c ON c.id=tv.content_id
+		WHERE s.id=$1 AND s.deleted_at IS NULL AND `+queryBuilder.BuildPublishedCondition(), id)
+	err := row.Scan(&season.ID, &season.Number, &season.EpisodesNumber, &season.TVShowID)
+	if err != nil {
+		return nil, err
+	}
+	return season, nil
+}
+
+// Delete moves season to the trash, episodes are kept
+// until the trash is purged
 func (rep *SeasonPgRepository) Delete(id uint64) error {
 	tx, err := rep.db.BeginTx(context.Background(), &sql.TxOptions{})
 	if err != nil {
@@ -89,9 +110,9 @@ func (rep *SeasonPgRepository) Delete(id uint64) error {
 	}
 
 	_, err = tx.Exec(`
-		DELETE
-		FROM seasons
-		WHERE id=$1`, id)
+		UPDATE seasons
+		SET deleted_at = now()
+		WHERE id=$1 AND deleted_at IS NULL`, id)
 	if err != nil {
 		if rollbackErr := tx.Rollback(); rollbackErr != nil {
 			logger.Error(rollbackErr.Error())
@@ -106,6 +127,8 @@ func (rep *SeasonPgRepository) Delete(id uint64) error {
 	return nil
 }
 
+// Select finds season by number including the trashed one,
+// so the restored season never conflicts
 func (rep *SeasonPgRepository) Select(season *models.Season) (*models.Season, error) {
 	dbSeason := &models.Season{}
 	row := rep.db.QueryRow(`
@@ -123,7 +146,7 @@ func (rep *SeasonPgRepository) SelectEpisodes(id uint64) ([]*models.Episode, err
 	rows, err := rep.db.Query(`
 		SELECT id, number, name, video, description, poster, season_id
 		FROM episodes
-		WHERE season_id=$1
+		WHERE season_id=$1 AND deleted_at IS NULL
 		ORDER BY number`, id)
 	if err != nil {
 		return nil, err
@@ -155,7 +178,7 @@ func (rep *SeasonPgRepository) SelectByTVShow(tvshowID uint64) ([]*models.Season
 	rows, err := rep.db.Query(`
 		SELECT id, number, episodes, tv_show_id
 		FROM seasons
-		WHERE tv_show_id=$1
+		WHERE tv_show_id=$1 AND deleted_at IS NULL
 		ORDER BY number`, tvshowID)
 	if err != nil {
 		return nil, err
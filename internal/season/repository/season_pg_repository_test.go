@@ -144,6 +144,24 @@ func TestSeasonPgRepository_SelectByID_NoRows(t *testing.T) {
 	}
 }
 
+func TestSeasonPgRepository_SelectPublishedByID_NoRows(t *testing.T) {
+	t.Parallel()
+	mock, seasonPgRep, err := BuildMockAndRepo()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mocks.ExpectSelectByIDReturnErrNoRows(mock, testSeason)
+
+	season, err := seasonPgRep.SelectPublishedByID(testSeason.ID)
+	assert.Nil(t, season)
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestSeasonPgRepository_Select_OK(t *testing.T) {
 	t.Parallel()
 	mock, seasonPgRep, err := BuildMockAndRepo()
@@ -9,6 +9,8 @@ type SeasonUsecase interface {
 	Create(season *models.Season) *errors.Error
 	Change(season *models.Season) *errors.Error
 	Get(id uint64) (*models.Season, *errors.Error)
+	// GetPublished hides seasons of tv shows that aren't published
+	GetPublished(id uint64) (*models.Season, *errors.Error)
 	GetEpisodes(id uint64) ([]*models.Episode, *errors.Error)
 	Delete(id uint64) *errors.Error
 	ListByTVShow(tvshowID uint64) ([]*models.Season, *errors.Error)
@@ -92,6 +92,16 @@ func (uc *SeasonUsecase) Get(id uint64) (*models.Season, *errors.Error) {
 	return season, nil
 }
 
+func (uc *SeasonUsecase) GetPublished(id uint64) (*models.Season, *errors.Error) {
+	season, err := uc.rep.SelectPublishedByID(id)
+	if err == sql.ErrNoRows {
+		return nil, errors.Get(consts.CodeSeasonDoesNotExist)
+	} else if err != nil {
+		return nil, errors.New(consts.CodeInternalError, err)
+	}
+	return season, nil
+}
+
 func (uc *SeasonUsecase) GetEpisodes(id uint64) ([]*models.Episode, *errors.Error) {
 	episodes, err := uc.rep.SelectEpisodes(id)
 	if err == sql.ErrNoRows {
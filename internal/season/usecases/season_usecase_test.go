@@ -281,6 +281,24 @@ func TestSeasonUsecase_Get_NoRows(t *testing.T) {
 	assert.Nil(t, seasonDB)
 }
 
+func TestSeasonUsecase_GetPublished_NotPublished(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	rep := mocks.NewMockSeasonRepository(ctrl)
+	tvShowUsecase := tvShowMocks.NewMockTVShowUsecase(ctrl)
+	seasonUsecase := NewSeasonUsecase(rep, tvShowUsecase)
+	defer ctrl.Finish()
+
+	rep.
+		EXPECT().
+		SelectPublishedByID(testSeason.ID).
+		Return(nil, sql.ErrNoRows)
+
+	seasonDB, customErr := seasonUsecase.GetPublished(testSeason.ID)
+	assert.Equal(t, errors.Get(consts.CodeSeasonDoesNotExist), customErr)
+	assert.Nil(t, seasonDB)
+}
+
 func TestSeasonUsecase_GetEpisodes_OK(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
@@ -8,23 +8,53 @@ import (
 func GrpcSessionToModel(grpcSess *Session) *models.Session {
 	// nolint: errcheck
 	ExpiresAt, _ := ptypes.Timestamp(grpcSess.ExpiresAt)
+	// nolint: errcheck
+	CreatedAt, _ := ptypes.Timestamp(grpcSess.CreatedAt)
 
 	return &models.Session{
 		ID:        grpcSess.ID,
 		Value:     grpcSess.Value,
 		UserID:    grpcSess.UserID,
 		ExpiresAt: ExpiresAt,
+		UserAgent: grpcSess.UserAgent,
+		IP:        grpcSess.IP,
+		CreatedAt: CreatedAt,
+		Remember:  grpcSess.Remember,
+		Renewed:   grpcSess.Renewed,
 	}
 }
 
 func ModelSessionToGrpc(modelSess *models.Session) *Session {
 	// nolint: errcheck
 	ExpiresAt, _ := ptypes.TimestampProto(modelSess.ExpiresAt)
+	// nolint: errcheck
+	CreatedAt, _ := ptypes.TimestampProto(modelSess.CreatedAt)
 
 	return &Session{
 		ID:        modelSess.ID,
 		Value:     modelSess.Value,
 		UserID:    modelSess.UserID,
 		ExpiresAt: ExpiresAt,
+		UserAgent: modelSess.UserAgent,
+		IP:        modelSess.IP,
+		CreatedAt: CreatedAt,
+		Remember:  modelSess.Remember,
+		Renewed:   modelSess.Renewed,
+	}
+}
+
+func GrpcSessionsToModels(grpcSessions *Sessions) []*models.Session {
+	var sessions []*models.Session
+	for _, grpcSess := range grpcSessions.GetSessions() {
+		sessions = append(sessions, GrpcSessionToModel(grpcSess))
+	}
+	return sessions
+}
+
+func ModelSessionsToGrpc(modelSessions []*models.Session) *Sessions {
+	sessions := &Sessions{}
+	for _, modelSess := range modelSessions {
+		sessions.Sessions = append(sessions.Sessions, ModelSessionToGrpc(modelSess))
 	}
+	return sessions
 }
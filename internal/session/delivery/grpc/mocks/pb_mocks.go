@@ -116,6 +116,46 @@ func (mr *MockSessionBlockClientMockRecorder) Check(ctx, in interface{}, opts ..
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockSessionBlockClient)(nil).Check), varargs...)
 }
 
+// ListByUser mocks base method
+func (m *MockSessionBlockClient) ListByUser(ctx context.Context, in *grpc.UserID, opts ...grpc0.CallOption) (*grpc.Sessions, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListByUser", varargs...)
+	ret0, _ := ret[0].(*grpc.Sessions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUser indicates an expected call of ListByUser
+func (mr *MockSessionBlockClientMockRecorder) ListByUser(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUser", reflect.TypeOf((*MockSessionBlockClient)(nil).ListByUser), varargs...)
+}
+
+// DeleteAllForUser mocks base method
+func (m *MockSessionBlockClient) DeleteAllForUser(ctx context.Context, in *grpc.UserSession, opts ...grpc0.CallOption) (*emptypb.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteAllForUser", varargs...)
+	ret0, _ := ret[0].(*emptypb.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAllForUser indicates an expected call of DeleteAllForUser
+func (mr *MockSessionBlockClientMockRecorder) DeleteAllForUser(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAllForUser", reflect.TypeOf((*MockSessionBlockClient)(nil).DeleteAllForUser), varargs...)
+}
+
 // MockSessionBlockServer is a mock of SessionBlockServer interface
 type MockSessionBlockServer struct {
 	ctrl     *gomock.Controller
@@ -198,3 +238,33 @@ func (mr *MockSessionBlockServerMockRecorder) Check(arg0, arg1 interface{}) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockSessionBlockServer)(nil).Check), arg0, arg1)
 }
+
+// ListByUser mocks base method
+func (m *MockSessionBlockServer) ListByUser(arg0 context.Context, arg1 *grpc.UserID) (*grpc.Sessions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUser", arg0, arg1)
+	ret0, _ := ret[0].(*grpc.Sessions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUser indicates an expected call of ListByUser
+func (mr *MockSessionBlockServerMockRecorder) ListByUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUser", reflect.TypeOf((*MockSessionBlockServer)(nil).ListByUser), arg0, arg1)
+}
+
+// DeleteAllForUser mocks base method
+func (m *MockSessionBlockServer) DeleteAllForUser(arg0 context.Context, arg1 *grpc.UserSession) (*emptypb.Empty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAllForUser", arg0, arg1)
+	ret0, _ := ret[0].(*emptypb.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAllForUser indicates an expected call of DeleteAllForUser
+func (mr *MockSessionBlockServerMockRecorder) DeleteAllForUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAllForUser", reflect.TypeOf((*MockSessionBlockServer)(nil).DeleteAllForUser), arg0, arg1)
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/session"
 
 	"context"
@@ -58,17 +59,53 @@ func (sm *SessionBlockMicroservice) Check(cntx context.Context, sessValue *Sessi
 		return nil, err
 	}
 
+	now := time.Now()
 	sessModel := GrpcSessionToModel(sess)
-	if sessModel.ExpiresAt.Before(time.Now()) {
+	if sessModel.ExpiresAt.Before(now) {
 		_, err := sm.Delete(cntx, sessValue)
 		if err != nil {
 			return nil, err
 		}
 		return nil, status.Error(codes.Code(consts.CodeSessionExpired), "")
 	}
+
+	// Sliding expiry: extend sessions which are past half of their lifetime
+	if sessModel.NeedsRenewal(now) {
+		sessModel.Renew(now)
+		if err := sm.sessRepo.UpdateExpires(sessModel); err != nil {
+			return nil, status.Error(codes.Code(consts.CodeInternalError), err.Error())
+		}
+		return ModelSessionToGrpc(sessModel), nil
+	}
 	return sess, nil
 }
 
+// ListByUser returns not expired sessions of the user
+func (sm *SessionBlockMicroservice) ListByUser(cntx context.Context, userID *UserID) (*Sessions, error) {
+	sessions, err := sm.sessRepo.SelectByUserID(userID.GetID())
+	if err != nil {
+		return nil, status.Error(codes.Code(consts.CodeInternalError), err.Error())
+	}
+
+	now := time.Now()
+	var activeSessions []*models.Session
+	for _, sess := range sessions {
+		if sess.ExpiresAt.After(now) {
+			activeSessions = append(activeSessions, sess)
+		}
+	}
+	return ModelSessionsToGrpc(activeSessions), nil
+}
+
+// DeleteAllForUser deletes all user sessions except the current one
+func (sm *SessionBlockMicroservice) DeleteAllForUser(cntx context.Context,
+	userSess *UserSession) (*emptypb.Empty, error) {
+	if err := sm.sessRepo.DeleteByUserID(userSess.GetUserID(), userSess.GetValue()); err != nil {
+		return &emptypb.Empty{}, status.Error(codes.Code(consts.CodeInternalError), err.Error())
+	}
+	return &emptypb.Empty{}, nil
+}
+
 func (sm *SessionBlockMicroservice) isExist(sessValue string) bool {
 	_, err := sm.Get(context.Background(), &SessionValue{Value: sessValue})
 	return err == nil
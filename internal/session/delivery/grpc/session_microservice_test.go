@@ -13,7 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-var sessModel = models.NewSession(3)
+var sessModel = models.NewSession(3, false)
 var sess = ModelSessionToGrpc(sessModel)
 
 func TestSessionUseCase_Create_OK(t *testing.T) {
@@ -91,6 +91,35 @@ func TestSessionUsecase_Check_OK(t *testing.T) {
 	assert.Equal(t, dbSession.Value, sessModel.Value)
 }
 
+func TestSessionUsecase_Check_Renewed(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sessionRep := mocks.NewMockSessionRepository(ctrl)
+	sessionClient := NewSessionBlockMicroservice(sessionRep)
+
+	sessModel := models.NewSession(3, true)
+	sessModel.ExpiresAt = time.Now().Add(sessModel.Lifetime() / 4)
+	oldExpiresAt := sessModel.ExpiresAt
+
+	sessionRep.
+		EXPECT().
+		SelectByValue(gomock.Eq(sessModel.Value)).
+		Return(sessModel, nil)
+
+	sessionRep.
+		EXPECT().
+		UpdateExpires(gomock.Any()).
+		Return(nil)
+
+	dbSession, err := sessionClient.Check(context.Background(), &SessionValue{Value: sessModel.Value})
+	assert.Equal(t, err, (error)(nil))
+	assert.True(t, dbSession.Renewed)
+	assert.True(t, dbSession.Remember)
+	assert.True(t, GrpcSessionToModel(dbSession).ExpiresAt.After(oldExpiresAt))
+}
+
 func TestSessionUsecase_Check_Expired(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
@@ -119,3 +148,44 @@ func TestSessionUsecase_Check_Expired(t *testing.T) {
 	_, err := sessionClient.Delete(context.Background(), &SessionValue{Value: sessModel.Value})
 	assert.Equal(t, err, (error)(nil))
 }
+
+func TestSessionUseCase_ListByUser_SkipsExpired(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sessionRep := mocks.NewMockSessionRepository(ctrl)
+	sessionClient := NewSessionBlockMicroservice(sessionRep)
+
+	expiredSess := models.NewSession(3, false)
+	expiredSess.ExpiresAt = time.Now().Add(-time.Hour)
+
+	sessionRep.
+		EXPECT().
+		SelectByUserID(gomock.Eq(sessModel.UserID)).
+		Return([]*models.Session{sessModel, expiredSess}, nil)
+
+	sessions, err := sessionClient.ListByUser(context.Background(), &UserID{ID: sessModel.UserID})
+	assert.Equal(t, err, (error)(nil))
+	if assert.Len(t, sessions.GetSessions(), 1) {
+		assert.Equal(t, sessModel.Value, sessions.GetSessions()[0].Value)
+	}
+}
+
+func TestSessionUseCase_DeleteAllForUser_OK(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sessionRep := mocks.NewMockSessionRepository(ctrl)
+	sessionClient := NewSessionBlockMicroservice(sessionRep)
+
+	sessionRep.
+		EXPECT().
+		DeleteByUserID(gomock.Eq(sessModel.UserID), gomock.Eq(sessModel.Value)).
+		Return(nil)
+
+	_, err := sessionClient.DeleteAllForUser(context.Background(),
+		&UserSession{UserID: sessModel.UserID, Value: sessModel.Value})
+	assert.Equal(t, err, (error)(nil))
+}
@@ -2,6 +2,7 @@ package delivery
 
 import (
 	"net/http"
+	"strconv"
 	"time"
 
 	. "github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
@@ -16,6 +17,7 @@ import (
 	reader "github.com/go-park-mail-ru/2020_2_Slash/tools/request_reader"
 	. "github.com/go-park-mail-ru/2020_2_Slash/tools/response"
 	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
 )
 
 type SessionHandler struct {
@@ -32,14 +34,17 @@ func NewSessionHandler(sessUcase session.SessionUsecase,
 }
 
 func (sh *SessionHandler) Configure(e *echo.Echo, mw *mwares.MiddlewareManager) {
-	e.POST("/api/v1/session", sh.LoginHandler())
+	e.POST("/api/v1/session", sh.LoginHandler(), middleware.BodyLimit("4K"), mw.RateLimit(LoginRateLimit))
 	e.DELETE("/api/v1/session", sh.LogoutHandler(), mw.CheckAuth, mw.CheckCSRF)
+	e.GET("/api/v1/sessions", sh.GetSessionsHandler(), mw.CheckAuth)
+	e.DELETE("/api/v1/sessions/:id", sh.DeleteSessionHandler(), mw.CheckAuth, mw.CheckCSRF)
 }
 
 func (sh *SessionHandler) LoginHandler() echo.HandlerFunc {
 	type Request struct {
 		Email    string `json:"email" validate:"required,email"`
 		Password string `json:"password" validate:"required,gte=6"`
+		Remember bool   `json:"remember"`
 	}
 
 	return func(cntx echo.Context) error {
@@ -56,11 +61,14 @@ func (sh *SessionHandler) LoginHandler() echo.HandlerFunc {
 		}
 
 		if err := sh.userUcase.CheckPassword(dbUser, req.Password); err != nil {
+			cntx.Set(RateLimitCountKey, true)
 			logger.Error(err.Message)
 			return cntx.JSON(err.HTTPCode, Response{Error: err})
 		}
 
-		sess := models.NewSession(dbUser.ID)
+		sess := models.NewSession(dbUser.ID, req.Remember)
+		sess.UserAgent = cntx.Request().UserAgent()
+		sess.IP = cntx.RealIP()
 		if err = sh.sessUcase.Create(sess); err != nil {
 			logger.Error(err.Message)
 			return cntx.JSON(err.HTTPCode, Response{Error: err})
@@ -106,6 +114,52 @@ func (sh *SessionHandler) LogoutHandler() echo.HandlerFunc {
 	}
 }
 
+func (sh *SessionHandler) GetSessionsHandler() echo.HandlerFunc {
+	return func(cntx echo.Context) error {
+		// nolint: errcheck
+		userID, _ := cntx.Get("userID").(uint64)
+		// nolint: errcheck
+		sessValue, _ := cntx.Get("sessValue").(string)
+
+		sessions, err := sh.sessUcase.ListByUser(userID)
+		if err != nil {
+			logger.Error(err.Message)
+			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		}
+		for _, sess := range sessions {
+			sess.Current = sess.Value == sessValue
+		}
+
+		return cntx.JSON(http.StatusOK, Response{
+			Body: &Body{
+				"sessions": sessions,
+			},
+		})
+	}
+}
+
+func (sh *SessionHandler) DeleteSessionHandler() echo.HandlerFunc {
+	return func(cntx echo.Context) error {
+		sessionID, parseErr := strconv.ParseUint(cntx.Param("id"), 10, 64)
+		if parseErr != nil {
+			customErr := errors.New(CodeBadRequest, parseErr)
+			logger.Error(customErr.Message)
+			return cntx.JSON(customErr.HTTPCode, Response{Error: customErr})
+		}
+
+		// nolint: errcheck
+		userID, _ := cntx.Get("userID").(uint64)
+		if err := sh.sessUcase.DeleteByID(userID, sessionID); err != nil {
+			logger.Error(err.Message)
+			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		}
+
+		return cntx.JSON(http.StatusOK, Response{
+			Message: "success",
+		})
+	}
+}
+
 func SetOverdueCookie(cntx echo.Context, cookie *http.Cookie) {
 	cookie.Path = "/"
 	cookie.Expires = time.Now().AddDate(0, 0, -2)
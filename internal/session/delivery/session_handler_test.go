@@ -7,7 +7,10 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/mwares"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/session"
@@ -34,7 +37,7 @@ func setupSessionHandler(sessionUseCase session.SessionUsecase,
 	c := e.NewContext(req, rec)
 	sessionHandler := NewSessionHandler(sessionUseCase, userUseCase)
 	sessionHandler.Configure(e, nil)
-	mw := mwares.NewMiddlewareManager(sessionUseCase, userUseCase, nil)
+	mw := mwares.NewMiddlewareManager(sessionUseCase, userUseCase, nil, nil, nil)
 	sessionHandler.Configure(e, mw)
 	return c, sessionHandler, rec
 }
@@ -110,6 +113,109 @@ func TestSessionHandler_LoginHandler(t *testing.T) {
 	}
 }
 
+func TestSessionHandler_LoginHandler_WrongPassword(t *testing.T) {
+	// Setup
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	sessionUseCase := mocks.NewMockSessionUsecase(ctrl)
+	userUseCase := userMocks.NewMockUserUsecase(ctrl)
+	logger.InitLogger("/dev/null", 10)
+
+	user := &models.User{
+		ID:    1,
+		Email: "test_user@mail.ru",
+	}
+
+	c, sessionHandler, rec := setupSessionHandler(sessionUseCase, userUseCase,
+		http.MethodPost, `{"email":"test_user@mail.ru","password":"1234567"}`)
+	handleFunc := sessionHandler.LoginHandler()
+
+	userUseCase.
+		EXPECT().
+		GetByEmail(user.Email).
+		Return(user, nil)
+
+	userUseCase.
+		EXPECT().
+		CheckPassword(user, "1234567").
+		Return(errors.Get(consts.CodeWrongPassword))
+
+	// Assertions
+	if assert.NoError(t, handleFunc(c)) {
+		assert.Equal(t, errors.Get(consts.CodeWrongPassword).HTTPCode, rec.Code)
+		assert.Equal(t, true, c.Get(consts.RateLimitCountKey))
+	}
+}
+
+func TestSessionHandler_LoginHandler_Remember(t *testing.T) {
+	// Setup
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	sessionUseCase := mocks.NewMockSessionUsecase(ctrl)
+	userUseCase := userMocks.NewMockUserUsecase(ctrl)
+	logger.InitLogger("/dev/null", 10)
+
+	type Request struct {
+		Email    string `json:"email"`
+		Password string `json:"password"`
+		Remember bool   `json:"remember"`
+	}
+	user := &models.User{
+		ID:       1,
+		Nickname: "test_user",
+		Email:    "test_user@mail.ru",
+		Password: "123456",
+		Role:     "user",
+	}
+	request := Request{
+		Email:    user.Email,
+		Password: user.Password,
+		Remember: true,
+	}
+
+	sessionJSON, err := converter.AnyToBytesBuffer(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, sessionHandler, rec := setupSessionHandler(sessionUseCase, userUseCase,
+		http.MethodPost, sessionJSON.String())
+	handleFunc := sessionHandler.LoginHandler()
+
+	userUseCase.
+		EXPECT().
+		GetByEmail(user.Email).
+		Return(user, nil)
+
+	userUseCase.
+		EXPECT().
+		CheckPassword(user, user.Password).
+		Return(nil)
+
+	var created *models.Session
+	sessionUseCase.
+		EXPECT().
+		Create(gomock.Any()).
+		DoAndReturn(func(sess *models.Session) *errors.Error {
+			created = sess
+			return nil
+		})
+
+	// Assertions
+	if assert.NoError(t, handleFunc(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.True(t, created.Remember)
+		assert.True(t, created.ExpiresAt.After(time.Now().Add(consts.ExpiresDuration)))
+
+		cookies := rec.Result().Cookies()
+		if assert.Len(t, cookies, 1) {
+			assert.Equal(t, created.ExpiresAt.Unix(), cookies[0].Expires.Unix())
+		}
+	}
+}
+
 func TestSessionHandler_LogoutHandler(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
@@ -117,7 +223,7 @@ func TestSessionHandler_LogoutHandler(t *testing.T) {
 	sessionUseCase := mocks.NewMockSessionUsecase(ctrl)
 	userUseCase := userMocks.NewMockUserUsecase(ctrl)
 
-	session := models.NewSession(3)
+	session := models.NewSession(3, false)
 	cookie := tools.CreateCookie(session)
 
 	e := echo.New()
@@ -133,7 +239,7 @@ func TestSessionHandler_LogoutHandler(t *testing.T) {
 	c.SetParamValues(session.Value, strconv.FormatUint(session.UserID, 10))
 
 	sessionHandler := NewSessionHandler(sessionUseCase, userUseCase)
-	mw := mwares.NewMiddlewareManager(sessionUseCase, userUseCase, nil)
+	mw := mwares.NewMiddlewareManager(sessionUseCase, userUseCase, nil, nil, nil)
 	sessionHandler.Configure(e, mw)
 
 	sessionUseCase.
@@ -158,3 +264,62 @@ func TestSessionHandler_LogoutHandler(t *testing.T) {
 		assert.JSONEq(t, expResBody.String(), string(bytes))
 	}
 }
+
+func TestSessionHandler_GetSessionsHandler(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	sessionUseCase := mocks.NewMockSessionUsecase(ctrl)
+	userUseCase := userMocks.NewMockUserUsecase(ctrl)
+
+	current := models.NewSession(3, false)
+	current.ID = 1
+	other := models.NewSession(3, false)
+	other.ID = 2
+
+	c, sessionHandler, rec := setupSessionHandler(sessionUseCase, userUseCase,
+		http.MethodGet, "")
+	c.Set("userID", current.UserID)
+	c.Set("sessValue", current.Value)
+	handleFunc := sessionHandler.GetSessionsHandler()
+
+	sessionUseCase.
+		EXPECT().
+		ListByUser(current.UserID).
+		Return([]*models.Session{current, other}, nil)
+
+	// Assertions
+	if assert.NoError(t, handleFunc(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.True(t, current.Current)
+		assert.False(t, other.Current)
+
+		bytes, _ := ioutil.ReadAll(rec.Body)
+		assert.NotContains(t, string(bytes), current.Value)
+	}
+}
+
+func TestSessionHandler_DeleteSessionHandler(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	sessionUseCase := mocks.NewMockSessionUsecase(ctrl)
+	userUseCase := userMocks.NewMockUserUsecase(ctrl)
+
+	c, sessionHandler, rec := setupSessionHandler(sessionUseCase, userUseCase,
+		http.MethodDelete, "")
+	c.SetParamNames("id")
+	c.SetParamValues("2")
+	c.Set("userID", uint64(3))
+	handleFunc := sessionHandler.DeleteSessionHandler()
+
+	sessionUseCase.
+		EXPECT().
+		DeleteByID(uint64(3), uint64(2)).
+		Return(nil)
+
+	// Assertions
+	if assert.NoError(t, handleFunc(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+}
@@ -12,7 +12,8 @@ func MockInsertReturnRows(mock sqlmock.Sqlmock, session *models.Session) {
 	insertAnswer := sqlmock.NewRows([]string{"id"}).AddRow(session.ID)
 	mock.
 		ExpectQuery(`INSERT INTO sessions`).
-		WithArgs(session.Value, session.ExpiresAt, session.UserID).
+		WithArgs(session.Value, session.ExpiresAt, session.UserID,
+			session.UserAgent, session.IP, session.CreatedAt, session.Remember).
 		WillReturnRows(insertAnswer)
 	mock.ExpectCommit()
 }
@@ -26,9 +27,20 @@ func MockDeleteReturnResultOk(mock sqlmock.Sqlmock, sessionValue string) {
 	mock.ExpectCommit()
 }
 
+func MockUpdateExpiresReturnResultOk(mock sqlmock.Sqlmock, session *models.Session) {
+	mock.ExpectBegin()
+	mock.
+		ExpectExec(`UPDATE sessions`).
+		WithArgs(session.Value, session.ExpiresAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+}
+
 func MockSelectReturnRows(mock sqlmock.Sqlmock, session *models.Session) {
-	rows := sqlmock.NewRows([]string{"id", "value", "expires", "user_id"})
-	rows.AddRow(session.ID, session.Value, session.ExpiresAt, session.UserID)
+	rows := sqlmock.NewRows([]string{"id", "value", "expires", "user_id",
+		"user_agent", "ip", "created", "remember"})
+	rows.AddRow(session.ID, session.Value, session.ExpiresAt, session.UserID,
+		session.UserAgent, session.IP, session.CreatedAt, session.Remember)
 	mock.
 		ExpectQuery(`SELECT`).
 		WithArgs(session.Value).
@@ -41,3 +53,25 @@ func MockSelectReturnErrNoRows(mock sqlmock.Sqlmock, sessionValue string) {
 		WithArgs(sessionValue).
 		WillReturnError(sql.ErrNoRows)
 }
+
+func MockSelectByUserIDReturnRows(mock sqlmock.Sqlmock, userID uint64, sessions []*models.Session) {
+	rows := sqlmock.NewRows([]string{"id", "value", "expires", "user_id",
+		"user_agent", "ip", "created", "remember"})
+	for _, session := range sessions {
+		rows.AddRow(session.ID, session.Value, session.ExpiresAt, session.UserID,
+			session.UserAgent, session.IP, session.CreatedAt, session.Remember)
+	}
+	mock.
+		ExpectQuery(`SELECT`).
+		WithArgs(userID).
+		WillReturnRows(rows)
+}
+
+func MockDeleteByUserIDReturnResultOk(mock sqlmock.Sqlmock, userID uint64, exceptValue string) {
+	mock.ExpectBegin()
+	mock.
+		ExpectExec(`DELETE FROM sessions`).
+		WithArgs(userID, exceptValue).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+}
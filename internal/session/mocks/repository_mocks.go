@@ -5,9 +5,9 @@
 package mocks
 
 import (
-models "github.com/go-park-mail-ru/2020_2_Slash/internal/models"
-gomock "github.com/golang/mock/gomock"
-reflect "reflect"
+	models "github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
 )
 
 // MockSessionRepository is a mock of SessionRepository interface
@@ -76,3 +76,45 @@ func (mr *MockSessionRepositoryMockRecorder) DeleteByValue(sessionValue interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByValue", reflect.TypeOf((*MockSessionRepository)(nil).DeleteByValue), sessionValue)
 }
 
+// UpdateExpires mocks base method
+func (m *MockSessionRepository) UpdateExpires(session *models.Session) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateExpires", session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateExpires indicates an expected call of UpdateExpires
+func (mr *MockSessionRepositoryMockRecorder) UpdateExpires(session interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateExpires", reflect.TypeOf((*MockSessionRepository)(nil).UpdateExpires), session)
+}
+
+// SelectByUserID mocks base method
+func (m *MockSessionRepository) SelectByUserID(userID uint64) ([]*models.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectByUserID", userID)
+	ret0, _ := ret[0].([]*models.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectByUserID indicates an expected call of SelectByUserID
+func (mr *MockSessionRepositoryMockRecorder) SelectByUserID(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectByUserID", reflect.TypeOf((*MockSessionRepository)(nil).SelectByUserID), userID)
+}
+
+// DeleteByUserID mocks base method
+func (m *MockSessionRepository) DeleteByUserID(userID uint64, exceptValue string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUserID", userID, exceptValue)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByUserID indicates an expected call of DeleteByUserID
+func (mr *MockSessionRepositoryMockRecorder) DeleteByUserID(userID, exceptValue interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUserID", reflect.TypeOf((*MockSessionRepository)(nil).DeleteByUserID), userID, exceptValue)
+}
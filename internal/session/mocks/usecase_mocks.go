@@ -91,3 +91,46 @@ func (mr *MockSessionUsecaseMockRecorder) Check(sessValue interface{}) *gomock.C
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockSessionUsecase)(nil).Check), sessValue)
 }
+
+// ListByUser mocks base method
+func (m *MockSessionUsecase) ListByUser(userID uint64) ([]*models.Session, *errors.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUser", userID)
+	ret0, _ := ret[0].([]*models.Session)
+	ret1, _ := ret[1].(*errors.Error)
+	return ret0, ret1
+}
+
+// ListByUser indicates an expected call of ListByUser
+func (mr *MockSessionUsecaseMockRecorder) ListByUser(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUser", reflect.TypeOf((*MockSessionUsecase)(nil).ListByUser), userID)
+}
+
+// DeleteByID mocks base method
+func (m *MockSessionUsecase) DeleteByID(userID, sessionID uint64) *errors.Error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", userID, sessionID)
+	ret0, _ := ret[0].(*errors.Error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID
+func (mr *MockSessionUsecaseMockRecorder) DeleteByID(userID, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockSessionUsecase)(nil).DeleteByID), userID, sessionID)
+}
+
+// DeleteAllForUser mocks base method
+func (m *MockSessionUsecase) DeleteAllForUser(userID uint64, exceptValue string) *errors.Error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAllForUser", userID, exceptValue)
+	ret0, _ := ret[0].(*errors.Error)
+	return ret0
+}
+
+// DeleteAllForUser indicates an expected call of DeleteAllForUser
+func (mr *MockSessionUsecaseMockRecorder) DeleteAllForUser(userID, exceptValue interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAllForUser", reflect.TypeOf((*MockSessionUsecase)(nil).DeleteAllForUser), userID, exceptValue)
+}
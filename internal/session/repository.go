@@ -6,4 +6,7 @@ type SessionRepository interface {
 	Insert(session *models.Session) error
 	SelectByValue(sessValue string) (*models.Session, error)
 	DeleteByValue(sessionValue string) error
+	UpdateExpires(session *models.Session) error
+	SelectByUserID(userID uint64) ([]*models.Session, error)
+	DeleteByUserID(userID uint64, exceptValue string) error
 }
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"database/sql"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/session"
+)
+
+// SessionMemoryRepository keeps sessions in process memory,
+// it is meant for tests and single-node deployments
+type SessionMemoryRepository struct {
+	mu        sync.RWMutex
+	sessions  map[string]models.Session
+	lastID    uint64
+	lastSweep time.Time
+}
+
+func NewSessionMemoryRepository() session.SessionRepository {
+	return &SessionMemoryRepository{
+		sessions:  make(map[string]models.Session),
+		lastSweep: time.Now(),
+	}
+}
+
+func (sr *SessionMemoryRepository) Insert(session *models.Session) error {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(sr.lastSweep) > consts.SessionMemorySweepEvery {
+		sr.sweep(now)
+	}
+
+	sr.lastID++
+	session.ID = sr.lastID
+	sr.sessions[session.Value] = *session
+	return nil
+}
+
+// SelectByValue returns sql.ErrNoRows for expired session as TTL storages do
+func (sr *SessionMemoryRepository) SelectByValue(sessValue string) (*models.Session, error) {
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+
+	sess, ok := sr.sessions[sessValue]
+	if !ok || !sess.ExpiresAt.After(time.Now()) {
+		return nil, sql.ErrNoRows
+	}
+	return &sess, nil
+}
+
+func (sr *SessionMemoryRepository) DeleteByValue(sessionValue string) error {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+
+	delete(sr.sessions, sessionValue)
+	return nil
+}
+
+func (sr *SessionMemoryRepository) UpdateExpires(session *models.Session) error {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+
+	sess, ok := sr.sessions[session.Value]
+	if !ok {
+		return sql.ErrNoRows
+	}
+	sess.ExpiresAt = session.ExpiresAt
+	sr.sessions[session.Value] = sess
+	return nil
+}
+
+func (sr *SessionMemoryRepository) SelectByUserID(userID uint64) ([]*models.Session, error) {
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+
+	now := time.Now()
+	var sessions []*models.Session
+	for _, sess := range sr.sessions {
+		if sess.UserID != userID || !sess.ExpiresAt.After(now) {
+			continue
+		}
+		sess := sess
+		sessions = append(sessions, &sess)
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].ID > sessions[j].ID
+	})
+	return sessions, nil
+}
+
+func (sr *SessionMemoryRepository) DeleteByUserID(userID uint64, exceptValue string) error {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+
+	for value, sess := range sr.sessions {
+		if sess.UserID == userID && value != exceptValue {
+			delete(sr.sessions, value)
+		}
+	}
+	return nil
+}
+
+// sweep removes expired sessions, caller must hold the lock
+func (sr *SessionMemoryRepository) sweep(now time.Time) {
+	for value, sess := range sr.sessions {
+		if !sess.ExpiresAt.After(now) {
+			delete(sr.sessions, value)
+		}
+	}
+	sr.lastSweep = now
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessionMemoryRepository_InsertSelectDelete(t *testing.T) {
+	t.Parallel()
+	sessionMemoryRepository := NewSessionMemoryRepository()
+	session := models.NewSession(3, false)
+
+	err := sessionMemoryRepository.Insert(session)
+	assert.NoError(t, err)
+	assert.NotZero(t, session.ID)
+
+	dbSession, err := sessionMemoryRepository.SelectByValue(session.Value)
+	assert.NoError(t, err)
+	assert.Equal(t, session, dbSession)
+
+	err = sessionMemoryRepository.DeleteByValue(session.Value)
+	assert.NoError(t, err)
+
+	_, err = sessionMemoryRepository.SelectByValue(session.Value)
+	assert.Equal(t, sql.ErrNoRows, err)
+}
+
+func TestSessionMemoryRepository_SelectByValue_Expired(t *testing.T) {
+	t.Parallel()
+	sessionMemoryRepository := NewSessionMemoryRepository()
+	session := models.NewSession(3, false)
+	session.ExpiresAt = time.Now().Add(-time.Second)
+
+	err := sessionMemoryRepository.Insert(session)
+	assert.NoError(t, err)
+
+	_, err = sessionMemoryRepository.SelectByValue(session.Value)
+	assert.Equal(t, sql.ErrNoRows, err)
+}
+
+func TestSessionMemoryRepository_UpdateExpires(t *testing.T) {
+	t.Parallel()
+	sessionMemoryRepository := NewSessionMemoryRepository()
+	session := models.NewSession(3, true)
+
+	err := sessionMemoryRepository.Insert(session)
+	assert.NoError(t, err)
+
+	session.ExpiresAt = session.ExpiresAt.Add(time.Hour)
+	err = sessionMemoryRepository.UpdateExpires(session)
+	assert.NoError(t, err)
+
+	dbSession, err := sessionMemoryRepository.SelectByValue(session.Value)
+	assert.NoError(t, err)
+	assert.Equal(t, session.ExpiresAt, dbSession.ExpiresAt)
+
+	err = sessionMemoryRepository.UpdateExpires(models.NewSession(3, false))
+	assert.Equal(t, sql.ErrNoRows, err)
+}
+
+func TestSessionMemoryRepository_DeleteByUserID(t *testing.T) {
+	t.Parallel()
+	sessionMemoryRepository := NewSessionMemoryRepository()
+	current := models.NewSession(3, false)
+	other := models.NewSession(3, false)
+	stranger := models.NewSession(4, false)
+	for _, session := range []*models.Session{current, other, stranger} {
+		err := sessionMemoryRepository.Insert(session)
+		assert.NoError(t, err)
+	}
+
+	err := sessionMemoryRepository.DeleteByUserID(3, current.Value)
+	assert.NoError(t, err)
+
+	sessions, err := sessionMemoryRepository.SelectByUserID(3)
+	assert.NoError(t, err)
+	assert.Equal(t, []*models.Session{current}, sessions)
+
+	sessions, err = sessionMemoryRepository.SelectByUserID(4)
+	assert.NoError(t, err)
+	assert.Equal(t, []*models.Session{stranger}, sessions)
+}
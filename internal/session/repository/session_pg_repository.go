@@ -25,9 +25,10 @@ func (sr *SessionPgRepository) Insert(session *models.Session) error {
 	}
 
 	err = tx.QueryRow(
-		`INSERT INTO sessions(value, expires, user_id)
-		VALUES ($1, $2, $3) RETURNING id`,
-		session.Value, session.ExpiresAt, session.UserID).Scan(&session.ID)
+		`INSERT INTO sessions(value, expires, user_id, user_agent, ip, created, remember)
+		VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`,
+		session.Value, session.ExpiresAt, session.UserID,
+		session.UserAgent, session.IP, session.CreatedAt, session.Remember).Scan(&session.ID)
 	if err != nil {
 		if rollbackErr := tx.Rollback(); rollbackErr != nil {
 			logger.Error(rollbackErr.Error())
@@ -45,10 +46,11 @@ func (sr *SessionPgRepository) SelectByValue(sessValue string) (*models.Session,
 	sess := &models.Session{}
 
 	row := sr.dbConn.QueryRow(
-		`SELECT id, value, expires, user_id
+		`SELECT id, value, expires, user_id, user_agent, ip, created, remember
 		FROM sessions WHERE value=$1`, sessValue)
 
-	err := row.Scan(&sess.ID, &sess.Value, &sess.ExpiresAt, &sess.UserID)
+	err := row.Scan(&sess.ID, &sess.Value, &sess.ExpiresAt, &sess.UserID,
+		&sess.UserAgent, &sess.IP, &sess.CreatedAt, &sess.Remember)
 	if err != nil {
 		return nil, err
 	}
@@ -76,3 +78,75 @@ func (sr *SessionPgRepository) DeleteByValue(sessionValue string) error {
 	}
 	return nil
 }
+
+func (sr *SessionPgRepository) UpdateExpires(session *models.Session) error {
+	tx, err := sr.dbConn.BeginTx(context.Background(), &sql.TxOptions{})
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(
+		`UPDATE sessions
+		SET expires=$2
+		WHERE value=$1`, session.Value, session.ExpiresAt)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			logger.Error(rollbackErr.Error())
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (sr *SessionPgRepository) SelectByUserID(userID uint64) ([]*models.Session, error) {
+	rows, err := sr.dbConn.Query(
+		`SELECT id, value, expires, user_id, user_agent, ip, created, remember
+		FROM sessions WHERE user_id=$1
+		ORDER BY created DESC, id DESC`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sessions []*models.Session
+	for rows.Next() {
+		sess := &models.Session{}
+		err := rows.Scan(&sess.ID, &sess.Value, &sess.ExpiresAt, &sess.UserID,
+			&sess.UserAgent, &sess.IP, &sess.CreatedAt, &sess.Remember)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, sess)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+func (sr *SessionPgRepository) DeleteByUserID(userID uint64, exceptValue string) error {
+	tx, err := sr.dbConn.BeginTx(context.Background(), &sql.TxOptions{})
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(
+		`DELETE FROM sessions
+		WHERE user_id=$1 AND value<>$2`, userID, exceptValue)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			logger.Error(rollbackErr.Error())
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	return nil
+}
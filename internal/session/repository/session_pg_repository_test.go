@@ -17,7 +17,7 @@ func TestSessionPgRepository_Insert_OK(t *testing.T) {
 	}
 	defer db.Close()
 
-	session := models.NewSession(3)
+	session := models.NewSession(3, false)
 
 	sessionPgRepository := NewSessionPgRepository(db)
 
@@ -38,7 +38,7 @@ func TestSessionPgRepository_Delete_OK(t *testing.T) {
 	}
 	defer db.Close()
 
-	session := models.NewSession(3)
+	session := models.NewSession(3, false)
 
 	sessionPgRepository := NewSessionPgRepository(db)
 
@@ -50,6 +50,27 @@ func TestSessionPgRepository_Delete_OK(t *testing.T) {
 		t.Errorf("there were unfulfilled expectations: %s", err)
 	}
 }
+func TestSessionPgRepository_UpdateExpires_OK(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	session := models.NewSession(3, true)
+
+	sessionPgRepository := NewSessionPgRepository(db)
+
+	mocks.MockUpdateExpiresReturnResultOk(mock, session)
+	err = sessionPgRepository.UpdateExpires(session)
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
 func TestSessionPgRepository_SelectByValue_OK(t *testing.T) {
 	t.Parallel()
 	db, mock, err := sqlmock.New()
@@ -58,7 +79,7 @@ func TestSessionPgRepository_SelectByValue_OK(t *testing.T) {
 	}
 	defer db.Close()
 
-	session := models.NewSession(3)
+	session := models.NewSession(3, false)
 
 	sessionPgRepository := NewSessionPgRepository(db)
 
@@ -80,7 +101,7 @@ func TestSessionPgRepository_SelectByValue_NoRows(t *testing.T) {
 	}
 	defer db.Close()
 
-	session := models.NewSession(3)
+	session := models.NewSession(3, false)
 
 	sessionPgRepository := NewSessionPgRepository(db)
 
@@ -92,4 +113,46 @@ func TestSessionPgRepository_SelectByValue_NoRows(t *testing.T) {
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Errorf("there were unfulfilled expectations: %s", err)
 	}
-}
\ No newline at end of file
+}
+func TestSessionPgRepository_SelectByUserID_OK(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	sessions := []*models.Session{models.NewSession(3, false), models.NewSession(3, false)}
+
+	sessionPgRepository := NewSessionPgRepository(db)
+
+	mocks.MockSelectByUserIDReturnRows(mock, 3, sessions)
+	dbSessions, err := sessionPgRepository.SelectByUserID(3)
+	assert.Equal(t, sessions, dbSessions)
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSessionPgRepository_DeleteByUserID_OK(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	session := models.NewSession(3, false)
+
+	sessionPgRepository := NewSessionPgRepository(db)
+
+	mocks.MockDeleteByUserIDReturnResultOk(mock, session.UserID, session.Value)
+	err = sessionPgRepository.DeleteByUserID(session.UserID, session.Value)
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
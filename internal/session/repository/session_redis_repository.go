@@ -0,0 +1,218 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/session"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
+	"github.com/gomodule/redigo/redis"
+)
+
+// SessionRedisRepository keeps sessions in Redis compatible storage,
+// expired sessions are removed by the key TTL.
+// Values of user sessions are indexed in a set per user
+type SessionRedisRepository struct {
+	pool *redis.Pool
+}
+
+// redisSession is the stored form of the session,
+// models.Session hides some fields from JSON
+type redisSession struct {
+	ID        uint64    `json:"id"`
+	Value     string    `json:"value"`
+	UserID    uint64    `json:"user_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+	UserAgent string    `json:"user_agent"`
+	IP        string    `json:"ip"`
+	CreatedAt time.Time `json:"created_at"`
+	Remember  bool      `json:"remember"`
+}
+
+func NewSessionRedisRepository(pool *redis.Pool) session.SessionRepository {
+	return &SessionRedisRepository{
+		pool: pool,
+	}
+}
+
+func (sr *SessionRedisRepository) Insert(session *models.Session) error {
+	conn := sr.pool.Get()
+	defer closeConn(conn)
+
+	id, err := redis.Uint64(conn.Do("INCR", consts.SessionRedisIDKey))
+	if err != nil {
+		return err
+	}
+	session.ID = id
+
+	return storeSession(conn, session)
+}
+
+func (sr *SessionRedisRepository) SelectByValue(sessValue string) (*models.Session, error) {
+	conn := sr.pool.Get()
+	defer closeConn(conn)
+
+	return selectSession(conn, sessValue)
+}
+
+func (sr *SessionRedisRepository) DeleteByValue(sessionValue string) error {
+	conn := sr.pool.Get()
+	defer closeConn(conn)
+
+	sess, err := selectSession(conn, sessionValue)
+	if err == sql.ErrNoRows {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	return deleteSession(conn, sess.UserID, sessionValue)
+}
+
+func (sr *SessionRedisRepository) UpdateExpires(session *models.Session) error {
+	conn := sr.pool.Get()
+	defer closeConn(conn)
+
+	return storeSession(conn, session)
+}
+
+func (sr *SessionRedisRepository) SelectByUserID(userID uint64) ([]*models.Session, error) {
+	conn := sr.pool.Get()
+	defer closeConn(conn)
+
+	values, err := redis.Strings(conn.Do("SMEMBERS", userSessionsKey(userID)))
+	if err != nil {
+		return nil, err
+	}
+
+	var sessions []*models.Session
+	for _, value := range values {
+		sess, err := selectSession(conn, value)
+		if err == sql.ErrNoRows {
+			// Session has expired, drop it from the index
+			if _, err := conn.Do("SREM", userSessionsKey(userID), value); err != nil {
+				return nil, err
+			}
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, sess)
+	}
+	return sessions, nil
+}
+
+func (sr *SessionRedisRepository) DeleteByUserID(userID uint64, exceptValue string) error {
+	conn := sr.pool.Get()
+	defer closeConn(conn)
+
+	values, err := redis.Strings(conn.Do("SMEMBERS", userSessionsKey(userID)))
+	if err != nil {
+		return err
+	}
+	for _, value := range values {
+		if value == exceptValue {
+			continue
+		}
+		if err := deleteSession(conn, userID, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// storeSession writes the session with TTL up to its expiry
+// and keeps the user index alive at least as long as the session
+func storeSession(conn redis.Conn, session *models.Session) error {
+	ttl := time.Until(session.ExpiresAt).Milliseconds()
+	if ttl <= 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(newRedisSession(session))
+	if err != nil {
+		return err
+	}
+	if _, err := conn.Do("SET", sessionKey(session.Value), data, "PX", ttl); err != nil {
+		return err
+	}
+	userKey := userSessionsKey(session.UserID)
+	if _, err := conn.Do("SADD", userKey, session.Value); err != nil {
+		return err
+	}
+	userTTL, err := redis.Int64(conn.Do("PTTL", userKey))
+	if err != nil {
+		return err
+	}
+	if userTTL >= ttl {
+		return nil
+	}
+	_, err = conn.Do("PEXPIRE", userKey, ttl)
+	return err
+}
+
+func selectSession(conn redis.Conn, sessValue string) (*models.Session, error) {
+	data, err := redis.Bytes(conn.Do("GET", sessionKey(sessValue)))
+	if err == redis.ErrNil {
+		return nil, sql.ErrNoRows
+	} else if err != nil {
+		return nil, err
+	}
+
+	stored := &redisSession{}
+	if err := json.Unmarshal(data, stored); err != nil {
+		return nil, err
+	}
+	return stored.toModel(), nil
+}
+
+func deleteSession(conn redis.Conn, userID uint64, sessValue string) error {
+	if _, err := conn.Do("DEL", sessionKey(sessValue)); err != nil {
+		return err
+	}
+	_, err := conn.Do("SREM", userSessionsKey(userID), sessValue)
+	return err
+}
+
+func newRedisSession(sess *models.Session) *redisSession {
+	return &redisSession{
+		ID:        sess.ID,
+		Value:     sess.Value,
+		UserID:    sess.UserID,
+		ExpiresAt: sess.ExpiresAt,
+		UserAgent: sess.UserAgent,
+		IP:        sess.IP,
+		CreatedAt: sess.CreatedAt,
+		Remember:  sess.Remember,
+	}
+}
+
+func (rs *redisSession) toModel() *models.Session {
+	return &models.Session{
+		ID:        rs.ID,
+		Value:     rs.Value,
+		UserID:    rs.UserID,
+		ExpiresAt: rs.ExpiresAt,
+		UserAgent: rs.UserAgent,
+		IP:        rs.IP,
+		CreatedAt: rs.CreatedAt,
+		Remember:  rs.Remember,
+	}
+}
+
+func sessionKey(sessValue string) string {
+	return consts.SessionRedisKeyPrefix + sessValue
+}
+
+func userSessionsKey(userID uint64) string {
+	return consts.SessionRedisUserKeyPrefix + strconv.FormatUint(userID, 10)
+}
+
+func closeConn(conn redis.Conn) {
+	if err := conn.Close(); err != nil {
+		logger.Error(err)
+	}
+}
@@ -0,0 +1,224 @@
+package repository
+
+import (
+	"bufio"
+	"database/sql"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeRedis serves the commands used by the repository over RESP
+type fakeRedis struct {
+	mu      sync.Mutex
+	values  map[string]string
+	sets    map[string]map[string]bool
+	ttls    map[string]int64
+	counter int64
+}
+
+func (fr *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	for {
+		args, err := readCommand(reader)
+		if err != nil {
+			return
+		}
+		if _, err := conn.Write([]byte(fr.exec(args))); err != nil {
+			return
+		}
+	}
+}
+
+func (fr *fakeRedis) exec(args []string) string {
+	fr.mu.Lock()
+	defer fr.mu.Unlock()
+
+	switch strings.ToUpper(args[0]) {
+	case "INCR":
+		fr.counter++
+		return fmt.Sprintf(":%d\r\n", fr.counter)
+	case "SET":
+		fr.values[args[1]] = args[2]
+		return "+OK\r\n"
+	case "GET":
+		value, ok := fr.values[args[1]]
+		if !ok {
+			return "$-1\r\n"
+		}
+		return fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+	case "DEL":
+		_, ok := fr.values[args[1]]
+		delete(fr.values, args[1])
+		if ok {
+			return ":1\r\n"
+		}
+		return ":0\r\n"
+	case "SADD":
+		if fr.sets[args[1]] == nil {
+			fr.sets[args[1]] = make(map[string]bool)
+		}
+		fr.sets[args[1]][args[2]] = true
+		return ":1\r\n"
+	case "SREM":
+		delete(fr.sets[args[1]], args[2])
+		return ":1\r\n"
+	case "SMEMBERS":
+		reply := fmt.Sprintf("*%d\r\n", len(fr.sets[args[1]]))
+		for member := range fr.sets[args[1]] {
+			reply += fmt.Sprintf("$%d\r\n%s\r\n", len(member), member)
+		}
+		return reply
+	case "PEXPIRE":
+		ttl, err := strconv.ParseInt(args[2], 10, 64)
+		if err != nil {
+			return "-ERR value is not an integer\r\n"
+		}
+		fr.ttls[args[1]] = ttl
+		return ":1\r\n"
+	case "PTTL":
+		ttl, ok := fr.ttls[args[1]]
+		if !ok {
+			return ":-1\r\n"
+		}
+		return fmt.Sprintf(":%d\r\n", ttl)
+	}
+	return "-ERR unknown command\r\n"
+}
+
+func readCommand(reader *bufio.Reader) ([]string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		if _, err := reader.ReadString('\n'); err != nil {
+			return nil, err
+		}
+		arg, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, strings.TrimSuffix(arg, "\r\n"))
+	}
+	return args, nil
+}
+
+func newFakeRedis() *fakeRedis {
+	return &fakeRedis{
+		values: make(map[string]string),
+		sets:   make(map[string]map[string]bool),
+		ttls:   make(map[string]int64),
+	}
+}
+
+func newFakeRedisPool() *redis.Pool {
+	return newFakeRedisPoolFor(newFakeRedis())
+}
+
+func newFakeRedisPoolFor(server *fakeRedis) *redis.Pool {
+	return &redis.Pool{
+		Dial: func() (redis.Conn, error) {
+			client, serverConn := net.Pipe()
+			go server.serve(serverConn)
+			return redis.NewConn(client, 0, 0), nil
+		},
+	}
+}
+
+func TestSessionRedisRepository_InsertSelectDelete(t *testing.T) {
+	t.Parallel()
+	pool := newFakeRedisPool()
+	defer pool.Close()
+
+	sessionRedisRepository := NewSessionRedisRepository(pool)
+	session := models.NewSession(3, false)
+
+	err := sessionRedisRepository.Insert(session)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), session.ID)
+
+	dbSession, err := sessionRedisRepository.SelectByValue(session.Value)
+	assert.NoError(t, err)
+	assert.Equal(t, session.UserID, dbSession.UserID)
+	assert.True(t, session.ExpiresAt.Equal(dbSession.ExpiresAt))
+
+	err = sessionRedisRepository.DeleteByValue(session.Value)
+	assert.NoError(t, err)
+
+	_, err = sessionRedisRepository.SelectByValue(session.Value)
+	assert.Equal(t, sql.ErrNoRows, err)
+}
+
+func TestSessionRedisRepository_DeleteByUserID(t *testing.T) {
+	t.Parallel()
+	pool := newFakeRedisPool()
+	defer pool.Close()
+
+	sessionRedisRepository := NewSessionRedisRepository(pool)
+	current := models.NewSession(3, false)
+	other := models.NewSession(3, false)
+	for _, session := range []*models.Session{current, other} {
+		err := sessionRedisRepository.Insert(session)
+		assert.NoError(t, err)
+	}
+
+	sessions, err := sessionRedisRepository.SelectByUserID(3)
+	assert.NoError(t, err)
+	assert.Len(t, sessions, 2)
+
+	err = sessionRedisRepository.DeleteByUserID(3, current.Value)
+	assert.NoError(t, err)
+
+	sessions, err = sessionRedisRepository.SelectByUserID(3)
+	assert.NoError(t, err)
+	if assert.Len(t, sessions, 1) {
+		assert.Equal(t, current.Value, sessions[0].Value)
+	}
+}
+
+func TestSessionRedisRepository_UpdateExpires(t *testing.T) {
+	t.Parallel()
+	server := newFakeRedis()
+	pool := newFakeRedisPoolFor(server)
+	defer pool.Close()
+
+	sessionRedisRepository := NewSessionRedisRepository(pool)
+	remembered := models.NewSession(3, true)
+	short := models.NewSession(3, false)
+	for _, session := range []*models.Session{remembered, short} {
+		err := sessionRedisRepository.Insert(session)
+		assert.NoError(t, err)
+	}
+	// Short session must not cut the index of the remembered one
+	assert.Greater(t, server.ttls[userSessionsKey(3)],
+		short.Lifetime().Milliseconds())
+
+	short.ExpiresAt = short.ExpiresAt.Add(time.Hour)
+	err := sessionRedisRepository.UpdateExpires(short)
+	assert.NoError(t, err)
+
+	dbSession, err := sessionRedisRepository.SelectByValue(short.Value)
+	assert.NoError(t, err)
+	assert.True(t, short.ExpiresAt.Equal(dbSession.ExpiresAt))
+	assert.False(t, dbSession.Remember)
+
+	dbSession, err = sessionRedisRepository.SelectByValue(remembered.Value)
+	assert.NoError(t, err)
+	assert.True(t, dbSession.Remember)
+}
@@ -10,4 +10,7 @@ type SessionUsecase interface {
 	Get(sessValue string) (*models.Session, *errors.Error)
 	Delete(sessionValue string) *errors.Error
 	Check(sessValue string) (*models.Session, *errors.Error)
+	ListByUser(userID uint64) ([]*models.Session, *errors.Error)
+	DeleteByID(userID, sessionID uint64) *errors.Error
+	DeleteAllForUser(userID uint64, exceptValue string) *errors.Error
 }
@@ -3,6 +3,7 @@ package usecases
 import (
 	"context"
 
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/session"
@@ -54,3 +55,42 @@ func (su *SessionUsecase) Check(sessValue string) (*models.Session, *errors.Erro
 	}
 	return sessGRPC.GrpcSessionToModel(sess), nil
 }
+
+func (su *SessionUsecase) ListByUser(userID uint64) ([]*models.Session, *errors.Error) {
+	sessions, err := su.sessBlockClient.ListByUser(context.Background(), &sessGRPC.UserID{ID: userID})
+	if err != nil {
+		customErr := errors.GetCustomErrFromStatus(err)
+		return nil, customErr
+	}
+
+	modelSessions := sessGRPC.GrpcSessionsToModels(sessions)
+	if len(modelSessions) == 0 {
+		return []*models.Session{}, nil
+	}
+	return modelSessions, nil
+}
+
+// DeleteByID deletes session of the user, sessions of others are not visible
+func (su *SessionUsecase) DeleteByID(userID, sessionID uint64) *errors.Error {
+	sessions, customErr := su.ListByUser(userID)
+	if customErr != nil {
+		return customErr
+	}
+
+	for _, sess := range sessions {
+		if sess.ID == sessionID {
+			return su.Delete(sess.Value)
+		}
+	}
+	return errors.Get(consts.CodeSessionDoesNotExist)
+}
+
+func (su *SessionUsecase) DeleteAllForUser(userID uint64, exceptValue string) *errors.Error {
+	_, err := su.sessBlockClient.DeleteAllForUser(context.Background(),
+		&sessGRPC.UserSession{UserID: userID, Value: exceptValue})
+	if err != nil {
+		customErr := errors.GetCustomErrFromStatus(err)
+		return customErr
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
 	sessGRPC "github.com/go-park-mail-ru/2020_2_Slash/internal/session/delivery/grpc"
@@ -13,7 +14,7 @@ import (
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
-var sessModel = models.NewSession(3)
+var sessModel = models.NewSession(3, false)
 var sess = sessGRPC.ModelSessionToGrpc(sessModel)
 
 func TestSessionUseCase_Create_OK(t *testing.T) {
@@ -85,3 +86,45 @@ func TestSessionUsecase_Check_OK(t *testing.T) {
 	assert.Equal(t, err, (*errors.Error)(nil))
 	assert.Equal(t, dbSession.Value, sessModel.Value)
 }
+
+func TestSessionUseCase_DeleteByID_OK(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sessionClient := sessMocks.NewMockSessionBlockClient(ctrl)
+	sessionUseCase := NewSessionUsecase(sessionClient)
+
+	otherSess := sessGRPC.ModelSessionToGrpc(models.NewSession(3, false))
+	otherSess.ID = 7
+
+	sessionClient.
+		EXPECT().
+		ListByUser(context.Background(), &sessGRPC.UserID{ID: 3}).
+		Return(&sessGRPC.Sessions{Sessions: []*sessGRPC.Session{sess, otherSess}}, nil)
+
+	sessionClient.
+		EXPECT().
+		Delete(context.Background(), &sessGRPC.SessionValue{Value: otherSess.Value}).
+		Return(&emptypb.Empty{}, nil)
+
+	err := sessionUseCase.DeleteByID(3, otherSess.ID)
+	assert.Equal(t, err, (*errors.Error)(nil))
+}
+
+func TestSessionUseCase_DeleteByID_DoesNotExist(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sessionClient := sessMocks.NewMockSessionBlockClient(ctrl)
+	sessionUseCase := NewSessionUsecase(sessionClient)
+
+	sessionClient.
+		EXPECT().
+		ListByUser(context.Background(), &sessGRPC.UserID{ID: 3}).
+		Return(&sessGRPC.Sessions{}, nil)
+
+	err := sessionUseCase.DeleteByID(3, 7)
+	assert.Equal(t, errors.Get(consts.CodeSessionDoesNotExist), err)
+}
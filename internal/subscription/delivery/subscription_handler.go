@@ -2,25 +2,33 @@ package delivery
 
 import (
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
-	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/mwares"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/payment"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/subscription"
 	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
 	"github.com/go-park-mail-ru/2020_2_Slash/tools/response"
 	"github.com/labstack/echo/v4"
 	"net/http"
-	"strconv"
-	"time"
+
+	reader "github.com/go-park-mail-ru/2020_2_Slash/tools/request_reader"
 )
 
 type SubscriptionHandler struct {
-	subUseCase subscription.SubscriptionUseCase
+	subUseCase      subscription.SubscriptionUseCase
+	paymentUseCase  payment.PaymentUsecase
+	paymentProvider payment.PaymentProvider
 }
 
-func NewSubscriptionHandler(uc subscription.SubscriptionUseCase) *SubscriptionHandler {
-	return &SubscriptionHandler{subUseCase: uc}
+func NewSubscriptionHandler(uc subscription.SubscriptionUseCase,
+	paymentUseCase payment.PaymentUsecase,
+	paymentProvider payment.PaymentProvider) *SubscriptionHandler {
+	return &SubscriptionHandler{
+		subUseCase:      uc,
+		paymentUseCase:  paymentUseCase,
+		paymentProvider: paymentProvider,
+	}
 }
 
 func (sh *SubscriptionHandler) Configure(e *echo.Echo, mw *mwares.MiddlewareManager) {
@@ -28,32 +36,31 @@ func (sh *SubscriptionHandler) Configure(e *echo.Echo, mw *mwares.MiddlewareMana
 	e.PUT("/api/v1/subscription", sh.RecoverSubscriptionHandler(), mw.CheckAuth, mw.CheckCSRF)
 	e.GET("/api/v1/subscription", sh.GetSubscriptionHandler(), mw.CheckAuth, mw.CheckCSRF)
 	e.DELETE("/api/v1/subscription", sh.DeleteSubscriptionHandler(), mw.CheckAuth, mw.CheckCSRF)
+	e.GET("/api/v1/subscription/history", sh.GetHistoryHandler(), mw.CheckAuth)
 }
 
 func (sh *SubscriptionHandler) CreateSubscriptionHandler() echo.HandlerFunc {
 	return func(cntx echo.Context) error {
-		paymentData := helpers.NewPaymentDataFromContext(cntx)
-		customErr := paymentData.CheckPaymentData()
-		if customErr != nil {
+		form, err := cntx.FormParams()
+		if err != nil {
+			customErr := errors.New(consts.CodeBadRequest, err)
 			logger.Error(customErr.Message)
 			return cntx.JSON(customErr.HTTPCode, response.Response{Error: customErr})
 		}
 
-		userID, err := strconv.ParseUint(paymentData.Label, 10, 64)
-		if err != nil {
-			customErr := errors.Get(consts.CodeParseUserIDError)
+		customErr := sh.paymentProvider.VerifyNotification(form)
+		if customErr != nil {
 			logger.Error(customErr.Message)
 			return cntx.JSON(customErr.HTTPCode, response.Response{Error: customErr})
 		}
 
-		subscription := &models.Subscription{
-			UserID:     userID,
-			Expires:    time.Now().AddDate(0, 1, 0),
-			IsPaid:     true,
-			IsCanceled: false,
+		payment, customErr := sh.paymentProvider.ParseNotification(form)
+		if customErr != nil {
+			logger.Error(customErr.Message)
+			return cntx.JSON(customErr.HTTPCode, response.Response{Error: customErr})
 		}
 
-		createdSubscription, customErr := sh.subUseCase.Create(subscription)
+		createdSubscription, customErr := sh.paymentUseCase.Process(payment)
 		if customErr != nil {
 			logger.Error(customErr.Message)
 			return cntx.JSON(customErr.HTTPCode, response.Response{Error: customErr})
@@ -136,3 +143,36 @@ func (sh *SubscriptionHandler) DeleteSubscriptionHandler() echo.HandlerFunc {
 			})
 	}
 }
+
+func (sh *SubscriptionHandler) GetHistoryHandler() echo.HandlerFunc {
+	type Request struct {
+		models.Pagination
+	}
+
+	return func(cntx echo.Context) error {
+		req := &Request{}
+		if err := reader.NewRequestReader(cntx).Read(req); err != nil {
+			logger.Error(err.Message)
+			return cntx.JSON(err.HTTPCode, response.Response{Error: err})
+		}
+
+		userID, ok := cntx.Get("userID").(uint64)
+		if !ok {
+			customErr := errors.Get(consts.CodeGetFromContextError)
+			logger.Error(customErr.Message)
+			return cntx.JSON(customErr.HTTPCode, response.Response{Error: customErr})
+		}
+
+		history, customErr := sh.subUseCase.ListHistory(userID, &req.Pagination)
+		if customErr != nil {
+			logger.Error(customErr.Message)
+			return cntx.JSON(customErr.HTTPCode, response.Response{Error: customErr})
+		}
+
+		return cntx.JSON(http.StatusOK, response.Response{
+			Body: &response.Body{
+				"history": history,
+			},
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package delivery
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	paymentMocks "github.com/go-park-mail-ru/2020_2_Slash/internal/payment/mocks"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/payment/providers"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/subscription/mocks"
+	"github.com/go-park-mail-ru/2020_2_Slash/pkg/converter"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/response"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+const paymentSecret = "secret"
+
+var plan = &models.Plan{
+	ID:             2,
+	Name:           "Месяц",
+	Price:          299,
+	Currency:       "643",
+	DurationMonths: 1,
+}
+
+func TestSubscriptionHandler_CreateSubscriptionHandler(t *testing.T) {
+	t.Parallel()
+	// Setup
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	subUseCase := mocks.NewMockSubscriptionUseCase(ctrl)
+	paymentUseCase := paymentMocks.NewMockPaymentUsecase(ctrl)
+
+	var userID uint64 = 3
+	planID := plan.ID
+	subscription := &models.Subscription{
+		ID:      1,
+		UserID:  userID,
+		PlanID:  &planID,
+		Expires: time.Now().AddDate(0, plan.DurationMonths, 0),
+		IsPaid:  true,
+	}
+	form := providers.NewFakeNotification(paymentSecret, "1234567", userID, plan)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/subscription", strings.NewReader(form.Encode()))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	subscriptionHandler := NewSubscriptionHandler(subUseCase, paymentUseCase,
+		providers.NewFakeProvider(paymentSecret))
+	handleFunc := subscriptionHandler.CreateSubscriptionHandler()
+
+	paymentUseCase.
+		EXPECT().
+		Process(gomock.Eq(&models.Payment{
+			OperationID: "1234567",
+			UserID:      userID,
+			PlanID:      plan.ID,
+			Amount:      plan.Price,
+			Currency:    plan.Currency,
+			Status:      consts.PaymentPending,
+		})).
+		Return(subscription, nil)
+
+	response := &response.Response{Body: &response.Body{"subscription": subscription}}
+
+	// Assertions
+	if assert.NoError(t, handleFunc(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		expResBody, err := converter.AnyToBytesBuffer(response)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		bytes, _ := ioutil.ReadAll(rec.Body)
+
+		assert.JSONEq(t, expResBody.String(), string(bytes))
+	}
+}
+
+func TestSubscriptionHandler_CreateSubscriptionHandler_WrongSecret(t *testing.T) {
+	t.Parallel()
+	// Setup
+	logger.DisableLogger()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	subUseCase := mocks.NewMockSubscriptionUseCase(ctrl)
+	paymentUseCase := paymentMocks.NewMockPaymentUsecase(ctrl)
+
+	form := providers.NewFakeNotification("wrong", "1234567", 3, plan)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/subscription", strings.NewReader(form.Encode()))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	subscriptionHandler := NewSubscriptionHandler(subUseCase, paymentUseCase,
+		providers.NewFakeProvider(paymentSecret))
+	handleFunc := subscriptionHandler.CreateSubscriptionHandler()
+
+	customErr := errors.Get(consts.CodeWrongPaymentHash)
+	response := &response.Response{Error: customErr}
+
+	// Assertions
+	if assert.NoError(t, handleFunc(c)) {
+		assert.Equal(t, customErr.HTTPCode, rec.Code)
+
+		expResBody, err := converter.AnyToBytesBuffer(response)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		bytes, _ := ioutil.ReadAll(rec.Body)
+
+		assert.JSONEq(t, expResBody.String(), string(bytes))
+	}
+}
@@ -0,0 +1,138 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/subscription/repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	subscription "github.com/go-park-mail-ru/2020_2_Slash/internal/subscription"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+	time "time"
+)
+
+// MockSubscriptionRepository is a mock of SubscriptionRepository interface
+type MockSubscriptionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubscriptionRepositoryMockRecorder
+}
+
+// MockSubscriptionRepositoryMockRecorder is the mock recorder for MockSubscriptionRepository
+type MockSubscriptionRepositoryMockRecorder struct {
+	mock *MockSubscriptionRepository
+}
+
+// NewMockSubscriptionRepository creates a new mock instance
+func NewMockSubscriptionRepository(ctrl *gomock.Controller) *MockSubscriptionRepository {
+	mock := &MockSubscriptionRepository{ctrl: ctrl}
+	mock.recorder = &MockSubscriptionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockSubscriptionRepository) EXPECT() *MockSubscriptionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Insert mocks base method
+func (m *MockSubscriptionRepository) Insert(subscription *models.Subscription, event string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", subscription, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert
+func (mr *MockSubscriptionRepositoryMockRecorder) Insert(subscription, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockSubscriptionRepository)(nil).Insert), subscription, event)
+}
+
+// Update mocks base method
+func (m *MockSubscriptionRepository) Update(subscription *models.Subscription, event string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", subscription, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update
+func (mr *MockSubscriptionRepositoryMockRecorder) Update(subscription, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSubscriptionRepository)(nil).Update), subscription, event)
+}
+
+// Renew mocks base method
+func (m *MockSubscriptionRepository) Renew(userID uint64, payment *models.Payment, renew subscription.RenewFunc) (*models.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Renew", userID, payment, renew)
+	ret0, _ := ret[0].(*models.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Renew indicates an expected call of Renew
+func (mr *MockSubscriptionRepositoryMockRecorder) Renew(userID, payment, renew interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Renew", reflect.TypeOf((*MockSubscriptionRepository)(nil).Renew), userID, payment, renew)
+}
+
+// SelectByUserID mocks base method
+func (m *MockSubscriptionRepository) SelectByUserID(userID uint64) (*models.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectByUserID", userID)
+	ret0, _ := ret[0].(*models.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectByUserID indicates an expected call of SelectByUserID
+func (mr *MockSubscriptionRepositoryMockRecorder) SelectByUserID(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectByUserID", reflect.TypeOf((*MockSubscriptionRepository)(nil).SelectByUserID), userID)
+}
+
+// SelectOverdue mocks base method
+func (m *MockSubscriptionRepository) SelectOverdue(now time.Time) ([]*models.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectOverdue", now)
+	ret0, _ := ret[0].([]*models.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectOverdue indicates an expected call of SelectOverdue
+func (mr *MockSubscriptionRepositoryMockRecorder) SelectOverdue(now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectOverdue", reflect.TypeOf((*MockSubscriptionRepository)(nil).SelectOverdue), now)
+}
+
+// Delete mocks base method
+func (m *MockSubscriptionRepository) Delete(subscription *models.Subscription, event string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", subscription, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete
+func (mr *MockSubscriptionRepositoryMockRecorder) Delete(subscription, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSubscriptionRepository)(nil).Delete), subscription, event)
+}
+
+// SelectEventsByUserID mocks base method
+func (m *MockSubscriptionRepository) SelectEventsByUserID(userID uint64, pgnt *models.Pagination) ([]*models.SubscriptionEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectEventsByUserID", userID, pgnt)
+	ret0, _ := ret[0].([]*models.SubscriptionEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectEventsByUserID indicates an expected call of SelectEventsByUserID
+func (mr *MockSubscriptionRepositoryMockRecorder) SelectEventsByUserID(userID, pgnt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectEventsByUserID", reflect.TypeOf((*MockSubscriptionRepository)(nil).SelectEventsByUserID), userID, pgnt)
+}
@@ -0,0 +1,138 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/subscription/usecase.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	errors "github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	models "github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockSubscriptionUseCase is a mock of SubscriptionUseCase interface
+type MockSubscriptionUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubscriptionUseCaseMockRecorder
+}
+
+// MockSubscriptionUseCaseMockRecorder is the mock recorder for MockSubscriptionUseCase
+type MockSubscriptionUseCaseMockRecorder struct {
+	mock *MockSubscriptionUseCase
+}
+
+// NewMockSubscriptionUseCase creates a new mock instance
+func NewMockSubscriptionUseCase(ctrl *gomock.Controller) *MockSubscriptionUseCase {
+	mock := &MockSubscriptionUseCase{ctrl: ctrl}
+	mock.recorder = &MockSubscriptionUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockSubscriptionUseCase) EXPECT() *MockSubscriptionUseCaseMockRecorder {
+	return m.recorder
+}
+
+// StartTrial mocks base method
+func (m *MockSubscriptionUseCase) StartTrial(userID uint64, plan *models.Plan) *errors.Error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartTrial", userID, plan)
+	ret0, _ := ret[0].(*errors.Error)
+	return ret0
+}
+
+// StartTrial indicates an expected call of StartTrial
+func (mr *MockSubscriptionUseCaseMockRecorder) StartTrial(userID, plan interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartTrial", reflect.TypeOf((*MockSubscriptionUseCase)(nil).StartTrial), userID, plan)
+}
+
+// Renew mocks base method
+func (m *MockSubscriptionUseCase) Renew(payment *models.Payment, plan *models.Plan) (*models.Subscription, *errors.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Renew", payment, plan)
+	ret0, _ := ret[0].(*models.Subscription)
+	ret1, _ := ret[1].(*errors.Error)
+	return ret0, ret1
+}
+
+// Renew indicates an expected call of Renew
+func (mr *MockSubscriptionUseCaseMockRecorder) Renew(payment, plan interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Renew", reflect.TypeOf((*MockSubscriptionUseCase)(nil).Renew), payment, plan)
+}
+
+// RecoverSubscriptionByUserID mocks base method
+func (m *MockSubscriptionUseCase) RecoverSubscriptionByUserID(userID uint64) (*models.Subscription, *errors.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecoverSubscriptionByUserID", userID)
+	ret0, _ := ret[0].(*models.Subscription)
+	ret1, _ := ret[1].(*errors.Error)
+	return ret0, ret1
+}
+
+// RecoverSubscriptionByUserID indicates an expected call of RecoverSubscriptionByUserID
+func (mr *MockSubscriptionUseCaseMockRecorder) RecoverSubscriptionByUserID(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecoverSubscriptionByUserID", reflect.TypeOf((*MockSubscriptionUseCase)(nil).RecoverSubscriptionByUserID), userID)
+}
+
+// GetByUserID mocks base method
+func (m *MockSubscriptionUseCase) GetByUserID(userID uint64) (*models.Subscription, *errors.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserID", userID)
+	ret0, _ := ret[0].(*models.Subscription)
+	ret1, _ := ret[1].(*errors.Error)
+	return ret0, ret1
+}
+
+// GetByUserID indicates an expected call of GetByUserID
+func (mr *MockSubscriptionUseCaseMockRecorder) GetByUserID(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockSubscriptionUseCase)(nil).GetByUserID), userID)
+}
+
+// DeleteByUserID mocks base method
+func (m *MockSubscriptionUseCase) DeleteByUserID(userID uint64) (*models.Subscription, *errors.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUserID", userID)
+	ret0, _ := ret[0].(*models.Subscription)
+	ret1, _ := ret[1].(*errors.Error)
+	return ret0, ret1
+}
+
+// DeleteByUserID indicates an expected call of DeleteByUserID
+func (mr *MockSubscriptionUseCaseMockRecorder) DeleteByUserID(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUserID", reflect.TypeOf((*MockSubscriptionUseCase)(nil).DeleteByUserID), userID)
+}
+
+// ExpireOverdue mocks base method
+func (m *MockSubscriptionUseCase) ExpireOverdue() *errors.Error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireOverdue")
+	ret0, _ := ret[0].(*errors.Error)
+	return ret0
+}
+
+// ExpireOverdue indicates an expected call of ExpireOverdue
+func (mr *MockSubscriptionUseCaseMockRecorder) ExpireOverdue() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireOverdue", reflect.TypeOf((*MockSubscriptionUseCase)(nil).ExpireOverdue))
+}
+
+// ListHistory mocks base method
+func (m *MockSubscriptionUseCase) ListHistory(userID uint64, pgnt *models.Pagination) ([]*models.SubscriptionEvent, *errors.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHistory", userID, pgnt)
+	ret0, _ := ret[0].([]*models.SubscriptionEvent)
+	ret1, _ := ret[1].(*errors.Error)
+	return ret0, ret1
+}
+
+// ListHistory indicates an expected call of ListHistory
+func (mr *MockSubscriptionUseCaseMockRecorder) ListHistory(userID, pgnt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHistory", reflect.TypeOf((*MockSubscriptionUseCase)(nil).ListHistory), userID, pgnt)
+}
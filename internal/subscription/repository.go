@@ -1,10 +1,24 @@
 package subscription
 
-import "github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+import (
+	"time"
 
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+)
+
+// RenewFunc returns subscription to store in place of the current one
+// and the event of the change, current is nil if user has no subscription
+type RenewFunc func(current *models.Subscription) (*models.Subscription, string, error)
+
+// Every change of subscription is stored with the event into history
 type SubscriptionRepository interface {
-	Insert(subscription *models.Subscription) error
-	Update(subscription *models.Subscription) error
+	Insert(subscription *models.Subscription, event string) error
+	Update(subscription *models.Subscription, event string) error
+	// Renew claims pending payment in the same transaction if it is set,
+	// sql.ErrNoRows is returned if the payment is already claimed
+	Renew(userID uint64, payment *models.Payment, renew RenewFunc) (*models.Subscription, error)
 	SelectByUserID(userID uint64) (*models.Subscription, error)
-	DeleteByID(id uint64) error
+	SelectOverdue(now time.Time) ([]*models.Subscription, error)
+	Delete(subscription *models.Subscription, event string) error
+	SelectEventsByUserID(userID uint64, pgnt *models.Pagination) ([]*models.SubscriptionEvent, error)
 }
@@ -3,6 +3,10 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/subscription"
 	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
@@ -16,19 +20,16 @@ func NewSubscriptionPgRepository(db *sql.DB) subscription.SubscriptionRepository
 	return &SubscriptionPgRepository{db: db}
 }
 
-func (rep *SubscriptionPgRepository) Insert(subscription *models.Subscription) error {
+func (rep *SubscriptionPgRepository) Insert(subscription *models.Subscription, event string) error {
 	tx, err := rep.db.BeginTx(context.Background(), &sql.TxOptions{})
 	if err != nil {
 		return err
 	}
 
-	err = tx.QueryRow(`
-		INSERT INTO subscriptions(owner, expires, is_paid, is_canceled)
-		VALUES ($1, $2, $3, $4)
-		RETURNING id`,
-		subscription.UserID, subscription.Expires,
-		subscription.IsPaid, subscription.IsCanceled).
-		Scan(&subscription.ID)
+	err = insertSubscription(tx, subscription)
+	if err == nil {
+		err = insertEvent(tx, subscription, event)
+	}
 	if err != nil {
 		if rollbackErr := tx.Rollback(); rollbackErr != nil {
 			logger.Error(rollbackErr)
@@ -42,20 +43,16 @@ func (rep *SubscriptionPgRepository) Insert(subscription *models.Subscription) e
 	return nil
 }
 
-func (rep *SubscriptionPgRepository) Update(subscription *models.Subscription) error {
+func (rep *SubscriptionPgRepository) Update(subscription *models.Subscription, event string) error {
 	tx, err := rep.db.BeginTx(context.Background(), &sql.TxOptions{})
 	if err != nil {
 		return err
 	}
 
-	_, err = tx.Exec(`
-		UPDATE subscriptions
-		SET expires = $2,
-		    is_paid = $3,
-		    is_canceled = $4
-		WHERE id = $1`,
-		subscription.ID, subscription.Expires,
-		subscription.IsPaid, subscription.IsCanceled)
+	err = updateSubscription(tx, subscription)
+	if err == nil {
+		err = insertEvent(tx, subscription, event)
+	}
 	if err != nil {
 		if rollbackErr := tx.Rollback(); rollbackErr != nil {
 			logger.Error(rollbackErr)
@@ -69,22 +66,80 @@ func (rep *SubscriptionPgRepository) Update(subscription *models.Subscription) e
 	return nil
 }
 
+// Renew stores subscription returned by renew in place of the current one
+// of the user, current subscription is locked until the change is committed
+func (rep *SubscriptionPgRepository) Renew(userID uint64, payment *models.Payment,
+	renew subscription.RenewFunc) (*models.Subscription, error) {
+	tx, err := rep.db.BeginTx(context.Background(), &sql.TxOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	if payment != nil {
+		err = claimPayment(tx, payment)
+	}
+	var subscription *models.Subscription
+	if err == nil {
+		subscription, err = renewSubscription(tx, userID, renew)
+	}
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			logger.Error(rollbackErr)
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
 func (rep *SubscriptionPgRepository) SelectByUserID(userID uint64) (*models.Subscription, error) {
 	subscription := &models.Subscription{}
 	err := rep.db.QueryRow(`
-		SELECT id, owner, expires, is_paid, is_canceled
+		SELECT id, owner, plan_id, expires, grace_until, is_paid, is_canceled
 		FROM subscriptions
 		WHERE owner=$1`, userID).
-		Scan(&subscription.ID, &subscription.UserID,
-			&subscription.Expires, &subscription.IsPaid,
-			&subscription.IsCanceled)
+		Scan(&subscription.ID, &subscription.UserID, &subscription.PlanID,
+			&subscription.Expires, &subscription.GraceUntil,
+			&subscription.IsPaid, &subscription.IsCanceled)
 	if err != nil {
 		return nil, err
 	}
 	return subscription, nil
 }
 
-func (rep *SubscriptionPgRepository) DeleteByID(id uint64) error {
+// SelectOverdue returns paid subscriptions whose grace period is over
+func (rep *SubscriptionPgRepository) SelectOverdue(now time.Time) ([]*models.Subscription, error) {
+	rows, err := rep.db.Query(`
+		SELECT id, owner, plan_id, expires, grace_until, is_paid, is_canceled
+		FROM subscriptions
+		WHERE is_paid AND grace_until < $1`, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subscriptions []*models.Subscription
+	for rows.Next() {
+		subscription := &models.Subscription{}
+		err := rows.Scan(&subscription.ID, &subscription.UserID, &subscription.PlanID,
+			&subscription.Expires, &subscription.GraceUntil,
+			&subscription.IsPaid, &subscription.IsCanceled)
+		if err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+func (rep *SubscriptionPgRepository) Delete(subscription *models.Subscription, event string) error {
 	tx, err := rep.db.BeginTx(context.Background(), &sql.TxOptions{})
 	if err != nil {
 		return err
@@ -92,7 +147,10 @@ func (rep *SubscriptionPgRepository) DeleteByID(id uint64) error {
 
 	_, err = tx.Exec(`
 		DELETE FROM subscriptions
-		WHERE id = $1`, id)
+		WHERE id = $1`, subscription.ID)
+	if err == nil {
+		err = insertEvent(tx, subscription, event)
+	}
 	if err != nil {
 		if rollbackErr := tx.Rollback(); rollbackErr != nil {
 			logger.Error(rollbackErr)
@@ -105,3 +163,150 @@ func (rep *SubscriptionPgRepository) DeleteByID(id uint64) error {
 	}
 	return nil
 }
+
+func (rep *SubscriptionPgRepository) SelectEventsByUserID(userID uint64,
+	pgnt *models.Pagination) ([]*models.SubscriptionEvent, error) {
+	var values []interface{}
+
+	selectQuery := `
+		SELECT id, user_id, event, expires, created
+		FROM subscription_events
+		WHERE user_id=$1
+		ORDER BY created DESC, id DESC`
+	values = append(values, userID)
+
+	var pgntQuery string
+	if pgnt.Count != 0 {
+		pgntQuery = "LIMIT $2 OFFSET $3"
+		values = append(values, pgnt.Count, pgnt.From)
+	}
+
+	resultQuery := strings.Join([]string{
+		selectQuery,
+		pgntQuery,
+	}, " ")
+
+	rows, err := rep.db.Query(resultQuery, values...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*models.SubscriptionEvent
+	for rows.Next() {
+		event := &models.SubscriptionEvent{}
+		err := rows.Scan(&event.ID, &event.UserID, &event.Event,
+			&event.Expires, &event.Created)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// claimPayment accepts pending payment, concurrent notification
+// waits for the claim and gets sql.ErrNoRows once it is committed
+func claimPayment(tx *sql.Tx, payment *models.Payment) error {
+	result, err := tx.Exec(`
+		UPDATE payments
+		SET status = $2
+		WHERE id = $1 AND status = $3`,
+		payment.ID, consts.PaymentAccepted, consts.PaymentPending)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func renewSubscription(tx *sql.Tx, userID uint64,
+	renew subscription.RenewFunc) (*models.Subscription, error) {
+	for {
+		current := &models.Subscription{}
+		err := tx.QueryRow(`
+			SELECT id, owner, plan_id, expires, grace_until, is_paid, is_canceled
+			FROM subscriptions
+			WHERE owner=$1
+			FOR UPDATE`, userID).
+			Scan(&current.ID, &current.UserID, &current.PlanID,
+				&current.Expires, &current.GraceUntil,
+				&current.IsPaid, &current.IsCanceled)
+		if err == sql.ErrNoRows {
+			current = nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		subscription, event, err := renew(current)
+		if err != nil {
+			return nil, err
+		}
+
+		if current != nil {
+			err = updateSubscription(tx, subscription)
+		} else {
+			err = tx.QueryRow(`
+				INSERT INTO subscriptions(owner, plan_id, expires, grace_until, is_paid, is_canceled)
+				VALUES ($1, $2, $3, $4, $5, $6)
+				ON CONFLICT (owner) DO NOTHING
+				RETURNING id`,
+				subscription.UserID, subscription.PlanID, subscription.Expires, subscription.GraceUntil,
+				subscription.IsPaid, subscription.IsCanceled).
+				Scan(&subscription.ID)
+			if err == sql.ErrNoRows {
+				// Concurrent first subscription is committed, renew it
+				continue
+			}
+		}
+		if err == nil {
+			err = insertEvent(tx, subscription, event)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return subscription, nil
+	}
+}
+
+func insertSubscription(tx *sql.Tx, subscription *models.Subscription) error {
+	return tx.QueryRow(`
+		INSERT INTO subscriptions(owner, plan_id, expires, grace_until, is_paid, is_canceled)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id`,
+		subscription.UserID, subscription.PlanID, subscription.Expires, subscription.GraceUntil,
+		subscription.IsPaid, subscription.IsCanceled).
+		Scan(&subscription.ID)
+}
+
+func updateSubscription(tx *sql.Tx, subscription *models.Subscription) error {
+	_, err := tx.Exec(`
+		UPDATE subscriptions
+		SET plan_id = $2,
+		    expires = $3,
+		    grace_until = $4,
+		    is_paid = $5,
+		    is_canceled = $6
+		WHERE id = $1`,
+		subscription.ID, subscription.PlanID, subscription.Expires, subscription.GraceUntil,
+		subscription.IsPaid, subscription.IsCanceled)
+	return err
+}
+
+func insertEvent(tx *sql.Tx, subscription *models.Subscription, event string) error {
+	_, err := tx.Exec(`
+		INSERT INTO subscription_events(user_id, event, expires)
+		VALUES ($1, $2, $3)`,
+		subscription.UserID, event, subscription.Expires)
+	return err
+}
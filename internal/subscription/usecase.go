@@ -6,8 +6,11 @@ import (
 )
 
 type SubscriptionUseCase interface {
-	Create(subscription *models.Subscription) (*models.Subscription, *errors.Error)
+	StartTrial(userID uint64, plan *models.Plan) *errors.Error
+	Renew(payment *models.Payment, plan *models.Plan) (*models.Subscription, *errors.Error)
 	RecoverSubscriptionByUserID(userID uint64) (*models.Subscription, *errors.Error)
 	GetByUserID(userID uint64) (*models.Subscription, *errors.Error)
 	DeleteByUserID(userID uint64) (*models.Subscription, *errors.Error)
+	ExpireOverdue() *errors.Error
+	ListHistory(userID uint64, pgnt *models.Pagination) ([]*models.SubscriptionEvent, *errors.Error)
 }
@@ -2,75 +2,113 @@ package usecases
 
 import (
 	"database/sql"
+	"time"
+
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/subscription"
-	"time"
 )
 
 type SubscriptionUseCase struct {
-	rep subscription.SubscriptionRepository
+	rep         subscription.SubscriptionRepository
+	gracePeriod time.Duration
 }
 
-func NewSubscriptionUseCase(
-	rep subscription.SubscriptionRepository) subscription.SubscriptionUseCase {
-	return &SubscriptionUseCase{rep: rep}
+func NewSubscriptionUseCase(rep subscription.SubscriptionRepository,
+	gracePeriod time.Duration) subscription.SubscriptionUseCase {
+	return &SubscriptionUseCase{
+		rep:         rep,
+		gracePeriod: gracePeriod,
+	}
 }
 
-func (uc *SubscriptionUseCase) Create(subscription *models.Subscription) (*models.Subscription, *errors.Error) {
-	dbSubscription, customErr := uc.GetByUserID(subscription.UserID)
-	if customErr != nil {
-		return nil, customErr
+// StartTrial subscribes user who has never been subscribed for the trial days
+// of the plan, paid period is added after the trial once the payment is accepted
+func (uc *SubscriptionUseCase) StartTrial(userID uint64, plan *models.Plan) *errors.Error {
+	if plan.TrialDays == 0 {
+		return nil
+	}
+	isFirst, customErr := uc.isFirstSubscription(userID)
+	if customErr != nil || !isFirst {
+		return customErr
 	}
 
-	if dbSubscription != nil {
-		dbSubscription.IsPaid = true
-		dbSubscription.IsCanceled = false
-		if !isExpired(dbSubscription) {
-			oldExpires := dbSubscription.Expires
-			dbSubscription.Expires = oldExpires.AddDate(0, 1, 0)
-		} else {
-			dbSubscription.Expires = subscription.Expires
-		}
-		err := uc.rep.Update(dbSubscription)
-		if err != nil {
-			return nil, errors.New(consts.CodeInternalError, err)
+	_, err := uc.rep.Renew(userID, nil, func(current *models.Subscription) (*models.Subscription, string, error) {
+		if current != nil {
+			// Concurrent checkout has already started the trial
+			return nil, "", sql.ErrNoRows
 		}
-		return dbSubscription, nil
+		expires := time.Now().AddDate(0, 0, plan.TrialDays)
+		return &models.Subscription{
+			UserID:     userID,
+			PlanID:     &plan.ID,
+			Expires:    expires,
+			GraceUntil: expires,
+			IsPaid:     true,
+		}, consts.SubscriptionTrialStarted, nil
+	})
+	if err != nil && err != sql.ErrNoRows {
+		return errors.New(consts.CodeInternalError, err)
 	}
+	return nil
+}
 
-	err := uc.rep.Insert(subscription)
-	if err != nil {
+// Renew accepts pending payment and starts new plan period,
+// active subscription is extended from its current expiration.
+// Payment is accepted only once, repeated call returns current subscription
+func (uc *SubscriptionUseCase) Renew(payment *models.Payment, plan *models.Plan) (*models.Subscription, *errors.Error) {
+	userID := payment.UserID
+	subscription, err := uc.rep.Renew(userID, payment, func(current *models.Subscription) (*models.Subscription, string, error) {
+		if current == nil {
+			subscription := &models.Subscription{
+				UserID: userID,
+			}
+			uc.setExpires(subscription, plan, time.Now())
+			subscription.IsPaid = true
+			return subscription, consts.SubscriptionCreated, nil
+		}
+
+		event := consts.SubscriptionCreated
+		if current.IsActive() {
+			event = consts.SubscriptionRenewed
+			uc.setExpires(current, plan, current.Expires)
+		} else {
+			uc.setExpires(current, plan, time.Now())
+		}
+		current.IsPaid = true
+		current.IsCanceled = false
+		return current, event, nil
+	})
+	if err == sql.ErrNoRows {
+		return uc.GetByUserID(userID)
+	} else if err != nil {
 		return nil, errors.New(consts.CodeInternalError, err)
 	}
+	payment.Status = consts.PaymentAccepted
 	return subscription, nil
 }
 
 func (uc *SubscriptionUseCase) RecoverSubscriptionByUserID(userID uint64) (*models.Subscription, *errors.Error) {
-	dbSubscription, err := uc.rep.SelectByUserID(userID)
-	if err != nil {
-		return nil, errors.New(consts.CodeInternalError, err)
+	dbSubscription, customErr := uc.GetByUserID(userID)
+	if customErr != nil {
+		return nil, customErr
 	}
 	if dbSubscription == nil {
 		return nil, errors.Get(consts.CodeSubscriptionDoesNotExist)
 	}
 
-	if dbSubscription.IsCanceled {
-		dbSubscription.IsCanceled = false
+	if !dbSubscription.IsCanceled {
+		return dbSubscription, nil
 	}
+	dbSubscription.IsCanceled = false
 
-	err = uc.rep.Update(dbSubscription)
-	if err != nil {
+	if err := uc.rep.Update(dbSubscription, consts.SubscriptionRecovered); err != nil {
 		return nil, errors.New(consts.CodeInternalError, err)
 	}
 	return dbSubscription, nil
 }
 
-func isExpired(subscription *models.Subscription) bool {
-	return subscription.Expires.Before(time.Now())
-}
-
 func (uc *SubscriptionUseCase) GetByUserID(userID uint64) (*models.Subscription, *errors.Error) {
 	dbSubscription, err := uc.rep.SelectByUserID(userID)
 	if err == sql.ErrNoRows {
@@ -79,47 +117,97 @@ func (uc *SubscriptionUseCase) GetByUserID(userID uint64) (*models.Subscription,
 		return nil, errors.New(consts.CodeInternalError, err)
 	}
 
-	if isExpired(dbSubscription) {
-		if dbSubscription.IsCanceled {
-			err := uc.rep.DeleteByID(dbSubscription.ID)
-			if err != nil {
-				return nil, errors.New(consts.CodeInternalError, err)
-			}
-			return nil, nil
-		} else {
-			dbSubscription.IsPaid = false
-			err := uc.rep.Update(dbSubscription)
-			if err != nil {
-				return nil, errors.New(consts.CodeInternalError, err)
-			}
-			return dbSubscription, nil
-		}
+	// Sweeper could have not reached this subscription yet
+	if dbSubscription.IsPaid && isOverdue(dbSubscription) {
+		return uc.expire(dbSubscription)
 	}
-
 	return dbSubscription, nil
 }
 
 func (uc *SubscriptionUseCase) DeleteByUserID(userID uint64) (*models.Subscription, *errors.Error) {
-	dbSubscription, err := uc.rep.SelectByUserID(userID)
-	if err != nil {
-		return nil, errors.New(consts.CodeInternalError, err)
+	dbSubscription, customErr := uc.GetByUserID(userID)
+	if customErr != nil {
+		return nil, customErr
 	}
 	if dbSubscription == nil {
 		return nil, errors.Get(consts.CodeSubscriptionDoesNotExist)
 	}
 
-	if isExpired(dbSubscription) {
-		err := uc.rep.DeleteByID(dbSubscription.ID)
-		if err != nil {
+	dbSubscription.IsCanceled = true
+
+	if !dbSubscription.IsActive() {
+		if err := uc.rep.Delete(dbSubscription, consts.SubscriptionCanceled); err != nil {
 			return nil, errors.New(consts.CodeInternalError, err)
 		}
+		return dbSubscription, nil
 	}
 
-	dbSubscription.IsCanceled = true
+	if err := uc.rep.Update(dbSubscription, consts.SubscriptionCanceled); err != nil {
+		return nil, errors.New(consts.CodeInternalError, err)
+	}
+	return dbSubscription, nil
+}
 
-	err = uc.rep.Update(dbSubscription)
+// ExpireOverdue expires all paid subscriptions whose grace period is over
+func (uc *SubscriptionUseCase) ExpireOverdue() *errors.Error {
+	subscriptions, err := uc.rep.SelectOverdue(time.Now())
+	if err != nil {
+		return errors.New(consts.CodeInternalError, err)
+	}
+
+	for _, subscription := range subscriptions {
+		if _, customErr := uc.expire(subscription); customErr != nil {
+			return customErr
+		}
+	}
+	return nil
+}
+
+func (uc *SubscriptionUseCase) ListHistory(userID uint64,
+	pgnt *models.Pagination) ([]*models.SubscriptionEvent, *errors.Error) {
+	events, err := uc.rep.SelectEventsByUserID(userID, pgnt)
 	if err != nil {
 		return nil, errors.New(consts.CodeInternalError, err)
 	}
-	return dbSubscription, nil
+	if len(events) == 0 {
+		return []*models.SubscriptionEvent{}, nil
+	}
+	return events, nil
+}
+
+// expire removes canceled subscription and marks the others as not paid
+func (uc *SubscriptionUseCase) expire(subscription *models.Subscription) (*models.Subscription, *errors.Error) {
+	if subscription.IsCanceled {
+		if err := uc.rep.Delete(subscription, consts.SubscriptionExpired); err != nil {
+			return nil, errors.New(consts.CodeInternalError, err)
+		}
+		return nil, nil
+	}
+
+	subscription.IsPaid = false
+	if err := uc.rep.Update(subscription, consts.SubscriptionExpired); err != nil {
+		return nil, errors.New(consts.CodeInternalError, err)
+	}
+	return subscription, nil
+}
+
+// isFirstSubscription reports whether user has never been subscribed,
+// trial days are given only once
+func (uc *SubscriptionUseCase) isFirstSubscription(userID uint64) (bool, *errors.Error) {
+	events, err := uc.rep.SelectEventsByUserID(userID, &models.Pagination{Count: 1})
+	if err != nil {
+		return false, errors.New(consts.CodeInternalError, err)
+	}
+	return len(events) == 0, nil
+}
+
+func (uc *SubscriptionUseCase) setExpires(subscription *models.Subscription,
+	plan *models.Plan, from time.Time) {
+	subscription.PlanID = &plan.ID
+	subscription.Expires = from.AddDate(0, plan.DurationMonths, 0)
+	subscription.GraceUntil = subscription.Expires.Add(uc.gracePeriod)
+}
+
+func isOverdue(subscription *models.Subscription) bool {
+	return subscription.GraceUntil.Before(time.Now())
 }
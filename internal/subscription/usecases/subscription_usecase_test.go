@@ -0,0 +1,354 @@
+package usecases
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/subscription"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/subscription/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+const gracePeriod = 72 * time.Hour
+
+var userID uint64 = 3
+
+func newPayment() *models.Payment {
+	return &models.Payment{
+		ID:     4,
+		UserID: userID,
+		PlanID: 2,
+		Status: consts.PaymentPending,
+	}
+}
+
+var plan = &models.Plan{
+	ID:             2,
+	Name:           "Полгода",
+	Price:          1499,
+	Currency:       "643",
+	DurationMonths: 6,
+	TrialDays:      7,
+}
+
+func setupSubscriptionUsecase(t *testing.T) (*gomock.Controller,
+	*mocks.MockSubscriptionRepository, *SubscriptionUseCase) {
+	ctrl := gomock.NewController(t)
+	subscriptionRep := mocks.NewMockSubscriptionRepository(ctrl)
+	// nolint: errcheck
+	subscriptionUseCase := NewSubscriptionUseCase(subscriptionRep, gracePeriod).(*SubscriptionUseCase)
+	return ctrl, subscriptionRep, subscriptionUseCase
+}
+
+// expectRenew applies renew function to the current subscription
+// and checks the event of the change
+func expectRenew(subscriptionRep *mocks.MockSubscriptionRepository, payment *models.Payment,
+	current *models.Subscription, expEvent string) {
+	subscriptionRep.
+		EXPECT().
+		Renew(gomock.Eq(userID), gomock.Eq(payment), gomock.Any()).
+		DoAndReturn(func(userID uint64, payment *models.Payment,
+			renew subscription.RenewFunc) (*models.Subscription, error) {
+			subscription, event, err := renew(current)
+			if event != expEvent {
+				return nil, fmt.Errorf("unexpected event %s", event)
+			}
+			return subscription, err
+		})
+}
+
+func TestSubscriptionUseCase_Renew_New(t *testing.T) {
+	t.Parallel()
+	ctrl, subscriptionRep, subscriptionUseCase := setupSubscriptionUsecase(t)
+	defer ctrl.Finish()
+
+	payment := newPayment()
+
+	expectRenew(subscriptionRep, payment, nil, consts.SubscriptionCreated)
+
+	from := time.Now()
+	subscription, err := subscriptionUseCase.Renew(payment, plan)
+	assert.Equal(t, err, (*errors.Error)(nil))
+	assert.Equal(t, consts.PaymentAccepted, payment.Status)
+	assert.True(t, subscription.IsActive())
+	assert.False(t, subscription.InGracePeriod())
+	assert.Equal(t, plan.ID, *subscription.PlanID)
+	assert.False(t, subscription.Expires.Before(from.AddDate(0, plan.DurationMonths, 0)))
+	assert.True(t, subscription.Expires.Before(time.Now().AddDate(0, plan.DurationMonths, plan.TrialDays)))
+	assert.Equal(t, subscription.Expires.Add(gracePeriod), subscription.GraceUntil)
+}
+
+func TestSubscriptionUseCase_Renew_AfterTrial(t *testing.T) {
+	t.Parallel()
+	ctrl, subscriptionRep, subscriptionUseCase := setupSubscriptionUsecase(t)
+	defer ctrl.Finish()
+
+	payment := newPayment()
+
+	trialExpires := time.Now().AddDate(0, 0, plan.TrialDays)
+	dbSubscription := &models.Subscription{
+		ID:         1,
+		UserID:     userID,
+		PlanID:     &plan.ID,
+		Expires:    trialExpires,
+		GraceUntil: trialExpires,
+		IsPaid:     true,
+	}
+	expectRenew(subscriptionRep, payment, dbSubscription, consts.SubscriptionRenewed)
+
+	// Paid period starts when the trial ends
+	subscription, err := subscriptionUseCase.Renew(payment, plan)
+	assert.Equal(t, err, (*errors.Error)(nil))
+	assert.Equal(t, trialExpires.AddDate(0, plan.DurationMonths, 0), subscription.Expires)
+}
+
+func TestSubscriptionUseCase_StartTrial_First(t *testing.T) {
+	t.Parallel()
+	ctrl, subscriptionRep, subscriptionUseCase := setupSubscriptionUsecase(t)
+	defer ctrl.Finish()
+
+	subscriptionRep.
+		EXPECT().
+		SelectEventsByUserID(gomock.Eq(userID), gomock.Any()).
+		Return(nil, nil)
+	subscriptionRep.
+		EXPECT().
+		Renew(gomock.Eq(userID), gomock.Nil(), gomock.Any()).
+		DoAndReturn(func(userID uint64, payment *models.Payment,
+			renew subscription.RenewFunc) (*models.Subscription, error) {
+			subscription, event, err := renew(nil)
+			assert.Equal(t, consts.SubscriptionTrialStarted, event)
+			assert.True(t, subscription.IsActive())
+			assert.Equal(t, subscription.Expires, subscription.GraceUntil)
+			assert.True(t, subscription.Expires.Before(time.Now().AddDate(0, 0, plan.TrialDays+1)))
+			return subscription, err
+		})
+
+	err := subscriptionUseCase.StartTrial(userID, plan)
+	assert.Equal(t, err, (*errors.Error)(nil))
+}
+
+func TestSubscriptionUseCase_StartTrial_NoRepeatedTrial(t *testing.T) {
+	t.Parallel()
+	ctrl, subscriptionRep, subscriptionUseCase := setupSubscriptionUsecase(t)
+	defer ctrl.Finish()
+
+	subscriptionRep.
+		EXPECT().
+		SelectEventsByUserID(gomock.Eq(userID), gomock.Any()).
+		Return([]*models.SubscriptionEvent{{UserID: userID, Event: consts.SubscriptionExpired}}, nil)
+
+	err := subscriptionUseCase.StartTrial(userID, plan)
+	assert.Equal(t, err, (*errors.Error)(nil))
+}
+
+func TestSubscriptionUseCase_StartTrial_Concurrent(t *testing.T) {
+	t.Parallel()
+	ctrl, subscriptionRep, subscriptionUseCase := setupSubscriptionUsecase(t)
+	defer ctrl.Finish()
+
+	subscriptionRep.
+		EXPECT().
+		SelectEventsByUserID(gomock.Eq(userID), gomock.Any()).
+		Return(nil, nil)
+	subscriptionRep.
+		EXPECT().
+		Renew(gomock.Eq(userID), gomock.Nil(), gomock.Any()).
+		DoAndReturn(func(userID uint64, payment *models.Payment,
+			renew subscription.RenewFunc) (*models.Subscription, error) {
+			_, _, err := renew(&models.Subscription{ID: 1, UserID: userID})
+			return nil, err
+		})
+
+	// Subscription created by concurrent checkout isn't changed
+	err := subscriptionUseCase.StartTrial(userID, plan)
+	assert.Equal(t, err, (*errors.Error)(nil))
+}
+
+func TestSubscriptionUseCase_Renew_ExtendsActive(t *testing.T) {
+	t.Parallel()
+	ctrl, subscriptionRep, subscriptionUseCase := setupSubscriptionUsecase(t)
+	defer ctrl.Finish()
+
+	payment := newPayment()
+
+	expires := time.Now().Add(24 * time.Hour)
+	dbSubscription := &models.Subscription{
+		ID:         1,
+		UserID:     userID,
+		Expires:    expires,
+		GraceUntil: expires.Add(gracePeriod),
+		IsPaid:     true,
+		IsCanceled: true,
+	}
+
+	expectRenew(subscriptionRep, payment, dbSubscription, consts.SubscriptionRenewed)
+
+	subscription, err := subscriptionUseCase.Renew(payment, plan)
+	assert.Equal(t, err, (*errors.Error)(nil))
+	assert.Equal(t, expires.AddDate(0, plan.DurationMonths, 0), subscription.Expires)
+	assert.False(t, subscription.IsCanceled)
+}
+
+func TestSubscriptionUseCase_Renew_Expired(t *testing.T) {
+	t.Parallel()
+	ctrl, subscriptionRep, subscriptionUseCase := setupSubscriptionUsecase(t)
+	defer ctrl.Finish()
+
+	payment := newPayment()
+
+	expires := time.Now().Add(-gracePeriod - time.Hour)
+	dbSubscription := &models.Subscription{
+		ID:         1,
+		UserID:     userID,
+		Expires:    expires,
+		GraceUntil: expires.Add(gracePeriod),
+		IsPaid:     true,
+	}
+
+	expectRenew(subscriptionRep, payment, dbSubscription, consts.SubscriptionCreated)
+
+	from := time.Now()
+	subscription, err := subscriptionUseCase.Renew(payment, plan)
+	assert.Equal(t, err, (*errors.Error)(nil))
+	assert.True(t, subscription.IsActive())
+	assert.False(t, subscription.Expires.Before(from.AddDate(0, plan.DurationMonths, 0)))
+}
+
+func TestSubscriptionUseCase_Renew_Failed(t *testing.T) {
+	t.Parallel()
+	ctrl, subscriptionRep, subscriptionUseCase := setupSubscriptionUsecase(t)
+	defer ctrl.Finish()
+
+	payment := newPayment()
+
+	subscriptionRep.
+		EXPECT().
+		Renew(gomock.Eq(userID), gomock.Eq(payment), gomock.Any()).
+		Return(nil, sql.ErrConnDone)
+
+	subscription, err := subscriptionUseCase.Renew(payment, plan)
+	assert.Equal(t, consts.CodeInternalError, err.Code)
+	assert.Nil(t, subscription)
+}
+
+func TestSubscriptionUseCase_Renew_AlreadyAccepted(t *testing.T) {
+	t.Parallel()
+	ctrl, subscriptionRep, subscriptionUseCase := setupSubscriptionUsecase(t)
+	defer ctrl.Finish()
+
+	expires := time.Now().Add(24 * time.Hour)
+	dbSubscription := &models.Subscription{
+		ID:         1,
+		UserID:     userID,
+		Expires:    expires,
+		GraceUntil: expires.Add(gracePeriod),
+		IsPaid:     true,
+	}
+	payment := newPayment()
+
+	subscriptionRep.
+		EXPECT().
+		Renew(gomock.Eq(userID), gomock.Eq(payment), gomock.Any()).
+		Return(nil, sql.ErrNoRows)
+	subscriptionRep.
+		EXPECT().
+		SelectByUserID(gomock.Eq(userID)).
+		Return(dbSubscription, nil)
+
+	// Concurrent notification has already renewed the subscription
+	subscription, err := subscriptionUseCase.Renew(payment, plan)
+	assert.Equal(t, err, (*errors.Error)(nil))
+	assert.Equal(t, dbSubscription, subscription)
+	assert.Equal(t, consts.PaymentPending, payment.Status)
+}
+
+func TestSubscriptionUseCase_GetByUserID_GracePeriod(t *testing.T) {
+	t.Parallel()
+	ctrl, subscriptionRep, subscriptionUseCase := setupSubscriptionUsecase(t)
+	defer ctrl.Finish()
+
+	expires := time.Now().Add(-time.Hour)
+	dbSubscription := &models.Subscription{
+		ID:         1,
+		UserID:     userID,
+		Expires:    expires,
+		GraceUntil: expires.Add(gracePeriod),
+		IsPaid:     true,
+	}
+
+	subscriptionRep.
+		EXPECT().
+		SelectByUserID(gomock.Eq(userID)).
+		Return(dbSubscription, nil)
+
+	subscription, err := subscriptionUseCase.GetByUserID(userID)
+	assert.Equal(t, err, (*errors.Error)(nil))
+	assert.True(t, subscription.IsActive())
+	assert.True(t, subscription.InGracePeriod())
+}
+
+func TestSubscriptionUseCase_ExpireOverdue(t *testing.T) {
+	t.Parallel()
+	ctrl, subscriptionRep, subscriptionUseCase := setupSubscriptionUsecase(t)
+	defer ctrl.Finish()
+
+	expires := time.Now().Add(-gracePeriod - time.Hour)
+	paidSubscription := &models.Subscription{
+		ID:         1,
+		UserID:     userID,
+		Expires:    expires,
+		GraceUntil: expires.Add(gracePeriod),
+		IsPaid:     true,
+	}
+	canceledSubscription := &models.Subscription{
+		ID:         2,
+		UserID:     userID + 1,
+		Expires:    expires,
+		GraceUntil: expires.Add(gracePeriod),
+		IsPaid:     true,
+		IsCanceled: true,
+	}
+
+	subscriptionRep.
+		EXPECT().
+		SelectOverdue(gomock.Any()).
+		Return([]*models.Subscription{paidSubscription, canceledSubscription}, nil)
+
+	subscriptionRep.
+		EXPECT().
+		Update(gomock.Eq(paidSubscription), gomock.Eq(consts.SubscriptionExpired)).
+		Return(nil)
+
+	subscriptionRep.
+		EXPECT().
+		Delete(gomock.Eq(canceledSubscription), gomock.Eq(consts.SubscriptionExpired)).
+		Return(nil)
+
+	err := subscriptionUseCase.ExpireOverdue()
+	assert.Equal(t, err, (*errors.Error)(nil))
+	assert.False(t, paidSubscription.IsPaid)
+}
+
+func TestSubscriptionUseCase_ListHistory_Empty(t *testing.T) {
+	t.Parallel()
+	ctrl, subscriptionRep, subscriptionUseCase := setupSubscriptionUsecase(t)
+	defer ctrl.Finish()
+
+	pgnt := &models.Pagination{From: 0, Count: 10}
+
+	subscriptionRep.
+		EXPECT().
+		SelectEventsByUserID(gomock.Eq(userID), gomock.Eq(pgnt)).
+		Return(nil, nil)
+
+	events, err := subscriptionUseCase.ListHistory(userID, pgnt)
+	assert.Equal(t, err, (*errors.Error)(nil))
+	assert.Equal(t, []*models.SubscriptionEvent{}, events)
+}
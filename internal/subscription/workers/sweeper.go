@@ -0,0 +1,68 @@
+package workers
+
+import (
+	"sync"
+	"time"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/subscription"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
+)
+
+type Sweeper struct {
+	subscriptionUcase subscription.SubscriptionUseCase
+	lock              *helpers.AdvisoryLock
+	interval          time.Duration
+	stop              chan struct{}
+	wg                sync.WaitGroup
+}
+
+func NewSweeper(subscriptionUcase subscription.SubscriptionUseCase,
+	lock *helpers.AdvisoryLock, interval time.Duration) *Sweeper {
+	return &Sweeper{
+		subscriptionUcase: subscriptionUcase,
+		lock:              lock,
+		interval:          interval,
+		stop:              make(chan struct{}),
+	}
+}
+
+// Start expires overdue subscriptions right away and then every interval,
+// instance that doesn't hold the lock skips the sweep
+func (sw *Sweeper) Start() {
+	sw.wg.Add(1)
+	go sw.work()
+}
+
+// Stop waits for running sweep to finish
+func (sw *Sweeper) Stop() {
+	close(sw.stop)
+	sw.wg.Wait()
+}
+
+func (sw *Sweeper) work() {
+	defer sw.wg.Done()
+
+	ticker := time.NewTicker(sw.interval)
+	defer ticker.Stop()
+	for {
+		sw.sweep()
+
+		select {
+		case <-sw.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (sw *Sweeper) sweep() {
+	_, err := sw.lock.Do(func() {
+		if err := sw.subscriptionUcase.ExpireOverdue(); err != nil {
+			logger.Error(err.Message)
+		}
+	})
+	if err != nil {
+		logger.Error(err)
+	}
+}
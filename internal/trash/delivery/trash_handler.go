@@ -0,0 +1,91 @@
+package delivery
+
+import (
+	"net/http"
+	"strconv"
+
+	. "github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/mwares"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/trash"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
+	reader "github.com/go-park-mail-ru/2020_2_Slash/tools/request_reader"
+	. "github.com/go-park-mail-ru/2020_2_Slash/tools/response"
+	"github.com/labstack/echo/v4"
+)
+
+type TrashHandler struct {
+	trashUcase trash.TrashUsecase
+}
+
+func NewTrashHandler(trashUcase trash.TrashUsecase) *TrashHandler {
+	return &TrashHandler{
+		trashUcase: trashUcase,
+	}
+}
+
+func (th *TrashHandler) Configure(e *echo.Echo, mw *mwares.MiddlewareManager) {
+	e.GET("/api/v1/admin/trash", th.GetTrashHandler(), mw.CheckAuth, mw.CheckAdmin)
+	e.POST("/api/v1/admin/trash/:id/restore", th.RestoreHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF,
+		mw.ResolveAuditEntityType("type"), mw.Audit(AuditTrash, "id"))
+}
+
+func (th *TrashHandler) GetTrashHandler() echo.HandlerFunc {
+	type Request struct {
+		models.Pagination
+	}
+
+	return func(cntx echo.Context) error {
+		req := &Request{}
+		if err := reader.NewRequestReader(cntx).Read(req); err != nil {
+			logger.Error(err.Message)
+			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		}
+
+		items, err := th.trashUcase.List(&req.Pagination)
+		if err != nil {
+			logger.Error(err.Message)
+			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		}
+
+		return cntx.JSON(http.StatusOK, Response{
+			Body: &Body{
+				"items": items,
+			},
+		})
+	}
+}
+
+// RestoreHandler restores the item of the type,
+// IDs of content, seasons and episodes overlap,
+// so the audit takes the type from the request as well
+func (th *TrashHandler) RestoreHandler() echo.HandlerFunc {
+	type Request struct {
+		Type string `json:"type" validate:"required,oneof=content season episode"`
+	}
+
+	return func(cntx echo.Context) error {
+		req := &Request{}
+		if err := reader.NewRequestReader(cntx).Read(req); err != nil {
+			logger.Error(err.Message)
+			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		}
+
+		itemID, parseErr := strconv.ParseUint(cntx.Param("id"), 10, 64)
+		if parseErr != nil {
+			customErr := errors.New(CodeBadRequest, parseErr)
+			logger.Error(customErr.Message)
+			return cntx.JSON(customErr.HTTPCode, Response{Error: customErr})
+		}
+
+		if err := th.trashUcase.Restore(req.Type, itemID); err != nil {
+			logger.Error(err.Message)
+			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		}
+
+		return cntx.JSON(http.StatusOK, Response{
+			Message: "success",
+		})
+	}
+}
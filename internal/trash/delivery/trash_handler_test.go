@@ -0,0 +1,143 @@
+package delivery
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/trash/mocks"
+	"github.com/go-park-mail-ru/2020_2_Slash/pkg/converter"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/response"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrashHandler_GetTrashHandler(t *testing.T) {
+	t.Parallel()
+	// Setup
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	trashUseCase := mocks.NewMockTrashUsecase(ctrl)
+
+	items := []*models.TrashItem{
+		&models.TrashItem{
+			ID:           4,
+			Type:         consts.TrashSeason,
+			Name:         "Dark",
+			ContentID:    2,
+			SeasonNumber: 2,
+			DeletedAt:    time.Date(2020, 12, 1, 10, 0, 0, 0, time.UTC),
+		},
+	}
+	pgnt := &models.Pagination{From: 0, Count: 10}
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/trash?from=0&count=10",
+		strings.NewReader(""))
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	trashHandler := NewTrashHandler(trashUseCase)
+	handleFunc := trashHandler.GetTrashHandler()
+
+	trashUseCase.
+		EXPECT().
+		List(pgnt).
+		Return(items, nil)
+
+	response := &response.Response{Body: &response.Body{"items": items}}
+
+	// Assertions
+	if assert.NoError(t, handleFunc(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		expResBody, err := converter.AnyToBytesBuffer(response)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		bytes, _ := ioutil.ReadAll(rec.Body)
+
+		assert.JSONEq(t, expResBody.String(), string(bytes))
+	}
+}
+
+func TestTrashHandler_RestoreHandler(t *testing.T) {
+	t.Parallel()
+	// Setup
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	trashUseCase := mocks.NewMockTrashUsecase(ctrl)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/trash/4/restore",
+		strings.NewReader(`{"type":"season"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("4")
+
+	trashHandler := NewTrashHandler(trashUseCase)
+	handleFunc := trashHandler.RestoreHandler()
+
+	trashUseCase.
+		EXPECT().
+		Restore(consts.TrashSeason, uint64(4)).
+		Return(nil)
+
+	response := &response.Response{Message: "success"}
+
+	// Assertions
+	if assert.NoError(t, handleFunc(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		expResBody, err := converter.AnyToBytesBuffer(response)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		bytes, _ := ioutil.ReadAll(rec.Body)
+
+		assert.JSONEq(t, expResBody.String(), string(bytes))
+	}
+}
+
+func TestTrashHandler_RestoreHandler_NotInTrash(t *testing.T) {
+	t.Parallel()
+	// Setup
+	logger.DisableLogger()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	trashUseCase := mocks.NewMockTrashUsecase(ctrl)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/trash/4/restore",
+		strings.NewReader(`{"type":"content"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("4")
+
+	trashHandler := NewTrashHandler(trashUseCase)
+	handleFunc := trashHandler.RestoreHandler()
+
+	trashUseCase.
+		EXPECT().
+		Restore(consts.TrashContent, uint64(4)).
+		Return(errors.Get(consts.CodeTrashItemDoesNotExist))
+
+	// Assertions
+	if assert.NoError(t, handleFunc(c)) {
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	}
+}
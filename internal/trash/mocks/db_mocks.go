@@ -0,0 +1,40 @@
+package mocks
+
+import (
+	"database/sql/driver"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+)
+
+func MockTrashRepoSelectReturnRows(mock sqlmock.Sqlmock, items []*models.TrashItem,
+	args ...driver.Value) {
+	rows := sqlmock.NewRows([]string{"type", "id", "name", "content_id", "season",
+		"deleted_at", "original_name", "images", "poster", "video"})
+	for _, item := range items {
+		rows.AddRow(item.Type, item.ID, item.Name, item.ContentID, item.SeasonNumber,
+			item.DeletedAt, item.OriginalName, item.Images, item.Poster, item.Video)
+	}
+	mock.ExpectQuery(`SELECT 'content' AS type`).WithArgs(args...).WillReturnRows(rows)
+}
+
+func MockTrashRepoRestoreReturnResult(mock sqlmock.Sqlmock, table string, id uint64,
+	rowsAffected int64) {
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE ` + table + ` SET deleted_at = NULL`).
+		WithArgs(id).WillReturnResult(sqlmock.NewResult(0, rowsAffected))
+	mock.ExpectCommit()
+}
+
+func MockTrashRepoHasEpisodeDuplicateReturnRows(mock sqlmock.Sqlmock, id uint64, exists bool) {
+	rows := sqlmock.NewRows([]string{"exists"}).AddRow(exists)
+	mock.ExpectQuery(`SELECT EXISTS`).
+		WithArgs(id).WillReturnRows(rows)
+}
+
+func MockTrashRepoDeleteReturnResultOk(mock sqlmock.Sqlmock, table string, id uint64) {
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM ` + table).
+		WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+}
@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/trash/repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+	time "time"
+)
+
+// MockTrashRepository is a mock of TrashRepository interface
+type MockTrashRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTrashRepositoryMockRecorder
+}
+
+// MockTrashRepositoryMockRecorder is the mock recorder for MockTrashRepository
+type MockTrashRepositoryMockRecorder struct {
+	mock *MockTrashRepository
+}
+
+// NewMockTrashRepository creates a new mock instance
+func NewMockTrashRepository(ctrl *gomock.Controller) *MockTrashRepository {
+	mock := &MockTrashRepository{ctrl: ctrl}
+	mock.recorder = &MockTrashRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockTrashRepository) EXPECT() *MockTrashRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Select mocks base method
+func (m *MockTrashRepository) Select(pgnt *models.Pagination) ([]*models.TrashItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Select", pgnt)
+	ret0, _ := ret[0].([]*models.TrashItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Select indicates an expected call of Select
+func (mr *MockTrashRepositoryMockRecorder) Select(pgnt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Select", reflect.TypeOf((*MockTrashRepository)(nil).Select), pgnt)
+}
+
+// SelectDeletedBefore mocks base method
+func (m *MockTrashRepository) SelectDeletedBefore(before time.Time) ([]*models.TrashItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectDeletedBefore", before)
+	ret0, _ := ret[0].([]*models.TrashItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectDeletedBefore indicates an expected call of SelectDeletedBefore
+func (mr *MockTrashRepositoryMockRecorder) SelectDeletedBefore(before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectDeletedBefore", reflect.TypeOf((*MockTrashRepository)(nil).SelectDeletedBefore), before)
+}
+
+// Restore mocks base method
+func (m *MockTrashRepository) Restore(itemType string, id uint64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", itemType, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore
+func (mr *MockTrashRepositoryMockRecorder) Restore(itemType, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockTrashRepository)(nil).Restore), itemType, id)
+}
+
+// HasEpisodeDuplicate mocks base method
+func (m *MockTrashRepository) HasEpisodeDuplicate(id uint64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasEpisodeDuplicate", id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasEpisodeDuplicate indicates an expected call of HasEpisodeDuplicate
+func (mr *MockTrashRepositoryMockRecorder) HasEpisodeDuplicate(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasEpisodeDuplicate", reflect.TypeOf((*MockTrashRepository)(nil).HasEpisodeDuplicate), id)
+}
+
+// Delete mocks base method
+func (m *MockTrashRepository) Delete(item *models.TrashItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete
+func (mr *MockTrashRepositoryMockRecorder) Delete(item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTrashRepository)(nil).Delete), item)
+}
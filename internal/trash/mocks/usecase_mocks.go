@@ -0,0 +1,78 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/trash/usecase.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	errors "github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	models "github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockTrashUsecase is a mock of TrashUsecase interface
+type MockTrashUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockTrashUsecaseMockRecorder
+}
+
+// MockTrashUsecaseMockRecorder is the mock recorder for MockTrashUsecase
+type MockTrashUsecaseMockRecorder struct {
+	mock *MockTrashUsecase
+}
+
+// NewMockTrashUsecase creates a new mock instance
+func NewMockTrashUsecase(ctrl *gomock.Controller) *MockTrashUsecase {
+	mock := &MockTrashUsecase{ctrl: ctrl}
+	mock.recorder = &MockTrashUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockTrashUsecase) EXPECT() *MockTrashUsecaseMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method
+func (m *MockTrashUsecase) List(pgnt *models.Pagination) ([]*models.TrashItem, *errors.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", pgnt)
+	ret0, _ := ret[0].([]*models.TrashItem)
+	ret1, _ := ret[1].(*errors.Error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List
+func (mr *MockTrashUsecaseMockRecorder) List(pgnt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockTrashUsecase)(nil).List), pgnt)
+}
+
+// Restore mocks base method
+func (m *MockTrashUsecase) Restore(itemType string, id uint64) *errors.Error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", itemType, id)
+	ret0, _ := ret[0].(*errors.Error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore
+func (mr *MockTrashUsecaseMockRecorder) Restore(itemType, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockTrashUsecase)(nil).Restore), itemType, id)
+}
+
+// Purge mocks base method
+func (m *MockTrashUsecase) Purge() *errors.Error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge")
+	ret0, _ := ret[0].(*errors.Error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge
+func (mr *MockTrashUsecaseMockRecorder) Purge() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockTrashUsecase)(nil).Purge))
+}
@@ -0,0 +1,17 @@
+package trash
+
+import (
+	"time"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+)
+
+type TrashRepository interface {
+	Select(pgnt *models.Pagination) ([]*models.TrashItem, error)
+	SelectDeletedBefore(before time.Time) ([]*models.TrashItem, error)
+	Restore(itemType string, id uint64) (int64, error)
+	// HasEpisodeDuplicate reports whether the number of the trashed episode
+	// was taken by another episode of the season
+	HasEpisodeDuplicate(id uint64) (bool, error)
+	Delete(item *models.TrashItem) error
+}
@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	. "github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/trash"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
+)
+
+var trashTables = map[string]string{
+	TrashContent: "content",
+	TrashSeason:  "seasons",
+	TrashEpisode: "episodes",
+}
+
+// trashQuery selects items of all types, %[1]s is the condition on deleted_at
+const trashQuery = `
+	SELECT 'content' AS type, c.id AS id, c.name, c.id AS content_id, 0, c.deleted_at AS deleted_at,
+	c.original_name, c.images, '', ''
+	FROM content AS c
+	WHERE c.deleted_at %[1]s
+	UNION ALL
+	SELECT 'season', s.id, c.name, c.id, s.number, s.deleted_at,
+	c.original_name, '', '', ''
+	FROM seasons AS s
+	JOIN tv_shows AS tv ON tv.id=s.tv_show_id
+	JOIN content AS c ON c.id=tv.content_id
+	WHERE s.deleted_at %[1]s
+	UNION ALL
+	SELECT 'episode', e.id, e.name, c.id, s.number, e.deleted_at,
+	c.original_name, '', e.poster, e.video
+	FROM episodes AS e
+	JOIN seasons AS s ON s.id=e.season_id
+	JOIN tv_shows AS tv ON tv.id=s.tv_show_id
+	JOIN content AS c ON c.id=tv.content_id
+	WHERE e.deleted_at %[1]s`
+
+type TrashPgRepository struct {
+	dbConn *sql.DB
+}
+
+func NewTrashPgRepository(conn *sql.DB) trash.TrashRepository {
+	return &TrashPgRepository{
+		dbConn: conn,
+	}
+}
+
+func (rep *TrashPgRepository) Select(pgnt *models.Pagination) ([]*models.TrashItem, error) {
+	var values []interface{}
+
+	selectQuery := fmt.Sprintf(trashQuery, "IS NOT NULL")
+	orderQuery := "ORDER BY deleted_at DESC, id DESC"
+
+	var pgntQuery string
+	if pgnt.Count != 0 {
+		pgntQuery = "LIMIT $1 OFFSET $2"
+		values = append(values, pgnt.Count, pgnt.From)
+	}
+
+	resultQuery := strings.Join([]string{
+		selectQuery,
+		orderQuery,
+		pgntQuery,
+	}, " ")
+
+	return rep.selectItems(resultQuery, values...)
+}
+
+func (rep *TrashPgRepository) SelectDeletedBefore(before time.Time) ([]*models.TrashItem, error) {
+	return rep.selectItems(fmt.Sprintf(trashQuery, "<= $1"), before)
+}
+
+// Restore returns the number of restored items,
+// it is zero if the item isn't in the trash
+func (rep *TrashPgRepository) Restore(itemType string, id uint64) (int64, error) {
+	table, ok := trashTables[itemType]
+	if !ok {
+		return 0, fmt.Errorf("unknown trash item type %q", itemType)
+	}
+
+	tx, err := rep.dbConn.BeginTx(context.Background(), &sql.TxOptions{})
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := tx.Exec(
+		`UPDATE `+table+`
+		SET deleted_at = NULL
+		WHERE id=$1 AND deleted_at IS NOT NULL`,
+		id)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			logger.Error(rollbackErr.Error())
+		}
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (rep *TrashPgRepository) HasEpisodeDuplicate(id uint64) (bool, error) {
+	var exists bool
+	err := rep.dbConn.QueryRow(`
+		SELECT EXISTS (
+			SELECT 1
+			FROM episodes AS e
+			JOIN episodes AS live ON live.season_id=e.season_id AND live.number=e.number
+			WHERE e.id=$1 AND live.id<>e.id AND live.deleted_at IS NULL
+		)`, id).Scan(&exists)
+	return exists, err
+}
+
+// Delete permanently deletes the item, related data are deleted in CASCADE
+func (rep *TrashPgRepository) Delete(item *models.TrashItem) error {
+	table, ok := trashTables[item.Type]
+	if !ok {
+		return fmt.Errorf("unknown trash item type %q", item.Type)
+	}
+
+	tx, err := rep.dbConn.BeginTx(context.Background(), &sql.TxOptions{})
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(
+		`DELETE FROM `+table+`
+		WHERE id=$1 AND deleted_at IS NOT NULL`,
+		item.ID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			logger.Error(rollbackErr.Error())
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (rep *TrashPgRepository) selectItems(query string, args ...interface{}) ([]*models.TrashItem, error) {
+	rows, err := rep.dbConn.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []*models.TrashItem
+	for rows.Next() {
+		item := &models.TrashItem{}
+		err := rows.Scan(&item.Type, &item.ID, &item.Name, &item.ContentID,
+			&item.SeasonNumber, &item.DeletedAt, &item.OriginalName,
+			&item.Images, &item.Poster, &item.Video)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
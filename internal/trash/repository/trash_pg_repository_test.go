@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/trash/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+var testItems = []*models.TrashItem{
+	&models.TrashItem{
+		ID:           5,
+		Type:         consts.TrashEpisode,
+		Name:         "Pilot",
+		ContentID:    2,
+		SeasonNumber: 1,
+		DeletedAt:    time.Now(),
+		OriginalName: "Dark",
+		Poster:       "/images/dark_2/1/1.png",
+		Video:        "/videos/dark_2/1/1.mp4",
+	},
+	&models.TrashItem{
+		ID:           3,
+		Type:         consts.TrashContent,
+		Name:         "Шрек",
+		ContentID:    3,
+		DeletedAt:    time.Now().Add(-time.Hour),
+		OriginalName: "Shrek",
+		Images:       "/images/3",
+	},
+}
+
+func TestTrashPgRepository_Select_OK(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	trashPgRep := NewTrashPgRepository(db)
+	pgnt := &models.Pagination{From: 0, Count: 10}
+
+	mocks.MockTrashRepoSelectReturnRows(mock, testItems, pgnt.Count, pgnt.From)
+
+	items, err := trashPgRep.Select(pgnt)
+	assert.NoError(t, err)
+	assert.Equal(t, testItems, items)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTrashPgRepository_SelectDeletedBefore_OK(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	trashPgRep := NewTrashPgRepository(db)
+	before := time.Now()
+
+	mocks.MockTrashRepoSelectReturnRows(mock, testItems, before)
+
+	items, err := trashPgRep.SelectDeletedBefore(before)
+	assert.NoError(t, err)
+	assert.Equal(t, testItems, items)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTrashPgRepository_Restore_OK(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	trashPgRep := NewTrashPgRepository(db)
+
+	mocks.MockTrashRepoRestoreReturnResult(mock, "seasons", 4, 1)
+
+	restored, err := trashPgRep.Restore(consts.TrashSeason, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), restored)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTrashPgRepository_Restore_UnknownType(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	trashPgRep := NewTrashPgRepository(db)
+
+	_, err = trashPgRep.Restore("movies", 4)
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTrashPgRepository_HasEpisodeDuplicate(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	trashPgRep := NewTrashPgRepository(db)
+
+	mocks.MockTrashRepoHasEpisodeDuplicateReturnRows(mock, 7, true)
+
+	duplicated, err := trashPgRep.HasEpisodeDuplicate(7)
+	assert.NoError(t, err)
+	assert.True(t, duplicated)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTrashPgRepository_Delete_OK(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	trashPgRep := NewTrashPgRepository(db)
+
+	mocks.MockTrashRepoDeleteReturnResultOk(mock, "episodes", testItems[0].ID)
+
+	err = trashPgRep.Delete(testItems[0])
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
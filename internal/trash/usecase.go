@@ -0,0 +1,13 @@
+package trash
+
+import (
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+)
+
+type TrashUsecase interface {
+	List(pgnt *models.Pagination) ([]*models.TrashItem, *errors.Error)
+	Restore(itemType string, id uint64) *errors.Error
+	// Purge permanently deletes items kept in the trash longer than retention period
+	Purge() *errors.Error
+}
@@ -0,0 +1,143 @@
+package usecases
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	. "github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/search"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/trash"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
+)
+
+const (
+	postersDirRoot = "/images/"
+	videosDirRoot  = "/videos/"
+)
+
+type TrashUsecase struct {
+	trashRepo    trash.TrashRepository
+	suggestIndex search.SuggestIndex
+	retention    time.Duration
+}
+
+func NewTrashUsecase(repo trash.TrashRepository, suggestIndex search.SuggestIndex,
+	retention time.Duration) trash.TrashUsecase {
+	return &TrashUsecase{
+		trashRepo:    repo,
+		suggestIndex: suggestIndex,
+		retention:    retention,
+	}
+}
+
+func (tu *TrashUsecase) List(pgnt *models.Pagination) ([]*models.TrashItem, *errors.Error) {
+	items, err := tu.trashRepo.Select(pgnt)
+	if err != nil {
+		return nil, errors.New(CodeInternalError, err)
+	}
+	if len(items) == 0 {
+		return []*models.TrashItem{}, nil
+	}
+	return items, nil
+}
+
+// Restore returns the item from the trash, restored season or episode
+// stays hidden while its content or season is in the trash,
+// episode isn't restored if its number was given to another episode
+func (tu *TrashUsecase) Restore(itemType string, id uint64) *errors.Error {
+	if itemType == TrashEpisode {
+		duplicated, err := tu.trashRepo.HasEpisodeDuplicate(id)
+		if err != nil {
+			return errors.New(CodeInternalError, err)
+		}
+		if duplicated {
+			return errors.Get(CodeEpisodeAlreadyExist)
+		}
+	}
+
+	restored, err := tu.trashRepo.Restore(itemType, id)
+	if err != nil {
+		return errors.New(CodeInternalError, err)
+	}
+	if restored == 0 {
+		return errors.Get(CodeTrashItemDoesNotExist)
+	}
+	if itemType == TrashContent {
+		tu.suggestIndex.Invalidate()
+	}
+	return nil
+}
+
+// Purge deletes expired items with their posters and videos,
+// files are removed before the item, so the item that fails to be removed
+// stays in the trash and is purged next time. Items are purged independently,
+// the first failure is returned after the rest are purged
+func (tu *TrashUsecase) Purge() *errors.Error {
+	items, err := tu.trashRepo.SelectDeletedBefore(time.Now().Add(-tu.retention))
+	if err != nil {
+		return errors.New(CodeInternalError, err)
+	}
+
+	var purgeErr *errors.Error
+	for _, item := range items {
+		if customErr := tu.purgeItem(item); customErr != nil {
+			logger.Error(customErr.Message)
+			if purgeErr == nil {
+				purgeErr = customErr
+			}
+		}
+	}
+	return purgeErr
+}
+
+func (tu *TrashUsecase) purgeItem(item *models.TrashItem) *errors.Error {
+	if customErr := removeMedia(item); customErr != nil {
+		return customErr
+	}
+	if err := tu.trashRepo.Delete(item); err != nil {
+		return errors.New(CodeInternalError, err)
+	}
+	return nil
+}
+
+// removeMedia removes files of the item, files of seasons and episodes
+// of the content are removed with content directories
+func removeMedia(item *models.TrashItem) *errors.Error {
+	wd, err := os.Getwd()
+	if err != nil {
+		return errors.New(CodeInternalError, err)
+	}
+
+	contentDir := helpers.GetContentDirTitle(item.OriginalName, item.ContentID)
+	var paths []string
+	switch item.Type {
+	case TrashContent:
+		paths = append(paths, postersDirRoot+contentDir, videosDirRoot+contentDir)
+		if item.Images != "" {
+			paths = append(paths, item.Images)
+		}
+	case TrashSeason:
+		seasonDir := filepath.Join(contentDir, strconv.Itoa(item.SeasonNumber))
+		paths = append(paths, postersDirRoot+seasonDir, videosDirRoot+seasonDir)
+	case TrashEpisode:
+		if item.Poster != "" {
+			paths = append(paths, item.Poster)
+		}
+		if item.Video != "" {
+			paths = append(paths, item.Video, path.Dir(helpers.GetPlaylistPath(item.Video)))
+		}
+	}
+
+	for _, rltPath := range paths {
+		if err := os.RemoveAll(filepath.Join(wd, rltPath)); err != nil {
+			return errors.New(CodeInternalError, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package usecases
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	customErrors "github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	searchMocks "github.com/go-park-mail-ru/2020_2_Slash/internal/search/mocks"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/trash/mocks"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+const retention = 30 * 24 * time.Hour
+
+var seasonItem = &models.TrashItem{
+	ID:           4,
+	Type:         consts.TrashSeason,
+	Name:         "Dark",
+	ContentID:    2,
+	SeasonNumber: 2,
+	DeletedAt:    time.Now().Add(-2 * retention),
+	OriginalName: "Dark",
+}
+
+func TestTrashUsecase_List_Empty(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	trashRep := mocks.NewMockTrashRepository(ctrl)
+	suggestIndex := searchMocks.NewMockSuggestIndex(ctrl)
+	trashUseCase := NewTrashUsecase(trashRep, suggestIndex, retention)
+
+	pgnt := &models.Pagination{From: 0, Count: 10}
+	trashRep.
+		EXPECT().
+		Select(pgnt).
+		Return(nil, nil)
+
+	items, err := trashUseCase.List(pgnt)
+	assert.Equal(t, err, (*customErrors.Error)(nil))
+	assert.Equal(t, []*models.TrashItem{}, items)
+}
+
+func TestTrashUsecase_Restore_Content(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	trashRep := mocks.NewMockTrashRepository(ctrl)
+	suggestIndex := searchMocks.NewMockSuggestIndex(ctrl)
+	trashUseCase := NewTrashUsecase(trashRep, suggestIndex, retention)
+
+	trashRep.
+		EXPECT().
+		Restore(consts.TrashContent, uint64(3)).
+		Return(int64(1), nil)
+	suggestIndex.EXPECT().Invalidate()
+
+	err := trashUseCase.Restore(consts.TrashContent, 3)
+	assert.Equal(t, err, (*customErrors.Error)(nil))
+}
+
+func TestTrashUsecase_Restore_NotInTrash(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	trashRep := mocks.NewMockTrashRepository(ctrl)
+	suggestIndex := searchMocks.NewMockSuggestIndex(ctrl)
+	trashUseCase := NewTrashUsecase(trashRep, suggestIndex, retention)
+
+	trashRep.
+		EXPECT().
+		HasEpisodeDuplicate(uint64(3)).
+		Return(false, nil)
+	trashRep.
+		EXPECT().
+		Restore(consts.TrashEpisode, uint64(3)).
+		Return(int64(0), nil)
+
+	err := trashUseCase.Restore(consts.TrashEpisode, 3)
+	assert.Equal(t, customErrors.Get(consts.CodeTrashItemDoesNotExist), err)
+}
+
+func TestTrashUsecase_Restore_EpisodeDuplicate(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	trashRep := mocks.NewMockTrashRepository(ctrl)
+	suggestIndex := searchMocks.NewMockSuggestIndex(ctrl)
+	trashUseCase := NewTrashUsecase(trashRep, suggestIndex, retention)
+
+	trashRep.
+		EXPECT().
+		HasEpisodeDuplicate(uint64(3)).
+		Return(true, nil)
+
+	err := trashUseCase.Restore(consts.TrashEpisode, 3)
+	assert.Equal(t, customErrors.Get(consts.CodeEpisodeAlreadyExist), err)
+}
+
+func TestTrashUsecase_Purge_OK(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	trashRep := mocks.NewMockTrashRepository(ctrl)
+	suggestIndex := searchMocks.NewMockSuggestIndex(ctrl)
+	trashUseCase := NewTrashUsecase(trashRep, suggestIndex, retention)
+
+	trashRep.
+		EXPECT().
+		SelectDeletedBefore(gomock.Any()).
+		DoAndReturn(func(before time.Time) ([]*models.TrashItem, error) {
+			assert.WithinDuration(t, time.Now().Add(-retention), before, time.Minute)
+			return []*models.TrashItem{seasonItem}, nil
+		})
+	trashRep.
+		EXPECT().
+		Delete(seasonItem).
+		Return(nil)
+
+	err := trashUseCase.Purge()
+	assert.Equal(t, err, (*customErrors.Error)(nil))
+}
+
+func TestTrashUsecase_Purge_DeleteFailed(t *testing.T) {
+	t.Parallel()
+	logger.DisableLogger()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	trashRep := mocks.NewMockTrashRepository(ctrl)
+	suggestIndex := searchMocks.NewMockSuggestIndex(ctrl)
+	trashUseCase := NewTrashUsecase(trashRep, suggestIndex, retention)
+	episodeItem := &models.TrashItem{
+		ID:           7,
+		Type:         consts.TrashEpisode,
+		Name:         "Dark",
+		ContentID:    2,
+		DeletedAt:    time.Now().Add(-2 * retention),
+		OriginalName: "Dark",
+	}
+
+	trashRep.
+		EXPECT().
+		SelectDeletedBefore(gomock.Any()).
+		Return([]*models.TrashItem{seasonItem, episodeItem}, nil)
+	trashRep.
+		EXPECT().
+		Delete(seasonItem).
+		Return(errors.New("connection lost"))
+	// Failed item doesn't stop the purge
+	trashRep.
+		EXPECT().
+		Delete(episodeItem).
+		Return(nil)
+
+	err := trashUseCase.Purge()
+	assert.Equal(t, consts.CodeInternalError, err.Code)
+}
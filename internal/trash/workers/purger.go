@@ -0,0 +1,58 @@
+package workers
+
+import (
+	"sync"
+	"time"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/trash"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
+)
+
+type Purger struct {
+	trashUcase trash.TrashUsecase
+	interval   time.Duration
+	stop       chan struct{}
+	wg         sync.WaitGroup
+}
+
+func NewPurger(trashUcase trash.TrashUsecase, interval time.Duration) *Purger {
+	return &Purger{
+		trashUcase: trashUcase,
+		interval:   interval,
+		stop:       make(chan struct{}),
+	}
+}
+
+// Start purges expired trash right away and then every interval
+func (pr *Purger) Start() {
+	pr.wg.Add(1)
+	go pr.work()
+}
+
+// Stop waits for running purge to finish
+func (pr *Purger) Stop() {
+	close(pr.stop)
+	pr.wg.Wait()
+}
+
+func (pr *Purger) work() {
+	defer pr.wg.Done()
+
+	ticker := time.NewTicker(pr.interval)
+	defer ticker.Stop()
+	for {
+		pr.purge()
+
+		select {
+		case <-pr.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (pr *Purger) purge() {
+	if err := pr.trashUcase.Purge(); err != nil {
+		logger.Error(err.Message)
+	}
+}
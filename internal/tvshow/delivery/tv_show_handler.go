@@ -50,9 +50,9 @@ func NewTVShowHandler(tvshowUcase tvshow.TVShowUsecase, contentUcase content.Con
 }
 
 func (th *TVShowHandler) Configure(e *echo.Echo, mw *mwares.MiddlewareManager) {
-	e.POST("/api/v1/tvshows", th.CreateTVShowHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF)
-	e.PUT("/api/v1/tvshows/:tid", th.UpdateTVShowHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF)
-	e.DELETE("/api/v1/tvshows/:tid", th.DeleteTVShowHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF)
+	e.POST("/api/v1/tvshows", th.CreateTVShowHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF, mw.Audit(AuditTVShow, ""))
+	e.PUT("/api/v1/tvshows/:tid", th.UpdateTVShowHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF, mw.Audit(AuditTVShow, "tid"))
+	e.DELETE("/api/v1/tvshows/:tid", th.DeleteTVShowHandler(), mw.CheckAuth, mw.CheckAdmin, mw.CheckCSRF, mw.Audit(AuditTVShow, "tid"))
 	e.GET("/api/v1/tvshows/:tid", th.GetTVShowHandler(), mw.GetAuth)
 	e.GET("/api/v1/tvshows/:tid/episodes", th.GetTVShowSeasonsHandler())
 	e.GET("/api/v1/tvshows", th.GetTVShowsHandler(), mw.GetAuth)
@@ -238,7 +238,7 @@ func (th *TVShowHandler) DeleteTVShowHandler() echo.HandlerFunc {
 			return cntx.JSON(err.HTTPCode, Response{Error: err})
 		}
 
-		// Other related data are deleted in CASCADE
+		// Related data are kept in the trash and deleted in CASCADE on purge
 		if err := th.contentUcase.DeleteByID(tvshow.ContentID); err != nil {
 			logger.Error(err.Message)
 			return cntx.JSON(err.HTTPCode, Response{Error: err})
@@ -342,7 +342,7 @@ func (th *TVShowHandler) GetTVShowsHandler() echo.HandlerFunc {
 		// nolint: errcheck
 		userID, _ := cntx.Get("userID").(uint64)
 
-		tvshows, err := th.tvshowUcase.ListByParams(&req.ContentFilter,
+		tvshows, err := th.tvshowUcase.ListByParams(&req.ContentFilter, nil,
 			&req.Pagination, userID)
 		if err != nil {
 			logger.Error(err.Message)
@@ -498,7 +498,7 @@ func TestTVShowHandler_GetTVShowsHandler(t *testing.T) {
 
 	tvshowUseCase.
 		EXPECT().
-		ListByParams(params, pgnt, userID).
+		ListByParams(params, nil, pgnt, userID).
 		Return(tvshows, nil)
 
 	response := &response.Response{Body: &response.Body{"tvshows": tvshows}}
@@ -72,11 +72,13 @@ func MockTVShowRepoSelectByParamsReturnRows(mock sqlmock.Sqlmock, params *models
 
 	rows := sqlmock.NewRows([]string{"tv.id", "tv.seasons", "c.id", "c.name",
 		"c.original_name", "c.description", "c.short_description", "c.rating",
-		"c.year", "c.images", "c.type", "c.is_free", "r.likes", "is_favourite"})
+		"c.year", "c.images", "c.type", "c.is_free", "c.status", "c.publish_at",
+		"r.likes", "is_favourite"})
 	for _, tvshow := range tv_shows {
 		rows.AddRow(tvshow.ID, tvshow.Seasons, tvshow.ContentID, tvshow.Name,
 			tvshow.OriginalName, tvshow.Description, tvshow.ShortDescription, tvshow.Rating,
-			tvshow.Year, tvshow.Images, tvshow.Type, tvshow.IsFree, tvshow.IsLiked, tvshow.IsFavourite)
+			tvshow.Year, tvshow.Images, tvshow.Type, tvshow.IsFree, tvshow.Status, tvshow.PublishAt,
+			tvshow.IsLiked, tvshow.IsFavourite)
 	}
 	query := `
 		SELECT tv.id, tv.seasons, c.id, c.name`
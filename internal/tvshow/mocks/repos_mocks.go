@@ -1,7 +1,7 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: repository.go
+// Source: internal/tvshow/repository.go
 
-// Package mock_tvshow is a generated GoMock package.
+// Package mocks is a generated GoMock package.
 package mocks
 
 import (
@@ -107,34 +107,34 @@ func (mr *MockTVShowRepositoryMockRecorder) SelectByContentID(contentID interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectByContentID", reflect.TypeOf((*MockTVShowRepository)(nil).SelectByContentID), contentID)
 }
 
-// SelectWhereNameLike mocks base method
-func (m *MockTVShowRepository) SelectWhereNameLike(name string, pgnt *models.Pagination, curUserID uint64) ([]*models.TVShow, error) {
+// SelectByQuery mocks base method
+func (m *MockTVShowRepository) SelectByQuery(query string, pgnt *models.Pagination, curUserID uint64) ([]*models.TVShow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SelectWhereNameLike", name, pgnt, curUserID)
+	ret := m.ctrl.Call(m, "SelectByQuery", query, pgnt, curUserID)
 	ret0, _ := ret[0].([]*models.TVShow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// SelectWhereNameLike indicates an expected call of SelectWhereNameLike
-func (mr *MockTVShowRepositoryMockRecorder) SelectWhereNameLike(name, pgnt, curUserID interface{}) *gomock.Call {
+// SelectByQuery indicates an expected call of SelectByQuery
+func (mr *MockTVShowRepositoryMockRecorder) SelectByQuery(query, pgnt, curUserID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectWhereNameLike", reflect.TypeOf((*MockTVShowRepository)(nil).SelectWhereNameLike), name, pgnt, curUserID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectByQuery", reflect.TypeOf((*MockTVShowRepository)(nil).SelectByQuery), query, pgnt, curUserID)
 }
 
 // SelectByParams mocks base method
-func (m *MockTVShowRepository) SelectByParams(params *models.ContentFilter, pgnt *models.Pagination, curUserID uint64) ([]*models.TVShow, error) {
+func (m *MockTVShowRepository) SelectByParams(params *models.ContentFilter, statuses []string, pgnt *models.Pagination, curUserID uint64) ([]*models.TVShow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SelectByParams", params, pgnt, curUserID)
+	ret := m.ctrl.Call(m, "SelectByParams", params, statuses, pgnt, curUserID)
 	ret0, _ := ret[0].([]*models.TVShow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SelectByParams indicates an expected call of SelectByParams
-func (mr *MockTVShowRepositoryMockRecorder) SelectByParams(params, pgnt, curUserID interface{}) *gomock.Call {
+func (mr *MockTVShowRepositoryMockRecorder) SelectByParams(params, statuses, pgnt, curUserID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectByParams", reflect.TypeOf((*MockTVShowRepository)(nil).SelectByParams), params, pgnt, curUserID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectByParams", reflect.TypeOf((*MockTVShowRepository)(nil).SelectByParams), params, statuses, pgnt, curUserID)
 }
 
 // SelectLatest mocks base method
@@ -1,7 +1,7 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: usecase.go
+// Source: internal/tvshow/usecase.go
 
-// Package mock_tvshow is a generated GoMock package.
+// Package mocks is a generated GoMock package.
 package mocks
 
 import (
@@ -109,18 +109,18 @@ func (mr *MockTVShowUsecaseMockRecorder) GetByContentID(contentID interface{}) *
 }
 
 // ListByParams mocks base method
-func (m *MockTVShowUsecase) ListByParams(params *models.ContentFilter, pgnt *models.Pagination, curUserID uint64) ([]*models.TVShow, *errors.Error) {
+func (m *MockTVShowUsecase) ListByParams(params *models.ContentFilter, statuses []string, pgnt *models.Pagination, curUserID uint64) ([]*models.TVShow, *errors.Error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListByParams", params, pgnt, curUserID)
+	ret := m.ctrl.Call(m, "ListByParams", params, statuses, pgnt, curUserID)
 	ret0, _ := ret[0].([]*models.TVShow)
 	ret1, _ := ret[1].(*errors.Error)
 	return ret0, ret1
 }
 
 // ListByParams indicates an expected call of ListByParams
-func (mr *MockTVShowUsecaseMockRecorder) ListByParams(params, pgnt, curUserID interface{}) *gomock.Call {
+func (mr *MockTVShowUsecaseMockRecorder) ListByParams(params, statuses, pgnt, curUserID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByParams", reflect.TypeOf((*MockTVShowUsecase)(nil).ListByParams), params, pgnt, curUserID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByParams", reflect.TypeOf((*MockTVShowUsecase)(nil).ListByParams), params, statuses, pgnt, curUserID)
 }
 
 // ListLatest mocks base method
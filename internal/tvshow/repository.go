@@ -10,9 +10,8 @@ type TVShowRepository interface {
 	SelectShortByID(tvshowID uint64) (*models.TVShow, error)
 	SelectFullByID(tvshowID uint64, curUserID uint64) (*models.TVShow, error)
 	SelectByContentID(contentID uint64) (*models.TVShow, error)
-	SelectWhereNameLike(name string, pgnt *models.Pagination,
-		curUserID uint64) ([]*models.TVShow, error)
-	SelectByParams(params *models.ContentFilter, pgnt *models.Pagination,
+	SelectByQuery(query string, pgnt *models.Pagination, curUserID uint64) ([]*models.TVShow, error)
+	SelectByParams(params *models.ContentFilter, statuses []string, pgnt *models.Pagination,
 		curUserID uint64) ([]*models.TVShow, error)
 	SelectLatest(pgnt *models.Pagination, curUserID uint64) ([]*models.TVShow, error)
 	SelectByRating(pgnt *models.Pagination, curUserID uint64) ([]*models.TVShow, error)
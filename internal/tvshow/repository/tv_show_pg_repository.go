@@ -53,9 +53,10 @@ func (tr *TVShowPgRepository) SelectByID(tvshowID uint64) (*models.TVShow, error
 	tvshow := &models.TVShow{}
 
 	row := tr.dbConn.QueryRow(
-		`SELECT id, seasons, content_id
-		FROM tv_shows
-		WHERE id=$1`,
+		`SELECT tv.id, tv.seasons, tv.content_id
+		FROM tv_shows AS tv
+		JOIN content AS c ON c.id=tv.content_id
+		WHERE tv.id=$1 AND c.deleted_at IS NULL`,
 		tvshowID)
 
 	if err := row.Scan(&tvshow.ID, &tvshow.Seasons, &tvshow.ContentID); err != nil {
@@ -71,7 +72,8 @@ func (tr *TVShowPgRepository) SelectShortByID(tvshowID uint64) (*models.TVShow,
 	row := tr.dbConn.QueryRow(
 		`SELECT tv.id, c.name
 		FROM content AS c
-		JOIN tv_shows as tv ON tv.content_id=c.id AND tv.id=$1`,
+		JOIN tv_shows as tv ON tv.content_id=c.id AND tv.id=$1
+		WHERE `+queryBuilder.BuildPublishedCondition(),
 		tvshowID)
 
 	err := row.Scan(&tvshow.ID, &cnt.Name)
@@ -94,7 +96,8 @@ func (tr *TVShowPgRepository) SelectFullByID(tvshowID uint64, curUserID uint64)
 		FROM content AS c
 		JOIN tv_shows as tv ON tv.content_id=c.id AND tv.id=$1
 		LEFT OUTER JOIN rates as r ON r.user_id=$2 AND r.content_id=c.id
-		LEFT OUTER JOIN favourites as f ON f.user_id=$2 AND f.content_id=c.id`,
+		LEFT OUTER JOIN favourites as f ON f.user_id=$2 AND f.content_id=c.id
+		WHERE `+queryBuilder.BuildPublishedCondition(),
 		tvshowID, curUserID)
 
 	err := row.Scan(&tvshow.ID, &tvshow.Seasons, &cnt.ContentID, &cnt.Name,
@@ -123,24 +126,24 @@ func (tr *TVShowPgRepository) SelectByContentID(contentID uint64) (*models.TVSho
 	return tvshow, nil
 }
 
-func (tr *TVShowPgRepository) SelectWhereNameLike(name string,
-	pgnt *models.Pagination, curUserID uint64) ([]*models.TVShow, error) {
+func (tr *TVShowPgRepository) SelectByQuery(query string, pgnt *models.Pagination,
+	curUserID uint64) ([]*models.TVShow, error) {
 	var values []interface{}
 
-	selectQuery := `
+	searchCondition, searchRank := queryBuilder.BuildContentSearchQuery(2)
+	selectQuery := fmt.Sprintf(`
 		SELECT tv.id, tv.seasons, c.id, c.name, c.original_name,
-		c.description, c.short_description, c.rating,
-		c.year, c.images, c.type, c.is_free, r.likes,
+		c.description, c.short_description,
+		c.rating, c.year, c.images, c.type, c.is_free, r.likes,
 		CASE WHEN f.content_id IS NULL THEN false ELSE true END AS is_favourite
 		FROM content AS c
 		JOIN tv_shows as tv ON tv.content_id=c.id
 		LEFT OUTER JOIN rates as r ON r.user_id=$1 AND r.content_id=c.id
 		LEFT OUTER JOIN favourites as f ON f.user_id=$1 AND f.content_id=c.id
-		WHERE c.name ILIKE $2 OR c.original_name ILIKE $2
-		ORDER BY c.year DESC`
-	values = append(values, curUserID)
-	searchName := "%" + name + "%"
-	values = append(values, searchName)
+		WHERE %s AND %s
+		ORDER BY %s DESC, c.rating DESC, c.id`,
+		searchCondition, queryBuilder.BuildPublishedCondition(), searchRank)
+	values = append(values, curUserID, query)
 
 	var pgntQuery string
 	if pgnt.Count != 0 {
@@ -165,28 +168,29 @@ func (tr *TVShowPgRepository) SelectWhereNameLike(name string,
 		cnt := &models.Content{}
 
 		err := rows.Scan(&tvshow.ID, &tvshow.Seasons, &cnt.ContentID, &cnt.Name,
-			&cnt.OriginalName, &cnt.Description, &cnt.ShortDescription, &cnt.Rating,
-			&cnt.Year, &cnt.Images, &cnt.Type, &cnt.IsFree, &cnt.IsLiked, &cnt.IsFavourite)
+			&cnt.OriginalName, &cnt.Description, &cnt.ShortDescription,
+			&cnt.Rating, &cnt.Year, &cnt.Images, &cnt.Type, &cnt.IsFree,
+			&cnt.IsLiked, &cnt.IsFavourite)
 		if err != nil {
 			return nil, err
 		}
-
 		tvshow.Content = *cnt
 		tvshows = append(tvshows, tvshow)
 	}
-
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
+
 	return tvshows, nil
 }
 
-func (tr *TVShowPgRepository) SelectByParams(params *models.ContentFilter,
+func (tr *TVShowPgRepository) SelectByParams(params *models.ContentFilter, statuses []string,
 	pgnt *models.Pagination, curUserID uint64) ([]*models.TVShow, error) {
 
 	selectQuery := `
 		SELECT tv.id, tv.seasons, c.id, c.name, c.original_name, c.description,
-		c.short_description, c.rating, c.year, c.images, c.type, c.is_free, r.likes,
+		c.short_description, c.rating, c.year, c.images, c.type, c.is_free, c.status,
+		c.publish_at, r.likes,
 		CASE WHEN f.content_id IS NULL THEN false ELSE true END AS is_favourite
 		FROM content as c`
 
@@ -210,7 +214,7 @@ func (tr *TVShowPgRepository) SelectByParams(params *models.ContentFilter,
 		values = append(values, params.IsFree)
 	}
 
-	filtersJoinQuery, values := queryBuilder.GetContentJoinFiltersByParams(values, params)
+	filtersJoinQuery, values := queryBuilder.GetContentJoinFiltersByParams(values, params, statuses)
 
 	resultQuery := strings.Join([]string{
 		selectQuery,
@@ -233,7 +237,8 @@ func (tr *TVShowPgRepository) SelectByParams(params *models.ContentFilter,
 
 		err := rows.Scan(&tvshow.ID, &tvshow.Seasons, &cnt.ContentID, &cnt.Name,
 			&cnt.OriginalName, &cnt.Description, &cnt.ShortDescription, &cnt.Rating,
-			&cnt.Year, &cnt.Images, &cnt.Type, &cnt.IsFree, &cnt.IsLiked, &cnt.IsFavourite)
+			&cnt.Year, &cnt.Images, &cnt.Type, &cnt.IsFree, &cnt.Status, &cnt.PublishAt,
+			&cnt.IsLiked, &cnt.IsFavourite)
 		if err != nil {
 			return nil, err
 		}
@@ -260,6 +265,7 @@ func (tr *TVShowPgRepository) SelectLatest(pgnt *models.Pagination, curUserID ui
 		JOIN tv_shows as tv ON tv.content_id=c.id
 		LEFT OUTER JOIN rates as r ON r.user_id=$1 AND r.content_id=c.id
 		LEFT OUTER JOIN favourites as f ON f.user_id=$1 AND f.content_id=c.id
+		WHERE ` + queryBuilder.BuildPublishedCondition() + `
 		ORDER BY c.year DESC`
 	values = append(values, curUserID)
 
@@ -315,6 +321,7 @@ func (tr *TVShowPgRepository) SelectByRating(pgnt *models.Pagination, curUserID
 		JOIN tv_shows as tv ON tv.content_id=c.id
 		LEFT OUTER JOIN rates as r ON r.user_id=$1 AND r.content_id=c.id
 		LEFT OUTER JOIN favourites as f ON f.user_id=$1 AND f.content_id=c.id
+		WHERE ` + queryBuilder.BuildPublishedCondition() + `
 		ORDER BY c.rating DESC`
 	values = append(values, curUserID)
 
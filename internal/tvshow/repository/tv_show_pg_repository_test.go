@@ -206,7 +206,7 @@ func TestTVShowPgRepository_SelectByParams_OK(t *testing.T) {
 	}
 
 	mocks.MockTVShowRepoSelectByParamsReturnRows(mock, params, pgnt, userID, tvshows)
-	dbTVShows, err := tvshowPgRep.SelectByParams(params, pgnt, userID)
+	dbTVShows, err := tvshowPgRep.SelectByParams(params, nil, pgnt, userID)
 	log.Println(dbTVShows)
 	assert.Equal(t, tvshows, dbTVShows)
 	assert.NoError(t, err)
@@ -11,7 +11,7 @@ type TVShowUsecase interface {
 	GetShortByID(tvshowID uint64) (*models.TVShow, *errors.Error)
 	GetFullByID(tvshowID uint64, curUserID uint64) (*models.TVShow, *errors.Error)
 	GetByContentID(contentID uint64) (*models.TVShow, *errors.Error)
-	ListByParams(params *models.ContentFilter, pgnt *models.Pagination,
+	ListByParams(params *models.ContentFilter, statuses []string, pgnt *models.Pagination,
 		curUserID uint64) ([]*models.TVShow, *errors.Error)
 	ListLatest(pgnt *models.Pagination, curUserID uint64) ([]*models.TVShow, *errors.Error)
 	ListByRating(pgnt *models.Pagination, curUserID uint64) ([]*models.TVShow, *errors.Error)
@@ -87,10 +87,10 @@ func (tu *TVShowUsecase) GetByContentID(contentID uint64) (*models.TVShow, *cust
 	return tvshow, nil
 }
 
-func (tu *TVShowUsecase) ListByParams(params *models.ContentFilter, pgnt *models.Pagination,
+func (tu *TVShowUsecase) ListByParams(params *models.ContentFilter, statuses []string, pgnt *models.Pagination,
 	curUserID uint64) ([]*models.TVShow, *customErrors.Error) {
 
-	tvshows, err := tu.tvshowRepo.SelectByParams(params, pgnt, curUserID)
+	tvshows, err := tu.tvshowRepo.SelectByParams(params, statuses, pgnt, curUserID)
 	if err != nil {
 		return nil, customErrors.New(CodeInternalError, err)
 	}
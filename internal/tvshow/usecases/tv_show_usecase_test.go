@@ -198,10 +198,10 @@ func TestTVShowUseCase_ListByParams_OK(t *testing.T) {
 
 	tvshowRep.
 		EXPECT().
-		SelectByParams(gomock.Eq(params), gomock.Eq(pgnt), gomock.Eq(userID)).
+		SelectByParams(gomock.Eq(params), gomock.Nil(), gomock.Eq(pgnt), gomock.Eq(userID)).
 		Return(tvshows, nil)
 
-	dbTVShows, err := tvshowUseCase.ListByParams(params, pgnt, userID)
+	dbTVShows, err := tvshowUseCase.ListByParams(params, nil, pgnt, userID)
 	assert.Equal(t, err, (*errors.Error)(nil))
 	assert.Equal(t, dbTVShows, tvshows)
 }
@@ -6,22 +6,40 @@ import (
 
 func GrpcUserToModel(grpcUser *User) *models.User {
 	return &models.User{
-		ID:       grpcUser.ID,
-		Nickname: grpcUser.Nickname,
-		Email:    grpcUser.Email,
-		Password: grpcUser.Password,
-		Avatar:   grpcUser.Avatar,
-		Role:     grpcUser.Role,
+		ID:            grpcUser.ID,
+		Nickname:      grpcUser.Nickname,
+		Email:         grpcUser.Email,
+		Password:      grpcUser.Password,
+		Avatar:        grpcUser.Avatar,
+		Role:          grpcUser.Role,
+		EmailVerified: grpcUser.EmailVerified,
 	}
 }
 
 func ModelUserToGrpc(modelUser *models.User) *User {
 	return &User{
-		ID:       modelUser.ID,
-		Nickname: modelUser.Nickname,
-		Email:    modelUser.Email,
-		Password: modelUser.Password,
-		Avatar:   modelUser.Avatar,
-		Role:     modelUser.Role,
+		ID:            modelUser.ID,
+		Nickname:      modelUser.Nickname,
+		Email:         modelUser.Email,
+		Password:      modelUser.Password,
+		Avatar:        modelUser.Avatar,
+		Role:          modelUser.Role,
+		EmailVerified: modelUser.EmailVerified,
+	}
+}
+
+func GrpcIdentityToModel(grpcIdentity *Identity) *models.Identity {
+	return &models.Identity{
+		Provider: grpcIdentity.Provider,
+		Subject:  grpcIdentity.Subject,
+		Email:    grpcIdentity.Email,
+	}
+}
+
+func ModelIdentityToGrpc(modelIdentity *models.Identity) *Identity {
+	return &Identity{
+		Provider: modelIdentity.Provider,
+		Subject:  modelIdentity.Subject,
+		Email:    modelIdentity.Email,
 	}
 }
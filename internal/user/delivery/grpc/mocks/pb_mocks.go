@@ -55,6 +55,26 @@ func (mr *MockUserBlockClientMockRecorder) Create(ctx, in interface{}, opts ...i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUserBlockClient)(nil).Create), varargs...)
 }
 
+// CreateWithIdentity mocks base method
+func (m *MockUserBlockClient) CreateWithIdentity(ctx context.Context, in *grpc.UserIdentity, opts ...grpc0.CallOption) (*grpc.User, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateWithIdentity", varargs...)
+	ret0, _ := ret[0].(*grpc.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWithIdentity indicates an expected call of CreateWithIdentity
+func (mr *MockUserBlockClientMockRecorder) CreateWithIdentity(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWithIdentity", reflect.TypeOf((*MockUserBlockClient)(nil).CreateWithIdentity), varargs...)
+}
+
 // GetByEmail mocks base method
 func (m *MockUserBlockClient) GetByEmail(ctx context.Context, in *grpc.Email, opts ...grpc0.CallOption) (*grpc.User, error) {
 	m.ctrl.T.Helper()
@@ -155,6 +175,86 @@ func (mr *MockUserBlockClientMockRecorder) UpdatePassword(ctx, in interface{}, o
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockUserBlockClient)(nil).UpdatePassword), varargs...)
 }
 
+// CreatePasswordResetToken mocks base method
+func (m *MockUserBlockClient) CreatePasswordResetToken(ctx context.Context, in *grpc.Email, opts ...grpc0.CallOption) (*grpc.Token, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreatePasswordResetToken", varargs...)
+	ret0, _ := ret[0].(*grpc.Token)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePasswordResetToken indicates an expected call of CreatePasswordResetToken
+func (mr *MockUserBlockClientMockRecorder) CreatePasswordResetToken(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePasswordResetToken", reflect.TypeOf((*MockUserBlockClient)(nil).CreatePasswordResetToken), varargs...)
+}
+
+// ResetPassword mocks base method
+func (m *MockUserBlockClient) ResetPassword(ctx context.Context, in *grpc.ResetPasswordMsg, opts ...grpc0.CallOption) (*grpc.User, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ResetPassword", varargs...)
+	ret0, _ := ret[0].(*grpc.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetPassword indicates an expected call of ResetPassword
+func (mr *MockUserBlockClientMockRecorder) ResetPassword(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockUserBlockClient)(nil).ResetPassword), varargs...)
+}
+
+// CreateVerificationToken mocks base method
+func (m *MockUserBlockClient) CreateVerificationToken(ctx context.Context, in *grpc.ID, opts ...grpc0.CallOption) (*grpc.Token, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateVerificationToken", varargs...)
+	ret0, _ := ret[0].(*grpc.Token)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVerificationToken indicates an expected call of CreateVerificationToken
+func (mr *MockUserBlockClientMockRecorder) CreateVerificationToken(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVerificationToken", reflect.TypeOf((*MockUserBlockClient)(nil).CreateVerificationToken), varargs...)
+}
+
+// VerifyEmail mocks base method
+func (m *MockUserBlockClient) VerifyEmail(ctx context.Context, in *grpc.Token, opts ...grpc0.CallOption) (*grpc.User, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "VerifyEmail", varargs...)
+	ret0, _ := ret[0].(*grpc.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail
+func (mr *MockUserBlockClientMockRecorder) VerifyEmail(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockUserBlockClient)(nil).VerifyEmail), varargs...)
+}
+
 // MockUserBlockServer is a mock of UserBlockServer interface
 type MockUserBlockServer struct {
 	ctrl     *gomock.Controller
@@ -193,6 +293,21 @@ func (mr *MockUserBlockServerMockRecorder) Create(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUserBlockServer)(nil).Create), arg0, arg1)
 }
 
+// CreateWithIdentity mocks base method
+func (m *MockUserBlockServer) CreateWithIdentity(arg0 context.Context, arg1 *grpc.UserIdentity) (*grpc.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWithIdentity", arg0, arg1)
+	ret0, _ := ret[0].(*grpc.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWithIdentity indicates an expected call of CreateWithIdentity
+func (mr *MockUserBlockServerMockRecorder) CreateWithIdentity(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWithIdentity", reflect.TypeOf((*MockUserBlockServer)(nil).CreateWithIdentity), arg0, arg1)
+}
+
 // GetByEmail mocks base method
 func (m *MockUserBlockServer) GetByEmail(arg0 context.Context, arg1 *grpc.Email) (*grpc.User, error) {
 	m.ctrl.T.Helper()
@@ -267,3 +382,63 @@ func (mr *MockUserBlockServerMockRecorder) UpdatePassword(arg0, arg1 interface{}
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockUserBlockServer)(nil).UpdatePassword), arg0, arg1)
 }
+
+// CreatePasswordResetToken mocks base method
+func (m *MockUserBlockServer) CreatePasswordResetToken(arg0 context.Context, arg1 *grpc.Email) (*grpc.Token, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePasswordResetToken", arg0, arg1)
+	ret0, _ := ret[0].(*grpc.Token)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePasswordResetToken indicates an expected call of CreatePasswordResetToken
+func (mr *MockUserBlockServerMockRecorder) CreatePasswordResetToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePasswordResetToken", reflect.TypeOf((*MockUserBlockServer)(nil).CreatePasswordResetToken), arg0, arg1)
+}
+
+// ResetPassword mocks base method
+func (m *MockUserBlockServer) ResetPassword(arg0 context.Context, arg1 *grpc.ResetPasswordMsg) (*grpc.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", arg0, arg1)
+	ret0, _ := ret[0].(*grpc.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetPassword indicates an expected call of ResetPassword
+func (mr *MockUserBlockServerMockRecorder) ResetPassword(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockUserBlockServer)(nil).ResetPassword), arg0, arg1)
+}
+
+// CreateVerificationToken mocks base method
+func (m *MockUserBlockServer) CreateVerificationToken(arg0 context.Context, arg1 *grpc.ID) (*grpc.Token, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVerificationToken", arg0, arg1)
+	ret0, _ := ret[0].(*grpc.Token)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVerificationToken indicates an expected call of CreateVerificationToken
+func (mr *MockUserBlockServerMockRecorder) CreateVerificationToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVerificationToken", reflect.TypeOf((*MockUserBlockServer)(nil).CreateVerificationToken), arg0, arg1)
+}
+
+// VerifyEmail mocks base method
+func (m *MockUserBlockServer) VerifyEmail(arg0 context.Context, arg1 *grpc.Token) (*grpc.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", arg0, arg1)
+	ret0, _ := ret[0].(*grpc.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail
+func (mr *MockUserBlockServerMockRecorder) VerifyEmail(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockUserBlockServer)(nil).VerifyEmail), arg0, arg1)
+}
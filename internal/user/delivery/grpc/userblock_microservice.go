@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/user"
 	"github.com/go-park-mail-ru/2020_2_Slash/pkg/sanitizer"
 	"golang.org/x/crypto/bcrypt"
@@ -12,17 +13,59 @@ import (
 	"google.golang.org/grpc/status"
 	"os"
 	"strings"
+	"time"
 )
 
 type UserblockMicroservice struct {
-	userRepo user.UserRepository
+	userRepo  user.UserRepository
+	tokenRepo user.UserTokenRepository
 }
 
-func NewUserblockMicroservice(userRepo user.UserRepository) UserBlockServer {
-	return &UserblockMicroservice{userRepo: userRepo}
+func NewUserblockMicroservice(userRepo user.UserRepository,
+	tokenRepo user.UserTokenRepository) UserBlockServer {
+	return &UserblockMicroservice{
+		userRepo:  userRepo,
+		tokenRepo: tokenRepo,
+	}
 }
 
 func (uu *UserblockMicroservice) Create(ctx context.Context, newUser *User) (*User, error) {
+	modelUser, err := uu.prepareNewUser(newUser)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uu.userRepo.Insert(modelUser); err != nil {
+		return nil, status.Error(codes.Code(consts.CodeInternalError), err.Error())
+	}
+	newUser.ID = modelUser.ID
+
+	return newUser, nil
+}
+
+// CreateWithIdentity registers user signed in through OIDC provider
+// and links the identity in the same transaction
+func (uu *UserblockMicroservice) CreateWithIdentity(ctx context.Context,
+	userIdentity *UserIdentity) (*User, error) {
+	newUser := userIdentity.GetUser()
+	if newUser == nil || userIdentity.GetIdentity() == nil {
+		return nil, status.Error(codes.Code(consts.CodeBadRequest), "")
+	}
+	modelUser, err := uu.prepareNewUser(newUser)
+	if err != nil {
+		return nil, err
+	}
+
+	identity := GrpcIdentityToModel(userIdentity.GetIdentity())
+	if err := uu.userRepo.InsertWithIdentity(modelUser, identity); err != nil {
+		return nil, status.Error(codes.Code(consts.CodeInternalError), err.Error())
+	}
+	newUser.ID = modelUser.ID
+
+	return newUser, nil
+}
+
+func (uu *UserblockMicroservice) prepareNewUser(newUser *User) (*models.User, error) {
 	sanitizer.Sanitize(newUser)
 	if err := uu.checkByEmail(newUser.Email); err == nil {
 		return nil, status.Error(codes.Code(consts.CodeEmailAlreadyExists), "")
@@ -40,13 +83,7 @@ func (uu *UserblockMicroservice) Create(ctx context.Context, newUser *User) (*Us
 	}
 	newUser.Password = string(hashedPassword)
 
-	modelUser := GrpcUserToModel(newUser)
-	if err := uu.userRepo.Insert(modelUser); err != nil {
-		return nil, status.Error(codes.Code(consts.CodeInternalError), err.Error())
-	}
-	newUser.ID = modelUser.ID
-
-	return newUser, nil
+	return GrpcUserToModel(newUser), nil
 }
 
 func (uu *UserblockMicroservice) GetByEmail(ctx context.Context, email *Email) (*User, error) {
@@ -84,6 +121,12 @@ func (uu *UserblockMicroservice) UpdateProfile(ctx context.Context, newUserData
 			return nil, status.Error(codes.Code(consts.CodeEmailAlreadyExists), "")
 		}
 		dbUser.Email = newUserData.Email
+		dbUser.EmailVerified = false
+
+		// Links sent to the old email must not verify the new one
+		if err := uu.tokenRepo.DeleteByUser(dbUser.ID); err != nil {
+			return nil, status.Error(codes.Code(consts.CodeInternalError), "")
+		}
 	}
 
 	// Update nickname
@@ -159,6 +202,92 @@ func (uu *UserblockMicroservice) UpdateAvatar(ctx context.Context, idAvatar *IdA
 	return dbUser, nil
 }
 
+func (uu *UserblockMicroservice) CreatePasswordResetToken(ctx context.Context, email *Email) (*Token, error) {
+	dbUser, err := uu.GetByEmail(context.Background(), email)
+	if err != nil {
+		return nil, err
+	}
+	return uu.createToken(dbUser.GetID(), consts.PasswordResetToken, consts.PasswordResetTokenTTL)
+}
+
+func (uu *UserblockMicroservice) ResetPassword(ctx context.Context, msg *ResetPasswordMsg) (*User, error) {
+	sanitizer.Sanitize(msg)
+
+	if msg.NewPassword == "" || msg.NewPassword != msg.RepeatedNewPassword {
+		return nil, status.Error(codes.Code(consts.CodePasswordsDoesNotMatch), "")
+	}
+
+	dbUser, err := uu.useToken(msg.GetToken(), consts.PasswordResetToken)
+	if err != nil {
+		return nil, err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(msg.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, status.Error(codes.Code(consts.CodeInternalError), err.Error())
+	}
+	dbUser.Password = string(hashedPassword)
+	// Reset link was received by email, so the email is confirmed
+	dbUser.EmailVerified = true
+
+	if err := uu.userRepo.Update(GrpcUserToModel(dbUser)); err != nil {
+		return nil, status.Error(codes.Code(consts.CodeInternalError), err.Error())
+	}
+	return dbUser, nil
+}
+
+func (uu *UserblockMicroservice) CreateVerificationToken(ctx context.Context, id *ID) (*Token, error) {
+	dbUser, err := uu.GetByID(context.Background(), id)
+	if err != nil {
+		return nil, err
+	}
+	if dbUser.GetEmailVerified() {
+		return nil, status.Error(codes.Code(consts.CodeEmailAlreadyVerified), "")
+	}
+	return uu.createToken(dbUser.GetID(), consts.EmailVerificationToken, consts.EmailVerificationTokenTTL)
+}
+
+func (uu *UserblockMicroservice) VerifyEmail(ctx context.Context, token *Token) (*User, error) {
+	dbUser, err := uu.useToken(token.GetToken(), consts.EmailVerificationToken)
+	if err != nil {
+		return nil, err
+	}
+
+	dbUser.EmailVerified = true
+	if err := uu.userRepo.Update(GrpcUserToModel(dbUser)); err != nil {
+		return nil, status.Error(codes.Code(consts.CodeInternalError), err.Error())
+	}
+	return dbUser, nil
+}
+
+func (uu *UserblockMicroservice) createToken(userID uint64, purpose string,
+	ttl time.Duration) (*Token, error) {
+	value, token, err := models.NewUserToken(userID, purpose, ttl)
+	if err != nil {
+		return nil, status.Error(codes.Code(consts.CodeInternalError), err.Error())
+	}
+	if err := uu.tokenRepo.Insert(token); err != nil {
+		return nil, status.Error(codes.Code(consts.CodeInternalError), err.Error())
+	}
+	return &Token{Token: value}, nil
+}
+
+// useToken deletes the token and returns its owner if the token is valid
+func (uu *UserblockMicroservice) useToken(value, purpose string) (*User, error) {
+	token, err := uu.tokenRepo.DeleteByHash(models.HashUserToken(value), purpose)
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, status.Error(codes.Code(consts.CodeInvalidUserToken), "")
+	case err != nil:
+		return nil, status.Error(codes.Code(consts.CodeInternalError), err.Error())
+	}
+
+	if token.ExpiresAt.Before(time.Now()) {
+		return nil, status.Error(codes.Code(consts.CodeInvalidUserToken), "")
+	}
+	return uu.GetByID(context.Background(), &ID{ID: token.UserID})
+}
+
 func (uu *UserblockMicroservice) checkByEmail(email string) error {
 	_, err := uu.GetByEmail(context.Background(), &Email{Email: email})
 	return err
@@ -2,13 +2,17 @@ package grpc
 
 import (
 	"context"
+	"database/sql"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/user/mocks"
 	"github.com/golang/mock/gomock"
 	_ "github.com/lib/pq"
+	"golang.org/x/crypto/bcrypt"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -51,7 +55,7 @@ import (
 //func TestUserblockMicroservice_Create_DB_OK(t *testing.T) {
 //	prepareTestDatabase()
 //	userRep := repository.NewUserPgRepository(db)
-//	userblockMicroservice := NewUserblockMicroservice(userRep)
+//	userblockMicroservice := NewUserblockMicroservice(userRep, tokenRep)
 //	userBuilder := NewUserBuilder()
 //	user := userBuilder.CreateRegularUser()
 //
@@ -65,7 +69,7 @@ import (
 //func TestUserblockMicroservice_Create_DB_EmailConflict(t *testing.T) {
 //	prepareTestDatabase()
 //	userRep := repository.NewUserPgRepository(db)
-//	userblockMicroservice := NewUserblockMicroservice(userRep)
+//	userblockMicroservice := NewUserblockMicroservice(userRep, tokenRep)
 //	userBuilder := NewUserBuilder()
 //	existedUser := userBuilder.CreateNinthUserFromDB()
 //
@@ -79,7 +83,7 @@ import (
 //func TestUserblockMicroservice_Create_DB_EmptyNickname(t *testing.T) {
 //	prepareTestDatabase()
 //	userRep := repository.NewUserPgRepository(db)
-//	userblockMicroservice := NewUserblockMicroservice(userRep)
+//	userblockMicroservice := NewUserblockMicroservice(userRep, tokenRep)
 //	userBuilder := NewUserBuilder()
 //	emptyNicknameUser := userBuilder.CreateUserWithEmptyNickname()
 //
@@ -92,7 +96,7 @@ import (
 //func TestUserblockMicroservice_UpdateProfile_DB_OK(t *testing.T) {
 //	prepareTestDatabase()
 //	userRep := repository.NewUserPgRepository(db)
-//	userblockMicroservice := NewUserblockMicroservice(userRep)
+//	userblockMicroservice := NewUserblockMicroservice(userRep, tokenRep)
 //	userBuilder := NewUserBuilder()
 //	userForUpdate := userBuilder.CreateNinthUserFromDB()
 //	newNickname, newEmail := "new nickname", "newEmail@mail.ru"
@@ -109,7 +113,7 @@ import (
 //func TestUserblockMicroservice_UpdateProfile_DB_EmailConflicts(t *testing.T) {
 //	prepareTestDatabase()
 //	userRep := repository.NewUserPgRepository(db)
-//	userblockMicroservice := NewUserblockMicroservice(userRep)
+//	userblockMicroservice := NewUserblockMicroservice(userRep, tokenRep)
 //	userBuilder := NewUserBuilder()
 //	userForUpdate := userBuilder.CreateUserWithConflictEmail()
 //
@@ -123,7 +127,7 @@ import (
 //func TestUserblockMicroservice_UpdateProfile_DB_IdDoesNotExist(t *testing.T) {
 //	prepareTestDatabase()
 //	userRep := repository.NewUserPgRepository(db)
-//	userblockMicroservice := NewUserblockMicroservice(userRep)
+//	userblockMicroservice := NewUserblockMicroservice(userRep, tokenRep)
 //	userBuilder := NewUserBuilder()
 //	userForUpdate := userBuilder.CreateUserWithNotExistedID()
 //
@@ -137,7 +141,7 @@ import (
 //func TestUserblockMicroservice_GetByID_DB_OK(t *testing.T) {
 //	prepareTestDatabase()
 //	userRep := repository.NewUserPgRepository(db)
-//	userblockMicroservice := NewUserblockMicroservice(userRep)
+//	userblockMicroservice := NewUserblockMicroservice(userRep, tokenRep)
 //	userBuilder := NewUserBuilder()
 //	ninthUserFromDB := userBuilder.CreateNinthUserFromDB()
 //
@@ -150,7 +154,7 @@ import (
 //func TestUserblockMicroservice_GetByID_DB_NoUserWithThisID(t *testing.T) {
 //	prepareTestDatabase()
 //	userRep := repository.NewUserPgRepository(db)
-//	userblockMicroservice := NewUserblockMicroservice(userRep)
+//	userblockMicroservice := NewUserblockMicroservice(userRep, tokenRep)
 //	userBuilder := NewUserBuilder()
 //	userWithNotExistedID := userBuilder.CreateUserWithNotExistedID()
 //
@@ -168,7 +172,8 @@ func TestUserUseCase_UpdateAvatar_OK(t *testing.T) {
 	defer ctrl.Finish()
 
 	userRep := mocks.NewMockUserRepository(ctrl)
-	userblockMicroservice := NewUserblockMicroservice(userRep)
+	tokenRep := mocks.NewMockUserTokenRepository(ctrl)
+	userblockMicroservice := NewUserblockMicroservice(userRep, tokenRep)
 	userBuilder := NewUserBuilder()
 	regularUser := userBuilder.CreateRegularUser()
 	newAvatar := "/avatar"
@@ -201,7 +206,8 @@ func TestUserUseCase_Create_OK(t *testing.T) {
 	defer ctrl.Finish()
 
 	userRep := mocks.NewMockUserRepository(ctrl)
-	userblockMicroservice := NewUserblockMicroservice(userRep)
+	tokenRep := mocks.NewMockUserTokenRepository(ctrl)
+	userblockMicroservice := NewUserblockMicroservice(userRep, tokenRep)
 	userBuilder := NewUserBuilder()
 	regularUser := userBuilder.CreateRegularUser()
 	modelUser := GrpcUserToModel(regularUser)
@@ -220,7 +226,8 @@ func TestUserUseCase_Create_Fail(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	userRep := mocks.NewMockUserRepository(ctrl)
-	userblockMicroservice := NewUserblockMicroservice(userRep)
+	tokenRep := mocks.NewMockUserTokenRepository(ctrl)
+	userblockMicroservice := NewUserblockMicroservice(userRep, tokenRep)
 
 	userBuilder := NewUserBuilder()
 	regularUser := userBuilder.CreateRegularUser()
@@ -235,13 +242,72 @@ func TestUserUseCase_Create_Fail(t *testing.T) {
 	assert.Equal(t, err, status.Error(codes.Code(consts.CodeEmailAlreadyExists), ""))
 }
 
+func TestUserblockMicroservice_CreateWithIdentity_OK(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	userRep := mocks.NewMockUserRepository(ctrl)
+	tokenRep := mocks.NewMockUserTokenRepository(ctrl)
+	userblockMicroservice := NewUserblockMicroservice(userRep, tokenRep)
+
+	userBuilder := NewUserBuilder()
+	regularUser := userBuilder.CreateRegularUser()
+	identity := &Identity{
+		Provider: "google",
+		Subject:  "1234567890",
+		Email:    regularUser.Email,
+	}
+
+	userRep.
+		EXPECT().
+		SelectByEmail(gomock.Eq(regularUser.Email)).
+		Return(nil, sql.ErrNoRows)
+
+	userRep.
+		EXPECT().
+		InsertWithIdentity(gomock.Any(), GrpcIdentityToModel(identity)).
+		DoAndReturn(func(user *models.User, identity *models.Identity) error {
+			user.ID = 5
+			identity.UserID = user.ID
+			return nil
+		})
+
+	newUser, err := userblockMicroservice.CreateWithIdentity(context.Background(),
+		&UserIdentity{User: regularUser, Identity: identity})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5), newUser.ID)
+}
+
+func TestUserblockMicroservice_CreateWithIdentity_EmailAlreadyExists(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	userRep := mocks.NewMockUserRepository(ctrl)
+	tokenRep := mocks.NewMockUserTokenRepository(ctrl)
+	userblockMicroservice := NewUserblockMicroservice(userRep, tokenRep)
+
+	userBuilder := NewUserBuilder()
+	regularUser := userBuilder.CreateRegularUser()
+	modelUser := GrpcUserToModel(regularUser)
+
+	userRep.
+		EXPECT().
+		SelectByEmail(gomock.Eq(modelUser.Email)).
+		Return(modelUser, nil)
+
+	_, err := userblockMicroservice.CreateWithIdentity(context.Background(),
+		&UserIdentity{User: regularUser, Identity: &Identity{Provider: "google"}})
+	assert.Equal(t, err, status.Error(codes.Code(consts.CodeEmailAlreadyExists), ""))
+}
+
 func TestUserUseCase_Update_Fail(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	userRep := mocks.NewMockUserRepository(ctrl)
-	userblockMicroservice := NewUserblockMicroservice(userRep)
+	tokenRep := mocks.NewMockUserTokenRepository(ctrl)
+	userblockMicroservice := NewUserblockMicroservice(userRep, tokenRep)
 
 	userBuilder := NewUserBuilder()
 	regularUser := userBuilder.CreateRegularUser()
@@ -269,7 +335,8 @@ func TestUserUseCase_GetByID_OK(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	userRep := mocks.NewMockUserRepository(ctrl)
-	userblockMicroservice := NewUserblockMicroservice(userRep)
+	tokenRep := mocks.NewMockUserTokenRepository(ctrl)
+	userblockMicroservice := NewUserblockMicroservice(userRep, tokenRep)
 
 	userBuilder := NewUserBuilder()
 	regularUser := userBuilder.CreateRegularUser()
@@ -290,7 +357,8 @@ func TestUserUseCase_GetByEmail_OK(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	userRep := mocks.NewMockUserRepository(ctrl)
-	userblockMicroservice := NewUserblockMicroservice(userRep)
+	tokenRep := mocks.NewMockUserTokenRepository(ctrl)
+	userblockMicroservice := NewUserblockMicroservice(userRep, tokenRep)
 
 	userBuilder := NewUserBuilder()
 	regularUser := userBuilder.CreateRegularUser()
@@ -305,3 +373,216 @@ func TestUserUseCase_GetByEmail_OK(t *testing.T) {
 	assert.Equal(t, err, (error)(nil))
 	assert.Equal(t, dbUser, regularUser)
 }
+
+func TestUserblockMicroservice_CreatePasswordResetToken_OK(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	userRep := mocks.NewMockUserRepository(ctrl)
+	tokenRep := mocks.NewMockUserTokenRepository(ctrl)
+	userblockMicroservice := NewUserblockMicroservice(userRep, tokenRep)
+
+	regularUser := NewUserBuilder().CreateRegularUser()
+
+	userRep.
+		EXPECT().
+		SelectByEmail(gomock.Eq(regularUser.Email)).
+		Return(GrpcUserToModel(regularUser), nil)
+
+	var stored *models.UserToken
+	tokenRep.
+		EXPECT().
+		Insert(gomock.Any()).
+		DoAndReturn(func(token *models.UserToken) error {
+			stored = token
+			return nil
+		})
+
+	token, err := userblockMicroservice.CreatePasswordResetToken(context.Background(),
+		&Email{Email: regularUser.Email})
+	assert.Equal(t, err, (error)(nil))
+	assert.Equal(t, regularUser.ID, stored.UserID)
+	assert.Equal(t, consts.PasswordResetToken, stored.Purpose)
+	assert.Equal(t, models.HashUserToken(token.Token), stored.Hash)
+}
+
+func TestUserblockMicroservice_ResetPassword_OK(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	userRep := mocks.NewMockUserRepository(ctrl)
+	tokenRep := mocks.NewMockUserTokenRepository(ctrl)
+	userblockMicroservice := NewUserblockMicroservice(userRep, tokenRep)
+
+	regularUser := NewUserBuilder().CreateRegularUser()
+	value, token, err := models.NewUserToken(regularUser.ID, consts.PasswordResetToken,
+		consts.PasswordResetTokenTTL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tokenRep.
+		EXPECT().
+		DeleteByHash(gomock.Eq(token.Hash), gomock.Eq(consts.PasswordResetToken)).
+		Return(token, nil)
+
+	userRep.
+		EXPECT().
+		SelectByID(gomock.Eq(regularUser.ID)).
+		Return(GrpcUserToModel(regularUser), nil)
+
+	userRep.
+		EXPECT().
+		Update(gomock.Any()).
+		Return(nil)
+
+	dbUser, err := userblockMicroservice.ResetPassword(context.Background(), &ResetPasswordMsg{
+		Token:               value,
+		NewPassword:         "new_password",
+		RepeatedNewPassword: "new_password",
+	})
+	assert.Equal(t, err, (error)(nil))
+	assert.True(t, dbUser.EmailVerified)
+	assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(dbUser.Password), []byte("new_password")))
+}
+
+func TestUserblockMicroservice_ResetPassword_TokenExpired(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	userRep := mocks.NewMockUserRepository(ctrl)
+	tokenRep := mocks.NewMockUserTokenRepository(ctrl)
+	userblockMicroservice := NewUserblockMicroservice(userRep, tokenRep)
+
+	value, token, err := models.NewUserToken(1, consts.PasswordResetToken, -time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tokenRep.
+		EXPECT().
+		DeleteByHash(gomock.Eq(token.Hash), gomock.Eq(consts.PasswordResetToken)).
+		Return(token, nil)
+
+	_, err = userblockMicroservice.ResetPassword(context.Background(), &ResetPasswordMsg{
+		Token:               value,
+		NewPassword:         "new_password",
+		RepeatedNewPassword: "new_password",
+	})
+	assert.Equal(t, err, status.Error(codes.Code(consts.CodeInvalidUserToken), ""))
+}
+
+func TestUserblockMicroservice_VerifyEmail_UnknownToken(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	userRep := mocks.NewMockUserRepository(ctrl)
+	tokenRep := mocks.NewMockUserTokenRepository(ctrl)
+	userblockMicroservice := NewUserblockMicroservice(userRep, tokenRep)
+
+	tokenRep.
+		EXPECT().
+		DeleteByHash(gomock.Eq(models.HashUserToken("unknown")), gomock.Eq(consts.EmailVerificationToken)).
+		Return(nil, sql.ErrNoRows)
+
+	_, err := userblockMicroservice.VerifyEmail(context.Background(), &Token{Token: "unknown"})
+	assert.Equal(t, err, status.Error(codes.Code(consts.CodeInvalidUserToken), ""))
+}
+
+func TestUserblockMicroservice_VerifyEmail_OK(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	userRep := mocks.NewMockUserRepository(ctrl)
+	tokenRep := mocks.NewMockUserTokenRepository(ctrl)
+	userblockMicroservice := NewUserblockMicroservice(userRep, tokenRep)
+
+	regularUser := NewUserBuilder().CreateRegularUser()
+	value, token, err := models.NewUserToken(regularUser.ID, consts.EmailVerificationToken,
+		consts.EmailVerificationTokenTTL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifiedUser := GrpcUserToModel(regularUser)
+	verifiedUser.EmailVerified = true
+
+	tokenRep.
+		EXPECT().
+		DeleteByHash(gomock.Eq(token.Hash), gomock.Eq(consts.EmailVerificationToken)).
+		Return(token, nil)
+
+	userRep.
+		EXPECT().
+		SelectByID(gomock.Eq(regularUser.ID)).
+		Return(GrpcUserToModel(regularUser), nil)
+
+	userRep.
+		EXPECT().
+		Update(gomock.Eq(verifiedUser)).
+		Return(nil)
+
+	dbUser, err := userblockMicroservice.VerifyEmail(context.Background(), &Token{Token: value})
+	assert.Equal(t, err, (error)(nil))
+	assert.True(t, dbUser.EmailVerified)
+}
+
+func TestUserblockMicroservice_CreateVerificationToken_AlreadyVerified(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	userRep := mocks.NewMockUserRepository(ctrl)
+	tokenRep := mocks.NewMockUserTokenRepository(ctrl)
+	userblockMicroservice := NewUserblockMicroservice(userRep, tokenRep)
+
+	verifiedUser := NewUserBuilder().CreateRegularUserModel()
+	verifiedUser.EmailVerified = true
+
+	userRep.
+		EXPECT().
+		SelectByID(gomock.Eq(verifiedUser.ID)).
+		Return(verifiedUser, nil)
+
+	_, err := userblockMicroservice.CreateVerificationToken(context.Background(), &ID{ID: verifiedUser.ID})
+	assert.Equal(t, err, status.Error(codes.Code(consts.CodeEmailAlreadyVerified), ""))
+}
+
+func TestUserblockMicroservice_UpdateProfile_EmailChangedRevokesTokens(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	userRep := mocks.NewMockUserRepository(ctrl)
+	tokenRep := mocks.NewMockUserTokenRepository(ctrl)
+	userblockMicroservice := NewUserblockMicroservice(userRep, tokenRep)
+
+	regularUser := NewUserBuilder().CreateRegularUser()
+	regularUser.Password = ""
+	userInDatabaseModel := GrpcUserToModel(regularUser)
+	userInDatabaseModel.Email = "oldEmail@mail.ru"
+	userInDatabaseModel.EmailVerified = true
+	updatedUser := GrpcUserToModel(regularUser)
+	updatedUser.EmailVerified = false
+
+	userRep.
+		EXPECT().
+		SelectByID(gomock.Eq(regularUser.ID)).
+		Return(userInDatabaseModel, nil)
+
+	userRep.
+		EXPECT().
+		SelectByEmail(gomock.Eq(regularUser.Email)).
+		Return(nil, sql.ErrNoRows)
+
+	tokenRep.
+		EXPECT().
+		DeleteByUser(gomock.Eq(regularUser.ID)).
+		Return(nil)
+
+	userRep.
+		EXPECT().
+		Update(gomock.Eq(updatedUser)).
+		Return(nil)
+
+	dbUser, err := userblockMicroservice.UpdateProfile(context.Background(), regularUser)
+	assert.Equal(t, err, (error)(nil))
+	assert.False(t, dbUser.EmailVerified)
+}
@@ -1,14 +1,12 @@
 package delivery
 
 import (
-	"io"
 	"net/http"
-	"os"
-	"path/filepath"
 
 	. "github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/job"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/mwares"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/session"
@@ -25,21 +23,31 @@ import (
 type UserHandler struct {
 	userUcase user.UserUsecase
 	sessUcase session.SessionUsecase
+	jobUcase  job.JobUsecase
 }
 
-func NewUserHandler(userUcase user.UserUsecase, sessUcase session.SessionUsecase) *UserHandler {
+func NewUserHandler(userUcase user.UserUsecase, sessUcase session.SessionUsecase,
+	jobUcase job.JobUsecase) *UserHandler {
 	return &UserHandler{
 		userUcase: userUcase,
 		sessUcase: sessUcase,
+		jobUcase:  jobUcase,
 	}
 }
 
 func (uh *UserHandler) Configure(e *echo.Echo, mw *mwares.MiddlewareManager) {
-	e.POST("/api/v1/user/register", uh.RegisterUserHandler())
+	e.POST("/api/v1/user/register", uh.RegisterUserHandler(), middleware.BodyLimit("4K"), mw.RateLimit(RegisterRateLimit))
 	e.GET("/api/v1/user/profile", uh.GetUserProfileHandler(), mw.CheckAuth)
 	e.PUT("/api/v1/user/profile", uh.UpdateUserProfileHandler(), mw.CheckAuth, mw.CheckCSRF)
 	e.PUT("/api/v1/user/password", uh.UpdateUserPassword(), mw.CheckAuth, mw.CheckCSRF)
 	e.POST("/api/v1/user/avatar", uh.UpdateAvatarHandler(), mw.CheckAuth, middleware.BodyLimit("10M"), mw.CheckCSRF)
+	e.POST("/api/v1/user/password/reset", uh.RequestPasswordResetHandler(),
+		middleware.BodyLimit("4K"), mw.RateLimit(PasswordResetRateLimit))
+	e.POST("/api/v1/user/password/reset/confirm", uh.ResetPasswordHandler(),
+		middleware.BodyLimit("4K"), mw.RateLimit(PasswordResetRateLimit))
+	e.POST("/api/v1/user/verify", uh.VerifyEmailHandler(),
+		middleware.BodyLimit("4K"), mw.RateLimit(VerifyEmailRateLimit))
+	e.POST("/api/v1/user/verify/resend", uh.ResendVerificationHandler(), mw.CheckAuth, mw.CheckCSRF)
 }
 
 func (uh *UserHandler) RegisterUserHandler() echo.HandlerFunc {
@@ -65,11 +73,22 @@ func (uh *UserHandler) RegisterUserHandler() echo.HandlerFunc {
 		}
 
 		if err := uh.userUcase.Create(user); err != nil {
+			if err.Code == CodeEmailAlreadyExists {
+				cntx.Set(RateLimitCountKey, true)
+			}
 			logger.Error(err.Message)
 			return cntx.JSON(err.HTTPCode, Response{Error: err})
 		}
 
-		sess := models.NewSession(user.ID)
+		// Registration is not rolled back if mail is not delivered,
+		// verification can be requested again
+		if err := uh.userUcase.SendVerification(user.ID); err != nil {
+			logger.Error(err.Message)
+		}
+
+		sess := models.NewSession(user.ID, false)
+		sess.UserAgent = cntx.Request().UserAgent()
+		sess.IP = cntx.RealIP()
 		if err := uh.sessUcase.Create(sess); err != nil {
 			logger.Error(err.Message)
 			return cntx.JSON(err.HTTPCode, Response{Error: err})
@@ -184,6 +203,14 @@ func (uh *UserHandler) UpdateUserPassword() echo.HandlerFunc {
 			return cntx.JSON(err.HTTPCode, Response{Error: err})
 		}
 
+		// Other devices have to log in with the new password
+		// nolint: errcheck
+		sessValue, _ := cntx.Get("sessValue").(string)
+		if err := uh.sessUcase.DeleteAllForUser(userID, sessValue); err != nil {
+			logger.Error(err.Message)
+			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		}
+
 		return cntx.JSON(http.StatusOK, Response{
 			Body: &Body{
 				"user": user,
@@ -202,14 +229,6 @@ func (uh *UserHandler) UpdateAvatarHandler() echo.HandlerFunc {
 			return cntx.JSON(customErr.HTTPCode, Response{Error: customErr})
 		}
 
-		imageFile, err := image.Open()
-		if err != nil {
-			logger.Error(err)
-			customErr := errors.New(CodeBadRequest, err)
-			return cntx.JSON(customErr.HTTPCode, Response{Error: customErr})
-		}
-		defer imageFile.Close()
-
 		fileExtension, err := helpers.GetImageExtension(image)
 		if err != nil {
 			logger.Error(err)
@@ -226,38 +245,130 @@ func (uh *UserHandler) UpdateAvatarHandler() echo.HandlerFunc {
 
 		newAvatarFileName := helpers.GetUniqFileName(userID, fileExtension)
 		rltNewAvatarFilePath := avatarsDir + newAvatarFileName
-		absNewAvatarFilePath := "." + rltNewAvatarFilePath
-		fileMode := int(0777)
+		absStagedPath := helpers.GetStagedPath("." + rltNewAvatarFilePath)
 
-		// Save image to storage
-		newAvatarFile, err := os.OpenFile(filepath.Clean(absNewAvatarFilePath), os.O_WRONLY|os.O_CREATE, os.FileMode(fileMode))
-		if err != nil {
-			logger.Error(err)
-			customErr := errors.New(CodeInternalError, err)
-			return cntx.JSON(customErr.HTTPCode, Response{Error: customErr})
-		}
-		defer newAvatarFile.Close()
-
-		if _, err := io.Copy(newAvatarFile, imageFile); err != nil {
-			removeErr := os.Remove(absNewAvatarFilePath)
-			if removeErr != nil {
-				logger.Error(err)
-			}
-			logger.Error(err)
-			customErr := errors.New(CodeInternalError, err)
+		// Stage image, it is moved to storage by background job
+		if customErr := helpers.StoreFile(image, absStagedPath); customErr != nil {
+			logger.Error(customErr.Message)
 			return cntx.JSON(customErr.HTTPCode, Response{Error: customErr})
 		}
 
-		_, customErr = uh.userUcase.UpdateAvatar(userID, rltNewAvatarFilePath)
+		job, customErr := uh.jobUcase.Enqueue(JobUserAvatar, &models.UserAvatarPayload{
+			UserID:     userID,
+			AvatarPath: rltNewAvatarFilePath,
+		})
 		if customErr != nil {
+			helpers.RemoveStagedFile(absStagedPath)
 			logger.Error(customErr.Message)
 			return cntx.JSON(customErr.HTTPCode, Response{Error: customErr})
 		}
 
+		return cntx.JSON(http.StatusAccepted, Response{
+			Body: &Body{
+				"job": job,
+			},
+		})
+	}
+}
+
+func (uh *UserHandler) RequestPasswordResetHandler() echo.HandlerFunc {
+	type Request struct {
+		Email string `json:"email" validate:"required,email,lte=64"`
+	}
+
+	return func(cntx echo.Context) error {
+		req := &Request{}
+		if err := reader.NewRequestReader(cntx).Read(req); err != nil {
+			logger.Error(err.Message)
+			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		}
+
+		// Every reset mail is counted, so the address can't be flooded
+		cntx.Set(RateLimitCountKey, true)
+		if err := uh.userUcase.RequestPasswordReset(req.Email); err != nil {
+			logger.Error(err.Message)
+			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		}
+
+		return cntx.JSON(http.StatusOK, Response{
+			Message: "success",
+		})
+	}
+}
+
+func (uh *UserHandler) ResetPasswordHandler() echo.HandlerFunc {
+	type Request struct {
+		Token               string `json:"token" validate:"required"`
+		NewPassword         string `json:"new_password" validate:"required,gte=6,lte=32"`
+		RepeatedNewPassword string `json:"repeated_new_password" validate:"required,gte=6,lte=32"`
+	}
+
+	return func(cntx echo.Context) error {
+		req := &Request{}
+		if err := reader.NewRequestReader(cntx).Read(req); err != nil {
+			logger.Error(err.Message)
+			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		}
+
+		user, err := uh.userUcase.ResetPassword(req.Token, req.NewPassword,
+			req.RepeatedNewPassword)
+		if err != nil {
+			logger.Error(err.Message)
+			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		}
+
+		// Whoever knew the old password has to log in again
+		if err := uh.sessUcase.DeleteAllForUser(user.ID, ""); err != nil {
+			logger.Error(err.Message)
+			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		}
+
 		return cntx.JSON(http.StatusOK, Response{
 			Body: &Body{
-				"avatar": newAvatarFileName,
+				"user": user,
 			},
 		})
 	}
 }
+
+func (uh *UserHandler) VerifyEmailHandler() echo.HandlerFunc {
+	type Request struct {
+		Token string `json:"token" validate:"required"`
+	}
+
+	return func(cntx echo.Context) error {
+		req := &Request{}
+		if err := reader.NewRequestReader(cntx).Read(req); err != nil {
+			logger.Error(err.Message)
+			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		}
+
+		user, err := uh.userUcase.VerifyEmail(req.Token)
+		if err != nil {
+			logger.Error(err.Message)
+			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		}
+
+		return cntx.JSON(http.StatusOK, Response{
+			Body: &Body{
+				"user": user,
+			},
+		})
+	}
+}
+
+func (uh *UserHandler) ResendVerificationHandler() echo.HandlerFunc {
+	return func(cntx echo.Context) error {
+		// nolint: errcheck
+		userID, _ := cntx.Get("userID").(uint64)
+
+		if err := uh.userUcase.SendVerification(userID); err != nil {
+			logger.Error(err.Message)
+			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		}
+
+		return cntx.JSON(http.StatusOK, Response{
+			Message: "success",
+		})
+	}
+}
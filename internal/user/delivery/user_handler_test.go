@@ -3,6 +3,7 @@ package delivery
 import (
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	jobMocks "github.com/go-park-mail-ru/2020_2_Slash/internal/job/mocks"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
 	sessMocks "github.com/go-park-mail-ru/2020_2_Slash/internal/session/mocks"
 	userMocks "github.com/go-park-mail-ru/2020_2_Slash/internal/user/mocks"
@@ -27,6 +28,7 @@ func TestUserHandler_CreateUserHandler_EmailAlreadyExists(t *testing.T) {
 	defer ctrl.Finish()
 	userUseCase := userMocks.NewMockUserUsecase(ctrl)
 	sessUseCase := sessMocks.NewMockSessionUsecase(ctrl)
+	jobUseCase := jobMocks.NewMockJobUsecase(ctrl)
 
 	type Request struct {
 		Nickname         string `json:"nickname" validate:"gte=3,lte=32"`
@@ -59,7 +61,7 @@ func TestUserHandler_CreateUserHandler_EmailAlreadyExists(t *testing.T) {
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
-	userHandler := NewUserHandler(userUseCase, sessUseCase)
+	userHandler := NewUserHandler(userUseCase, sessUseCase, jobUseCase)
 	handleFunc := userHandler.RegisterUserHandler()
 	userHandler.Configure(e, nil)
 
@@ -93,6 +95,7 @@ func TestUserHandler_UpdateUserHandler(t *testing.T) {
 	defer ctrl.Finish()
 	userUseCase := userMocks.NewMockUserUsecase(ctrl)
 	sessUseCase := sessMocks.NewMockSessionUsecase(ctrl)
+	jobUseCase := jobMocks.NewMockJobUsecase(ctrl)
 
 	type Request struct {
 		Nickname string `json:"nickname" validate:"omitempty,gte=3,lte=32"`
@@ -125,7 +128,7 @@ func TestUserHandler_UpdateUserHandler(t *testing.T) {
 	c := e.NewContext(req, rec)
 	c.Set("userID", userInst.ID)
 
-	userHandler := NewUserHandler(userUseCase, sessUseCase)
+	userHandler := NewUserHandler(userUseCase, sessUseCase, jobUseCase)
 	handleFunc := userHandler.UpdateUserProfileHandler()
 	userHandler.Configure(e, nil)
 
@@ -159,6 +162,7 @@ func TestUserHandler_GetUserProfileHandler(t *testing.T) {
 	defer ctrl.Finish()
 	userUseCase := userMocks.NewMockUserUsecase(ctrl)
 	sessUseCase := sessMocks.NewMockSessionUsecase(ctrl)
+	jobUseCase := jobMocks.NewMockJobUsecase(ctrl)
 
 	var userInst = &models.User{
 		Nickname: "Jhon",
@@ -174,7 +178,7 @@ func TestUserHandler_GetUserProfileHandler(t *testing.T) {
 	c := e.NewContext(req, rec)
 	c.Set("userID", userInst.ID)
 
-	userHandler := NewUserHandler(userUseCase, sessUseCase)
+	userHandler := NewUserHandler(userUseCase, sessUseCase, jobUseCase)
 	handleFunc := userHandler.GetUserProfileHandler()
 	userHandler.Configure(e, nil)
 
@@ -208,6 +212,7 @@ func TestUserHandler_UpdateAvatarHandler_Fail(t *testing.T) {
 	defer ctrl.Finish()
 	userUseCase := userMocks.NewMockUserUsecase(ctrl)
 	sessUseCase := sessMocks.NewMockSessionUsecase(ctrl)
+	jobUseCase := jobMocks.NewMockJobUsecase(ctrl)
 
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodPost, "/api/v1/users/avatar", strings.NewReader(""))
@@ -215,7 +220,7 @@ func TestUserHandler_UpdateAvatarHandler_Fail(t *testing.T) {
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
 
-	userHandler := NewUserHandler(userUseCase, sessUseCase)
+	userHandler := NewUserHandler(userUseCase, sessUseCase, jobUseCase)
 	handleFunc := userHandler.UpdateAvatarHandler()
 	userHandler.Configure(e, nil)
 
@@ -241,3 +246,144 @@ func TestUserHandler_UpdateAvatarHandler_Fail(t *testing.T) {
 		assert.JSONEq(t, expResBody.String(), string(bytes))
 	}
 }
+
+func TestUserHandler_ResetPasswordHandler_OK(t *testing.T) {
+	t.Parallel()
+	// Setup
+	logger.DisableLogger()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	userUseCase := userMocks.NewMockUserUsecase(ctrl)
+	sessUseCase := sessMocks.NewMockSessionUsecase(ctrl)
+	jobUseCase := jobMocks.NewMockJobUsecase(ctrl)
+
+	type Request struct {
+		Token               string `json:"token"`
+		NewPassword         string `json:"new_password"`
+		RepeatedNewPassword string `json:"repeated_new_password"`
+	}
+
+	var reqInst = &Request{
+		Token:               "abc",
+		NewPassword:         "new_password",
+		RepeatedNewPassword: "new_password",
+	}
+
+	var userInst = &models.User{
+		ID:            3,
+		Nickname:      "Jhon",
+		Email:         "jhon@gmail.com",
+		EmailVerified: true,
+	}
+
+	reqJSON, err := converter.AnyToBytesBuffer(reqInst)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/user/password/reset/confirm",
+		strings.NewReader(reqJSON.String()))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	userHandler := NewUserHandler(userUseCase, sessUseCase, jobUseCase)
+	handleFunc := userHandler.ResetPasswordHandler()
+
+	userUseCase.
+		EXPECT().
+		ResetPassword(reqInst.Token, reqInst.NewPassword, reqInst.RepeatedNewPassword).
+		Return(userInst, nil)
+
+	sessUseCase.
+		EXPECT().
+		DeleteAllForUser(userInst.ID, "").
+		Return(nil)
+
+	response := &response.Response{Body: &response.Body{"user": userInst}}
+
+	// Assertions
+	if assert.NoError(t, handleFunc(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		expResBody, err := converter.AnyToBytesBuffer(response)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		bytes, _ := ioutil.ReadAll(rec.Body)
+
+		assert.JSONEq(t, expResBody.String(), string(bytes))
+	}
+}
+
+func TestUserHandler_RequestPasswordResetHandler_OK(t *testing.T) {
+	t.Parallel()
+	// Setup
+	logger.DisableLogger()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	userUseCase := userMocks.NewMockUserUsecase(ctrl)
+	sessUseCase := sessMocks.NewMockSessionUsecase(ctrl)
+	jobUseCase := jobMocks.NewMockJobUsecase(ctrl)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/user/password/reset",
+		strings.NewReader(`{"email":"jhon@gmail.com"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	userHandler := NewUserHandler(userUseCase, sessUseCase, jobUseCase)
+	handleFunc := userHandler.RequestPasswordResetHandler()
+
+	userUseCase.
+		EXPECT().
+		RequestPasswordReset("jhon@gmail.com").
+		Return(nil)
+
+	// Assertions
+	if assert.NoError(t, handleFunc(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+}
+
+func TestUserHandler_VerifyEmailHandler_InvalidToken(t *testing.T) {
+	t.Parallel()
+	// Setup
+	logger.DisableLogger()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	userUseCase := userMocks.NewMockUserUsecase(ctrl)
+	sessUseCase := sessMocks.NewMockSessionUsecase(ctrl)
+	jobUseCase := jobMocks.NewMockJobUsecase(ctrl)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/user/verify",
+		strings.NewReader(`{"token":"abc"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	userHandler := NewUserHandler(userUseCase, sessUseCase, jobUseCase)
+	handleFunc := userHandler.VerifyEmailHandler()
+
+	userUseCase.
+		EXPECT().
+		VerifyEmail("abc").
+		Return(nil, errors.Get(consts.CodeInvalidUserToken))
+
+	response := &response.Response{Error: errors.Get(consts.CodeInvalidUserToken)}
+
+	// Assertions
+	if assert.NoError(t, handleFunc(c)) {
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+		expResBody, err := converter.AnyToBytesBuffer(response)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		bytes, _ := ioutil.ReadAll(rec.Body)
+
+		assert.JSONEq(t, expResBody.String(), string(bytes))
+	}
+}
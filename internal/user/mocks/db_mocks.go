@@ -1,6 +1,7 @@
 package mocks
 
 import (
+	"database/sql"
 	"errors"
 
 	"github.com/DATA-DOG/go-sqlmock"
@@ -11,7 +12,8 @@ func MockUserRepoInsertReturnRows(mock sqlmock.Sqlmock, user *models.User) {
 	mock.ExpectBegin()
 	insertAnswer := sqlmock.NewRows([]string{"id"}).AddRow(user.ID)
 	mock.ExpectQuery(`INSERT INTO users`).
-		WithArgs(user.Nickname, user.Email, user.Password, user.Avatar, user.Role).
+		WithArgs(user.Nickname, user.Email, user.Password, user.Avatar, user.Role,
+			user.EmailVerified).
 		WillReturnRows(insertAnswer)
 	mock.ExpectCommit()
 }
@@ -19,7 +21,38 @@ func MockUserRepoInsertReturnRows(mock sqlmock.Sqlmock, user *models.User) {
 func MockUserRepoInsertReturnErrNoUniq(mock sqlmock.Sqlmock, user *models.User) {
 	mock.ExpectBegin()
 	mock.ExpectQuery(`INSERT INTO users`).
-		WithArgs(user.Nickname, user.Email, user.Password, user.Avatar, user.Role).
+		WithArgs(user.Nickname, user.Email, user.Password, user.Avatar, user.Role,
+			user.EmailVerified).
+		WillReturnError(errors.New("No UNIQUE"))
+	mock.ExpectRollback()
+}
+
+func MockUserRepoInsertWithIdentityReturnRows(mock sqlmock.Sqlmock, user *models.User,
+	identity *models.Identity) {
+	mock.ExpectBegin()
+	insertAnswer := sqlmock.NewRows([]string{"id"}).AddRow(user.ID)
+	mock.ExpectQuery(`INSERT INTO users`).
+		WithArgs(user.Nickname, user.Email, user.Password, user.Avatar, user.Role,
+			user.EmailVerified).
+		WillReturnRows(insertAnswer)
+	identityAnswer := sqlmock.NewRows([]string{"id", "created"}).
+		AddRow(identity.ID, identity.Created)
+	mock.ExpectQuery(`INSERT INTO user_identities`).
+		WithArgs(user.ID, identity.Provider, identity.Subject, identity.Email).
+		WillReturnRows(identityAnswer)
+	mock.ExpectCommit()
+}
+
+func MockUserRepoInsertWithIdentityReturnErrNoUniq(mock sqlmock.Sqlmock, user *models.User,
+	identity *models.Identity) {
+	mock.ExpectBegin()
+	insertAnswer := sqlmock.NewRows([]string{"id"}).AddRow(user.ID)
+	mock.ExpectQuery(`INSERT INTO users`).
+		WithArgs(user.Nickname, user.Email, user.Password, user.Avatar, user.Role,
+			user.EmailVerified).
+		WillReturnRows(insertAnswer)
+	mock.ExpectQuery(`INSERT INTO user_identities`).
+		WithArgs(user.ID, identity.Provider, identity.Subject, identity.Email).
 		WillReturnError(errors.New("No UNIQUE"))
 	mock.ExpectRollback()
 }
@@ -27,23 +60,60 @@ func MockUserRepoInsertReturnErrNoUniq(mock sqlmock.Sqlmock, user *models.User)
 func MockUserRepoUpdateReturnResultOk(mock sqlmock.Sqlmock, user *models.User) {
 	mock.ExpectBegin()
 	mock.ExpectExec(`UPDATE users`).
-		WithArgs(user.ID, user.Nickname, user.Email, user.Password, user.Avatar, user.Role).
+		WithArgs(user.ID, user.Nickname, user.Email, user.Password, user.Avatar, user.Role,
+			user.EmailVerified).
 		WillReturnResult(sqlmock.NewResult(int64(user.ID), 1))
 	mock.ExpectCommit()
 }
 
 func MockUserRepoSelectByIDReturnRows(mock sqlmock.Sqlmock, user *models.User) {
 	rows := sqlmock.NewRows([]string{"id", "nickname", "email", "password",
-		"avatar", "role"})
+		"avatar", "role", "email_verified"})
 	rows.AddRow(user.ID, user.Nickname, user.Email, user.Password,
-		user.Avatar, user.Role)
+		user.Avatar, user.Role, user.EmailVerified)
 	mock.ExpectQuery(`SELECT`).WithArgs(user.ID).WillReturnRows(rows)
 }
 
 func MockUserRepoSelectByEmailReturnRows(mock sqlmock.Sqlmock, user *models.User) {
 	rows := sqlmock.NewRows([]string{"id", "nickname", "email", "password",
-		"avatar", "role"})
+		"avatar", "role", "email_verified"})
 	rows.AddRow(user.ID, user.Nickname, user.Email, user.Password,
-		user.Avatar, user.Role)
+		user.Avatar, user.Role, user.EmailVerified)
 	mock.ExpectQuery(`SELECT`).WithArgs(user.Email).WillReturnRows(rows)
 }
+
+func MockUserTokenRepoInsertReturnRows(mock sqlmock.Sqlmock, token *models.UserToken) {
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM user_tokens`).
+		WithArgs(token.UserID, token.Purpose).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	insertAnswer := sqlmock.NewRows([]string{"id"}).AddRow(token.ID)
+	mock.ExpectQuery(`INSERT INTO user_tokens`).
+		WithArgs(token.UserID, token.Purpose, token.Hash, token.ExpiresAt).
+		WillReturnRows(insertAnswer)
+	mock.ExpectCommit()
+}
+
+func MockUserTokenRepoDeleteByHashReturnRows(mock sqlmock.Sqlmock, token *models.UserToken) {
+	mock.ExpectBegin()
+	rows := sqlmock.NewRows([]string{"id", "user_id", "purpose", "hash", "expires"})
+	rows.AddRow(token.ID, token.UserID, token.Purpose, token.Hash, token.ExpiresAt)
+	mock.ExpectQuery(`DELETE FROM user_tokens`).
+		WithArgs(token.Hash, token.Purpose).
+		WillReturnRows(rows)
+	mock.ExpectCommit()
+}
+
+func MockUserTokenRepoDeleteByHashReturnErrNoRows(mock sqlmock.Sqlmock, hash, purpose string) {
+	mock.ExpectBegin()
+	mock.ExpectQuery(`DELETE FROM user_tokens`).
+		WithArgs(hash, purpose).
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+}
+
+func MockUserTokenRepoDeleteByUserReturnResult(mock sqlmock.Sqlmock, userID uint64) {
+	mock.ExpectExec(`DELETE FROM user_tokens`).
+		WithArgs(userID).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+}
@@ -47,6 +47,20 @@ func (mr *MockUserRepositoryMockRecorder) Insert(user interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockUserRepository)(nil).Insert), user)
 }
 
+// InsertWithIdentity mocks base method
+func (m *MockUserRepository) InsertWithIdentity(user *models.User, identity *models.Identity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertWithIdentity", user, identity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertWithIdentity indicates an expected call of InsertWithIdentity
+func (mr *MockUserRepositoryMockRecorder) InsertWithIdentity(user, identity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertWithIdentity", reflect.TypeOf((*MockUserRepository)(nil).InsertWithIdentity), user, identity)
+}
+
 // SelectByEmail mocks base method
 func (m *MockUserRepository) SelectByEmail(email string) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -90,3 +104,69 @@ func (mr *MockUserRepositoryMockRecorder) Update(user interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUserRepository)(nil).Update), user)
 }
+
+// MockUserTokenRepository is a mock of UserTokenRepository interface
+type MockUserTokenRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserTokenRepositoryMockRecorder
+}
+
+// MockUserTokenRepositoryMockRecorder is the mock recorder for MockUserTokenRepository
+type MockUserTokenRepositoryMockRecorder struct {
+	mock *MockUserTokenRepository
+}
+
+// NewMockUserTokenRepository creates a new mock instance
+func NewMockUserTokenRepository(ctrl *gomock.Controller) *MockUserTokenRepository {
+	mock := &MockUserTokenRepository{ctrl: ctrl}
+	mock.recorder = &MockUserTokenRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockUserTokenRepository) EXPECT() *MockUserTokenRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Insert mocks base method
+func (m *MockUserTokenRepository) Insert(token *models.UserToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert
+func (mr *MockUserTokenRepositoryMockRecorder) Insert(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockUserTokenRepository)(nil).Insert), token)
+}
+
+// DeleteByHash mocks base method
+func (m *MockUserTokenRepository) DeleteByHash(hash, purpose string) (*models.UserToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByHash", hash, purpose)
+	ret0, _ := ret[0].(*models.UserToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteByHash indicates an expected call of DeleteByHash
+func (mr *MockUserTokenRepositoryMockRecorder) DeleteByHash(hash, purpose interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByHash", reflect.TypeOf((*MockUserTokenRepository)(nil).DeleteByHash), hash, purpose)
+}
+
+// DeleteByUser mocks base method
+func (m *MockUserTokenRepository) DeleteByUser(userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUser", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByUser indicates an expected call of DeleteByUser
+func (mr *MockUserTokenRepositoryMockRecorder) DeleteByUser(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUser", reflect.TypeOf((*MockUserTokenRepository)(nil).DeleteByUser), userID)
+}
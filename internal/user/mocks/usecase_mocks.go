@@ -48,6 +48,20 @@ func (mr *MockUserUsecaseMockRecorder) Create(user interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUserUsecase)(nil).Create), user)
 }
 
+// CreateWithIdentity mocks base method
+func (m *MockUserUsecase) CreateWithIdentity(user *models.User, identity *models.Identity) *errors.Error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWithIdentity", user, identity)
+	ret0, _ := ret[0].(*errors.Error)
+	return ret0
+}
+
+// CreateWithIdentity indicates an expected call of CreateWithIdentity
+func (mr *MockUserUsecaseMockRecorder) CreateWithIdentity(user, identity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWithIdentity", reflect.TypeOf((*MockUserUsecase)(nil).CreateWithIdentity), user, identity)
+}
+
 // GetByEmail mocks base method
 func (m *MockUserUsecase) GetByEmail(email string) (*models.User, *errors.Error) {
 	m.ctrl.T.Helper()
@@ -123,6 +137,20 @@ func (mr *MockUserUsecaseMockRecorder) UpdateAvatar(userID, newAvatar interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAvatar", reflect.TypeOf((*MockUserUsecase)(nil).UpdateAvatar), userID, newAvatar)
 }
 
+// ProcessAvatarJob mocks base method
+func (m *MockUserUsecase) ProcessAvatarJob(job *models.Job) *errors.Error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessAvatarJob", job)
+	ret0, _ := ret[0].(*errors.Error)
+	return ret0
+}
+
+// ProcessAvatarJob indicates an expected call of ProcessAvatarJob
+func (mr *MockUserUsecaseMockRecorder) ProcessAvatarJob(job interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessAvatarJob", reflect.TypeOf((*MockUserUsecase)(nil).ProcessAvatarJob), job)
+}
+
 // CheckPassword mocks base method
 func (m *MockUserUsecase) CheckPassword(user *models.User, password string) *errors.Error {
 	m.ctrl.T.Helper()
@@ -151,3 +179,61 @@ func (mr *MockUserUsecaseMockRecorder) IsAdmin(userID interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAdmin", reflect.TypeOf((*MockUserUsecase)(nil).IsAdmin), userID)
 }
+
+// RequestPasswordReset mocks base method
+func (m *MockUserUsecase) RequestPasswordReset(email string) *errors.Error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestPasswordReset", email)
+	ret0, _ := ret[0].(*errors.Error)
+	return ret0
+}
+
+// RequestPasswordReset indicates an expected call of RequestPasswordReset
+func (mr *MockUserUsecaseMockRecorder) RequestPasswordReset(email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestPasswordReset", reflect.TypeOf((*MockUserUsecase)(nil).RequestPasswordReset), email)
+}
+
+// ResetPassword mocks base method
+func (m *MockUserUsecase) ResetPassword(token, newPassword, repeatedNewPassword string) (*models.User, *errors.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", token, newPassword, repeatedNewPassword)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(*errors.Error)
+	return ret0, ret1
+}
+
+// ResetPassword indicates an expected call of ResetPassword
+func (mr *MockUserUsecaseMockRecorder) ResetPassword(token, newPassword, repeatedNewPassword interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockUserUsecase)(nil).ResetPassword), token, newPassword, repeatedNewPassword)
+}
+
+// SendVerification mocks base method
+func (m *MockUserUsecase) SendVerification(userID uint64) *errors.Error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendVerification", userID)
+	ret0, _ := ret[0].(*errors.Error)
+	return ret0
+}
+
+// SendVerification indicates an expected call of SendVerification
+func (mr *MockUserUsecaseMockRecorder) SendVerification(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendVerification", reflect.TypeOf((*MockUserUsecase)(nil).SendVerification), userID)
+}
+
+// VerifyEmail mocks base method
+func (m *MockUserUsecase) VerifyEmail(token string) (*models.User, *errors.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", token)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(*errors.Error)
+	return ret0, ret1
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail
+func (mr *MockUserUsecaseMockRecorder) VerifyEmail(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockUserUsecase)(nil).VerifyEmail), token)
+}
@@ -4,7 +4,14 @@ import "github.com/go-park-mail-ru/2020_2_Slash/internal/models"
 
 type UserRepository interface {
 	Insert(user *models.User) error
+	InsertWithIdentity(user *models.User, identity *models.Identity) error
 	SelectByEmail(email string) (*models.User, error)
 	SelectByID(userID uint64) (*models.User, error)
 	Update(user *models.User) error
 }
+
+type UserTokenRepository interface {
+	Insert(token *models.UserToken) error
+	DeleteByHash(hash, purpose string) (*models.UserToken, error)
+	DeleteByUser(userID uint64) error
+}
@@ -25,13 +25,38 @@ func (ur *UserPgRepository) Insert(user *models.User) error {
 		return err
 	}
 
-	row := tx.QueryRow(
-		`INSERT INTO users(nickname, email, password, avatar, role)
-		VALUES ($1, $2, $3, $4, $5)
-		RETURNING id`,
-		user.Nickname, user.Email, user.Password, user.Avatar, user.Role)
+	err = insertUser(tx, user)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			logger.Error(rollbackErr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	return nil
+}
 
-	err = row.Scan(&user.ID)
+// InsertWithIdentity registers user together with the linked identity,
+// so that no account is left without the way to sign in
+func (ur *UserPgRepository) InsertWithIdentity(user *models.User, identity *models.Identity) error {
+	tx, err := ur.dbConn.BeginTx(context.Background(), &sql.TxOptions{})
+	if err != nil {
+		return err
+	}
+
+	err = insertUser(tx, user)
+	if err == nil {
+		identity.UserID = user.ID
+		err = tx.QueryRow(
+			`INSERT INTO user_identities(user_id, provider, subject, email)
+			VALUES ($1, $2, $3, $4)
+			RETURNING id, created`,
+			identity.UserID, identity.Provider, identity.Subject,
+			identity.Email).Scan(&identity.ID, &identity.Created)
+	}
 	if err != nil {
 		if rollbackErr := tx.Rollback(); rollbackErr != nil {
 			logger.Error(rollbackErr)
@@ -45,15 +70,25 @@ func (ur *UserPgRepository) Insert(user *models.User) error {
 	return nil
 }
 
+func insertUser(tx *sql.Tx, user *models.User) error {
+	return tx.QueryRow(
+		`INSERT INTO users(nickname, email, password, avatar, role, email_verified)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id`,
+		user.Nickname, user.Email, user.Password, user.Avatar, user.Role,
+		user.EmailVerified).Scan(&user.ID)
+}
+
 func (ur *UserPgRepository) SelectByEmail(email string) (*models.User, error) {
 	user := &models.User{}
 
 	row := ur.dbConn.QueryRow(
-		`SELECT id, nickname, email, password, avatar, role
+		`SELECT id, nickname, email, password, avatar, role, email_verified
 		FROM users
 		WHERE email=$1`, email)
 
-	err := row.Scan(&user.ID, &user.Nickname, &user.Email, &user.Password, &user.Avatar, &user.Role)
+	err := row.Scan(&user.ID, &user.Nickname, &user.Email, &user.Password, &user.Avatar, &user.Role,
+		&user.EmailVerified)
 	if err != nil {
 		return nil, err
 	}
@@ -63,11 +98,12 @@ func (ur *UserPgRepository) SelectByEmail(email string) (*models.User, error) {
 func (ur *UserPgRepository) SelectByID(userID uint64) (*models.User, error) {
 	user := &models.User{}
 	row := ur.dbConn.QueryRow(
-		`SELECT id, nickname, email, password, avatar, role
+		`SELECT id, nickname, email, password, avatar, role, email_verified
 		FROM users
 		WHERE id=$1`, userID)
 
-	err := row.Scan(&user.ID, &user.Nickname, &user.Email, &user.Password, &user.Avatar, &user.Role)
+	err := row.Scan(&user.ID, &user.Nickname, &user.Email, &user.Password, &user.Avatar, &user.Role,
+		&user.EmailVerified)
 	if err != nil {
 		return nil, err
 	}
@@ -82,9 +118,11 @@ func (ur *UserPgRepository) Update(user *models.User) error {
 
 	_, err = tx.Exec(
 		`UPDATE users
-		SET nickname = $2, email = $3, password = $4, avatar = $5, role = $6
+		SET nickname = $2, email = $3, password = $4, avatar = $5, role = $6,
+		email_verified = $7
 		WHERE id = $1;`,
-		user.ID, user.Nickname, user.Email, user.Password, user.Avatar, user.Role)
+		user.ID, user.Nickname, user.Email, user.Password, user.Avatar, user.Role,
+		user.EmailVerified)
 	if err != nil {
 		if rollbackErr := tx.Rollback(); rollbackErr != nil {
 			logger.Error(rollbackErr)
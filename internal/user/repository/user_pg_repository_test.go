@@ -3,6 +3,7 @@ package repository
 import (
 	_ "github.com/lib/pq"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
@@ -184,6 +185,55 @@ func TestUserPgRepository_Insert_UserAlreadyExist(t *testing.T) {
 	}
 }
 
+func TestUserPgRepository_InsertWithIdentity_OK(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	userPgRep := NewUserPgRepository(db)
+	identity := &models.Identity{
+		ID:       1,
+		Provider: "google",
+		Subject:  "1234567890",
+		Email:    userInst.Email,
+		Created:  time.Now(),
+	}
+	mocks.MockUserRepoInsertWithIdentityReturnRows(mock, userInst, identity)
+
+	err = userPgRep.InsertWithIdentity(userInst, identity)
+
+	assert.NoError(t, err)
+	assert.Equal(t, userInst.ID, identity.UserID)
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestUserPgRepository_InsertWithIdentity_IdentityAlreadyExist(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	userPgRep := NewUserPgRepository(db)
+	identity := &models.Identity{
+		Provider: "google",
+		Subject:  "1234567890",
+		Email:    userInst.Email,
+	}
+	mocks.MockUserRepoInsertWithIdentityReturnErrNoUniq(mock, userInst, identity)
+
+	err = userPgRep.InsertWithIdentity(userInst, identity)
+
+	assert.Error(t, err)
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
 func TestUserPgRepository_Update_OK(t *testing.T) {
 	t.Parallel()
 	db, mock, err := sqlmock.New()
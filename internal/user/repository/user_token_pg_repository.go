@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/user"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
+)
+
+type UserTokenPgRepository struct {
+	dbConn *sql.DB
+}
+
+func NewUserTokenPgRepository(conn *sql.DB) user.UserTokenRepository {
+	return &UserTokenPgRepository{
+		dbConn: conn,
+	}
+}
+
+// Insert replaces previous tokens of the user with the same purpose,
+// so only the last sent link is valid
+func (tr *UserTokenPgRepository) Insert(token *models.UserToken) error {
+	tx, err := tr.dbConn.BeginTx(context.Background(), &sql.TxOptions{})
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(
+		`DELETE FROM user_tokens
+		WHERE user_id=$1 AND purpose=$2`,
+		token.UserID, token.Purpose)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			logger.Error(rollbackErr)
+		}
+		return err
+	}
+
+	err = tx.QueryRow(
+		`INSERT INTO user_tokens(user_id, purpose, hash, expires)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id`,
+		token.UserID, token.Purpose, token.Hash, token.ExpiresAt).Scan(&token.ID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			logger.Error(rollbackErr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteByHash takes the token out of the storage, so it can be used once
+func (tr *UserTokenPgRepository) DeleteByHash(hash, purpose string) (*models.UserToken, error) {
+	tx, err := tr.dbConn.BeginTx(context.Background(), &sql.TxOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	token := &models.UserToken{}
+	err = tx.QueryRow(
+		`DELETE FROM user_tokens
+		WHERE hash=$1 AND purpose=$2
+		RETURNING id, user_id, purpose, hash, expires`,
+		hash, purpose).Scan(&token.ID, &token.UserID, &token.Purpose,
+		&token.Hash, &token.ExpiresAt)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			logger.Error(rollbackErr)
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// DeleteByUser revokes all links sent to the user
+func (tr *UserTokenPgRepository) DeleteByUser(userID uint64) error {
+	_, err := tr.dbConn.Exec(
+		`DELETE FROM user_tokens
+		WHERE user_id=$1`,
+		userID)
+	return err
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/user/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+var tokenInst = &models.UserToken{
+	ID:        1,
+	UserID:    3,
+	Purpose:   consts.PasswordResetToken,
+	Hash:      models.HashUserToken("token"),
+	ExpiresAt: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+}
+
+func TestUserTokenPgRepository_Insert_OK(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	tokenPgRep := NewUserTokenPgRepository(db)
+
+	token := *tokenInst
+	mocks.MockUserTokenRepoInsertReturnRows(mock, &token)
+	err = tokenPgRep.Insert(&token)
+
+	assert.NoError(t, err)
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestUserTokenPgRepository_DeleteByHash_OK(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	tokenPgRep := NewUserTokenPgRepository(db)
+
+	mocks.MockUserTokenRepoDeleteByHashReturnRows(mock, tokenInst)
+	dbToken, err := tokenPgRep.DeleteByHash(tokenInst.Hash, tokenInst.Purpose)
+
+	assert.NoError(t, err)
+	assert.Equal(t, tokenInst, dbToken)
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestUserTokenPgRepository_DeleteByHash_NoRows(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	tokenPgRep := NewUserTokenPgRepository(db)
+
+	mocks.MockUserTokenRepoDeleteByHashReturnErrNoRows(mock, tokenInst.Hash, tokenInst.Purpose)
+	_, err = tokenPgRep.DeleteByHash(tokenInst.Hash, tokenInst.Purpose)
+
+	assert.Equal(t, sql.ErrNoRows, err)
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestUserTokenPgRepository_DeleteByUser_OK(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	tokenPgRep := NewUserTokenPgRepository(db)
+
+	mocks.MockUserTokenRepoDeleteByUserReturnResult(mock, tokenInst.UserID)
+	err = tokenPgRep.DeleteByUser(tokenInst.UserID)
+
+	assert.NoError(t, err)
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
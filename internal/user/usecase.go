@@ -7,12 +7,20 @@ import (
 
 type UserUsecase interface {
 	Create(user *models.User) *errors.Error
+	CreateWithIdentity(user *models.User, identity *models.Identity) *errors.Error
 	GetByEmail(email string) (*models.User, *errors.Error)
 	GetByID(userID uint64) (*models.User, *errors.Error)
 	UpdateProfile(newUserData *models.User) (*models.User, *errors.Error)
 	UpdatePassword(userID uint64, oldPassword, newPassword,
 		repeatedNewPassword string) (*models.User, *errors.Error)
 	UpdateAvatar(userID uint64, newAvatar string) (*models.User, *errors.Error)
+	ProcessAvatarJob(job *models.Job) *errors.Error
 	CheckPassword(user *models.User, password string) *errors.Error
 	IsAdmin(userID uint64) (bool, *errors.Error)
+	// RequestPasswordReset mails reset link in background, neither unknown
+	// email nor mailing failure is reported
+	RequestPasswordReset(email string) *errors.Error
+	ResetPassword(token, newPassword, repeatedNewPassword string) (*models.User, *errors.Error)
+	SendVerification(userID uint64) *errors.Error
+	VerifyEmail(token string) (*models.User, *errors.Error)
 }
@@ -2,21 +2,36 @@ package usecases
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"sync"
+
 	. "github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/mail"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/user/delivery/grpc"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
 	"github.com/jinzhu/copier"
 	"golang.org/x/crypto/bcrypt"
 )
 
 type UserUsecase struct {
 	userBlockClient grpc.UserBlockClient
+	mailer          mail.Mailer
+	siteURL         string
+	resets          sync.WaitGroup
 }
 
-func NewUserUsecase(client grpc.UserBlockClient) *UserUsecase {
+func NewUserUsecase(client grpc.UserBlockClient, mailer mail.Mailer,
+	siteURL string) *UserUsecase {
 	return &UserUsecase{
 		userBlockClient: client,
+		mailer:          mailer,
+		siteURL:         siteURL,
 	}
 }
 
@@ -36,6 +51,27 @@ func (uu *UserUsecase) Create(modelUser *models.User) *errors.Error {
 	return nil
 }
 
+func (uu *UserUsecase) CreateWithIdentity(modelUser *models.User,
+	identity *models.Identity) *errors.Error {
+	grpcUser, err := uu.userBlockClient.CreateWithIdentity(context.Background(),
+		&grpc.UserIdentity{
+			User:     grpc.ModelUserToGrpc(modelUser),
+			Identity: grpc.ModelIdentityToGrpc(identity),
+		})
+	if err != nil {
+		customErr := errors.GetCustomErrFromStatus(err)
+		return customErr
+	}
+
+	err = copier.Copy(modelUser, grpc.GrpcUserToModel(grpcUser))
+	if err != nil {
+		return errors.New(CodeInternalError, err)
+	}
+	identity.UserID = modelUser.ID
+
+	return nil
+}
+
 func (uu *UserUsecase) UpdatePassword(userID uint64, oldPassword, newPassword,
 	repeatedNewPassword string) (*models.User, *errors.Error) {
 	grpcUser, err := uu.userBlockClient.UpdatePassword(context.Background(),
@@ -100,6 +136,37 @@ func (uu *UserUsecase) UpdateAvatar(userID uint64, newAvatar string) (*models.Us
 	return grpc.GrpcUserToModel(grpcUser), nil
 }
 
+// ProcessAvatarJob moves staged avatar to storage and sets it to the user
+func (uu *UserUsecase) ProcessAvatarJob(job *models.Job) *errors.Error {
+	payload := &models.UserAvatarPayload{}
+	if err := json.Unmarshal(job.Payload, payload); err != nil {
+		return errors.New(CodeInternalError, err)
+	}
+
+	absAvatarPath := "." + payload.AvatarPath
+	absStagedPath := helpers.GetStagedPath(absAvatarPath)
+
+	// Staged avatar is already moved if previous attempt failed on update
+	if _, err := os.Stat(absStagedPath); err == nil {
+		if err := os.Rename(absStagedPath, absAvatarPath); err != nil {
+			return errors.New(CodeInternalError, err)
+		}
+	}
+	if _, err := os.Stat(absAvatarPath); err != nil {
+		return errors.New(CodeInternalError, err)
+	}
+
+	if _, customErr := uu.UpdateAvatar(payload.UserID, payload.AvatarPath); customErr != nil {
+		if job.IsLastAttempt() {
+			if err := os.Remove(absAvatarPath); err != nil {
+				logger.Error(err)
+			}
+		}
+		return customErr
+	}
+	return nil
+}
+
 func (uu *UserUsecase) CheckPassword(user *models.User, password string) *errors.Error {
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password),
 		[]byte(password)); err != nil {
@@ -116,3 +183,93 @@ func (uu *UserUsecase) IsAdmin(userID uint64) (bool, *errors.Error) {
 
 	return user.Role == Admin, nil
 }
+
+// RequestPasswordReset answers the same for registered and unknown emails,
+// the link is created and mailed in background not to reveal by timing
+func (uu *UserUsecase) RequestPasswordReset(email string) *errors.Error {
+	uu.resets.Add(1)
+	go func() {
+		defer uu.resets.Done()
+		if err := uu.sendPasswordReset(email); err != nil {
+			logger.Error(err.Message)
+		}
+	}()
+	return nil
+}
+
+func (uu *UserUsecase) sendPasswordReset(email string) *errors.Error {
+	token, err := uu.userBlockClient.CreatePasswordResetToken(context.Background(),
+		&grpc.Email{Email: email})
+	if err != nil {
+		customErr := errors.GetCustomErrFromStatus(err)
+		// Don't let to find out registered emails
+		if customErr.Code == CodeUserDoesNotExist {
+			return nil
+		}
+		return customErr
+	}
+
+	return uu.mailer.Send(&models.Mail{
+		To:      email,
+		Subject: PasswordResetMailSubject,
+		Body: fmt.Sprintf("Чтобы задать новый пароль, перейдите по ссылке:\n%s\n\n"+
+			"Ссылка действует %d мин. Если вы не запрашивали сброс пароля, "+
+			"просто проигнорируйте это письмо.\n",
+			uu.tokenLink(PasswordResetPage, token.GetToken()),
+			int(PasswordResetTokenTTL.Minutes())),
+	})
+}
+
+func (uu *UserUsecase) ResetPassword(token, newPassword,
+	repeatedNewPassword string) (*models.User, *errors.Error) {
+	grpcUser, err := uu.userBlockClient.ResetPassword(context.Background(),
+		&grpc.ResetPasswordMsg{
+			Token:               token,
+			NewPassword:         newPassword,
+			RepeatedNewPassword: repeatedNewPassword,
+		})
+	if err != nil {
+		customErr := errors.GetCustomErrFromStatus(err)
+		return nil, customErr
+	}
+
+	return grpc.GrpcUserToModel(grpcUser), nil
+}
+
+func (uu *UserUsecase) SendVerification(userID uint64) *errors.Error {
+	user, customErr := uu.GetByID(userID)
+	if customErr != nil {
+		return customErr
+	}
+
+	token, err := uu.userBlockClient.CreateVerificationToken(context.Background(),
+		&grpc.ID{ID: userID})
+	if err != nil {
+		customErr := errors.GetCustomErrFromStatus(err)
+		return customErr
+	}
+
+	return uu.mailer.Send(&models.Mail{
+		To:      user.Email,
+		Subject: EmailVerificationMailSubject,
+		Body: fmt.Sprintf("Чтобы подтвердить почту, перейдите по ссылке:\n%s\n\n"+
+			"Ссылка действует %d ч.\n",
+			uu.tokenLink(EmailVerificationPage, token.GetToken()),
+			int(EmailVerificationTokenTTL.Hours())),
+	})
+}
+
+func (uu *UserUsecase) VerifyEmail(token string) (*models.User, *errors.Error) {
+	grpcUser, err := uu.userBlockClient.VerifyEmail(context.Background(),
+		&grpc.Token{Token: token})
+	if err != nil {
+		customErr := errors.GetCustomErrFromStatus(err)
+		return nil, customErr
+	}
+
+	return grpc.GrpcUserToModel(grpcUser), nil
+}
+
+func (uu *UserUsecase) tokenLink(page, token string) string {
+	return uu.siteURL + page + "?" + url.Values{"token": {token}}.Encode()
+}
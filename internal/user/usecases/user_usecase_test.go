@@ -4,12 +4,18 @@ import (
 	"context"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/user/delivery/grpc"
 	grpcMocks "github.com/go-park-mail-ru/2020_2_Slash/internal/user/delivery/grpc/mocks"
+	"strings"
 	"testing"
 
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/mail/mailers"
 	"github.com/go-park-mail-ru/2020_2_Slash/internal/models"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var userModel = &models.User{
@@ -19,13 +25,21 @@ var userModel = &models.User{
 }
 var userInst = grpc.ModelUserToGrpc(userModel)
 
+const testSiteURL = "https://www.flicksbox.ru"
+
+type failingMailer struct{}
+
+func (failingMailer) Send(mail *models.Mail) *errors.Error {
+	return errors.Get(consts.CodeInternalError)
+}
+
 func TestUserUseCase_Create_OK(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	userClient := grpcMocks.NewMockUserBlockClient(ctrl)
-	userUseCase := NewUserUsecase(userClient)
+	userUseCase := NewUserUsecase(userClient, mailers.NewMemoryMailer(), testSiteURL)
 
 	userClient.
 		EXPECT().
@@ -36,13 +50,47 @@ func TestUserUseCase_Create_OK(t *testing.T) {
 	assert.Equal(t, err, (*errors.Error)(nil))
 }
 
+func TestUserUseCase_CreateWithIdentity_OK(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userClient := grpcMocks.NewMockUserBlockClient(ctrl)
+	userUseCase := NewUserUsecase(userClient, mailers.NewMemoryMailer(), testSiteURL)
+	newUser := &models.User{
+		Email:         "jhon@gmail.com",
+		Password:      "hardpassword",
+		EmailVerified: true,
+	}
+	identity := &models.Identity{
+		Provider: "google",
+		Subject:  "1234567890",
+		Email:    newUser.Email,
+	}
+	createdUser := grpc.ModelUserToGrpc(newUser)
+	createdUser.ID = 3
+
+	userClient.
+		EXPECT().
+		CreateWithIdentity(context.Background(), &grpc.UserIdentity{
+			User:     grpc.ModelUserToGrpc(newUser),
+			Identity: grpc.ModelIdentityToGrpc(identity),
+		}).
+		Return(createdUser, nil)
+
+	err := userUseCase.CreateWithIdentity(newUser, identity)
+	assert.Equal(t, err, (*errors.Error)(nil))
+	assert.Equal(t, uint64(3), newUser.ID)
+	assert.Equal(t, uint64(3), identity.UserID)
+}
+
 func TestUserUseCase_Update_OK(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	userClient := grpcMocks.NewMockUserBlockClient(ctrl)
-	userUseCase := NewUserUsecase(userClient)
+	userUseCase := NewUserUsecase(userClient, mailers.NewMemoryMailer(), testSiteURL)
 
 	userClient.
 		EXPECT().
@@ -62,7 +110,7 @@ func TestUserUseCase_UpdateAvatar_OK(t *testing.T) {
 	newAvatar := "/avatar"
 
 	userClient := grpcMocks.NewMockUserBlockClient(ctrl)
-	userUseCase := NewUserUsecase(userClient)
+	userUseCase := NewUserUsecase(userClient, mailers.NewMemoryMailer(), testSiteURL)
 
 	userAvatar := &grpc.IdAvatar{
 		Id:     &grpc.ID{ID: userModel.ID},
@@ -85,7 +133,7 @@ func TestUserUseCase_GetByID_OK(t *testing.T) {
 	defer ctrl.Finish()
 
 	userClient := grpcMocks.NewMockUserBlockClient(ctrl)
-	userUseCase := NewUserUsecase(userClient)
+	userUseCase := NewUserUsecase(userClient, mailers.NewMemoryMailer(), testSiteURL)
 
 	userClient.
 		EXPECT().
@@ -103,7 +151,7 @@ func TestUserUseCase_GetByEmail_OK(t *testing.T) {
 	defer ctrl.Finish()
 
 	userClient := grpcMocks.NewMockUserBlockClient(ctrl)
-	userUseCase := NewUserUsecase(userClient)
+	userUseCase := NewUserUsecase(userClient, mailers.NewMemoryMailer(), testSiteURL)
 
 	userClient.
 		EXPECT().
@@ -114,3 +162,116 @@ func TestUserUseCase_GetByEmail_OK(t *testing.T) {
 	assert.Equal(t, err, (*errors.Error)(nil))
 	assert.Equal(t, dbUser, userModel)
 }
+
+func TestUserUseCase_RequestPasswordReset_OK(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userClient := grpcMocks.NewMockUserBlockClient(ctrl)
+	mailer := mailers.NewMemoryMailer()
+	userUseCase := NewUserUsecase(userClient, mailer, testSiteURL)
+
+	userClient.
+		EXPECT().
+		CreatePasswordResetToken(context.Background(), &grpc.Email{Email: userModel.Email}).
+		Return(&grpc.Token{Token: "abc"}, nil)
+
+	err := userUseCase.RequestPasswordReset(userModel.Email)
+	assert.Equal(t, err, (*errors.Error)(nil))
+	userUseCase.resets.Wait()
+
+	mails := mailer.Mails()
+	if assert.Len(t, mails, 1) {
+		assert.Equal(t, userModel.Email, mails[0].To)
+		assert.Equal(t, consts.PasswordResetMailSubject, mails[0].Subject)
+		assert.True(t, strings.Contains(mails[0].Body,
+			testSiteURL+consts.PasswordResetPage+"?token=abc"))
+	}
+}
+
+func TestUserUseCase_RequestPasswordReset_UnknownEmail(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userClient := grpcMocks.NewMockUserBlockClient(ctrl)
+	mailer := mailers.NewMemoryMailer()
+	userUseCase := NewUserUsecase(userClient, mailer, testSiteURL)
+
+	userClient.
+		EXPECT().
+		CreatePasswordResetToken(context.Background(), &grpc.Email{Email: userModel.Email}).
+		Return(nil, status.Error(codes.Code(consts.CodeUserDoesNotExist), ""))
+
+	err := userUseCase.RequestPasswordReset(userModel.Email)
+	assert.Equal(t, err, (*errors.Error)(nil))
+	userUseCase.resets.Wait()
+	assert.Empty(t, mailer.Mails())
+}
+
+func TestUserUseCase_RequestPasswordReset_MailerFailed(t *testing.T) {
+	t.Parallel()
+	logger.DisableLogger()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userClient := grpcMocks.NewMockUserBlockClient(ctrl)
+	userUseCase := NewUserUsecase(userClient, failingMailer{}, testSiteURL)
+
+	userClient.
+		EXPECT().
+		CreatePasswordResetToken(context.Background(), &grpc.Email{Email: userModel.Email}).
+		Return(&grpc.Token{Token: "abc"}, nil)
+
+	err := userUseCase.RequestPasswordReset(userModel.Email)
+	assert.Equal(t, err, (*errors.Error)(nil))
+	userUseCase.resets.Wait()
+}
+
+func TestUserUseCase_SendVerification_OK(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userClient := grpcMocks.NewMockUserBlockClient(ctrl)
+	mailer := mailers.NewMemoryMailer()
+	userUseCase := NewUserUsecase(userClient, mailer, testSiteURL)
+
+	userClient.
+		EXPECT().
+		GetByID(context.Background(), &grpc.ID{ID: userModel.ID}).
+		Return(userInst, nil)
+
+	userClient.
+		EXPECT().
+		CreateVerificationToken(context.Background(), &grpc.ID{ID: userModel.ID}).
+		Return(&grpc.Token{Token: "abc"}, nil)
+
+	err := userUseCase.SendVerification(userModel.ID)
+	assert.Equal(t, err, (*errors.Error)(nil))
+
+	mails := mailer.Mails()
+	if assert.Len(t, mails, 1) {
+		assert.Equal(t, userModel.Email, mails[0].To)
+		assert.True(t, strings.Contains(mails[0].Body,
+			testSiteURL+consts.EmailVerificationPage+"?token=abc"))
+	}
+}
+
+func TestUserUseCase_VerifyEmail_InvalidToken(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userClient := grpcMocks.NewMockUserBlockClient(ctrl)
+	userUseCase := NewUserUsecase(userClient, mailers.NewMemoryMailer(), testSiteURL)
+
+	userClient.
+		EXPECT().
+		VerifyEmail(context.Background(), &grpc.Token{Token: "abc"}).
+		Return(nil, status.Error(codes.Code(consts.CodeInvalidUserToken), ""))
+
+	_, err := userUseCase.VerifyEmail("abc")
+	assert.Equal(t, errors.Get(consts.CodeInvalidUserToken), err)
+}
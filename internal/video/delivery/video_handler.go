@@ -0,0 +1,173 @@
+package delivery
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	. "github.com/go-park-mail-ru/2020_2_Slash/internal/consts"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers/errors"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/mwares"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/mwares/monitoring"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
+	. "github.com/go-park-mail-ru/2020_2_Slash/tools/response"
+	"github.com/labstack/echo/v4"
+)
+
+const playlistExt = ".m3u8"
+
+var conditionalHeaders = []string{
+	"If-Match", "If-None-Match", "If-Modified-Since", "If-Unmodified-Since", "If-Range",
+}
+
+type VideoHandler struct {
+	videoSigner *helpers.VideoURLSigner
+	mntng       *monitoring.Monitoring
+	videosPath  string
+}
+
+func NewVideoHandler(videoSigner *helpers.VideoURLSigner, mntng *monitoring.Monitoring,
+	videosPath string) *VideoHandler {
+	return &VideoHandler{
+		videoSigner: videoSigner,
+		mntng:       mntng,
+		videosPath:  videosPath,
+	}
+}
+
+func (vh *VideoHandler) Configure(e *echo.Echo, mw *mwares.MiddlewareManager) {
+	e.GET("/videos/*", vh.GetVideoHandler(), mw.GetAuth)
+	e.HEAD("/videos/*", vh.GetVideoHandler(), mw.GetAuth)
+}
+
+func (vh *VideoHandler) GetVideoHandler() echo.HandlerFunc {
+	return func(cntx echo.Context) error {
+		videoPath, parseErr := url.PathUnescape(cntx.Param("*"))
+		if parseErr != nil {
+			customErr := errors.New(CodeBadRequest, parseErr)
+			logger.Error(customErr.Message)
+			return cntx.JSON(customErr.HTTPCode, Response{Error: customErr})
+		}
+		// "/"+ for security
+		videoPath = path.Clean("/" + videoPath)
+
+		// nolint: errcheck
+		userID, _ := cntx.Get("userID").(uint64)
+
+		// Video url is signed for the user who got access to the content,
+		// HLS files are signed by their directory
+		signedPath := "/videos" + videoPath
+		if hlsDir, isHLS := helpers.GetHLSDir(signedPath); isHLS {
+			signedPath = hlsDir
+		}
+		if err := vh.videoSigner.Verify(signedPath, userID, cntx.QueryParams()); err != nil {
+			logger.Error(err.Message)
+			return cntx.JSON(err.HTTPCode, Response{Error: err})
+		}
+
+		contentType, allowed := helpers.GetVideoContentType(path.Ext(videoPath))
+		if !allowed {
+			return echo.NotFoundHandler(cntx)
+		}
+
+		absVideoPath := filepath.Join(vh.videosPath, videoPath)
+		file, osErr := os.Open(absVideoPath)
+		if osErr != nil {
+			return echo.NotFoundHandler(cntx)
+		}
+		defer file.Close()
+
+		fileInfo, osErr := file.Stat()
+		if osErr != nil || fileInfo.IsDir() {
+			return echo.NotFoundHandler(cntx)
+		}
+
+		// ServeContent handles Range, If-Range and other conditional headers
+		// using the ETag and Content-Type set here
+		header := cntx.Response().Header()
+		header.Set(echo.HeaderContentType, contentType)
+
+		var videoContent io.ReadSeeker = file
+		modTime := fileInfo.ModTime()
+		if path.Ext(videoPath) == playlistExt {
+			// Player resolves nested playlists and segments relative to the playlist,
+			// so they have to carry the same signature
+			playlist, err := signPlaylistURIs(file, cntx.QueryString())
+			if err != nil {
+				customErr := errors.New(CodeInternalError, err)
+				logger.Error(customErr.Message)
+				return cntx.JSON(customErr.HTTPCode, Response{Error: customErr})
+			}
+			videoContent = bytes.NewReader(playlist)
+
+			// Signatures expire, so the playlist is never revalidated
+			// or served from a cache, zero time disables Last-Modified
+			header.Set("Cache-Control", "no-store")
+			modTime = time.Time{}
+			for _, condHeader := range conditionalHeaders {
+				cntx.Request().Header.Del(condHeader)
+			}
+		} else {
+			header.Set("ETag", getETag(fileInfo))
+		}
+
+		writer := &countingWriter{ResponseWriter: cntx.Response()}
+		http.ServeContent(writer, cntx.Request(), fileInfo.Name(), modTime, videoContent)
+		vh.countVideoBytes(videoPath, writer.written)
+		return nil
+	}
+}
+
+func (vh *VideoHandler) countVideoBytes(videoPath string, written int64) {
+	if written == 0 {
+		return
+	}
+
+	contentID, err := helpers.GetContentIDFromVideoPath(videoPath)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+	vh.mntng.VideoBytes.WithLabelValues(strconv.FormatUint(contentID, 10)).Add(float64(written))
+}
+
+func signPlaylistURIs(playlist io.Reader, query string) ([]byte, error) {
+	var signed bytes.Buffer
+	scanner := bufio.NewScanner(playlist)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line != "" && !strings.HasPrefix(line, "#") {
+			line += "?" + query
+		}
+		signed.WriteString(line + "\n")
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return signed.Bytes(), nil
+}
+
+func getETag(fileInfo os.FileInfo) string {
+	return fmt.Sprintf(`"%x-%x"`, fileInfo.ModTime().UnixNano(), fileInfo.Size())
+}
+
+type countingWriter struct {
+	http.ResponseWriter
+	written int64
+}
+
+func (cw *countingWriter) Write(b []byte) (int, error) {
+	n, err := cw.ResponseWriter.Write(b)
+	cw.written += int64(n)
+	return n, err
+}
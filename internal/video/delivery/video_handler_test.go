@@ -0,0 +1,219 @@
+package delivery
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/helpers"
+	"github.com/go-park-mail-ru/2020_2_Slash/internal/mwares/monitoring"
+	"github.com/go-park-mail-ru/2020_2_Slash/tools/logger"
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	videoParam   = "shrek_1/movie.mp4"
+	videoContent = "0123456789abcdefghij"
+)
+
+func newTestVideoHandler(t *testing.T) (*VideoHandler, *helpers.VideoURLSigner, func()) {
+	videosPath, err := ioutil.TempDir("", "videos")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(videosPath, "shrek_1"), 0777); err != nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(filepath.Join(videosPath, videoParam), []byte(videoContent), 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mntng := &monitoring.Monitoring{
+		VideoBytes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "video_bytes",
+		}, []string{"content_id"}),
+	}
+	videoSigner := helpers.NewVideoURLSigner("secret")
+	videoHandler := NewVideoHandler(videoSigner, mntng, videosPath)
+	return videoHandler, videoSigner, func() {
+		os.RemoveAll(videosPath)
+	}
+}
+
+func serveVideo(videoHandler *VideoHandler, req *http.Request, userID uint64,
+	videoParam string) *httptest.ResponseRecorder {
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("*")
+	c.SetParamValues(videoParam)
+	c.Set("userID", userID)
+
+	// nolint: errcheck
+	videoHandler.GetVideoHandler()(c)
+	return rec
+}
+
+func TestVideoHandler_GetVideoHandler_Full(t *testing.T) {
+	t.Parallel()
+	videoHandler, videoSigner, cleanup := newTestVideoHandler(t)
+	defer cleanup()
+
+	var userID uint64 = 3
+	url := videoSigner.Sign("/videos/"+videoParam, userID)
+	req := httptest.NewRequest(http.MethodGet, url, nil)
+	rec := serveVideo(videoHandler, req, userID, videoParam)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "video/mp4", rec.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, "bytes", rec.Header().Get("Accept-Ranges"))
+	assert.NotEmpty(t, rec.Header().Get("ETag"))
+	assert.Equal(t, videoContent, rec.Body.String())
+	assert.Equal(t, float64(len(videoContent)),
+		testutil.ToFloat64(videoHandler.mntng.VideoBytes.WithLabelValues("1")))
+}
+
+func TestVideoHandler_GetVideoHandler_SingleRange(t *testing.T) {
+	t.Parallel()
+	videoHandler, videoSigner, cleanup := newTestVideoHandler(t)
+	defer cleanup()
+
+	var userID uint64 = 3
+	url := videoSigner.Sign("/videos/"+videoParam, userID)
+	req := httptest.NewRequest(http.MethodGet, url, nil)
+	req.Header.Set("Range", "bytes=5-9")
+	rec := serveVideo(videoHandler, req, userID, videoParam)
+
+	assert.Equal(t, http.StatusPartialContent, rec.Code)
+	assert.Equal(t, "bytes 5-9/20", rec.Header().Get("Content-Range"))
+	assert.Equal(t, "video/mp4", rec.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, "56789", rec.Body.String())
+	assert.Equal(t, float64(5),
+		testutil.ToFloat64(videoHandler.mntng.VideoBytes.WithLabelValues("1")))
+}
+
+func TestVideoHandler_GetVideoHandler_MultiRange(t *testing.T) {
+	t.Parallel()
+	videoHandler, videoSigner, cleanup := newTestVideoHandler(t)
+	defer cleanup()
+
+	var userID uint64 = 3
+	url := videoSigner.Sign("/videos/"+videoParam, userID)
+	req := httptest.NewRequest(http.MethodGet, url, nil)
+	req.Header.Set("Range", "bytes=0-1,18-19")
+	rec := serveVideo(videoHandler, req, userID, videoParam)
+
+	assert.Equal(t, http.StatusPartialContent, rec.Code)
+	assert.True(t, strings.HasPrefix(rec.Header().Get(echo.HeaderContentType), "multipart/byteranges"))
+	assert.Contains(t, rec.Body.String(), "Content-Type: video/mp4")
+	assert.Contains(t, rec.Body.String(), "Content-Range: bytes 18-19/20")
+	assert.Equal(t, float64(rec.Body.Len()),
+		testutil.ToFloat64(videoHandler.mntng.VideoBytes.WithLabelValues("1")))
+}
+
+func TestVideoHandler_GetVideoHandler_IfRangeMismatch(t *testing.T) {
+	t.Parallel()
+	videoHandler, videoSigner, cleanup := newTestVideoHandler(t)
+	defer cleanup()
+
+	var userID uint64 = 3
+	url := videoSigner.Sign("/videos/"+videoParam, userID)
+	req := httptest.NewRequest(http.MethodGet, url, nil)
+	req.Header.Set("Range", "bytes=5-9")
+	req.Header.Set("If-Range", `"outdated"`)
+	rec := serveVideo(videoHandler, req, userID, videoParam)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, videoContent, rec.Body.String())
+}
+
+func TestVideoHandler_GetVideoHandler_IfNoneMatch(t *testing.T) {
+	t.Parallel()
+	videoHandler, videoSigner, cleanup := newTestVideoHandler(t)
+	defer cleanup()
+
+	var userID uint64 = 3
+	url := videoSigner.Sign("/videos/"+videoParam, userID)
+	req := httptest.NewRequest(http.MethodGet, url, nil)
+	etag := serveVideo(videoHandler, req, userID, videoParam).Header().Get("ETag")
+
+	req = httptest.NewRequest(http.MethodGet, url, nil)
+	req.Header.Set("If-None-Match", etag)
+	rec := serveVideo(videoHandler, req, userID, videoParam)
+
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+	assert.Empty(t, rec.Body.String())
+}
+
+func TestVideoHandler_GetVideoHandler_WrongSignature(t *testing.T) {
+	t.Parallel()
+	videoHandler, videoSigner, cleanup := newTestVideoHandler(t)
+	defer cleanup()
+	logger.DisableLogger()
+
+	url := videoSigner.Sign("/videos/"+videoParam, 3)
+	req := httptest.NewRequest(http.MethodGet, url, nil)
+	rec := serveVideo(videoHandler, req, 4, videoParam)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Equal(t, float64(0),
+		testutil.ToFloat64(videoHandler.mntng.VideoBytes.WithLabelValues("1")))
+}
+
+func TestVideoHandler_GetVideoHandler_Playlist(t *testing.T) {
+	t.Parallel()
+	videoHandler, videoSigner, cleanup := newTestVideoHandler(t)
+	defer cleanup()
+
+	hlsDir := filepath.Join(videoHandler.videosPath, "shrek_1", "movie_hls")
+	if err := os.MkdirAll(filepath.Join(hlsDir, "720p"), 0777); err != nil {
+		t.Fatal(err)
+	}
+	master := "#EXTM3U\n#EXT-X-STREAM-INF:BANDWIDTH=2928000\n720p/index.m3u8\n"
+	if err := ioutil.WriteFile(filepath.Join(hlsDir, "index.m3u8"), []byte(master), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(hlsDir, "720p", "segment_000.ts"), []byte(videoContent), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	var userID uint64 = 3
+	url := videoSigner.SignPlaylist(helpers.GetPlaylistPath("/videos/"+videoParam), userID)
+	query := url[strings.Index(url, "?")+1:]
+
+	req := httptest.NewRequest(http.MethodGet, url, nil)
+	rec := serveVideo(videoHandler, req, userID, "shrek_1/movie_hls/index.m3u8")
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/vnd.apple.mpegurl", rec.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, "#EXTM3U\n#EXT-X-STREAM-INF:BANDWIDTH=2928000\n720p/index.m3u8?"+query+"\n",
+		rec.Body.String())
+	assert.Empty(t, rec.Header().Get("ETag"))
+	assert.Empty(t, rec.Header().Get("Last-Modified"))
+	assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+
+	// Playlist with expiring signatures isn't revalidated
+	req = httptest.NewRequest(http.MethodGet, url, nil)
+	req.Header.Set("If-None-Match", "*")
+	req.Header.Set("If-Modified-Since", time.Now().UTC().Format(http.TimeFormat))
+	rec = serveVideo(videoHandler, req, userID, "shrek_1/movie_hls/index.m3u8")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "720p/index.m3u8?"+query)
+
+	// Segments are signed by the same query
+	req = httptest.NewRequest(http.MethodGet, "/videos/shrek_1/movie_hls/720p/segment_000.ts?"+query, nil)
+	rec = serveVideo(videoHandler, req, userID, "shrek_1/movie_hls/720p/segment_000.ts")
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "video/mp2t", rec.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, videoContent, rec.Body.String())
+}
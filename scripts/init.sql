@@ -2,14 +2,22 @@ DROP TRIGGER IF EXISTS seasons_inc on seasons;
 DROP TRIGGER IF EXISTS seasons_dec on seasons;
 DROP TRIGGER IF EXISTS episodes_inc on episodes;
 DROP TRIGGER IF exists episodes_dec on episodes;
+DROP TRIGGER IF EXISTS seasons_trash on seasons;
+DROP TRIGGER IF EXISTS episodes_trash on episodes;
 DROP TRIGGER IF EXISTS rating_ins_upd on rates;
 DROP TRIGGER IF EXISTS rating_del on rates;
+DROP TRIGGER IF EXISTS content_search_vector on content;
 DROP TABLE IF EXISTS
     users, sessions, content, directors, content_director, actors, content_actor,
     genres, content_genre, countries, content_country, movies, tv_shows, seasons,
-    episodes, rates, favourites, subscriptions
+    episodes, rates, favourites, subscriptions, jobs, watch_progress,
+    recommendations, subscription_events, payments, plans, user_tokens, user_identities, audit_log,
+    content_revisions
     CASCADE;
 
+-- Trigram matching for typo tolerant search
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
 DO $$ BEGIN
     CREATE TYPE role AS ENUM ('admin', 'user');
 EXCEPTION
@@ -23,34 +31,119 @@ CREATE TABLE IF NOT EXISTS users (
     email varchar(64) UNIQUE NOT NULL,
     password text NOT NULL,
     avatar varchar(64) NOT NULL DEFAULT '',
-    role role NOT NULL DEFAULT 'user'
+    role role NOT NULL DEFAULT 'user',
+    email_verified boolean NOT NULL DEFAULT false
+);
+
+CREATE TABLE IF NOT EXISTS user_tokens (
+    id serial PRIMARY KEY,
+    user_id int NOT NULL,
+    purpose varchar(32) NOT NULL,
+    hash varchar(64) UNIQUE NOT NULL,
+    expires timestamptz NOT NULL,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS user_identities (
+    id serial PRIMARY KEY,
+    user_id int NOT NULL,
+    provider varchar(32) NOT NULL,
+    subject varchar(255) NOT NULL,
+    email varchar(64) NOT NULL DEFAULT '',
+    created timestamptz NOT NULL DEFAULT now(),
+
+    UNIQUE (provider, subject),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
 );
 
+-- Subscription plans
+CREATE TABLE IF NOT EXISTS plans (
+    id serial PRIMARY KEY,
+    name varchar(64) UNIQUE NOT NULL,
+    price numeric(12, 2) NOT NULL,
+    currency varchar(8) NOT NULL, -- код валюты ISO 4217, 643 - рубли
+    duration_months int NOT NULL,
+    trial_days int NOT NULL DEFAULT 0 -- пробный период начинается при первом оформлении подписки
+);
+
+INSERT INTO plans(name, price, currency, duration_months)
+VALUES ('Месяц', 299, '643', 1)
+ON CONFLICT DO NOTHING;
+
 CREATE TABLE IF NOT EXISTS subscriptions (
     id serial PRIMARY KEY,
     owner int NOT NULL UNIQUE,
+    plan_id int,
     expires timestamptz NOT NULL,
+    grace_until timestamptz NOT NULL, -- доступ сохраняется до конца льготного периода
     is_paid bool NOT NULL,
     is_canceled bool NOT NULL,
 
-    FOREIGN KEY (owner) REFERENCES users(id)
+    FOREIGN KEY (owner) REFERENCES users(id),
+    FOREIGN KEY (plan_id) REFERENCES plans(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS subscriptions_grace_until_idx ON subscriptions (grace_until) WHERE is_paid;
+
+-- History of subscription changes
+CREATE TABLE IF NOT EXISTS subscription_events (
+    id serial PRIMARY KEY,
+    user_id int NOT NULL,
+    event varchar(16) NOT NULL, -- trial_started, created, renewed, canceled, recovered, expired
+    expires timestamptz NOT NULL,
+    created timestamptz NOT NULL DEFAULT now(),
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
 );
 
+CREATE INDEX IF NOT EXISTS subscription_events_user_idx ON subscription_events (user_id, created DESC);
+
+-- Ledger of payment notifications
+CREATE TABLE IF NOT EXISTS payments (
+    id serial PRIMARY KEY,
+    operation_id varchar(64) UNIQUE NOT NULL, -- повторное уведомление не создаёт новый платёж
+    user_id int NOT NULL,
+    plan_id int NOT NULL,
+    amount numeric(12, 2) NOT NULL,
+    currency varchar(8) NOT NULL,
+    status varchar(16) NOT NULL, -- held, pending, accepted, rejected
+    reason text NOT NULL DEFAULT '',
+    created timestamptz NOT NULL DEFAULT now(),
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (plan_id) REFERENCES plans(id) ON DELETE RESTRICT -- история платежей не теряет тариф
+);
+
+CREATE INDEX IF NOT EXISTS payments_user_idx ON payments (user_id, created DESC);
+
 CREATE TABLE IF NOT EXISTS sessions (
     id serial PRIMARY KEY,
     value varchar(64) UNIQUE NOT NULL,
     expires timestamptz NOT NULL,
     user_id int NOT NULL,
+    user_agent varchar(512) NOT NULL DEFAULT '',
+    ip varchar(64) NOT NULL DEFAULT '',
+    created timestamptz NOT NULL DEFAULT now(),
+    remember boolean NOT NULL DEFAULT false,
 
     FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
 );
 
+CREATE INDEX IF NOT EXISTS sessions_user_id_idx ON sessions (user_id);
+
 DO $$ BEGIN
     CREATE TYPE content_type AS ENUM ('movie', 'tvshow');
 EXCEPTION
     WHEN duplicate_object THEN null;
 END $$;
 
+DO $$ BEGIN
+    CREATE TYPE content_status AS ENUM ('draft', 'scheduled', 'published', 'archived');
+EXCEPTION
+    WHEN duplicate_object THEN null;
+END $$;
+
 -- Content
 CREATE TABLE IF NOT EXISTS content (
     id serial PRIMARY KEY,
@@ -62,9 +155,16 @@ CREATE TABLE IF NOT EXISTS content (
     year smallint NOT NULL, -- если сериал, то год выхода 1 сезона
     images varchar(128) NOT NULL, -- путь к папке с постерами (/images/witcher), в которой лежит small.png и large.png
     type content_type NOT NULL, -- movie, tv_show
-    is_free boolean NOT NULL DEFAULT TRUE
+    is_free boolean NOT NULL DEFAULT TRUE,
+    status content_status NOT NULL DEFAULT 'draft', -- пользователям показывается только published
+    publish_at timestamptz, -- время публикации для scheduled
+    deleted_at timestamptz, -- время удаления в корзину, NULL у неудалённого
+    search_vector tsvector -- триггер на изменение названий и описаний
 );
 
+CREATE INDEX IF NOT EXISTS content_publish_at_idx ON content (publish_at) WHERE status = 'scheduled';
+CREATE INDEX IF NOT EXISTS content_deleted_at_idx ON content (deleted_at) WHERE deleted_at IS NOT NULL;
+
 
 -- Content directors
 CREATE TABLE IF NOT EXISTS directors (
@@ -154,10 +254,13 @@ CREATE TABLE IF NOT EXISTS seasons (
     number int NOT NULL,
     episodes int NOT NULL DEFAULT 0, -- тригер на каждое создание эпизода
     tv_show_id int NOT NULL,
+    deleted_at timestamptz,
 
     FOREIGN KEY (tv_show_id) REFERENCES tv_shows(id) ON DELETE CASCADE
 );
 
+CREATE INDEX IF NOT EXISTS seasons_deleted_at_idx ON seasons (deleted_at) WHERE deleted_at IS NOT NULL;
+
 -- TVShow episodes
 CREATE TABLE IF NOT EXISTS episodes (
     id serial PRIMARY KEY,
@@ -167,11 +270,15 @@ CREATE TABLE IF NOT EXISTS episodes (
     description text NOT NULL,
     poster varchar(128) NOT NULL, -- путь к папке с постерами (/images/witcher/s1 /s2 ...), в которой лежит e1.png e2.png ...
     season_id int NOT NULL,
+    deleted_at timestamptz,
 
-    UNIQUE (number, season_id),
     FOREIGN KEY (season_id) REFERENCES seasons(id) ON DELETE CASCADE
 );
 
+CREATE INDEX IF NOT EXISTS episodes_deleted_at_idx ON episodes (deleted_at) WHERE deleted_at IS NOT NULL;
+-- Number of the trashed episode can be taken by the new one
+CREATE UNIQUE INDEX IF NOT EXISTS episodes_number_season_idx ON episodes (number, season_id) WHERE deleted_at IS NULL;
+
 
 -- Users content rating
 CREATE TABLE IF NOT EXISTS rates (
@@ -196,6 +303,115 @@ CREATE TABLE IF NOT EXISTS favourites (
     FOREIGN KEY (content_id) REFERENCES content(id) ON DELETE CASCADE
 );
 
+-- Users playback progress of movies and episodes
+CREATE TABLE IF NOT EXISTS watch_progress (
+    id serial PRIMARY KEY,
+    user_id int NOT NULL,
+    content_id int NOT NULL,
+    episode_id int, -- NULL для фильмов
+    position int NOT NULL, -- секунды
+    duration int NOT NULL, -- секунды
+    watched boolean NOT NULL DEFAULT FALSE,
+    updated timestamptz NOT NULL,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (content_id) REFERENCES content(id) ON DELETE CASCADE,
+    FOREIGN KEY (episode_id) REFERENCES episodes(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS watch_progress_movie_idx
+    ON watch_progress (user_id, content_id) WHERE episode_id IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS watch_progress_episode_idx
+    ON watch_progress (user_id, episode_id) WHERE episode_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS watch_progress_user_idx ON watch_progress (user_id, updated DESC);
+
+-- Users recommendations, periodically rebuilt from rates and favourites
+CREATE TABLE IF NOT EXISTS recommendations (
+    user_id int NOT NULL,
+    content_id int NOT NULL,
+    score real NOT NULL,
+
+    PRIMARY KEY(user_id, content_id),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (content_id) REFERENCES content(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS recommendations_score_idx ON recommendations (user_id, score DESC);
+
+DO $$ BEGIN
+    CREATE TYPE job_state AS ENUM ('queued', 'running', 'failed', 'done');
+EXCEPTION
+    WHEN duplicate_object THEN null;
+END $$;
+
+-- Background media processing jobs
+CREATE TABLE IF NOT EXISTS jobs (
+    id serial PRIMARY KEY,
+    type varchar(64) NOT NULL,
+    payload jsonb NOT NULL,
+    state job_state NOT NULL DEFAULT 'queued',
+    error text NOT NULL DEFAULT '',
+    attempts int NOT NULL DEFAULT 0,
+    max_attempts int NOT NULL,
+    run_at timestamptz NOT NULL, -- время, раньше которого задача не будет взята воркером
+    created timestamptz NOT NULL,
+    updated timestamptz NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS jobs_queued_idx ON jobs (run_at) WHERE state = 'queued';
+
+-- Mutations made by admins, user is kept after deletion for the history
+CREATE TABLE IF NOT EXISTS audit_log (
+    id serial PRIMARY KEY,
+    user_id int NOT NULL,
+    action varchar(16) NOT NULL,
+    entity_type varchar(32) NOT NULL,
+    entity_id int NOT NULL,
+    changes jsonb NOT NULL,
+    ip varchar(45) NOT NULL DEFAULT '',
+    created timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS audit_log_entity_idx ON audit_log (entity_type, entity_id);
+CREATE INDEX IF NOT EXISTS audit_log_created_idx ON audit_log (created);
+
+-- Revisions of content metadata, every change of the content is a new revision
+CREATE TABLE IF NOT EXISTS content_revisions (
+    id serial PRIMARY KEY,
+    content_id int NOT NULL,
+    data jsonb NOT NULL, -- названия, описания, год, is_free и ID связей
+    created timestamptz NOT NULL DEFAULT now(),
+
+    FOREIGN KEY (content_id) REFERENCES content(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS content_revisions_content_idx ON content_revisions (content_id, id);
+
+-- Search indexes
+CREATE INDEX IF NOT EXISTS content_search_vector_idx ON content USING gin (search_vector);
+CREATE INDEX IF NOT EXISTS content_name_trgm_idx ON content USING gin (name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS content_original_name_trgm_idx ON content USING gin (original_name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS actors_name_trgm_idx ON actors USING gin (name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS directors_name_trgm_idx ON directors USING gin (name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS genres_name_trgm_idx ON genres USING gin (name gin_trgm_ops);
+
+-- Trigger for keeping content search vector up to date
+CREATE OR REPLACE FUNCTION content_search_vector() RETURNS trigger AS
+$content_search_vector$
+BEGIN
+    NEW.search_vector :=
+        setweight(to_tsvector('russian', coalesce(NEW.name, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(NEW.original_name, '')), 'A') ||
+        setweight(to_tsvector('russian', coalesce(NEW.short_description, '')), 'B') ||
+        setweight(to_tsvector('russian', coalesce(NEW.description, '')), 'C');
+    RETURN NEW;
+END;
+$content_search_vector$
+LANGUAGE plpgsql;
+CREATE TRIGGER content_search_vector
+    BEFORE INSERT OR UPDATE OF name, original_name, description, short_description ON content
+    FOR EACH ROW EXECUTE PROCEDURE content_search_vector();
+
 CREATE OR REPLACE FUNCTION rating_ins_upd() RETURNS trigger AS $$
     DECLARE
         value int;
@@ -267,9 +483,12 @@ CREATE TRIGGER seasons_inc AFTER INSERT ON seasons
 CREATE OR REPLACE FUNCTION seasons_dec() RETURNS trigger AS
 $seasons_dec$
 BEGIN
-    UPDATE tv_shows
-    SET seasons = seasons - 1
-    WHERE id=OLD.tv_show_id;
+    -- Trashed season is already uncounted
+    IF OLD.deleted_at IS NULL THEN
+        UPDATE tv_shows
+        SET seasons = seasons - 1
+        WHERE id=OLD.tv_show_id;
+    END IF;
     RETURN OLD;
 END;
 $seasons_dec$
@@ -278,6 +497,27 @@ $seasons_dec$
 CREATE TRIGGER seasons_dec BEFORE DELETE ON seasons
     FOR EACH ROW EXECUTE PROCEDURE seasons_dec();
 
+-- Trigger for seasons number on moving season to the trash and restoring it
+CREATE OR REPLACE FUNCTION seasons_trash() RETURNS trigger AS
+$seasons_trash$
+BEGIN
+    IF OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN
+        UPDATE tv_shows
+        SET seasons = seasons - 1
+        WHERE id=NEW.tv_show_id;
+    ELSIF OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL THEN
+        UPDATE tv_shows
+        SET seasons = seasons + 1
+        WHERE id=NEW.tv_show_id;
+    END IF;
+    RETURN NEW;
+END;
+$seasons_trash$
+    LANGUAGE plpgsql;
+
+CREATE TRIGGER seasons_trash AFTER UPDATE OF deleted_at ON seasons
+    FOR EACH ROW EXECUTE PROCEDURE seasons_trash();
+
 -- Trigger for increment episodes number in seasons
 CREATE OR REPLACE FUNCTION episodes_inc() RETURNS trigger AS
 $episodes_inc$
@@ -295,12 +535,35 @@ CREATE TRIGGER episodes_inc AFTER INSERT ON episodes
 CREATE OR REPLACE FUNCTION episodes_dec() RETURNS trigger AS
 $episodes_dec$
 BEGIN
-    UPDATE seasons
-    SET episodes = episodes - 1
-    WHERE id=OLD.season_id;
+    -- Trashed episode is already uncounted
+    IF OLD.deleted_at IS NULL THEN
+        UPDATE seasons
+        SET episodes = episodes - 1
+        WHERE id=OLD.season_id;
+    END IF;
     RETURN OLD;
 END;
 $episodes_dec$
 LANGUAGE plpgsql;
 CREATE TRIGGER episodes_dec BEFORE DELETE ON episodes
     FOR EACH ROW EXECUTE PROCEDURE episodes_dec();
+
+-- Trigger for episodes number on moving episode to the trash and restoring it
+CREATE OR REPLACE FUNCTION episodes_trash() RETURNS trigger AS
+$episodes_trash$
+BEGIN
+    IF OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN
+        UPDATE seasons
+        SET episodes = episodes - 1
+        WHERE id=NEW.season_id;
+    ELSIF OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL THEN
+        UPDATE seasons
+        SET episodes = episodes + 1
+        WHERE id=NEW.season_id;
+    END IF;
+    RETURN NEW;
+END;
+$episodes_trash$
+LANGUAGE plpgsql;
+CREATE TRIGGER episodes_trash AFTER UPDATE OF deleted_at ON episodes
+    FOR EACH ROW EXECUTE PROCEDURE episodes_trash();